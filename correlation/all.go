@@ -0,0 +1,80 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+)
+
+// AllCoefficients holds every correlation coefficient supported by the
+// package, computed on the same data.
+type AllCoefficients struct {
+	Pearson        float64
+	Spearman       float64
+	KendallTau     float64
+	GoodmanKruskal float64
+}
+
+// Coefficient returns the coefficient of the given type, or NaN if the type
+// is not supported.
+func (a AllCoefficients) Coefficient(correlationType Type) float64 {
+	switch correlationType {
+	case Pearson:
+		return a.Pearson
+	case Spearman:
+		return a.Spearman
+	case KendallTau:
+		return a.KendallTau
+	case GoodmanKruskal:
+		return a.GoodmanKruskal
+	default:
+		return math.NaN()
+	}
+}
+
+// CorrelateAll calculates every supported correlation coefficient between
+// x and y in one call, for comparing how the methods see the same data.
+//
+// The three rank based coefficients share a single analysis of the data:
+// the values are sorted once by x and once by y, which gives both the ranks
+// for Spearman's rho and the concordant, discordant and tied pair counts for
+// Kendall's tau-b and Goodman and Kruskal's gamma. This is considerably
+// cheaper than calling each function separately.
+//
+// An error is returned if the slices have different lengths, are empty,
+// contain NaN, or if either series is constant.
+func CorrelateAll[T Numeric](x, y []T) (AllCoefficients, error) {
+	var all AllCoefficients
+
+	st, err := numericRankStats(x, y)
+	if err != nil {
+		return all, err
+	}
+
+	if all.Pearson, err = Pearsons(x, y); err != nil {
+		return all, err
+	}
+	if all.Spearman, err = clamped(st.spearman()); err != nil {
+		return all, err
+	}
+	if all.KendallTau, err = clamped(st.kendallTauB()); err != nil {
+		return all, err
+	}
+	if all.GoodmanKruskal, err = clamped(st.gamma()); err != nil {
+		return all, err
+	}
+
+	return all, nil
+}
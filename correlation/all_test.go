@@ -0,0 +1,64 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCorrelateAll(t *testing.T) {
+	x := []float64{43, 21, 25, 42, 57, 59}
+	y := []float64{99, 65, 79, 75, 87, 81}
+
+	all, err := CorrelateAll(x, y)
+	if err != nil {
+		t.Fatalf("CorrelateAll() unexpected error: %v", err)
+	}
+
+	for _, ct := range []Type{Pearson, Spearman, KendallTau, GoodmanKruskal} {
+		want, err := Correlate(x, y, ct)
+		if err != nil {
+			t.Fatalf("Correlate(%v) unexpected error: %v", ct, err)
+		}
+		if got := all.Coefficient(ct); math.Abs(got-want) > 1e-15 {
+			t.Errorf("CorrelateAll().Coefficient(%v) = %v, want %v", ct, got, want)
+		}
+	}
+
+	if !math.IsNaN(all.Coefficient(Type(99))) {
+		t.Errorf("Coefficient(Type(99)) = %v, want NaN", all.Coefficient(Type(99)))
+	}
+}
+
+func TestCorrelateAllErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []float64
+	}{
+		{name: "empty", x: []float64{}, y: []float64{}},
+		{name: "length mismatch", x: []float64{1, 2, 3}, y: []float64{1, 2}},
+		{name: "constant", x: []float64{1, 1, 1}, y: []float64{1, 2, 3}},
+		{name: "NaN", x: []float64{1, math.NaN(), 3}, y: []float64{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CorrelateAll(tt.x, tt.y); err == nil {
+				t.Errorf("CorrelateAll() expected error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"context"
+	"math/big"
+	"sync"
+)
+
+// bigFloatPool holds slices of big.Float values between calculations. A
+// big.Float keeps its mantissa when it is set again, so the values of one
+// large calculation serve the next without allocating.
+var bigFloatPool = sync.Pool{
+	New: func() any {
+		return new([]big.Float)
+	},
+}
+
+// getBigFloats returns a slice of n big.Float values from the pool. Their
+// values are left over from earlier use, so each must be set before it is
+// read. The slice should be handed back with putBigFloats once no longer
+// referenced.
+func getBigFloats(n int) *[]big.Float {
+	s, _ := bigFloatPool.Get().(*[]big.Float)
+	if cap(*s) < n {
+		*s = make([]big.Float, n)
+	}
+	*s = (*s)[:n]
+
+	return s
+}
+
+// putBigFloats returns s to the pool.
+func putBigFloats(s *[]big.Float) {
+	bigFloatPool.Put(s)
+}
+
+// setBigNumeric sets z to the value of v and returns z. Like a new
+// big.Float, z takes the precision of v, whatever precision it had before.
+func setBigNumeric[T BigNumeric](z *big.Float, v T) *big.Float {
+	switch v := any(v).(type) {
+	case *big.Float:
+		return z.Copy(v)
+	case *big.Int:
+		return z.SetPrec(0).SetInt(v)
+	default:
+		panic("unsupported big numeric type")
+	}
+}
+
+// bigFloatsOf returns the values of data as *big.Float for reading. A
+// []*big.Float is returned as it is, without copying. Otherwise the values
+// are converted into big.Float values from the pool, and the pooled slice
+// is returned too so the caller can hand it back with putBigFloats once
+// done with the values.
+func bigFloatsOf[T BigNumeric](ctx context.Context, data []T) ([]*big.Float, *[]big.Float, error) {
+	if fs, ok := any(data).([]*big.Float); ok {
+		return fs, nil, nil
+	}
+
+	buf := getBigFloats(len(data))
+	vals := make([]*big.Float, len(data))
+	for i, v := range data {
+		if i%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				putBigFloats(buf)
+
+				return nil, nil, err
+			}
+		}
+		vals[i] = setBigNumeric(&(*buf)[i], v)
+	}
+
+	return vals, buf, nil
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"testing"
+)
+
+func TestGetBigFloats(t *testing.T) {
+	for _, n := range []int{0, 1, 10, 1000, 5} {
+		s := getBigFloats(n)
+		if len(*s) != n {
+			t.Errorf("getBigFloats(%d) has length %d", n, len(*s))
+		}
+		for i := range *s {
+			(*s)[i].SetInt64(int64(i))
+		}
+		putBigFloats(s)
+	}
+}
+
+func TestSetBigNumeric(t *testing.T) {
+	i := new(big.Int).Lsh(big.NewInt(1), 100)
+	i.Add(i, big.NewInt(1))
+
+	// A reused value with a low precision must not round the new value.
+	z := new(big.Float).SetPrec(8).SetFloat64(3)
+	setBigNumeric(z, i)
+	if got := z.Prec(); got != 101 {
+		t.Errorf("setBigNumeric(*big.Int) precision = %d, want 101", got)
+	}
+	if got, _ := z.Int(nil); got.Cmp(i) != 0 {
+		t.Errorf("setBigNumeric(*big.Int) = %v, want %v", got, i)
+	}
+
+	f := new(big.Float).SetPrec(200).SetInt(i)
+	setBigNumeric(z, f)
+	if z.Prec() != 200 || z.Cmp(f) != 0 {
+		t.Errorf("setBigNumeric(*big.Float) = %v at precision %d, want %v at precision 200", z, z.Prec(), f)
+	}
+	if z == f {
+		t.Errorf("setBigNumeric(*big.Float) aliased its input")
+	}
+}
+
+func TestPearsonsBigReusesPool(t *testing.T) {
+	// A high precision run followed by an integer run must not carry the
+	// earlier precision or values over through the pool.
+	wide := make([]*big.Float, 50)
+	other := make([]*big.Float, 50)
+	xs := make([]*big.Int, 50)
+	ys := make([]*big.Int, 50)
+	for i := range 50 {
+		wide[i] = new(big.Float).SetPrec(512).SetInt64(int64(i * i))
+		other[i] = new(big.Float).SetPrec(512).SetInt64(int64(3 * i))
+		xs[i] = big.NewInt(int64(i))
+		ys[i] = big.NewInt(int64(2*i + 1))
+	}
+
+	if _, err := PearsonsBig(wide, other); err != nil {
+		t.Fatalf("PearsonsBig() unexpected error: %v", err)
+	}
+	got, err := PearsonsBig(xs, ys)
+	if err != nil {
+		t.Fatalf("PearsonsBig() unexpected error: %v", err)
+	}
+	if got != 1 {
+		t.Errorf("PearsonsBig() after pooled run = %v, want 1", got)
+	}
+}
+
+func TestBigFloatsOf(t *testing.T) {
+	fs := []*big.Float{big.NewFloat(1.5), big.NewFloat(-2)}
+	got, buf, err := bigFloatsOf(context.Background(), fs)
+	if err != nil {
+		t.Fatalf("bigFloatsOf([]*big.Float) unexpected error: %v", err)
+	}
+	if buf != nil {
+		t.Errorf("bigFloatsOf([]*big.Float) used the pool")
+	}
+	for i := range fs {
+		if got[i] != fs[i] {
+			t.Errorf("bigFloatsOf([]*big.Float)[%d] is a copy, want the input value", i)
+		}
+	}
+
+	is := []*big.Int{big.NewInt(3), big.NewInt(-4)}
+	got, buf, err = bigFloatsOf(context.Background(), is)
+	if err != nil {
+		t.Fatalf("bigFloatsOf([]*big.Int) unexpected error: %v", err)
+	}
+	if buf == nil {
+		t.Fatalf("bigFloatsOf([]*big.Int) returned no pooled slice")
+	}
+	for i := range is {
+		if want := new(big.Float).SetInt(is[i]); got[i].Cmp(want) != 0 {
+			t.Errorf("bigFloatsOf([]*big.Int)[%d] = %v, want %v", i, got[i], want)
+		}
+	}
+	putBigFloats(buf)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := bigFloatsOf(ctx, is); err == nil {
+		t.Errorf("bigFloatsOf() with a cancelled context = nil error, want error")
+	}
+}
+
+func TestPearsonsBigLeavesInputs(t *testing.T) {
+	x := []*big.Float{big.NewFloat(1), big.NewFloat(2), big.NewFloat(4)}
+	y := []*big.Float{big.NewFloat(3), big.NewFloat(1), big.NewFloat(2)}
+	before := make([]string, 0, 2*len(x))
+	for _, v := range append(append([]*big.Float{}, x...), y...) {
+		before = append(before, v.Text('p', 0)+"/"+strconv.FormatUint(uint64(v.Prec()), 10))
+	}
+
+	if _, err := PearsonsBig(x, y, WithPrecision(256)); err != nil {
+		t.Fatalf("PearsonsBig() unexpected error: %v", err)
+	}
+
+	for i, v := range append(append([]*big.Float{}, x...), y...) {
+		if got := v.Text('p', 0) + "/" + strconv.FormatUint(uint64(v.Prec()), 10); got != before[i] {
+			t.Errorf("input %d changed from %s to %s", i, before[i], got)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// CorrelateBinned calculates the specified correlation coefficient from
+// grouped data, such as a 2D histogram or a contingency table of numeric
+// bins, for when only aggregated counts are available.
+//
+// counts[i][j] is the number (or total weight) of observations whose x
+// value falls in the bin centred at xCenters[i] and whose y value falls in
+// the bin centred at yCenters[j]. The result is the coefficient of the data
+// set in which each observation is replaced by its bin centres, so for
+// integer counts it equals Correlate on the expanded data:
+//
+//   - Pearson uses the weighted moments of the bin centres. No correction
+//     for grouping is applied, so coarse bins attenuate the coefficient
+//     somewhat compared with the ungrouped data.
+//   - Spearman ranks each bin by the mid-rank of the observations it holds.
+//   - Kendall's tau-b and Goodman and Kruskal's gamma count concordant and
+//     discordant pairs of observations across cells, with every pair inside
+//     the same row or column of bins being tied.
+//
+// The centres must be finite and strictly increasing along each axis.
+//
+// An error is returned if the dimensions of counts do not match the
+// centres, a count is negative or not finite, the centres are not strictly
+// increasing, there are fewer than 2 observations, the coefficient is
+// undefined for the data, or the correlation type is not supported.
+func CorrelateBinned(xCenters, yCenters []float64, counts [][]float64, correlationType Type) (float64, error) {
+	if !correlationType.valid() {
+		return 0, errors.New("unsupported correlation type")
+	}
+	if err := checkBinCenters(xCenters, "x"); err != nil {
+		return 0, err
+	}
+	if err := checkBinCenters(yCenters, "y"); err != nil {
+		return 0, err
+	}
+	if len(counts) != len(xCenters) {
+		return 0, errors.New("counts must have one row per x bin")
+	}
+
+	rowTotals := make([]float64, len(xCenters))
+	colTotals := make([]float64, len(yCenters))
+	total := 0.0
+	for i, row := range counts {
+		if len(row) != len(yCenters) {
+			return 0, errors.New("counts must have one column per y bin")
+		}
+		for j, c := range row {
+			if !isFinite(c) || c < 0 {
+				return 0, errors.New("counts must be finite and non-negative")
+			}
+			rowTotals[i] += c
+			colTotals[j] += c
+			total += c
+		}
+	}
+	if total < 2 {
+		return 0, errors.New("correlation requires at least 2 data points")
+	}
+
+	switch correlationType {
+	case Pearson:
+		return weightedPearson(xCenters, yCenters, counts, total)
+	case Spearman:
+		return weightedPearson(midRanks(rowTotals), midRanks(colTotals), counts, total)
+	default:
+		c, d := binnedPairCounts(counts)
+		if correlationType == GoodmanKruskal {
+			if c+d == 0 {
+				return 0, errors.New("correlation undefined: every pair is tied")
+			}
+
+			return (c - d) / (c + d), nil
+		}
+
+		pairs := total * (total - 1) / 2
+		den := (pairs - tiedPairs(rowTotals)) * (pairs - tiedPairs(colTotals))
+		if den <= 0 {
+			return 0, errors.New("correlation undefined: one or both variables have zero variance")
+		}
+
+		return clampUnit((c - d) / math.Sqrt(den)), nil
+	}
+}
+
+// checkBinCenters checks that centers are finite and strictly increasing.
+func checkBinCenters(centers []float64, name string) error {
+	if len(centers) == 0 {
+		return errors.New(name + " bin centers cannot be empty")
+	}
+	for i, v := range centers {
+		if !isFinite(v) {
+			return errors.New(name + " bin centers must be finite")
+		}
+		if i > 0 && v <= centers[i-1] {
+			return errors.New(name + " bin centers must be strictly increasing")
+		}
+	}
+
+	return nil
+}
+
+// weightedPearson returns the Pearson correlation of the bin values
+// weighted by counts, using the two pass algorithm.
+func weightedPearson(xs, ys []float64, counts [][]float64, total float64) (float64, error) {
+	var meanX, meanY float64
+	for i, row := range counts {
+		for j, c := range row {
+			meanX += c * xs[i]
+			meanY += c * ys[j]
+		}
+	}
+	meanX /= total
+	meanY /= total
+
+	var sxx, syy, sxy float64
+	for i, row := range counts {
+		dx := xs[i] - meanX
+		for j, c := range row {
+			dy := ys[j] - meanY
+			sxx += c * dx * dx
+			syy += c * dy * dy
+			sxy += c * dx * dy
+		}
+	}
+	if sxx <= 0 || syy <= 0 {
+		return 0, errors.New("correlation undefined: one or both variables have zero variance")
+	}
+
+	return clampUnit(sxy / math.Sqrt(sxx*syy)), nil
+}
+
+// midRanks returns the mid-rank of the observations in each bin, given the
+// number of observations per bin in order.
+func midRanks(totals []float64) []float64 {
+	out := make([]float64, len(totals))
+	before := 0.0
+	for i, t := range totals {
+		out[i] = before + (t+1)/2
+		before += t
+	}
+
+	return out
+}
+
+// tiedPairs returns the number of pairs of observations sharing a bin.
+func tiedPairs(totals []float64) float64 {
+	ties := 0.0
+	for _, t := range totals {
+		ties += t * (t - 1) / 2
+	}
+
+	return ties
+}
+
+// binnedPairCounts returns the numbers of concordant and discordant pairs
+// of observations in a table of counts, using suffix sums so that each is
+// found in time proportional to the size of the table.
+func binnedPairCounts(counts [][]float64) (float64, float64) {
+	rows := len(counts)
+	cols := len(counts[0])
+
+	// after[j] is the total count in column j over the rows after i. A
+	// cell pairs concordantly with the later rows in later columns and
+	// discordantly with the later rows in earlier columns.
+	after := make([]float64, cols)
+	var concordant, discordant float64
+	for i := rows - 1; i >= 0; i-- {
+		prefix := 0.0
+		suffix := 0.0
+		for _, v := range after {
+			suffix += v
+		}
+		for j := range cols {
+			suffix -= after[j]
+			c := counts[i][j]
+			concordant += c * suffix
+			discordant += c * prefix
+			prefix += after[j]
+		}
+		for j := range cols {
+			after[j] += counts[i][j]
+		}
+	}
+
+	return concordant, discordant
+}
@@ -0,0 +1,130 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"testing"
+)
+
+// expandBins returns the raw data set described by a table of integer
+// counts.
+func expandBins(t *testing.T, xCenters, yCenters []float64, counts [][]float64) ([]float64, []float64) {
+	t.Helper()
+
+	var x, y []float64
+	for i, row := range counts {
+		for j, c := range row {
+			for range int(c) {
+				x = append(x, xCenters[i])
+				y = append(y, yCenters[j])
+			}
+		}
+	}
+
+	return x, y
+}
+
+func TestCorrelateBinnedMatchesExpanded(t *testing.T) {
+	tests := []struct {
+		name     string
+		xCenters []float64
+		yCenters []float64
+		counts   [][]float64
+	}{
+		{
+			name:     "positive",
+			xCenters: []float64{10, 20, 30},
+			yCenters: []float64{1, 2, 3, 4},
+			counts: [][]float64{
+				{5, 3, 1, 0},
+				{2, 6, 4, 1},
+				{0, 1, 4, 7},
+			},
+		},
+		{
+			name:     "negative",
+			xCenters: []float64{-1, 0, 1},
+			yCenters: []float64{0.5, 1.5},
+			counts: [][]float64{
+				{1, 9},
+				{5, 5},
+				{8, 2},
+			},
+		},
+		{
+			name:     "sparse with empty bins",
+			xCenters: []float64{1, 2, 3, 4},
+			yCenters: []float64{1, 2, 3},
+			counts: [][]float64{
+				{3, 0, 0},
+				{0, 0, 0},
+				{1, 2, 0},
+				{0, 1, 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			x, y := expandBins(t, tt.xCenters, tt.yCenters, tt.counts)
+			for _, ct := range []Type{Pearson, Spearman, KendallTau, GoodmanKruskal} {
+				want, err := Correlate(x, y, ct)
+				if err != nil {
+					t.Fatalf("Correlate(%v) unexpected error: %v", ct, err)
+				}
+				got, err := CorrelateBinned(tt.xCenters, tt.yCenters, tt.counts, ct)
+				if err != nil {
+					t.Fatalf("CorrelateBinned(%v) unexpected error: %v", ct, err)
+				}
+				if math.Abs(got-want) > 1e-12 {
+					t.Errorf("CorrelateBinned(%v) = %v, want %v", ct, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCorrelateBinnedErrors(t *testing.T) {
+	xs := []float64{1, 2}
+	ys := []float64{1, 2}
+	tests := []struct {
+		name     string
+		xCenters []float64
+		yCenters []float64
+		counts   [][]float64
+		typ      Type
+	}{
+		{name: "unsupported type", xCenters: xs, yCenters: ys, counts: [][]float64{{1, 0}, {0, 1}}, typ: Type(99)},
+		{name: "no x bins", xCenters: []float64{}, yCenters: ys, counts: [][]float64{}, typ: Pearson},
+		{name: "unsorted centers", xCenters: []float64{2, 1}, yCenters: ys, counts: [][]float64{{1, 0}, {0, 1}}, typ: Pearson},
+		{name: "NaN center", xCenters: xs, yCenters: []float64{1, math.NaN()}, counts: [][]float64{{1, 0}, {0, 1}}, typ: Pearson},
+		{name: "missing row", xCenters: xs, yCenters: ys, counts: [][]float64{{1, 1}}, typ: Pearson},
+		{name: "short row", xCenters: xs, yCenters: ys, counts: [][]float64{{1, 1}, {1}}, typ: Pearson},
+		{name: "negative count", xCenters: xs, yCenters: ys, counts: [][]float64{{3, -1}, {0, 1}}, typ: Pearson},
+		{name: "one observation", xCenters: xs, yCenters: ys, counts: [][]float64{{1, 0}, {0, 0}}, typ: Pearson},
+		{name: "single x bin used", xCenters: xs, yCenters: ys, counts: [][]float64{{2, 3}, {0, 0}}, typ: Pearson},
+		{name: "tau with single y bin used", xCenters: xs, yCenters: ys, counts: [][]float64{{2, 0}, {3, 0}}, typ: KendallTau},
+		{name: "gamma all tied", xCenters: xs, yCenters: ys, counts: [][]float64{{2, 0}, {3, 0}}, typ: GoodmanKruskal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CorrelateBinned(tt.xCenters, tt.yCenters, tt.counts, tt.typ); err == nil {
+				t.Errorf("CorrelateBinned() expected error but got none")
+			}
+		})
+	}
+}
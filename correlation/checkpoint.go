@@ -0,0 +1,121 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+)
+
+// onlineCorrelatorVersion is the leading byte of the binary encoding of an
+// OnlineCorrelator, bumped whenever the layout changes.
+const onlineCorrelatorVersion = 1
+
+// onlineCorrelatorBinarySize is the length of the binary encoding: the
+// version byte, the count and five float64 statistics.
+const onlineCorrelatorBinarySize = 1 + 8 + 5*8
+
+// onlineCorrelatorJSON is the JSON form of an OnlineCorrelator.
+type onlineCorrelatorJSON struct {
+	N     int     `json:"n"`
+	MeanX float64 `json:"meanX"`
+	MeanY float64 `json:"meanY"`
+	M2X   float64 `json:"m2x"`
+	M2Y   float64 `json:"m2y"`
+	CXY   float64 `json:"cxy"`
+}
+
+// MarshalBinary encodes the state of the correlator so a long running
+// pipeline can checkpoint it and resume later. It is also used by
+// encoding/gob.
+func (o *OnlineCorrelator) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, onlineCorrelatorBinarySize)
+	b = append(b, onlineCorrelatorVersion)
+	b = binary.LittleEndian.AppendUint64(b, uint64(o.stats.n))
+	for _, v := range []float64{o.stats.meanX, o.stats.meanY, o.stats.m2x, o.stats.m2y, o.stats.cxy} {
+		b = binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+	}
+
+	return b, nil
+}
+
+// UnmarshalBinary restores state encoded by MarshalBinary, replacing the
+// current contents of the correlator.
+//
+// An error is returned if the data is truncated, was written by an
+// unsupported version, or holds invalid statistics.
+func (o *OnlineCorrelator) UnmarshalBinary(data []byte) error {
+	if len(data) != onlineCorrelatorBinarySize {
+		return errors.New("invalid OnlineCorrelator encoding: length " + strconv.Itoa(len(data)))
+	}
+	if data[0] != onlineCorrelatorVersion {
+		return errors.New("unsupported OnlineCorrelator encoding version " + strconv.Itoa(int(data[0])))
+	}
+
+	n := binary.LittleEndian.Uint64(data[1:])
+	if n > math.MaxInt64 {
+		return errors.New("invalid OnlineCorrelator encoding: count out of range")
+	}
+	var v [5]float64
+	for i := range v {
+		v[i] = math.Float64frombits(binary.LittleEndian.Uint64(data[9+8*i:]))
+	}
+
+	return o.restore(comoments{n: int(n), meanX: v[0], meanY: v[1], m2x: v[2], m2y: v[3], cxy: v[4]})
+}
+
+// MarshalJSON encodes the state of the correlator as a JSON object.
+func (o *OnlineCorrelator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(onlineCorrelatorJSON{
+		N:     o.stats.n,
+		MeanX: o.stats.meanX,
+		MeanY: o.stats.meanY,
+		M2X:   o.stats.m2x,
+		M2Y:   o.stats.m2y,
+		CXY:   o.stats.cxy,
+	})
+}
+
+// UnmarshalJSON restores state encoded by MarshalJSON, replacing the
+// current contents of the correlator.
+func (o *OnlineCorrelator) UnmarshalJSON(data []byte) error {
+	var j onlineCorrelatorJSON
+	if err := json.Unmarshal(data, &j); err != nil {
+		return err
+	}
+
+	return o.restore(comoments{n: j.N, meanX: j.MeanX, meanY: j.MeanY, m2x: j.M2X, m2y: j.M2Y, cxy: j.CXY})
+}
+
+// restore validates decoded statistics and installs them in o.
+func (o *OnlineCorrelator) restore(c comoments) error {
+	if c.n < 0 {
+		return errors.New("invalid OnlineCorrelator state: negative count")
+	}
+	for _, v := range []float64{c.meanX, c.meanY, c.m2x, c.m2y, c.cxy} {
+		if !isFinite(v) {
+			return errors.New("invalid OnlineCorrelator state: non-finite statistic")
+		}
+	}
+	if c.m2x < 0 || c.m2y < 0 {
+		return errors.New("invalid OnlineCorrelator state: negative sum of squares")
+	}
+	o.stats = c
+
+	return nil
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestOnlineCorrelatorCheckpoint(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	y := []float64{2, 1, 4, 3, 7, 5, 8, 6}
+
+	// Checkpoint halfway through the stream, restore, and finish.
+	var before OnlineCorrelator
+	for i := range 4 {
+		if err := before.Add(x[i], y[i]); err != nil {
+			t.Fatalf("Add() unexpected error: %v", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&before); err != nil {
+		t.Fatalf("gob Encode() unexpected error: %v", err)
+	}
+	js, err := json.Marshal(&before)
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %v", err)
+	}
+
+	var fromGob, fromJSON OnlineCorrelator
+	if err := gob.NewDecoder(&buf).Decode(&fromGob); err != nil {
+		t.Fatalf("gob Decode() unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(js, &fromJSON); err != nil {
+		t.Fatalf("json.Unmarshal() unexpected error: %v", err)
+	}
+
+	want, _ := Pearsons(x, y)
+	for name, o := range map[string]*OnlineCorrelator{"gob": &fromGob, "json": &fromJSON} {
+		if o.stats != before.stats {
+			t.Errorf("%s round trip state = %+v, want %+v", name, o.stats, before.stats)
+		}
+		for i := 4; i < len(x); i++ {
+			if err := o.Add(x[i], y[i]); err != nil {
+				t.Fatalf("Add() unexpected error: %v", err)
+			}
+		}
+		got, err := o.Value()
+		if err != nil {
+			t.Fatalf("Value() unexpected error: %v", err)
+		}
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("%s resumed Value() = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestOnlineCorrelatorUnmarshalErrors(t *testing.T) {
+	var o OnlineCorrelator
+	if err := o.Add(1, 2); err != nil {
+		t.Fatalf("Add() unexpected error: %v", err)
+	}
+	good, _ := o.MarshalBinary()
+
+	badVersion := bytes.Clone(good)
+	badVersion[0] = 99
+
+	binaryTests := map[string][]byte{
+		"empty":       nil,
+		"truncated":   good[:10],
+		"bad version": badVersion,
+	}
+	for name, data := range binaryTests {
+		if err := new(OnlineCorrelator).UnmarshalBinary(data); err == nil {
+			t.Errorf("UnmarshalBinary(%s) expected error but got none", name)
+		}
+	}
+
+	jsonTests := map[string]string{
+		"malformed":        `{"n":`,
+		"negative count":   `{"n":-1}`,
+		"negative squares": `{"n":3,"m2x":-2,"m2y":1}`,
+	}
+	for name, data := range jsonTests {
+		if err := new(OnlineCorrelator).UnmarshalJSON([]byte(data)); err == nil {
+			t.Errorf("UnmarshalJSON(%s) expected error but got none", name)
+		}
+	}
+}
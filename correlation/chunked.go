@@ -0,0 +1,147 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// ValueReader reads the next values of a series into buf, in the manner of
+// io.Reader. It returns the number of values read, which may be fewer than
+// len(buf), and io.EOF once the series is exhausted. Like io.Reader, it may
+// return a final count of values together with io.EOF.
+type ValueReader func(buf []float64) (int, error)
+
+// BinaryValueReader returns a ValueReader that decodes a series of IEEE 754
+// float64 values, stored back to back in the given byte order, from r. It is
+// suited to series too large to load, such as a column written to disk by
+// encoding/binary.
+//
+// The reader returns io.ErrUnexpectedEOF if r ends partway through a value.
+func BinaryValueReader(r io.Reader, order binary.ByteOrder) ValueReader {
+	var raw []byte
+
+	return func(buf []float64) (int, error) {
+		if need := 8 * len(buf); cap(raw) < need {
+			raw = make([]byte, need)
+		}
+		n, err := io.ReadFull(r, raw[:8*len(buf)])
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			if n%8 != 0 {
+				return 0, io.ErrUnexpectedEOF
+			}
+			err = io.EOF
+		}
+		for i := range n / 8 {
+			buf[i] = math.Float64frombits(order.Uint64(raw[8*i:]))
+		}
+
+		return n / 8, err
+	}
+}
+
+// CorrelateChunked calculates Pearson's correlation between two series that
+// are read chunkSize values at a time from x and y, so that series larger
+// than memory can be correlated. Only the two buffers of chunkSize values
+// and a fixed set of running statistics are held, however long the series.
+//
+// The rank based coefficients need every value at once, so only Pearson
+// is supported. As with Correlate, a NaN or infinite value makes the result
+// NaN. Chunks whose statistics overflow float64 are computed in big.Float.
+//
+// An error is returned if chunkSize is not positive, the correlation type
+// is not Pearson, either reader fails, the series have different lengths,
+// there are fewer than 2 pairs, or the coefficient is undefined.
+func CorrelateChunked(x, y ValueReader, chunkSize int, correlationType Type) (float64, error) {
+	if chunkSize <= 0 {
+		return 0, errors.New("chunk size must be positive")
+	}
+	if correlationType != Pearson {
+		return 0, errors.New("chunked correlation supports only Pearson")
+	}
+
+	xBuf := make([]float64, chunkSize)
+	yBuf := make([]float64, chunkSize)
+	var acc pearsonAccumulator
+	nonFinite := false
+	for {
+		nx, xDone, err := fillValues(x, xBuf)
+		if err != nil {
+			return 0, err
+		}
+		ny, yDone, err := fillValues(y, yBuf)
+		if err != nil {
+			return 0, err
+		}
+		// A reader may report the end with its last values or on the call
+		// after, so check that the other series has ended too.
+		if nx == ny && xDone != yDone {
+			other := x
+			if xDone {
+				other = y
+			}
+			var probe [1]float64
+			m, _, err := fillValues(other, probe[:])
+			if err != nil {
+				return 0, err
+			}
+			xDone, yDone = true, m == 0
+		}
+		if nx != ny || xDone != yDone {
+			return 0, errors.New("input series must have the same length")
+		}
+
+		if !nonFinite && !addPearsonChunk(&acc, xBuf[:nx], yBuf[:ny]) {
+			// Keep reading so that a length mismatch is still reported.
+			nonFinite = true
+		}
+		if xDone {
+			break
+		}
+	}
+
+	if nonFinite {
+		return math.NaN(), nil
+	}
+	if acc.count() < 2 {
+		return 0, errors.New("correlation requires at least 2 data points")
+	}
+
+	return clamped(acc.value())
+}
+
+// fillValues reads from read until buf is full or the series ends. It
+// returns the number of values read and whether the series has ended.
+func fillValues(read ValueReader, buf []float64) (int, bool, error) {
+	n := 0
+	for n < len(buf) {
+		m, err := read(buf[n:])
+		if m < 0 || m > len(buf)-n {
+			return 0, false, errors.New("value reader returned an invalid count")
+		}
+		n += m
+		if errors.Is(err, io.EOF) {
+			return n, true, nil
+		}
+		if err != nil {
+			return 0, false, err
+		}
+	}
+
+	return n, false, nil
+}
@@ -0,0 +1,225 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// sliceValueReader returns a ValueReader over data that returns at most
+// step values per call. With eofWithData it reports io.EOF along with the
+// last values rather than on the following call.
+func sliceValueReader(data []float64, step int, eofWithData bool) ValueReader {
+	pos := 0
+
+	return func(buf []float64) (int, error) {
+		if pos == len(data) {
+			return 0, io.EOF
+		}
+		n := copy(buf[:min(len(buf), step)], data[pos:])
+		pos += n
+		if eofWithData && pos == len(data) {
+			return n, io.EOF
+		}
+
+		return n, nil
+	}
+}
+
+func TestCorrelateChunked(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	x := make([]float64, 1000)
+	y := make([]float64, 1000)
+	for i := range x {
+		x[i] = rng.NormFloat64()
+		y[i] = 0.3*x[i] + rng.NormFloat64()
+	}
+	want, err := Pearsons(x, y)
+	if err != nil {
+		t.Fatalf("Pearsons() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		chunkSize   int
+		xStep       int
+		yStep       int
+		eofWithData bool
+	}{
+		{name: "single values", chunkSize: 1, xStep: 1, yStep: 1, eofWithData: false},
+		{name: "short reads", chunkSize: 64, xStep: 7, yStep: 64, eofWithData: false},
+		{name: "eof with data", chunkSize: 100, xStep: 100, yStep: 33, eofWithData: true},
+		{name: "chunk larger than input", chunkSize: 4096, xStep: 4096, yStep: 4096, eofWithData: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := CorrelateChunked(
+				sliceValueReader(x, test.xStep, test.eofWithData),
+				sliceValueReader(y, test.yStep, false),
+				test.chunkSize, Pearson)
+			if err != nil {
+				t.Fatalf("CorrelateChunked() unexpected error: %v", err)
+			}
+			if math.Abs(got-want) > 1e-12 {
+				t.Errorf("CorrelateChunked() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCorrelateChunkedErrors(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 5}
+	readErr := errors.New("disk on fire")
+	failing := func([]float64) (int, error) { return 0, readErr }
+
+	tests := []struct {
+		name      string
+		x         ValueReader
+		y         ValueReader
+		chunkSize int
+		ct        Type
+	}{
+		{
+			name:      "zero chunk size",
+			x:         sliceValueReader(data, 5, false),
+			y:         sliceValueReader(data, 5, false),
+			chunkSize: 0,
+			ct:        Pearson,
+		},
+		{
+			name:      "rank correlation",
+			x:         sliceValueReader(data, 5, false),
+			y:         sliceValueReader(data, 5, false),
+			chunkSize: 2,
+			ct:        Spearman,
+		},
+		{
+			name:      "y longer",
+			x:         sliceValueReader(data[:4], 5, true),
+			y:         sliceValueReader(data, 5, false),
+			chunkSize: 4,
+			ct:        Pearson,
+		},
+		{
+			name:      "x longer",
+			x:         sliceValueReader(data, 5, false),
+			y:         sliceValueReader(data[:3], 5, false),
+			chunkSize: 2,
+			ct:        Pearson,
+		},
+		{
+			name:      "one pair",
+			x:         sliceValueReader(data[:1], 5, false),
+			y:         sliceValueReader(data[:1], 5, false),
+			chunkSize: 2,
+			ct:        Pearson,
+		},
+		{
+			name:      "reader error",
+			x:         sliceValueReader(data, 5, false),
+			y:         failing,
+			chunkSize: 2,
+			ct:        Pearson,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := CorrelateChunked(test.x, test.y, test.chunkSize, test.ct); err == nil {
+				t.Errorf("CorrelateChunked() = nil error, want error")
+			}
+		})
+	}
+}
+
+func TestCorrelateChunkedNonFinite(t *testing.T) {
+	x := []float64{1, 2, math.NaN(), 4, 5}
+	y := []float64{2, 1, 4, 3, 5}
+
+	got, err := CorrelateChunked(sliceValueReader(x, 5, false), sliceValueReader(y, 5, false), 2, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateChunked() unexpected error: %v", err)
+	}
+	if !math.IsNaN(got) {
+		t.Errorf("CorrelateChunked() = %v, want NaN", got)
+	}
+
+	// A mismatch after the NaN is still reported.
+	_, err = CorrelateChunked(sliceValueReader(x, 5, false), sliceValueReader(y[:4], 5, false), 2, Pearson)
+	if err == nil {
+		t.Errorf("CorrelateChunked() with different lengths after a NaN = nil error, want error")
+	}
+}
+
+func TestCorrelateChunkedOverflow(t *testing.T) {
+	x := []float64{1, 2, 3, 1e300, -1e300, 4, 5}
+	y := []float64{1, 3, 2, 5, -5, 4, 6}
+	want, err := pearsonsSinglePass(x, y)
+	if err != nil {
+		t.Fatalf("pearsonsSinglePass() unexpected error: %v", err)
+	}
+
+	got, err := CorrelateChunked(sliceValueReader(x, 7, false), sliceValueReader(y, 7, false), 2, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateChunked() unexpected error: %v", err)
+	}
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("CorrelateChunked() = %v, want %v", got, want)
+	}
+}
+
+func TestBinaryValueReader(t *testing.T) {
+	want := []float64{1.5, -2, math.Pi, 1e300, 0}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		var b bytes.Buffer
+		if err := binary.Write(&b, order, want); err != nil {
+			t.Fatalf("binary.Write() unexpected error: %v", err)
+		}
+
+		read := BinaryValueReader(&b, order)
+		buf := make([]float64, 3)
+		var got []float64
+		for {
+			n, err := read(buf)
+			got = append(got, buf[:n]...)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Fatalf("%v: read() unexpected error: %v", order, err)
+			}
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%v: read %d values, want %d", order, len(got), len(want))
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Errorf("%v: value %d = %v, want %v", order, i, got[i], want[i])
+			}
+		}
+	}
+
+	read := BinaryValueReader(bytes.NewReader(make([]byte, 12)), binary.LittleEndian)
+	if _, err := read(make([]float64, 2)); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("read() of a partial value error = %v, want io.ErrUnexpectedEOF", err)
+	}
+}
@@ -0,0 +1,258 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// JennrichTest tests the null hypothesis that two correlation matrices,
+// computed on independent samples of sizes n1 and n2, are equal.
+//
+// Following Jennrich (1970), with c = n1*n2/(n1+n2), the pooled matrix
+// R = (n1*R1 + n2*R2)/(n1+n2), Z = sqrt(c) * R⁻¹(R1 - R2) and
+// S = I + R∘R⁻¹ (∘ being the element-wise product), the statistic
+//
+//	χ² = ½ tr(Z²) - dg(Z)ᵀ S⁻¹ dg(Z)
+//
+// is asymptotically chi-square with p(p-1)/2 degrees of freedom, where p is
+// the number of variables.
+//
+// Both matrices must be Pearson matrices of the same dimension with no
+// undefined cells.
+//
+// Jennrich, R. I. (1970). An Asymptotic χ² Test for the Equality of Two
+// Correlation Matrices. Journal of the American Statistical Association,
+// 65(330), 904-912.
+func JennrichTest(r1 *Matrix, n1 int, r2 *Matrix, n2 int) (ChiSquareResult, error) {
+	var res ChiSquareResult
+
+	if r1 == nil || r2 == nil {
+		return res, errors.New("correlation matrices cannot be nil")
+	}
+	if r1.Dim() != r2.Dim() {
+		return res, errors.New("correlation matrices must have the same dimension")
+	}
+	if r1.Type != Pearson || r2.Type != Pearson {
+		return res, errors.New("Jennrich test requires Pearson correlation matrices")
+	}
+	if n1 <= r1.Dim() || n2 <= r2.Dim() {
+		return res, errors.New("sample sizes must exceed the number of variables")
+	}
+
+	p := r1.Dim()
+	a, err := definedCoefficients(r1)
+	if err != nil {
+		return res, err
+	}
+	b, err := definedCoefficients(r2)
+	if err != nil {
+		return res, err
+	}
+	nf1 := float64(n1)
+	nf2 := float64(n2)
+
+	pooled := make([][]float64, p)
+	diff := make([][]float64, p)
+	for i := range p {
+		pooled[i] = make([]float64, p)
+		diff[i] = make([]float64, p)
+		for j := range p {
+			pooled[i][j] = (nf1*a[i][j] + nf2*b[i][j]) / (nf1 + nf2)
+			diff[i][j] = a[i][j] - b[i][j]
+		}
+	}
+
+	pooledInv, err := invertMatrix(pooled)
+	if err != nil {
+		return res, errors.New("pooled correlation matrix is singular")
+	}
+
+	c := nf1 * nf2 / (nf1 + nf2)
+	z := multiplySquare(pooledInv, diff)
+	for i := range p {
+		for j := range p {
+			z[i][j] *= math.Sqrt(c)
+		}
+	}
+
+	s := identity(p)
+	for i := range p {
+		for j := range p {
+			s[i][j] += pooled[i][j] * pooledInv[i][j]
+		}
+	}
+	sInv, err := invertMatrix(s)
+	if err != nil {
+		return res, errors.New("Jennrich weight matrix is singular")
+	}
+
+	// ½ tr(Z²) = ½ Σ_ij z_ij z_ji
+	trace := 0.0
+	for i := range p {
+		for j := range p {
+			trace += z[i][j] * z[j][i]
+		}
+	}
+
+	quad := 0.0
+	for i := range p {
+		for j := range p {
+			quad += z[i][i] * sInv[i][j] * z[j][j]
+		}
+	}
+
+	res.Statistic = 0.5*trace - quad
+	if res.Statistic < 0 {
+		// Guard against tiny negative values from rounding when the
+		// matrices are (nearly) identical.
+		res.Statistic = 0
+	}
+	res.DF = float64(p*(p-1)) / 2
+	res.PValue = chiSquareSurvival(res.Statistic, res.DF)
+
+	return res, nil
+}
+
+// BoxMTest tests the null hypothesis that the covariance matrices of two or
+// more independent groups are equal, using Box's M statistic with its
+// chi-square approximation.
+//
+// Each group is given as a set of columns (one slice per variable); every
+// group must have the same number of variables, and all columns within a
+// group must have the same length.
+//
+// With k groups of sizes n_i, N = Σ n_i, and p variables,
+//
+//	M = (N-k) ln|S_pooled| - Σ (n_i-1) ln|S_i|
+//	c = (2p² + 3p - 1) / (6(p+1)(k-1)) * (Σ 1/(n_i-1) - 1/(N-k))
+//
+// and M(1-c) is approximately chi-square with p(p+1)(k-1)/2 degrees of freedom.
+//
+// Box, G. E. P. (1949). A General Distribution Theory for a Class of
+// Likelihood Criteria. Biometrika, 36(3/4), 317-346.
+func BoxMTest(groups ...[][]float64) (ChiSquareResult, error) {
+	var res ChiSquareResult
+
+	k := len(groups)
+	if k < 2 {
+		return res, errors.New("Box's M test requires at least 2 groups")
+	}
+	p := len(groups[0])
+	if p == 0 {
+		return res, errors.New("groups must have at least 1 variable")
+	}
+
+	covs := make([][][]float64, k)
+	sizes := make([]float64, k)
+	total := 0.0
+	for g, cols := range groups {
+		if len(cols) != p {
+			return res, errors.New("all groups must have the same number of variables")
+		}
+		cov, n, err := covarianceMatrix(cols)
+		if err != nil {
+			return res, err
+		}
+		if n <= p {
+			return res, errors.New("each group must have more observations than variables")
+		}
+		covs[g] = cov
+		sizes[g] = float64(n)
+		total += float64(n)
+	}
+
+	kf := float64(k)
+	pf := float64(p)
+
+	pooled := make([][]float64, p)
+	for i := range p {
+		pooled[i] = make([]float64, p)
+		for j := range p {
+			for g := range k {
+				pooled[i][j] += (sizes[g] - 1) * covs[g][i][j]
+			}
+			pooled[i][j] /= total - kf
+		}
+	}
+
+	logPooled, err := logDeterminant(pooled)
+	if err != nil {
+		return res, errors.New("pooled covariance matrix is singular")
+	}
+
+	m := (total - kf) * logPooled
+	sumInv := 0.0
+	for g := range k {
+		logDet, err := logDeterminant(covs[g])
+		if err != nil {
+			return res, errors.New("group covariance matrix is singular")
+		}
+		m -= (sizes[g] - 1) * logDet
+		sumInv += 1 / (sizes[g] - 1)
+	}
+
+	c := (2*pf*pf + 3*pf - 1) / (6 * (pf + 1) * (kf - 1)) * (sumInv - 1/(total-kf))
+
+	res.Statistic = m * (1 - c)
+	if res.Statistic < 0 {
+		res.Statistic = 0
+	}
+	res.DF = pf * (pf + 1) * (kf - 1) / 2
+	res.PValue = chiSquareSurvival(res.Statistic, res.DF)
+
+	return res, nil
+}
+
+// covarianceMatrix returns the sample covariance matrix (n-1 denominator)
+// of the given columns along with the number of observations.
+func covarianceMatrix(cols [][]float64) ([][]float64, int, error) {
+	p := len(cols)
+	n := len(cols[0])
+	for _, col := range cols {
+		if len(col) != n {
+			return nil, 0, errors.New("columns must have the same length")
+		}
+	}
+	if n < 2 {
+		return nil, 0, errors.New("covariance requires at least 2 data points")
+	}
+
+	means := make([]float64, p)
+	for i, col := range cols {
+		for _, v := range col {
+			means[i] += v
+		}
+		means[i] /= float64(n)
+	}
+
+	cov := make([][]float64, p)
+	for i := range p {
+		cov[i] = make([]float64, p)
+	}
+	for i := range p {
+		for j := i; j < p; j++ {
+			sum := 0.0
+			for k := range n {
+				sum += (cols[i][k] - means[i]) * (cols[j][k] - means[j])
+			}
+			cov[i][j] = sum / float64(n-1)
+			cov[j][i] = cov[i][j]
+		}
+	}
+
+	return cov, n, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestJennrichTestTwoVariables(t *testing.T) {
+	// With two variables the Jennrich statistic reduces to the closed form
+	// c*(r1-r2)²/(1-r̄²)² with one degree of freedom.
+	r1 := newMatrix(2, Pearson)
+	r1.set(0, 0, 1, 40)
+	r1.set(1, 1, 1, 40)
+	r1.set(0, 1, 0.6, 40)
+	r2 := newMatrix(2, Pearson)
+	r2.set(0, 0, 1, 60)
+	r2.set(1, 1, 1, 60)
+	r2.set(0, 1, 0.3, 60)
+
+	got, err := JennrichTest(r1, 40, r2, 60)
+	if err != nil {
+		t.Fatalf("JennrichTest() unexpected error: %v", err)
+	}
+
+	c := 40.0 * 60.0 / 100.0
+	rbar := (40*0.6 + 60*0.3) / 100.0
+	want := c * 0.3 * 0.3 / ((1 - rbar*rbar) * (1 - rbar*rbar))
+
+	if math.Abs(got.Statistic-want) > 1e-9 {
+		t.Errorf("JennrichTest() statistic = %v, want %v", got.Statistic, want)
+	}
+	if got.DF != 1 {
+		t.Errorf("JennrichTest() DF = %v, want 1", got.DF)
+	}
+	if got.PValue <= 0 || got.PValue >= 1 {
+		t.Errorf("JennrichTest() PValue = %v, want in (0, 1)", got.PValue)
+	}
+}
+
+func TestJennrichTestIdentical(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	cols := make([][]float64, 4)
+	for i := range cols {
+		cols[i] = make([]float64, 50)
+		for j := range cols[i] {
+			cols[i][j] = rng.NormFloat64() + float64(i)*float64(j)/50
+		}
+	}
+
+	m, err := PairwiseCorrelationMatrix(cols, Pearson)
+	if err != nil {
+		t.Fatalf("PairwiseCorrelationMatrix() unexpected error: %v", err)
+	}
+
+	got, err := JennrichTest(m, 50, m, 80)
+	if err != nil {
+		t.Fatalf("JennrichTest() unexpected error: %v", err)
+	}
+	if got.Statistic > 1e-9 {
+		t.Errorf("JennrichTest() statistic = %v, want 0 for identical matrices", got.Statistic)
+	}
+	if got.DF != 6 {
+		t.Errorf("JennrichTest() DF = %v, want 6", got.DF)
+	}
+	if math.Abs(got.PValue-1) > 1e-9 {
+		t.Errorf("JennrichTest() PValue = %v, want 1", got.PValue)
+	}
+}
+
+func TestJennrichTestErrors(t *testing.T) {
+	a := newMatrix(2, Pearson)
+	a.set(0, 0, 1, 10)
+	a.set(1, 1, 1, 10)
+	b := newMatrix(3, Pearson)
+	s := newMatrix(2, Spearman)
+
+	tests := []struct {
+		name   string
+		r1, r2 *Matrix
+		n1, n2 int
+	}{
+		{name: "nil matrix", r1: nil, r2: a, n1: 10, n2: 10},
+		{name: "dimension mismatch", r1: a, r2: b, n1: 10, n2: 10},
+		{name: "not pearson", r1: a, r2: s, n1: 10, n2: 10},
+		{name: "sample too small", r1: a, r2: a, n1: 2, n2: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := JennrichTest(tt.r1, tt.n1, tt.r2, tt.n2); err == nil {
+				t.Errorf("JennrichTest() expected error but got none")
+			}
+		})
+	}
+}
+
+func TestBoxMTest(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	makeGroup := func(n int, scale float64) [][]float64 {
+		x := make([]float64, n)
+		y := make([]float64, n)
+		for i := range n {
+			x[i] = rng.NormFloat64() * scale
+			y[i] = 0.5*x[i] + rng.NormFloat64()*scale
+		}
+
+		return [][]float64{x, y}
+	}
+
+	g := makeGroup(40, 1)
+	same, err := BoxMTest(g, g)
+	if err != nil {
+		t.Fatalf("BoxMTest() unexpected error: %v", err)
+	}
+	if same.Statistic > 1e-9 {
+		t.Errorf("BoxMTest() statistic = %v, want 0 for identical groups", same.Statistic)
+	}
+	if same.DF != 3 {
+		t.Errorf("BoxMTest() DF = %v, want 3", same.DF)
+	}
+
+	diff, err := BoxMTest(makeGroup(100, 1), makeGroup(100, 5))
+	if err != nil {
+		t.Fatalf("BoxMTest() unexpected error: %v", err)
+	}
+	if diff.PValue > 0.001 {
+		t.Errorf("BoxMTest() PValue = %v, expected strong rejection for differently scaled groups", diff.PValue)
+	}
+
+	if _, err := BoxMTest(g); err == nil {
+		t.Errorf("BoxMTest() with one group expected error but got none")
+	}
+	if _, err := BoxMTest(g, [][]float64{g[0]}); err == nil {
+		t.Errorf("BoxMTest() with mismatched variables expected error but got none")
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"context"
+	"errors"
+)
+
+// contextCheckInterval is how many elements the long running loops process
+// between checks for cancellation. Checking on every element would add
+// measurable overhead to the float64 paths.
+const contextCheckInterval = 1 << 12
+
+// CorrelateContext is like Correlate but stops early and returns ctx.Err()
+// if ctx is cancelled or its deadline passes during the calculation.
+func CorrelateContext[T Numeric](ctx context.Context, x, y []T, correlationType Type) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	switch correlationType {
+	case Pearson:
+		return PearsonsContext(ctx, x, y)
+	case Spearman:
+		return Spearmans(x, y)
+	case KendallTau:
+		return KendallsTau(x, y)
+	case GoodmanKruskal:
+		return GoodmanKruskals(x, y)
+	default:
+		return 0, errors.New("unsupported correlation type")
+	}
+}
+
+// CorrelateBigContext is like CorrelateBig but stops early and returns
+// ctx.Err() if ctx is cancelled or its deadline passes during the
+// calculation. High precision calculations over millions of values can take
+// minutes, so this is the recommended entry point for services.
+func CorrelateBigContext[T BigNumeric](ctx context.Context, x, y []T, correlationType Type, opts ...BigOption) (float64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if len(x) != len(y) {
+		return 0, errors.New("slices must have the same length")
+	}
+	if len(x) == 0 {
+		return 0, errors.New("slices cannot be empty")
+	}
+
+	switch correlationType {
+	case Pearson:
+		return PearsonsBigContext(ctx, x, y, opts...)
+	case Spearman:
+		return SpearmansBig(x, y)
+	case KendallTau:
+		return KendallsTauBig(x, y)
+	case GoodmanKruskal:
+		return GoodmanKruskalsBig(x, y)
+	default:
+		return 0, errors.New("unsupported correlation type")
+	}
+}
+
+// CorrelateMixedContext is like CorrelateMixed but stops early and returns
+// ctx.Err() if ctx is cancelled or its deadline passes during the
+// calculation.
+func CorrelateMixedContext[T1, T2 MixedNumeric](ctx context.Context, x []T1, y []T2, correlationType Type) (float64, error) {
+	if len(x) != len(y) {
+		return 0, errors.New("slices must have the same length")
+	}
+	if len(x) == 0 {
+		return 0, errors.New("slices cannot be empty")
+	}
+
+	xVals, err := mixedToBig(x)
+	if err != nil {
+		return 0, err
+	}
+	yVals, err := mixedToBig(y)
+	if err != nil {
+		return 0, err
+	}
+
+	return CorrelateBigContext(ctx, xVals, yVals, correlationType)
+}
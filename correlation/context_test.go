@@ -0,0 +1,95 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestCorrelateContext(t *testing.T) {
+	x := []float64{43, 21, 25, 42, 57, 59}
+	y := []float64{99, 65, 79, 75, 87, 81}
+
+	got, err := CorrelateContext(context.Background(), x, y, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateContext() unexpected error: %v", err)
+	}
+	if math.Abs(got-0.529) > 0.001 {
+		t.Errorf("CorrelateContext() = %v, expected 0.529", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CorrelateContext(ctx, x, y, Pearson); !errors.Is(err, context.Canceled) {
+		t.Errorf("CorrelateContext() with cancelled context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestCorrelateBigContext(t *testing.T) {
+	x, err := parseBigFloats([]string{"1e1000", "2e1000", "3e1000", "4e1000", "5e1000"}, "x", NumberFormat{})
+	if err != nil {
+		t.Fatalf("parseBigFloats() unexpected error: %v", err)
+	}
+	y, err := parseBigFloats([]string{"3e1000", "6e1000", "7e1000", "3e1000", "9e1000"}, "y", NumberFormat{})
+	if err != nil {
+		t.Fatalf("parseBigFloats() unexpected error: %v", err)
+	}
+
+	got, err := CorrelateBigContext(context.Background(), x, y, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateBigContext() unexpected error: %v", err)
+	}
+	if math.Abs(got-0.545705) > 0.000001 {
+		t.Errorf("CorrelateBigContext() = %v, expected 0.545705", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := CorrelateBigContext(ctx, x, y, Pearson); !errors.Is(err, context.Canceled) {
+		t.Errorf("CorrelateBigContext() with cancelled context error = %v, want %v", err, context.Canceled)
+	}
+	if _, err := CorrelateMixedContext(ctx, []int{1, 2, 3}, []float64{1, 2, 4}, Pearson); !errors.Is(err, context.Canceled) {
+		t.Errorf("CorrelateMixedContext() with cancelled context error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestPearsonsBigContextDeadline(t *testing.T) {
+	// Large enough that the calculation cannot finish before the deadline,
+	// so the cancellation check inside the loop must be what stops it.
+	const n = 1 << 20
+	x := make([]*big.Float, n)
+	y := make([]*big.Float, n)
+	for i := range n {
+		x[i] = new(big.Float).SetPrec(2048).SetInt64(int64(i))
+		y[i] = new(big.Float).SetPrec(2048).SetInt64(int64(n - i))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := PearsonsBigContext(ctx, x, y)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("PearsonsBigContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("PearsonsBigContext() took %v to notice the deadline", elapsed)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// ToFloat64s converts a slice of any supported numeric type to float64.
+//
+// Primitive values are converted with the usual Go conversion, so integers
+// wider than 53 bits are rounded to the nearest float64. Big values are
+// rounded to the nearest float64 as well, but a finite big value whose
+// magnitude is too large for float64 is reported as an error rather than
+// silently becoming ±Inf.
+//
+// An error is returned if a big value is nil or overflows float64, or if
+// the element type is a named type not handled by the package.
+func ToFloat64s[T MixedNumeric](data []T) ([]float64, error) {
+	switch d := any(data).(type) {
+	case []float64:
+		return numericToFloat64s(d), nil
+	case []float32:
+		return numericToFloat64s(d), nil
+	case []int:
+		return numericToFloat64s(d), nil
+	case []int8:
+		return numericToFloat64s(d), nil
+	case []int16:
+		return numericToFloat64s(d), nil
+	case []int32:
+		return numericToFloat64s(d), nil
+	case []int64:
+		return numericToFloat64s(d), nil
+	case []uint:
+		return numericToFloat64s(d), nil
+	case []uint8:
+		return numericToFloat64s(d), nil
+	case []uint16:
+		return numericToFloat64s(d), nil
+	case []uint32:
+		return numericToFloat64s(d), nil
+	case []uint64:
+		return numericToFloat64s(d), nil
+	case []*big.Float:
+		out := make([]float64, len(d))
+		for i, v := range d {
+			if v == nil {
+				return nil, nilValueError(i)
+			}
+			f, _ := v.Float64()
+			if math.IsInf(f, 0) && !v.IsInf() {
+				return nil, overflowError(i)
+			}
+			out[i] = f
+		}
+
+		return out, nil
+	case []*big.Int:
+		out := make([]float64, len(d))
+		for i, v := range d {
+			if v == nil {
+				return nil, nilValueError(i)
+			}
+			f, _ := v.Float64()
+			if math.IsInf(f, 0) {
+				return nil, overflowError(i)
+			}
+			out[i] = f
+		}
+
+		return out, nil
+	default:
+		return nil, errors.New("unsupported element type for conversion to float64")
+	}
+}
+
+// ToBigFloats converts a slice of any supported numeric type to *big.Float.
+// Every element of the result is a new value, so the results may be
+// modified without affecting the input.
+//
+// Unlike the float64 conversion no precision is lost: integers of any size
+// are represented exactly.
+//
+// An error is returned if a value is NaN or a nil pointer, since neither
+// can be represented by big.Float, or if the element type is a named type
+// not handled by the package.
+func ToBigFloats[T MixedNumeric](data []T) ([]*big.Float, error) {
+	for i, v := range data {
+		switch b := any(v).(type) {
+		case *big.Float:
+			if b == nil {
+				return nil, nilValueError(i)
+			}
+		case *big.Int:
+			if b == nil {
+				return nil, nilValueError(i)
+			}
+		case float64:
+			if math.IsNaN(b) {
+				return nil, errors.New("NaN at index " + strconv.Itoa(i) + " cannot be converted to big.Float")
+			}
+		case float32:
+			if math.IsNaN(float64(b)) {
+				return nil, errors.New("NaN at index " + strconv.Itoa(i) + " cannot be converted to big.Float")
+			}
+		}
+	}
+
+	return mixedToBig(data)
+}
+
+// numericToFloat64s converts primitive numeric values to float64.
+func numericToFloat64s[T Numeric](data []T) []float64 {
+	out := make([]float64, len(data))
+	for i, v := range data {
+		out[i] = float64(v)
+	}
+
+	return out
+}
+
+// nilValueError reports a nil big value at index i.
+func nilValueError(i int) error {
+	return errors.New("nil value at index " + strconv.Itoa(i))
+}
+
+// overflowError reports a value at index i that is too large for float64.
+func overflowError(i int) error {
+	return errors.New("value at index " + strconv.Itoa(i) + " overflows float64")
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestToFloat64s(t *testing.T) {
+	want := []float64{-3, 0, 7}
+
+	check := func(t *testing.T, name string, got []float64, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("ToFloat64s(%s) unexpected error: %v", name, err)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("ToFloat64s(%s) = %v, want %v", name, got, want)
+		}
+	}
+
+	got, err := ToFloat64s([]int{-3, 0, 7})
+	check(t, "int", got, err)
+	got, err = ToFloat64s([]int8{-3, 0, 7})
+	check(t, "int8", got, err)
+	got, err = ToFloat64s([]float32{-3, 0, 7})
+	check(t, "float32", got, err)
+	got, err = ToFloat64s([]*big.Int{big.NewInt(-3), big.NewInt(0), big.NewInt(7)})
+	check(t, "big.Int", got, err)
+	got, err = ToFloat64s([]*big.Float{big.NewFloat(-3), big.NewFloat(0), big.NewFloat(7)})
+	check(t, "big.Float", got, err)
+
+	in := []float64{-3, 0, 7}
+	got, err = ToFloat64s(in)
+	check(t, "float64", got, err)
+	got[0] = 99
+	if in[0] != -3 {
+		t.Errorf("ToFloat64s() result aliases its input")
+	}
+
+	got, err = ToFloat64s([]uint64{math.MaxUint64})
+	if err != nil || got[0] != math.MaxUint64 {
+		t.Errorf("ToFloat64s(MaxUint64) = %v, %v, want %v", got, err, float64(math.MaxUint64))
+	}
+
+	// An infinite big.Float is not an overflow.
+	got, err = ToFloat64s([]*big.Float{new(big.Float).SetInf(true)})
+	if err != nil || !math.IsInf(got[0], -1) {
+		t.Errorf("ToFloat64s(-Inf) = %v, %v, want [-Inf]", got, err)
+	}
+}
+
+func TestToFloat64sErrors(t *testing.T) {
+	huge := new(big.Int).Lsh(big.NewInt(1), 2000)
+	if _, err := ToFloat64s([]*big.Int{big.NewInt(1), huge}); err == nil {
+		t.Errorf("ToFloat64s() with 2^2000 expected error but got none")
+	}
+	if _, err := ToFloat64s([]*big.Float{new(big.Float).SetInt(huge)}); err == nil {
+		t.Errorf("ToFloat64s() with big.Float 2^2000 expected error but got none")
+	}
+	if _, err := ToFloat64s([]*big.Float{nil}); err == nil {
+		t.Errorf("ToFloat64s() with nil expected error but got none")
+	}
+
+	type celsius float64
+	if _, err := ToFloat64s([]celsius{1}); err == nil {
+		t.Errorf("ToFloat64s() with named type expected error but got none")
+	}
+}
+
+func TestToBigFloats(t *testing.T) {
+	got, err := ToBigFloats([]int64{math.MaxInt64, -1})
+	if err != nil {
+		t.Fatalf("ToBigFloats() unexpected error: %v", err)
+	}
+	if v, acc := got[0].Int64(); v != math.MaxInt64 || acc != big.Exact {
+		t.Errorf("ToBigFloats(MaxInt64) = %v (%v), want exact %d", v, acc, int64(math.MaxInt64))
+	}
+
+	in := []*big.Float{big.NewFloat(2)}
+	got, err = ToBigFloats(in)
+	if err != nil {
+		t.Fatalf("ToBigFloats() unexpected error: %v", err)
+	}
+	got[0].SetInt64(5)
+	if in[0].Cmp(big.NewFloat(2)) != 0 {
+		t.Errorf("ToBigFloats() result aliases its input")
+	}
+
+	if _, err := ToBigFloats([]float64{1, math.NaN()}); err == nil {
+		t.Errorf("ToBigFloats() with NaN expected error but got none")
+	}
+	if _, err := ToBigFloats([]*big.Int{big.NewInt(1), nil}); err == nil {
+		t.Errorf("ToBigFloats() with nil expected error but got none")
+	}
+}
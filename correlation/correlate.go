@@ -15,9 +15,13 @@
 package correlation
 
 import (
+	"context"
 	"errors"
+	"math"
 	"math/big"
 	"strconv"
+	"strings"
+	"unicode"
 )
 
 // Type represents the type of correlation coefficient to calculate.
@@ -52,6 +56,89 @@ func (c Type) String() string {
 	}
 }
 
+// typeNames maps the normalized names accepted by ParseType to the types.
+// The first name listed for each type is the one MarshalText produces.
+var typeNames = map[string]Type{
+	"pearson":                 Pearson,
+	"spearman":                Spearman,
+	"kendalltau":              KendallTau,
+	"goodmankruskal":          GoodmanKruskal,
+	"r":                       Pearson,
+	"rho":                     Spearman,
+	"kendall":                 KendallTau,
+	"tau":                     KendallTau,
+	"kendallstau":             KendallTau,
+	"gamma":                   GoodmanKruskal,
+	"goodmanandkruskalsgamma": GoodmanKruskal,
+	"goodmankruskalgamma":     GoodmanKruskal,
+}
+
+// ParseType returns the correlation type with the given name, so that
+// command line flags and configuration files can select the method by name.
+// Matching ignores case, spaces, hyphens, underscores and apostrophes, and
+// accepts the constant names ("KendallTau"), the String forms ("Kendall's
+// Tau") and common aliases: "r", "rho", "kendall", "tau" and "gamma".
+//
+// An error is returned if the name is not recognized.
+func ParseType(name string) (Type, error) {
+	key := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_', '\'', '’':
+			return -1
+		}
+
+		return unicode.ToLower(r)
+	}, name)
+
+	if t, ok := typeNames[key]; ok {
+		return t, nil
+	}
+
+	return Pearson, errors.New("unknown correlation type " + strconv.Quote(name))
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the type as its
+// lower case constant name, such as "kendalltau".
+//
+// An error is returned if c is not a defined correlation type.
+func (c Type) MarshalText() ([]byte, error) {
+	switch c {
+	case Pearson:
+		return []byte("pearson"), nil
+	case Spearman:
+		return []byte("spearman"), nil
+	case KendallTau:
+		return []byte("kendalltau"), nil
+	case GoodmanKruskal:
+		return []byte("goodmankruskal"), nil
+	default:
+		return nil, errors.New("unknown correlation type " + strconv.Itoa(int(c)))
+	}
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, accepting any name
+// ParseType does.
+func (c *Type) UnmarshalText(text []byte) error {
+	t, err := ParseType(string(text))
+	if err != nil {
+		return err
+	}
+	*c = t
+
+	return nil
+}
+
+// Set implements flag.Value, so a Type can be used with flag.Var. It
+// accepts any name ParseType does.
+func (c *Type) Set(name string) error {
+	return c.UnmarshalText([]byte(name))
+}
+
+// valid reports whether c is one of the defined correlation types.
+func (c Type) valid() bool {
+	return c >= Pearson && c <= GoodmanKruskal
+}
+
 // Numeric represents any primitive numeric type that can be used in correlation calculations.
 type Numeric interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
@@ -75,6 +162,11 @@ type MixedNumeric interface {
 // - 0 indicates no relationship
 // - -1 indicates a perfect negative relationship
 //
+// Every coefficient in this package is clamped to [-1, 1], so rounding error
+// cannot produce values such as 1.0000000000000002 that break downstream
+// code like math.Acos or FisherZ. CorrelateWithOptions can turn this off
+// with WithoutClamping.
+//
 // Returns an error if the slices have different lengths, are empty, or if the
 // correlation type is not supported.
 func Correlate[T Numeric](x, y []T, correlationType Type) (float64, error) {
@@ -92,15 +184,56 @@ func Correlate[T Numeric](x, y []T, correlationType Type) (float64, error) {
 	}
 }
 
+// correlateUnclamped is Correlate without the final clamping of the
+// coefficient to [-1, 1].
+func correlateUnclamped[T Numeric](x, y []T, correlationType Type) (float64, error) {
+	if correlationType == Pearson {
+		return pearsonsSinglePass(x, y)
+	}
+	if !correlationType.valid() {
+		return 0, errors.New("unsupported correlation type")
+	}
+
+	st, err := numericRankStats(x, y)
+	if err != nil {
+		return 0, err
+	}
+	switch correlationType {
+	case Spearman:
+		return st.spearman()
+	case KendallTau:
+		return st.kendallTauB()
+	default:
+		return st.gamma()
+	}
+}
+
+// clampUnit limits r to the interval [-1, 1] to absorb rounding error.
+func clampUnit(r float64) float64 {
+	return math.Max(-1, math.Min(1, r))
+}
+
+// clamped applies clampUnit to the result of a calculation that succeeded.
+func clamped(r float64, err error) (float64, error) {
+	if err != nil {
+		return 0, err
+	}
+
+	return clampUnit(r), nil
+}
+
 // CorrelateBig calculates the specified correlation coefficient between two datasets x and y
 // of big number types (*big.Float or *big.Int). It returns a float64 value between -1 and 1, where:
 // - 1 indicates a perfect positive relationship
 // - 0 indicates no relationship
 // - -1 indicates a perfect negative relationship
 //
+// The options set the working precision of the Pearson calculation; see
+// WithPrecision.
+//
 // Returns an error if the slices have different lengths, are empty, or if the
 // correlation type is not supported, or any conversion errors occur.
-func CorrelateBig[T BigNumeric](x, y []T, correlationType Type) (float64, error) {
+func CorrelateBig[T BigNumeric](x, y []T, correlationType Type, opts ...BigOption) (float64, error) {
 	if len(x) != len(y) {
 		return 0, errors.New("slices must have the same length")
 	}
@@ -110,7 +243,7 @@ func CorrelateBig[T BigNumeric](x, y []T, correlationType Type) (float64, error)
 
 	switch correlationType {
 	case Pearson:
-		return PearsonsBig(x, y)
+		return PearsonsBig(x, y, opts...)
 	case Spearman:
 		return SpearmansBig(x, y)
 	case KendallTau:
@@ -122,11 +255,75 @@ func CorrelateBig[T BigNumeric](x, y []T, correlationType Type) (float64, error)
 	}
 }
 
+// CorrelateBigExact is like CorrelateBig but returns the coefficient as a
+// *big.Float instead of rounding it to float64, so the precision of the
+// inputs is carried through to the result.
+//
+// The result has the working precision of the calculation, which is the
+// largest precision among the inputs, and at least 64 bits, unless set with
+// WithPrecision. A *big.Int counts as having as many bits of precision as it
+// has significant bits. For the rank based coefficients the pair counts and ranks are exact, so
+// only the final division and square root are rounded. Like every other
+// coefficient in this package, the result is clamped to [-1, 1].
+//
+// Returns an error if the slices have different lengths, are empty, or if the
+// correlation type is not supported, the coefficient is undefined, or an
+// option has an invalid value.
+func CorrelateBigExact[T BigNumeric](x, y []T, correlationType Type, opts ...BigOption) (*big.Float, error) {
+	if len(x) != len(y) {
+		return nil, errors.New("slices must have the same length")
+	}
+	if len(x) == 0 {
+		return nil, errors.New("slices cannot be empty")
+	}
+	if !correlationType.valid() {
+		return nil, errors.New("unsupported correlation type")
+	}
+
+	prec, err := workingPrecision(x, y, opts)
+	if err != nil {
+		return nil, err
+	}
+	if correlationType == Pearson {
+		return clampedBig(pearsonsBigFloat(context.Background(), x, y, prec))
+	}
+
+	st, err := bigRankStats(x, y)
+	if err != nil {
+		return nil, err
+	}
+	switch correlationType {
+	case Spearman:
+		return clampedBig(st.bigSpearman(prec))
+	case KendallTau:
+		return clampedBig(st.bigKendallTauB(prec))
+	default:
+		return clampedBig(st.bigGamma(prec))
+	}
+}
+
+// clampedBig is clamped for a *big.Float result, limiting it to [-1, 1].
+func clampedBig(r *big.Float, err error) (*big.Float, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case r.Cmp(big.NewFloat(1)) > 0:
+		r.SetInt64(1)
+	case r.Cmp(big.NewFloat(-1)) < 0:
+		r.SetInt64(-1)
+	}
+
+	return r, nil
+}
+
 // CorrelateMixed calculates the specified correlation coefficient
 // between two datasets x and y with a set of mixed type inputs.
 //
-// TODO(rsned): Make this smarter by checking if all the mixed inputs
-// are of primitive types and staying in the float64 realm where possible.
+// When every value is exactly representable as a float64 the coefficient is
+// calculated with the float64 implementation, as by Correlate. Otherwise all
+// values are converted to *big.Float and it is calculated as by CorrelateBig.
 func CorrelateMixed[T1, T2 MixedNumeric](x []T1, y []T2, correlationType Type) (float64, error) {
 	if len(x) != len(y) {
 		return 0, errors.New("slices must have the same length")
@@ -135,6 +332,12 @@ func CorrelateMixed[T1, T2 MixedNumeric](x []T1, y []T2, correlationType Type) (
 		return 0, errors.New("slices cannot be empty")
 	}
 
+	if xf, ok := mixedToFloat64(x); ok {
+		if yf, ok := mixedToFloat64(y); ok {
+			return Correlate(xf, yf, correlationType)
+		}
+	}
+
 	// Convert mixed types to big.Float using the helper function
 	xVals, err := mixedToBig(x)
 	if err != nil {
@@ -149,6 +352,77 @@ func CorrelateMixed[T1, T2 MixedNumeric](x []T1, y []T2, correlationType Type) (
 	return CorrelateBig(xVals, yVals, correlationType)
 }
 
+// maxExactInt is the largest magnitude below which every integer is exactly
+// representable as a float64.
+const maxExactInt = 1 << 53
+
+// mixedToFloat64 converts data to float64 and reports whether every value
+// was converted exactly. It gives up at the first value that would be
+// rounded or that is not finite, leaving those to the big.Float path.
+func mixedToFloat64[T MixedNumeric](data []T) ([]float64, bool) {
+	result := make([]float64, len(data))
+
+	for i, val := range data {
+		switch v := any(val).(type) {
+		case *big.Float:
+			f, acc := v.Float64()
+			if acc != big.Exact {
+				return nil, false
+			}
+			result[i] = f
+		case *big.Int:
+			if !v.IsInt64() || v.Int64() > maxExactInt || v.Int64() < -maxExactInt {
+				return nil, false
+			}
+			result[i] = float64(v.Int64())
+		case int:
+			// Converted first, as the bound overflows a 32-bit int.
+			if int64(v) > maxExactInt || int64(v) < -maxExactInt {
+				return nil, false
+			}
+			result[i] = float64(v)
+		case int64:
+			if v > maxExactInt || v < -maxExactInt {
+				return nil, false
+			}
+			result[i] = float64(v)
+		case uint:
+			if uint64(v) > maxExactInt {
+				return nil, false
+			}
+			result[i] = float64(v)
+		case uint64:
+			if v > maxExactInt {
+				return nil, false
+			}
+			result[i] = float64(v)
+		case int8:
+			result[i] = float64(v)
+		case int16:
+			result[i] = float64(v)
+		case int32:
+			result[i] = float64(v)
+		case uint8:
+			result[i] = float64(v)
+		case uint16:
+			result[i] = float64(v)
+		case uint32:
+			result[i] = float64(v)
+		case float32:
+			result[i] = float64(v)
+		case float64:
+			result[i] = v
+		default:
+			return nil, false
+		}
+		if !isFinite(result[i]) {
+			return nil, false
+		}
+	}
+
+	return result, true
+}
+
 // mixedToBig takes a slice of generic type MixedNumeric and converts each
 // element to *big.Float for consistent arithmetic. The method makes no attempt
 // at optimizing to float64 even if all values fall within the valid range
@@ -157,8 +431,101 @@ func CorrelateMixed[T1, T2 MixedNumeric](x []T1, y []T2, correlationType Type) (
 // This helper function handles the conversion from various numeric types
 // (int, float, *big.Int, *big.Float) to *big.Float.
 //
+// The slice types are recognized once, and each is converted in a tight
+// loop into a single block of big.Float values. Slices of named types fall
+// back to a type switch per element.
+//
 // Returns an error if any element cannot be converted.
 func mixedToBig[T MixedNumeric](data []T) ([]*big.Float, error) {
+	switch d := any(data).(type) {
+	case []float64:
+		return floatsToBig(d), nil
+	case []float32:
+		return floatsToBig(d), nil
+	case []int:
+		return intsToBig(d), nil
+	case []int8:
+		return intsToBig(d), nil
+	case []int16:
+		return intsToBig(d), nil
+	case []int32:
+		return intsToBig(d), nil
+	case []int64:
+		return intsToBig(d), nil
+	case []uint:
+		return uintsToBig(d), nil
+	case []uint8:
+		return uintsToBig(d), nil
+	case []uint16:
+		return uintsToBig(d), nil
+	case []uint32:
+		return uintsToBig(d), nil
+	case []uint64:
+		return uintsToBig(d), nil
+	case []*big.Float:
+		vals, result := newBigFloats(len(d))
+		for i, v := range d {
+			vals[i].Copy(v)
+		}
+
+		return result, nil
+	case []*big.Int:
+		vals, result := newBigFloats(len(d))
+		for i, v := range d {
+			vals[i].SetInt(v)
+		}
+
+		return result, nil
+	}
+
+	return mixedToBigEach(data)
+}
+
+// newBigFloats returns n zero big.Float values allocated as one block,
+// together with pointers to each of them.
+func newBigFloats(n int) ([]big.Float, []*big.Float) {
+	vals := make([]big.Float, n)
+	ptrs := make([]*big.Float, n)
+	for i := range vals {
+		ptrs[i] = &vals[i]
+	}
+
+	return vals, ptrs
+}
+
+// floatsToBig converts floating point values to *big.Float.
+func floatsToBig[E float32 | float64](data []E) []*big.Float {
+	vals, result := newBigFloats(len(data))
+	for i, v := range data {
+		vals[i].SetFloat64(float64(v))
+	}
+
+	return result
+}
+
+// intsToBig converts signed integers to *big.Float.
+func intsToBig[E int | int8 | int16 | int32 | int64](data []E) []*big.Float {
+	vals, result := newBigFloats(len(data))
+	for i, v := range data {
+		vals[i].SetInt64(int64(v))
+	}
+
+	return result
+}
+
+// uintsToBig converts unsigned integers to *big.Float.
+func uintsToBig[E uint | uint8 | uint16 | uint32 | uint64](data []E) []*big.Float {
+	vals, result := newBigFloats(len(data))
+	for i, v := range data {
+		vals[i].SetUint64(uint64(v))
+	}
+
+	return result
+}
+
+// mixedToBigEach is mixedToBig with a type switch on every element, for
+// slices whose type is not one of the predeclared ones.
+func mixedToBigEach[T MixedNumeric](data []T) ([]*big.Float, error) {
 	n := len(data)
 	result := make([]*big.Float, n)
 
@@ -207,19 +574,5 @@ func mixedToBig[T MixedNumeric](data []T) ([]*big.Float, error) {
 //
 // Panics if the input type is not supported (should only be used with BigNumeric types).
 func bigNumericToBigFloat[T BigNumeric](val T) *big.Float {
-	switch v := any(val).(type) {
-	case *big.Float:
-		return new(big.Float).Copy(v)
-	case *big.Int:
-		return new(big.Float).SetInt(v)
-	default:
-		panic("unsupported big numeric type")
-	}
+	return setBigNumeric(new(big.Float), val)
 }
-
-// TODO(rsned): Consider adding a variation of Correlate that takes slices of string
-// values that represent numbers. (To allow for passing in values in scientific
-// notation, or in a format that is not easily converted to a number.) In this
-// method, detect when to switch to math/big values based on parsing the strings.
-// TODO(rsned): Once string support is in, i18n the method to handle numbers
-// formatted in different styles, e.g. ' ', or '.' as the Thousands Separator, etc.
@@ -40,6 +40,96 @@ func getSeed() int64 {
 	return *seed
 }
 
+func TestParseType(t *testing.T) {
+	tests := []struct {
+		name string
+		want Type
+	}{
+		{name: "pearson", want: Pearson},
+		{name: "Pearson", want: Pearson},
+		{name: "r", want: Pearson},
+		{name: "SPEARMAN", want: Spearman},
+		{name: "rho", want: Spearman},
+		{name: "kendall", want: KendallTau},
+		{name: "KendallTau", want: KendallTau},
+		{name: "kendall_tau", want: KendallTau},
+		{name: "Kendall's Tau", want: KendallTau},
+		{name: "tau", want: KendallTau},
+		{name: "gamma", want: GoodmanKruskal},
+		{name: "goodman-kruskal", want: GoodmanKruskal},
+		{name: "Goodman and Kruskal's Gamma", want: GoodmanKruskal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseType(tt.name)
+			if err != nil {
+				t.Fatalf("ParseType(%q) unexpected error: %v", tt.name, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseType(%q) = %v, want %v", tt.name, got, tt.want)
+			}
+		})
+	}
+
+	for _, name := range []string{"", "pearsons", "cosine"} {
+		if _, err := ParseType(name); err == nil {
+			t.Errorf("ParseType(%q) expected error but got none", name)
+		}
+	}
+}
+
+func TestTypeText(t *testing.T) {
+	for _, ct := range []Type{Pearson, Spearman, KendallTau, GoodmanKruskal} {
+		text, err := ct.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText(%v) unexpected error: %v", ct, err)
+		}
+		var got Type
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q) unexpected error: %v", text, err)
+		}
+		if got != ct {
+			t.Errorf("UnmarshalText(MarshalText(%v)) = %v", ct, got)
+		}
+
+		// The String form is accepted too.
+		if err := got.UnmarshalText([]byte(ct.String())); err != nil || got != ct {
+			t.Errorf("UnmarshalText(%q) = %v, %v, want %v", ct.String(), got, err, ct)
+		}
+	}
+
+	if _, err := Type(99).MarshalText(); err == nil {
+		t.Errorf("MarshalText(Type(99)) expected error but got none")
+	}
+
+	got := KendallTau
+	if err := got.UnmarshalText([]byte("unknown")); err == nil {
+		t.Errorf("UnmarshalText(unknown) expected error but got none")
+	}
+	if got != KendallTau {
+		t.Errorf("UnmarshalText(unknown) changed the value to %v", got)
+	}
+}
+
+func TestTypeFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	ct := Pearson
+	fs.Var(&ct, "method", "correlation method")
+
+	if err := fs.Parse([]string{"-method", "spearman"}); err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if ct != Spearman {
+		t.Errorf("-method spearman set %v, want Spearman", ct)
+	}
+
+	fs.SetOutput(&strings.Builder{})
+	if err := fs.Parse([]string{"-method", "cosine"}); err == nil {
+		t.Errorf("Parse(-method cosine) expected error but got none")
+	}
+}
+
 func TestCorrelate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -368,6 +458,91 @@ func TestCorrelateBig(t *testing.T) {
 	}
 }
 
+func TestCorrelateBigExact(t *testing.T) {
+	const prec = 256
+	floats := func(vs ...int64) []*big.Float {
+		out := make([]*big.Float, len(vs))
+		for i, v := range vs {
+			out[i] = new(big.Float).SetPrec(prec).SetInt64(v)
+		}
+
+		return out
+	}
+	exact := func(num, den int64) *big.Float {
+		r := new(big.Float).SetPrec(prec).SetInt64(num)
+
+		return r.Quo(r, new(big.Float).SetPrec(prec).SetInt64(den))
+	}
+
+	tests := []struct {
+		name string
+		typ  Type
+		x, y []*big.Float
+		want *big.Float
+	}{
+		{name: "Pearson", typ: Pearson, x: floats(1, 2, 3, 4), y: floats(1, 3, 2, 4), want: exact(4, 5)},
+		{name: "Spearman", typ: Spearman, x: floats(1, 2, 3), y: floats(1, 3, 2), want: exact(1, 2)},
+		{name: "Kendall", typ: KendallTau, x: floats(1, 2, 3), y: floats(1, 3, 2), want: exact(1, 3)},
+		{name: "Goodman Kruskal", typ: GoodmanKruskal, x: floats(1, 2, 3), y: floats(1, 3, 2), want: exact(1, 3)},
+		{name: "perfect", typ: Pearson, x: floats(1, 2, 3), y: floats(-2, -4, -6), want: exact(-1, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CorrelateBigExact(tt.x, tt.y, tt.typ)
+			if err != nil {
+				t.Fatalf("CorrelateBigExact() unexpected error: %v", err)
+			}
+			if got.Prec() != prec {
+				t.Errorf("CorrelateBigExact() precision = %d, want %d", got.Prec(), prec)
+			}
+			if got.Cmp(tt.want) != 0 {
+				t.Errorf("CorrelateBigExact() = %s, want %s", got.Text('g', 80), tt.want.Text('g', 80))
+			}
+
+			f, err := CorrelateBig(tt.x, tt.y, tt.typ)
+			if err != nil {
+				t.Fatalf("CorrelateBig() unexpected error: %v", err)
+			}
+			if g, _ := got.Float64(); g != f {
+				t.Errorf("CorrelateBigExact() rounds to %v, CorrelateBig() = %v", g, f)
+			}
+		})
+	}
+
+	// A *big.Int contributes its bit length to the working precision.
+	huge := new(big.Int).Lsh(big.NewInt(1), 200)
+	xs := []*big.Int{big.NewInt(1), big.NewInt(2), huge}
+	ys := []*big.Int{big.NewInt(3), huge, big.NewInt(5)}
+	got, err := CorrelateBigExact(xs, ys, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateBigExact() unexpected error: %v", err)
+	}
+	if got.Prec() != 201 {
+		t.Errorf("CorrelateBigExact() precision = %d, want 201", got.Prec())
+	}
+
+	errorTests := []struct {
+		name string
+		x, y []*big.Float
+		typ  Type
+	}{
+		{name: "length mismatch", x: floats(1, 2, 3), y: floats(1, 2), typ: Pearson},
+		{name: "empty", x: floats(), y: floats(), typ: Pearson},
+		{name: "unsupported type", x: floats(1, 2), y: floats(2, 1), typ: Type(99)},
+		{name: "constant Pearson", x: floats(1, 1, 1), y: floats(1, 2, 3), typ: Pearson},
+		{name: "constant Kendall", x: floats(1, 1, 1), y: floats(1, 2, 3), typ: KendallTau},
+		{name: "all tied gamma", x: floats(1, 1, 1), y: floats(1, 2, 3), typ: GoodmanKruskal},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CorrelateBigExact(tt.x, tt.y, tt.typ); err == nil {
+				t.Errorf("CorrelateBigExact() expected error but got none")
+			}
+		})
+	}
+}
+
 func TestCorrelateMixed(t *testing.T) {
 	// Test with int slices
 	t.Run("int types", func(t *testing.T) {
@@ -587,6 +762,121 @@ func TestCorrelateMixed(t *testing.T) {
 	})
 }
 
+func TestMixedToFloat64(t *testing.T) {
+	third := new(big.Float).SetPrec(100).Quo(big.NewFloat(1), big.NewFloat(3))
+	tests := []struct {
+		name    string
+		convert func() ([]float64, bool)
+		want    []float64
+		ok      bool
+	}{
+		{
+			name:    "int8",
+			convert: func() ([]float64, bool) { return mixedToFloat64([]int8{-3, 7}) },
+			want:    []float64{-3, 7},
+			ok:      true,
+		},
+		{
+			name:    "float32",
+			convert: func() ([]float64, bool) { return mixedToFloat64([]float32{0.5, -2.25}) },
+			want:    []float64{0.5, -2.25},
+			ok:      true,
+		},
+		{
+			name:    "int64 at the exact limit",
+			convert: func() ([]float64, bool) { return mixedToFloat64([]int64{1 << 53, -(1 << 53)}) },
+			want:    []float64{1 << 53, -(1 << 53)},
+			ok:      true,
+		},
+		{
+			name:    "int64 beyond the exact limit",
+			convert: func() ([]float64, bool) { return mixedToFloat64([]int64{1<<53 + 1}) },
+			want:    nil,
+			ok:      false,
+		},
+		{
+			name:    "uint64 beyond the exact limit",
+			convert: func() ([]float64, bool) { return mixedToFloat64([]uint64{1 << 60}) },
+			want:    nil,
+			ok:      false,
+		},
+		{
+			name:    "exact big.Int",
+			convert: func() ([]float64, bool) { return mixedToFloat64([]*big.Int{big.NewInt(-12), big.NewInt(5)}) },
+			want:    []float64{-12, 5},
+			ok:      true,
+		},
+		{
+			name:    "big.Int beyond int64",
+			convert: func() ([]float64, bool) { return mixedToFloat64([]*big.Int{new(big.Int).Lsh(big.NewInt(1), 70)}) },
+			want:    nil,
+			ok:      false,
+		},
+		{
+			name:    "exact big.Float",
+			convert: func() ([]float64, bool) { return mixedToFloat64([]*big.Float{big.NewFloat(0.125)}) },
+			want:    []float64{0.125},
+			ok:      true,
+		},
+		{
+			name:    "big.Float that rounds",
+			convert: func() ([]float64, bool) { return mixedToFloat64([]*big.Float{third}) },
+			want:    nil,
+			ok:      false,
+		},
+		{
+			name: "big.Float beyond the float64 range",
+			convert: func() ([]float64, bool) {
+				return mixedToFloat64([]*big.Float{new(big.Float).SetMantExp(big.NewFloat(1), 5000)})
+			},
+			want: nil,
+			ok:   false,
+		},
+		{
+			name:    "infinite float64",
+			convert: func() ([]float64, bool) { return mixedToFloat64([]float64{1, math.Inf(1)}) },
+			want:    nil,
+			ok:      false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := test.convert()
+			if ok != test.ok {
+				t.Fatalf("mixedToFloat64() ok = %v, want %v", ok, test.ok)
+			}
+			if !ok {
+				return
+			}
+			for i := range test.want {
+				if got[i] != test.want[i] {
+					t.Errorf("mixedToFloat64()[%d] = %v, want %v", i, got[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCorrelateMixedBigFallback(t *testing.T) {
+	// Steps of 2^-60 above 1 all round to 1 in float64, so only the
+	// big.Float path sees that y increases with x.
+	x := make([]*big.Float, 4)
+	for i := range x {
+		step := new(big.Float).SetMantExp(big.NewFloat(float64(i)), -60)
+		x[i] = new(big.Float).SetPrec(300).Add(big.NewFloat(1), step)
+	}
+	y := []float64{1, 2, 3, 4}
+
+	got, err := CorrelateMixed(x, y, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateMixed() unexpected error: %v", err)
+	}
+	if math.Abs(got-1) > 1e-12 {
+		t.Errorf("CorrelateMixed() = %v, want 1", got)
+	}
+}
+
 func TestCorrelateEdgeCases(t *testing.T) {
 	// Test with negative numbers
 	x := []float64{-5, -3, -1, 1, 3, 5}
@@ -611,6 +901,47 @@ func TestCorrelateEdgeCases(t *testing.T) {
 	}
 }
 
+func TestCorrelateClamping(t *testing.T) {
+	// Rounding in the single pass updates puts the exact coefficient of this
+	// perfectly linear data just above 1.
+	x := []float64{5.9, 5.3}
+	y := []float64{0.6321428571428571, 0.5678571428571428}
+	negY := []float64{-0.6321428571428571, -0.5678571428571428}
+
+	raw, err := CorrelateWithOptions(x, y, Pearson, WithoutClamping())
+	if err != nil {
+		t.Fatalf("CorrelateWithOptions(WithoutClamping) unexpected error: %v", err)
+	}
+	if raw.Coefficient <= 1 {
+		t.Fatalf("CorrelateWithOptions(WithoutClamping) = %v, want a value above 1 for this test", raw.Coefficient)
+	}
+
+	for _, ct := range []Type{Pearson, Spearman, KendallTau, GoodmanKruskal} {
+		got, err := Correlate(x, y, ct)
+		if err != nil {
+			t.Fatalf("Correlate(%v) unexpected error: %v", ct, err)
+		}
+		if got != 1 {
+			t.Errorf("Correlate(%v) = %v, want 1", ct, got)
+		}
+		got, err = Correlate(x, negY, ct)
+		if err != nil {
+			t.Fatalf("Correlate(%v) unexpected error: %v", ct, err)
+		}
+		if got != -1 {
+			t.Errorf("Correlate(%v) of negated y = %v, want -1", ct, got)
+		}
+
+		res, err := CorrelateWithOptions(x, y, ct)
+		if err != nil {
+			t.Fatalf("CorrelateWithOptions(%v) unexpected error: %v", ct, err)
+		}
+		if res.Coefficient != 1 {
+			t.Errorf("CorrelateWithOptions(%v) = %v, want 1", ct, res.Coefficient)
+		}
+	}
+}
+
 func TestCorrelateGenericTypes(t *testing.T) {
 	// Test with int
 	xInt := []int{1, 2, 3, 4, 5}
@@ -725,29 +1056,27 @@ func TestCorrelateWithDifferentTypes(t *testing.T) {
 			corrType: Pearson,
 			expected: 1.0,
 		},
-		/*
-			{
-				name:     "Spearman",
-				x:        []float64{1, 2, 3, 4, 5},
-				y:        []float64{2, 4, 6, 8, 10},
-				corrType: Spearman,
-				expected: 1.0,
-			},
-			{
-				name:     "KendallTau",
-				x:        []float64{1, 2, 3, 4, 5},
-				y:        []float64{2, 4, 6, 8, 10},
-				corrType: KendallTau,
-				expected: 1.0,
-			},
-			{
-				name:     "GoodmanKruskal",
-				x:        []float64{1, 2, 3, 4, 5},
-				y:        []float64{2, 4, 6, 8, 10},
-				corrType: GoodmanKruskal,
-				expected: 1.0,
-			},
-		*/
+		{
+			name:     "Spearman",
+			x:        []float64{1, 2, 3, 4, 5},
+			y:        []float64{2, 4, 6, 8, 10},
+			corrType: Spearman,
+			expected: 1.0,
+		},
+		{
+			name:     "KendallTau",
+			x:        []float64{1, 2, 3, 4, 5},
+			y:        []float64{2, 4, 6, 8, 10},
+			corrType: KendallTau,
+			expected: 1.0,
+		},
+		{
+			name:     "GoodmanKruskal",
+			x:        []float64{1, 2, 3, 4, 5},
+			y:        []float64{2, 4, 6, 8, 10},
+			corrType: GoodmanKruskal,
+			expected: 1.0,
+		},
 	}
 
 	for _, test := range tests {
@@ -1041,6 +1370,59 @@ func TestMixedToBigEdgeCases(t *testing.T) {
 	})
 }
 
+func TestMixedToBigFastPaths(t *testing.T) {
+	// Each specialized slice conversion must agree with the per element
+	// conversion.
+	check := func(name string, fast func() ([]*big.Float, error), each func() ([]*big.Float, error)) {
+		got, err := fast()
+		if err != nil {
+			t.Fatalf("%s: mixedToBig() unexpected error: %v", name, err)
+		}
+		want, err := each()
+		if err != nil {
+			t.Fatalf("%s: mixedToBigEach() unexpected error: %v", name, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: mixedToBig() has %d values, want %d", name, len(got), len(want))
+		}
+		for i := range want {
+			if got[i].Cmp(want[i]) != 0 || got[i].Prec() != want[i].Prec() {
+				t.Errorf("%s: mixedToBig()[%d] = %v at precision %d, want %v at precision %d",
+					name, i, got[i], got[i].Prec(), want[i], want[i].Prec())
+			}
+		}
+	}
+
+	ints := []int64{math.MinInt64, -3, 0, 7, math.MaxInt64}
+	uints := []uint64{0, 1, math.MaxUint64}
+	floats := []float64{-math.MaxFloat64, -0.5, 0, math.SmallestNonzeroFloat64, math.Inf(1)}
+	huge := new(big.Int).Lsh(big.NewInt(1), 200)
+
+	check("int64", func() ([]*big.Float, error) { return mixedToBig(ints) },
+		func() ([]*big.Float, error) { return mixedToBigEach(ints) })
+	check("int8", func() ([]*big.Float, error) { return mixedToBig([]int8{-128, 127}) },
+		func() ([]*big.Float, error) { return mixedToBigEach([]int8{-128, 127}) })
+	check("uint64", func() ([]*big.Float, error) { return mixedToBig(uints) },
+		func() ([]*big.Float, error) { return mixedToBigEach(uints) })
+	check("uint16", func() ([]*big.Float, error) { return mixedToBig([]uint16{0, 65535}) },
+		func() ([]*big.Float, error) { return mixedToBigEach([]uint16{0, 65535}) })
+	check("float64", func() ([]*big.Float, error) { return mixedToBig(floats) },
+		func() ([]*big.Float, error) { return mixedToBigEach(floats) })
+	check("float32", func() ([]*big.Float, error) { return mixedToBig([]float32{1.5, -math.MaxFloat32}) },
+		func() ([]*big.Float, error) { return mixedToBigEach([]float32{1.5, -math.MaxFloat32}) })
+	check("*big.Int", func() ([]*big.Float, error) { return mixedToBig([]*big.Int{huge, big.NewInt(-1)}) },
+		func() ([]*big.Float, error) { return mixedToBigEach([]*big.Int{huge, big.NewInt(-1)}) })
+	check("*big.Float", func() ([]*big.Float, error) { return mixedToBig([]*big.Float{big.NewFloat(2.5)}) },
+		func() ([]*big.Float, error) { return mixedToBigEach([]*big.Float{big.NewFloat(2.5)}) })
+
+	// The *big.Float values are copies, not the inputs.
+	in := []*big.Float{big.NewFloat(1)}
+	out, _ := mixedToBig(in)
+	if out[0] == in[0] {
+		t.Errorf("mixedToBig([]*big.Float) returned the input value rather than a copy")
+	}
+}
+
 func TestBigNumericToBigFloat(t *testing.T) {
 	t.Run("big.Float input", func(t *testing.T) {
 		input := big.NewFloat(123.456)
@@ -1251,3 +1633,47 @@ func BenchmarkCorrelateBigFloatPrecisionLargeNumbers(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkMixedToBig(b *testing.B) {
+	const n = 100000
+	rng := rand.New(rand.NewSource(getSeed()))
+	floats := make([]float64, n)
+	ints := make([]int64, n)
+	bigInts := make([]*big.Int, n)
+	for i := range n {
+		floats[i] = rng.NormFloat64()
+		ints[i] = rng.Int63()
+		bigInts[i] = big.NewInt(rng.Int63())
+	}
+
+	b.Run("float64", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = mixedToBig(floats)
+		}
+	})
+	b.Run("float64/each", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = mixedToBigEach(floats)
+		}
+	})
+	b.Run("int64", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = mixedToBig(ints)
+		}
+	})
+	b.Run("int64/each", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = mixedToBigEach(ints)
+		}
+	})
+	b.Run("*big.Int", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = mixedToBig(bigInts)
+		}
+	})
+	b.Run("*big.Int/each", func(b *testing.B) {
+		for b.Loop() {
+			_, _ = mixedToBigEach(bigInts)
+		}
+	})
+}
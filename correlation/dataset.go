@@ -0,0 +1,31 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import "github.com/rsned/stats/datasets"
+
+// CorrelateDataset calculates the specified correlation coefficient between
+// the X and Y series of d.
+//
+// The dataset is checked with Validate first, so a problem with the data is
+// reported as a *datasets.ValidationError naming the dataset and, for a
+// NaN or infinite value, the series and index where it was found.
+func CorrelateDataset(d datasets.Dataset, correlationType Type) (float64, error) {
+	if err := d.Validate(); err != nil {
+		return 0, err
+	}
+
+	return Correlate(d.X, d.Y, correlationType)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/rsned/stats/datasets"
+)
+
+func TestCorrelateDataset(t *testing.T) {
+	got, err := CorrelateDataset(datasets.AnscombeI, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateDataset() unexpected error: %v", err)
+	}
+	want, err := Correlate(datasets.AnscombeI.X, datasets.AnscombeI.Y, Pearson)
+	if err != nil {
+		t.Fatalf("Correlate() unexpected error: %v", err)
+	}
+	if got != want {
+		t.Errorf("CorrelateDataset() = %v, want %v", got, want)
+	}
+
+	bad := datasets.Dataset{
+		Name:        "bad",
+		Description: "",
+		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           []float64{1, 2, 3},
+		Y:           []float64{1, math.NaN(), 3},
+		Valid:       nil,
+	}
+	_, err = CorrelateDataset(bad, Pearson)
+	var ve *datasets.ValidationError
+	if !errors.As(err, &ve) || !errors.Is(err, datasets.ErrNonFinite) {
+		t.Fatalf("CorrelateDataset() error = %v, want a non-finite ValidationError", err)
+	}
+	if ve.Series != "Y" || ve.Index != 1 {
+		t.Errorf("CorrelateDataset() error at %s[%d], want Y[1]", ve.Series, ve.Index)
+	}
+
+	if _, err := CorrelateDataset(datasets.AnscombeI, Type(99)); err == nil {
+		t.Errorf("CorrelateDataset() with an invalid type = nil error, want error")
+	}
+}
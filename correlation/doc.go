@@ -40,3 +40,5 @@ To use with big.Floats:
 	result, err := CorrelateBig(x, y, correlate.Pearson)  // result will be ~0.545705
 */
 package correlation
+
+//go:generate go run ../tools/golden/gengolden -o testdata/golden.json
@@ -0,0 +1,186 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// DriftOption configures a DriftDetector.
+type DriftOption func(*DriftDetector)
+
+// WithDriftThreshold sets the minimum absolute difference between the recent
+// and baseline correlations that can raise an alert. The default is 0.3.
+func WithDriftThreshold(d float64) DriftOption {
+	return func(dd *DriftDetector) {
+		dd.threshold = d
+	}
+}
+
+// WithDriftSignificance sets the significance level the Fisher z comparison
+// of the recent and baseline correlations must reach to raise an alert. The
+// default is 0.01.
+func WithDriftSignificance(alpha float64) DriftOption {
+	return func(dd *DriftDetector) {
+		dd.alpha = alpha
+	}
+}
+
+// WithMinBaseline sets how many pairs must have passed through the recent
+// window into the baseline before alerts can be raised. The default is the
+// window size.
+func WithMinBaseline(n int) DriftOption {
+	return func(dd *DriftDetector) {
+		dd.minBaseline = n
+	}
+}
+
+// DriftStatus reports the state of a DriftDetector after a pair is added.
+type DriftStatus struct {
+	// Baseline is the correlation of all pairs older than the recent window,
+	// or NaN if it is not yet defined.
+	Baseline float64
+	// BaselineN is the number of pairs in the baseline.
+	BaselineN int
+	// Recent is the correlation of the pairs in the recent window, or NaN if
+	// it is not yet defined.
+	Recent float64
+	// RecentN is the number of pairs in the recent window.
+	RecentN int
+	// Z is the Fisher z statistic comparing Recent to Baseline.
+	Z float64
+	// PValue is the two-sided p-value of Z, or NaN if no comparison was made.
+	PValue float64
+	// Alert is true when the recent correlation has drifted away from the
+	// baseline by more than the threshold and the difference is significant.
+	Alert bool
+}
+
+// DriftDetector watches a stream of (x, y) pairs and raises an alert when
+// the correlation over a recent window departs from the historical baseline.
+//
+// Pairs enter a WindowedCorrelator for the recent window. When a pair is
+// evicted from the window it is added to the baseline, so the two never
+// overlap and can be compared as independent samples with FisherZCompare.
+// An alert requires both that |recent - baseline| exceeds the threshold and
+// that the comparison is significant at the configured level, which guards
+// against false alarms from small windows as well as trivially small but
+// significant changes over very long baselines.
+//
+// A DriftDetector is not safe for concurrent use.
+type DriftDetector struct {
+	threshold   float64
+	alpha       float64
+	minBaseline int
+
+	recent   *WindowedCorrelator
+	baseline comoments
+}
+
+// NewDriftDetector returns a detector that compares the correlation of the
+// most recent window pairs against all earlier pairs.
+//
+// An error is returned if the window is smaller than 4 or an option has an
+// invalid value.
+func NewDriftDetector(window int, opts ...DriftOption) (*DriftDetector, error) {
+	if window < 4 {
+		return nil, errors.New("drift window must be at least 4")
+	}
+
+	recent, err := NewWindowedCorrelator(window)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &DriftDetector{
+		threshold:   0.3,
+		alpha:       0.01,
+		minBaseline: window,
+		recent:      recent,
+		baseline:    comoments{},
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	if d.threshold < 0 || d.threshold > 2 || math.IsNaN(d.threshold) {
+		return nil, errors.New("drift threshold must be in the interval [0, 2]")
+	}
+	if d.alpha <= 0 || d.alpha >= 1 || math.IsNaN(d.alpha) {
+		return nil, errors.New("significance level must be in the open interval (0, 1)")
+	}
+	if d.minBaseline < 4 {
+		return nil, errors.New("minimum baseline must be at least 4")
+	}
+
+	return d, nil
+}
+
+// Add includes the pair (x, y) in the stream and returns the resulting
+// status.
+//
+// An error is returned if either value is NaN or ±Inf.
+func (d *DriftDetector) Add(x, y float64) (DriftStatus, error) {
+	if !isFinite(x) || !isFinite(y) {
+		return d.Status(), errors.New("values must be finite")
+	}
+
+	if oldX, oldY, evicted := d.recent.push(x, y); evicted {
+		d.baseline.add(oldX, oldY)
+	}
+
+	return d.Status(), nil
+}
+
+// Status returns the current state of the detector without adding a pair.
+func (d *DriftDetector) Status() DriftStatus {
+	st := DriftStatus{
+		Baseline:  math.NaN(),
+		BaselineN: d.baseline.n,
+		Recent:    math.NaN(),
+		RecentN:   d.recent.N(),
+		Z:         0,
+		PValue:    math.NaN(),
+		Alert:     false,
+	}
+
+	if r, err := d.baseline.value(); err == nil {
+		st.Baseline = r
+	}
+	if r, err := d.recent.Value(); err == nil {
+		st.Recent = r
+	}
+
+	if st.BaselineN < d.minBaseline || st.RecentN < d.recent.Size() ||
+		math.IsNaN(st.Baseline) || math.IsNaN(st.Recent) {
+		return st
+	}
+
+	// Keep perfect correlations inside the domain of the Fisher transform.
+	const limit = 1 - 1e-12
+	z, p, err := FisherZCompare(
+		math.Max(-limit, math.Min(limit, st.Recent)), st.RecentN,
+		math.Max(-limit, math.Min(limit, st.Baseline)), st.BaselineN,
+		TwoSided)
+	if err != nil {
+		return st
+	}
+	st.Z = z
+	st.PValue = p
+	st.Alert = math.Abs(st.Recent-st.Baseline) > d.threshold && p < d.alpha
+
+	return st
+}
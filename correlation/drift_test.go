@@ -0,0 +1,115 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDriftDetector(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	d, err := NewDriftDetector(100)
+	if err != nil {
+		t.Fatalf("NewDriftDetector() unexpected error: %v", err)
+	}
+
+	// A long stable stretch with strong positive correlation must not alert.
+	for i := range 1000 {
+		x := rng.NormFloat64()
+		st, err := d.Add(x, x+0.3*rng.NormFloat64())
+		if err != nil {
+			t.Fatalf("Add() unexpected error: %v", err)
+		}
+		if st.Alert {
+			t.Fatalf("Add() raised an alert during the stable period at pair %d: %+v", i, st)
+		}
+	}
+
+	st := d.Status()
+	if st.BaselineN != 900 || st.RecentN != 100 {
+		t.Errorf("Status() BaselineN = %d, RecentN = %d, want 900 and 100", st.BaselineN, st.RecentN)
+	}
+	if st.Baseline < 0.9 {
+		t.Errorf("Status() Baseline = %v, want > 0.9", st.Baseline)
+	}
+
+	// The relationship breaks down; the detector should notice before the
+	// window has completely turned over.
+	alerted := false
+	for range 100 {
+		st, err = d.Add(rng.NormFloat64(), rng.NormFloat64())
+		if err != nil {
+			t.Fatalf("Add() unexpected error: %v", err)
+		}
+		if st.Alert {
+			alerted = true
+
+			break
+		}
+	}
+	if !alerted {
+		t.Errorf("DriftDetector did not alert after the correlation broke down: %+v", st)
+	}
+	if st.Alert && (st.PValue >= 0.01 || math.Abs(st.Recent-st.Baseline) <= 0.3) {
+		t.Errorf("alert raised without meeting its criteria: %+v", st)
+	}
+}
+
+func TestDriftDetectorWarmUp(t *testing.T) {
+	d, err := NewDriftDetector(10, WithMinBaseline(20))
+	if err != nil {
+		t.Fatalf("NewDriftDetector() unexpected error: %v", err)
+	}
+
+	// Anti-correlated then perfectly correlated data, but without enough
+	// baseline the detector must stay quiet.
+	for i := range 25 {
+		x := float64(i % 7)
+		st, err := d.Add(x, -x)
+		if err != nil {
+			t.Fatalf("Add() unexpected error: %v", err)
+		}
+		if st.Alert || !math.IsNaN(st.PValue) {
+			t.Errorf("Add() status before minimum baseline = %+v, want no comparison", st)
+		}
+	}
+
+	if _, err := d.Add(math.Inf(1), 0); err == nil {
+		t.Errorf("Add(Inf) expected error but got none")
+	}
+}
+
+func TestNewDriftDetectorErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		window int
+		opts   []DriftOption
+	}{
+		{name: "small window", window: 3},
+		{name: "negative threshold", window: 10, opts: []DriftOption{WithDriftThreshold(-0.1)}},
+		{name: "alpha of one", window: 10, opts: []DriftOption{WithDriftSignificance(1)}},
+		{name: "small baseline", window: 10, opts: []DriftOption{WithMinBaseline(2)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewDriftDetector(tt.window, tt.opts...); err == nil {
+				t.Errorf("NewDriftDetector() expected error but got none")
+			}
+		})
+	}
+}
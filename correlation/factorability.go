@@ -0,0 +1,126 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// BartlettSphericity performs Bartlett's test of sphericity, testing the
+// null hypothesis that the correlation matrix m, computed from n
+// observations, is an identity matrix (i.e. the variables are uncorrelated).
+//
+// The statistic is
+//
+//	χ² = -(n - 1 - (2p+5)/6) ln|R|
+//
+// with p(p-1)/2 degrees of freedom, where p is the number of variables.
+// Rejecting the null hypothesis indicates there is enough shared variance
+// for factor analysis or PCA to be worthwhile.
+//
+// An error is returned if m contains undefined cells, is singular, or if n
+// is not larger than the number of variables.
+func BartlettSphericity(m *Matrix, n int) (ChiSquareResult, error) {
+	var res ChiSquareResult
+
+	r, err := definedCoefficients(m)
+	if err != nil {
+		return res, err
+	}
+	p := len(r)
+	if n <= p {
+		return res, errors.New("sample size must exceed the number of variables")
+	}
+
+	logDet, err := logDeterminant(r)
+	if err != nil {
+		return res, errors.New("correlation matrix is singular")
+	}
+
+	pf := float64(p)
+	res.Statistic = -(float64(n) - 1 - (2*pf+5)/6) * logDet
+	res.DF = pf * (pf - 1) / 2
+	res.PValue = chiSquareSurvival(res.Statistic, res.DF)
+
+	return res, nil
+}
+
+// KMO computes the Kaiser-Meyer-Olkin measure of sampling adequacy for the
+// correlation matrix m.
+//
+// It compares the magnitude of the observed correlations r_ij to that of the
+// partial correlations a_ij (taken from the inverse of m):
+//
+//	KMO = Σ r_ij² / (Σ r_ij² + Σ a_ij²),  i ≠ j
+//
+// It returns the overall measure and the per-variable measures (MSA_i,
+// where the sums are restricted to row i). Values above about 0.6 are
+// generally taken to indicate the data are suitable for factor analysis.
+//
+// An error is returned if m contains undefined cells or is singular.
+func KMO(m *Matrix) (float64, []float64, error) {
+	r, err := definedCoefficients(m)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	inv, err := invertMatrix(r)
+	if err != nil {
+		return 0, nil, errors.New("correlation matrix is singular")
+	}
+
+	p := len(r)
+	perVariable := make([]float64, p)
+	var sumR, sumA float64
+	for i := range p {
+		var rowR, rowA float64
+		for j := range p {
+			if i == j {
+				continue
+			}
+			a := -inv[i][j] / math.Sqrt(inv[i][i]*inv[j][j])
+			rowR += r[i][j] * r[i][j]
+			rowA += a * a
+		}
+		perVariable[i] = rowR / (rowR + rowA)
+		sumR += rowR
+		sumA += rowA
+	}
+
+	return sumR / (sumR + sumA), perVariable, nil
+}
+
+// definedCoefficients returns the coefficients of m, or an error if m is nil,
+// has fewer than two variables, or contains undefined (NaN) cells.
+func definedCoefficients(m *Matrix) ([][]float64, error) {
+	if m == nil {
+		return nil, errors.New("correlation matrix cannot be nil")
+	}
+	if m.Dim() < 2 {
+		return nil, errors.New("correlation matrix requires at least 2 variables")
+	}
+
+	r := m.Coefficients()
+	for i := range r {
+		for j := range r[i] {
+			if math.IsNaN(r[i][j]) {
+				return nil, errors.New("correlation matrix cannot contain undefined cells")
+			}
+		}
+	}
+
+	return r, nil
+}
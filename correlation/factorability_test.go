@@ -0,0 +1,121 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// matrixFromRows builds a Pearson Matrix from a full set of coefficients.
+func matrixFromRows(t *testing.T, rows [][]float64, n int) *Matrix {
+	t.Helper()
+
+	m := newMatrix(len(rows), Pearson)
+	for i := range rows {
+		for j := i; j < len(rows); j++ {
+			m.set(i, j, rows[i][j], n)
+		}
+	}
+
+	return m
+}
+
+func TestBartlettSphericity(t *testing.T) {
+	id := matrixFromRows(t, [][]float64{
+		{1, 0, 0},
+		{0, 1, 0},
+		{0, 0, 1},
+	}, 50)
+
+	got, err := BartlettSphericity(id, 50)
+	if err != nil {
+		t.Fatalf("BartlettSphericity() unexpected error: %v", err)
+	}
+	if math.Abs(got.Statistic) > 1e-12 || got.DF != 3 || math.Abs(got.PValue-1) > 1e-12 {
+		t.Errorf("BartlettSphericity(identity) = %+v, want statistic 0, DF 3, p-value 1", got)
+	}
+
+	// Two variables: χ² = -(n - 1 - 9/6) ln(1 - r²)
+	two := matrixFromRows(t, [][]float64{{1, 0.4}, {0.4, 1}}, 30)
+	got, err = BartlettSphericity(two, 30)
+	if err != nil {
+		t.Fatalf("BartlettSphericity() unexpected error: %v", err)
+	}
+	want := -(30 - 1 - 9.0/6) * math.Log(1-0.16)
+	if math.Abs(got.Statistic-want) > 1e-9 {
+		t.Errorf("BartlettSphericity() statistic = %v, want %v", got.Statistic, want)
+	}
+
+	if _, err := BartlettSphericity(two, 2); err == nil {
+		t.Errorf("BartlettSphericity() with n=2 expected error but got none")
+	}
+	singular := matrixFromRows(t, [][]float64{{1, 1}, {1, 1}}, 30)
+	if _, err := BartlettSphericity(singular, 30); err == nil {
+		t.Errorf("BartlettSphericity() of singular matrix expected error but got none")
+	}
+}
+
+func TestKMO(t *testing.T) {
+	// With two variables the partial correlation equals the correlation,
+	// so the KMO measure is always one half.
+	two := matrixFromRows(t, [][]float64{{1, 0.7}, {0.7, 1}}, 30)
+	overall, per, err := KMO(two)
+	if err != nil {
+		t.Fatalf("KMO() unexpected error: %v", err)
+	}
+	if math.Abs(overall-0.5) > 1e-12 {
+		t.Errorf("KMO() = %v, want 0.5", overall)
+	}
+	for i, v := range per {
+		if math.Abs(v-0.5) > 1e-12 {
+			t.Errorf("KMO() per-variable[%d] = %v, want 0.5", i, v)
+		}
+	}
+
+	// Several noisy indicators of one common factor should be well suited
+	// to factor analysis.
+	rng := rand.New(rand.NewSource(getSeed()))
+	const n = 500
+	cols := make([][]float64, 5)
+	for i := range cols {
+		cols[i] = make([]float64, n)
+	}
+	for k := range n {
+		f := rng.NormFloat64()
+		for i := range cols {
+			cols[i][k] = f + 0.5*rng.NormFloat64()
+		}
+	}
+	m, err := PairwiseCorrelationMatrix(cols, Pearson)
+	if err != nil {
+		t.Fatalf("PairwiseCorrelationMatrix() unexpected error: %v", err)
+	}
+	overall, per, err = KMO(m)
+	if err != nil {
+		t.Fatalf("KMO() unexpected error: %v", err)
+	}
+	if overall < 0.8 {
+		t.Errorf("KMO() = %v, want > 0.8 for single factor data", overall)
+	}
+	if len(per) != 5 {
+		t.Errorf("KMO() returned %d per-variable values, want 5", len(per))
+	}
+
+	if _, _, err := KMO(nil); err == nil {
+		t.Errorf("KMO(nil) expected error but got none")
+	}
+}
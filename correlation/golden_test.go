@@ -0,0 +1,82 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"os"
+	"testing"
+
+	"github.com/rsned/stats/tools/golden"
+)
+
+// goldenTolerance is the largest absolute difference from the exact
+// reference value that the float64 implementations may show.
+const goldenTolerance = 1e-12
+
+// goldenTypes lists the measures checked against the golden file, with the
+// reference value for each.
+var goldenTypes = []struct {
+	correlationType Type
+	want            func(golden.Record) *float64
+}{
+	{correlationType: Pearson, want: func(r golden.Record) *float64 { return r.Pearson }},
+	{correlationType: Spearman, want: func(r golden.Record) *float64 { return r.Spearman }},
+	{correlationType: KendallTau, want: func(r golden.Record) *float64 { return r.KendallTau }},
+	{correlationType: GoodmanKruskal, want: func(r golden.Record) *float64 { return r.GoodmanKruskal }},
+}
+
+// goldenKnownIssues lists golden cases, keyed by test name, that an
+// implementation is known not to meet yet, with the reason.
+var goldenKnownIssues = map[string]string{}
+
+func TestGoldenValues(t *testing.T) {
+	f, err := os.Open("testdata/golden.json")
+	if err != nil {
+		t.Fatalf("opening golden file: %v", err)
+	}
+	defer f.Close()
+
+	records, err := golden.Read(f)
+	if err != nil {
+		t.Fatalf("reading golden file: %v", err)
+	}
+
+	for _, rec := range records {
+		for _, gt := range goldenTypes {
+			name := rec.Name + "/" + gt.correlationType.String()
+			t.Run(name, func(t *testing.T) {
+				if reason, ok := goldenKnownIssues[name]; ok {
+					t.Skip("known issue: " + reason)
+				}
+				want := gt.want(rec)
+				got, err := Correlate(rec.X, rec.Y, gt.correlationType)
+				if want == nil {
+					if err == nil {
+						t.Errorf("Correlate() = %v, want error for undefined coefficient", got)
+					}
+
+					return
+				}
+				if err != nil {
+					t.Fatalf("Correlate() unexpected error: %v", err)
+				}
+				if math.Abs(got-*want) > goldenTolerance {
+					t.Errorf("Correlate() = %v, want %v (diff %g)", got, *want, got-*want)
+				}
+			})
+		}
+	}
+}
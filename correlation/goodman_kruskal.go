@@ -29,9 +29,21 @@ import (
 //   - 0 indicates no monotonic relationship
 //   - -1 indicates a perfect negative monotonic relationship
 //
-// An error is returned if the slices have different lengths or are empty.
+// It is computed as
+//
+//	γ = (C - D) / (C + D)
+//
+// where C and D are the numbers of concordant and discordant pairs.
+//
+// An error is returned if the slices have different lengths or are empty,
+// contain NaN, or if every pair is tied in x or y.
 func GoodmanKruskals[T Numeric](x, y []T) (float64, error) {
-	return 0, errors.New("not implemented")
+	st, err := numericRankStats(x, y)
+	if err != nil {
+		return 0, err
+	}
+
+	return clamped(st.gamma())
 }
 
 // GoodmanKruskalsBig calculates Goodman and Kruskal's gamma correlation coefficient
@@ -40,7 +52,12 @@ func GoodmanKruskals[T Numeric](x, y []T) (float64, error) {
 // Gamma is a rank-based measure of association that ranges from -1 to +1.
 // Unlike Kendall's Tau, Gamma ignores tied pairs entirely in the calculation.
 func GoodmanKruskalsBig[T BigNumeric](x, y []T) (float64, error) {
-	return 0, errors.New("not implemented")
+	st, err := bigRankStats(x, y)
+	if err != nil {
+		return 0, err
+	}
+
+	return clamped(st.gamma())
 }
 
 // GoodmanKruskalsMixed calculates Goodman and Kruskal's gamma correlation coefficient
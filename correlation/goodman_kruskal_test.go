@@ -15,11 +15,50 @@
 package correlation
 
 import (
+	"math"
 	"math/big"
 	"math/rand"
 	"testing"
 )
 
+func TestGoodmanKruskals(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []float64
+		want float64
+	}{
+		{name: "perfect", x: []float64{1, 2, 3, 4}, y: []float64{1, 4, 9, 16}, want: 1},
+		// Tied pairs are ignored: C = 3, D = 0.
+		{name: "ties ignored", x: []float64{1, 1, 2, 2}, y: []float64{1, 2, 2, 3}, want: 1},
+		// C = 5, D = 1.
+		{name: "mixed", x: []float64{1, 1, 2, 2, 3}, y: []float64{1, 2, 2, 1, 3}, want: 4.0 / 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GoodmanKruskals(tt.x, tt.y)
+			if err != nil {
+				t.Fatalf("GoodmanKruskals() unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("GoodmanKruskals() = %v, want %v", got, tt.want)
+			}
+
+			got, err = GoodmanKruskalsMixed(tt.x, tt.y)
+			if err != nil {
+				t.Fatalf("GoodmanKruskalsMixed() unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("GoodmanKruskalsMixed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := GoodmanKruskals([]float64{1, 1}, []float64{1, 2}); err == nil {
+		t.Errorf("GoodmanKruskals() with every pair tied expected error but got none")
+	}
+}
+
 func BenchmarkGoodmanKruskals100(b *testing.B) {
 	x := make([]float64, 100)
 	y := make([]float64, 100)
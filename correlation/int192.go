@@ -0,0 +1,203 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/bits"
+)
+
+// int192 is a 192-bit two's complement integer stored as three 64-bit words,
+// least significant first.
+//
+// It is wide enough to hold the exact sum of up to 2^63 products of two
+// 64-bit integers, so the sums needed for Pearson's correlation of integer
+// data can be accumulated without rounding and without allocating.
+type int192 [3]uint64
+
+// add adds the two's complement value held in v to a.
+func (a *int192) add(v int192) {
+	var c uint64
+	a[0], c = bits.Add64(a[0], v[0], 0)
+	a[1], c = bits.Add64(a[1], v[1], c)
+	a[2], _ = bits.Add64(a[2], v[2], c)
+}
+
+// addInt64 adds v to a.
+func (a *int192) addInt64(v int64) {
+	ext := uint64(v >> 63) // all ones when v is negative
+	a.add(int192{uint64(v), ext, ext})
+}
+
+// addUint64 adds v to a.
+func (a *int192) addUint64(v uint64) {
+	a.add(int192{v, 0, 0})
+}
+
+// addMulInt64 adds the full 128-bit product u*v to a.
+func (a *int192) addMulInt64(u, v int64) {
+	hi, lo := bits.Mul64(absInt64(u), absInt64(v))
+	p := int192{lo, hi, 0}
+	if (u < 0) != (v < 0) {
+		p.negate()
+	}
+	a.add(p)
+}
+
+// addMulUint64 adds the full 128-bit product u*v to a.
+func (a *int192) addMulUint64(u, v uint64) {
+	hi, lo := bits.Mul64(u, v)
+	a.add(int192{lo, hi, 0})
+}
+
+// negate replaces a with -a.
+func (a *int192) negate() {
+	var c uint64
+	a[0], c = bits.Add64(^a[0], 1, 0)
+	a[1], c = bits.Add64(^a[1], 0, c)
+	a[2], _ = bits.Add64(^a[2], 0, c)
+}
+
+// big returns a as a big.Int.
+func (a int192) big() *big.Int {
+	neg := a[2]>>63 == 1
+	if neg {
+		a.negate()
+	}
+
+	b := new(big.Int).SetUint64(a[2])
+	b.Lsh(b, 64).Or(b, new(big.Int).SetUint64(a[1]))
+	b.Lsh(b, 64).Or(b, new(big.Int).SetUint64(a[0]))
+	if neg {
+		b.Neg(b)
+	}
+
+	return b
+}
+
+// absInt64 returns |v| as a uint64, which is exact even for math.MinInt64.
+func absInt64(v int64) uint64 {
+	if v < 0 {
+		return -uint64(v)
+	}
+
+	return uint64(v)
+}
+
+// integerSums holds the exact sufficient statistics for Pearson's
+// correlation of integer data.
+type integerSums struct {
+	n     int
+	sumX  int192
+	sumY  int192
+	sumXX int192
+	sumYY int192
+	sumXY int192
+}
+
+// pearsonsSignedExact calculates Pearson's correlation of 64-bit signed
+// integer data, accumulating the sums exactly in 192-bit integers.
+func pearsonsSignedExact[T ~int | ~int64](ctx context.Context, x, y []T) (float64, error) {
+	var s integerSums
+	s.n = len(x)
+	for i := range x {
+		if i%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+
+		xi, yi := int64(x[i]), int64(y[i])
+		s.sumX.addInt64(xi)
+		s.sumY.addInt64(yi)
+		s.sumXX.addMulInt64(xi, xi)
+		s.sumYY.addMulInt64(yi, yi)
+		s.sumXY.addMulInt64(xi, yi)
+	}
+
+	return s.value()
+}
+
+// pearsonsUnsignedExact calculates Pearson's correlation of 64-bit unsigned
+// integer data, accumulating the sums exactly in 192-bit integers.
+func pearsonsUnsignedExact[T ~uint | ~uint64](ctx context.Context, x, y []T) (float64, error) {
+	var s integerSums
+	s.n = len(x)
+	for i := range x {
+		if i%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+
+		xi, yi := uint64(x[i]), uint64(y[i])
+		s.sumX.addUint64(xi)
+		s.sumY.addUint64(yi)
+		s.sumXX.addMulUint64(xi, xi)
+		s.sumYY.addMulUint64(yi, yi)
+		s.sumXY.addMulUint64(xi, yi)
+	}
+
+	return s.value()
+}
+
+// value returns the correlation of the accumulated sums as a float64.
+func (s *integerSums) value() (float64, error) {
+	r, err := s.bigValue(128)
+	if err != nil {
+		return 0, err
+	}
+	result, _ := r.Float64()
+
+	return result, nil
+}
+
+// bigValue returns the correlation of the accumulated sums with prec bits
+// of precision. The numerator and variances are formed exactly as
+//
+//	n·Σxy - Σx·Σy,  n·Σx² - (Σx)²,  n·Σy² - (Σy)²
+//
+// so the only rounding is in the final division and square root.
+func (s *integerSums) bigValue(prec uint) (*big.Float, error) {
+	n := big.NewInt(int64(s.n))
+	sx, sy := s.sumX.big(), s.sumY.big()
+
+	num := new(big.Int).Mul(n, s.sumXY.big())
+	num.Sub(num, new(big.Int).Mul(sx, sy))
+
+	varX := new(big.Int).Mul(n, s.sumXX.big())
+	varX.Sub(varX, new(big.Int).Mul(sx, sx))
+
+	varY := new(big.Int).Mul(n, s.sumYY.big())
+	varY.Sub(varY, new(big.Int).Mul(sy, sy))
+
+	if varX.Sign() <= 0 || varY.Sign() <= 0 {
+		return nil, errors.New("correlation undefined: one or both variables have zero variance")
+	}
+
+	return bigRatioOfRoot(num, new(big.Int).Mul(varX, varY), prec), nil
+}
+
+// bigRatioOfRoot returns num / sqrt(den) with prec bits of precision. den
+// must be positive.
+func bigRatioOfRoot(num, den *big.Int, prec uint) *big.Float {
+	root := new(big.Float).SetPrec(prec).SetInt(den)
+	root.Sqrt(root)
+	r := new(big.Float).SetPrec(prec).SetInt(num)
+
+	return r.Quo(r, root)
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestInt192MatchesBigInt(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	signed := []int64{0, 1, -1, math.MaxInt64, math.MinInt64, math.MinInt64 + 1}
+	for range 50 {
+		signed = append(signed, int64(rng.Uint64()))
+	}
+
+	var a int192
+	want := new(big.Int)
+	for _, u := range signed {
+		for _, v := range signed {
+			a.addMulInt64(u, v)
+			want.Add(want, new(big.Int).Mul(big.NewInt(u), big.NewInt(v)))
+		}
+		a.addInt64(u)
+		want.Add(want, big.NewInt(u))
+	}
+	if got := a.big(); got.Cmp(want) != 0 {
+		t.Errorf("signed int192 = %v, want %v", got, want)
+	}
+
+	var b int192
+	want.SetInt64(0)
+	unsigned := []uint64{0, 1, math.MaxUint64, math.MaxUint64 - 1}
+	for range 50 {
+		unsigned = append(unsigned, rng.Uint64())
+	}
+	for _, u := range unsigned {
+		for _, v := range unsigned {
+			b.addMulUint64(u, v)
+			want.Add(want, new(big.Int).Mul(new(big.Int).SetUint64(u), new(big.Int).SetUint64(v)))
+		}
+		b.addUint64(u)
+		want.Add(want, new(big.Int).SetUint64(u))
+	}
+	if got := b.big(); got.Cmp(want) != 0 {
+		t.Errorf("unsigned int192 = %v, want %v", got, want)
+	}
+}
+
+func TestPearsonsLargeIntegers(t *testing.T) {
+	// Shifting the data does not change the correlation, but squaring values
+	// near 3e12 needs more precision than float64 has.
+	small := []int64{1, 2, 3, 4, 5, 6, 7, 8}
+	other := []int64{3, 1, 4, 1, 5, 9, 2, 6}
+	want, err := pearsonsTwoPass(small, other)
+	if err != nil {
+		t.Fatalf("pearsonsTwoPass() unexpected error: %v", err)
+	}
+
+	const offset = 3_000_000_000_000
+	x := make([]int64, len(small))
+	y := make([]int64, len(small))
+	ux := make([]uint64, len(small))
+	uy := make([]uint64, len(small))
+	for i := range small {
+		x[i] = small[i] - offset
+		y[i] = other[i] + offset
+		ux[i] = uint64(small[i]) + math.MaxUint64/2
+		uy[i] = uint64(other[i]) + math.MaxUint64/2
+	}
+
+	got, err := Pearsons(x, y)
+	if err != nil {
+		t.Fatalf("Pearsons() unexpected error: %v", err)
+	}
+	if math.Abs(got-want) > 1e-15 {
+		t.Errorf("Pearsons(int64) = %v, want %v", got, want)
+	}
+
+	got, err = Pearsons(ux, uy)
+	if err != nil {
+		t.Fatalf("Pearsons() unexpected error: %v", err)
+	}
+	if math.Abs(got-want) > 1e-15 {
+		t.Errorf("Pearsons(uint64) = %v, want %v", got, want)
+	}
+
+	if _, err := Pearsons([]int{5, 5, 5}, []int{1, 2, 3}); err == nil {
+		t.Errorf("Pearsons() with constant x expected error but got none")
+	}
+}
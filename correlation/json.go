@@ -0,0 +1,148 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"encoding/json"
+	"math"
+)
+
+// jsonConfidenceLevel is the level of the confidence interval included in
+// the JSON form of a Result.
+const jsonConfidenceLevel = 0.95
+
+// resultJSONSchema is the JSON form of a Result. Values that are undefined,
+// such as a NaN coefficient or a p-value that is not available for the
+// correlation type, are null.
+type resultJSONSchema struct {
+	Method             Type                `json:"method"`
+	Coefficient        *float64            `json:"coefficient"`
+	N                  int                 `json:"n"`
+	PValue             *float64            `json:"pValue"`
+	ConfidenceInterval *confidenceInterval `json:"confidenceInterval"`
+	Imputed            int                 `json:"imputed"`
+	Clipped            int                 `json:"clipped"`
+}
+
+// confidenceInterval is the JSON form of a confidence interval.
+type confidenceInterval struct {
+	Level float64 `json:"level"`
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+}
+
+// matrixJSONSchema is the JSON form of a Matrix. Each of the cell arrays is
+// a slice of rows, with undefined values null.
+type matrixJSONSchema struct {
+	Method       Type         `json:"method"`
+	Dim          int          `json:"dim"`
+	Labels       []string     `json:"labels,omitempty"`
+	Coefficients [][]*float64 `json:"coefficients"`
+	N            [][]int      `json:"n"`
+	PValues      [][]*float64 `json:"pValues"`
+}
+
+// MarshalJSON encodes the result in a stable schema, so services built on
+// this package can return it directly:
+//
+//	{
+//	  "method": "pearson",
+//	  "coefficient": 0.5,
+//	  "n": 50,
+//	  "pValue": 0.0002,
+//	  "confidenceInterval": {"level": 0.95, "lower": 0.26, "upper": 0.68},
+//	  "imputed": 0,
+//	  "clipped": 0
+//	}
+//
+// The method is the name produced by Type.MarshalText. The p-value and the
+// 95% confidence interval are null when they are not available for the
+// correlation type or sample size, as is a NaN coefficient.
+func (r Result) MarshalJSON() ([]byte, error) {
+	j := resultJSONSchema{
+		Method:             r.Type,
+		Coefficient:        finiteOrNil(r.Coefficient),
+		N:                  r.N,
+		PValue:             nil,
+		ConfidenceInterval: nil,
+		Imputed:            r.Imputed,
+		Clipped:            r.Clipped,
+	}
+	if p, err := PValue(r.Coefficient, r.N, r.Type); err == nil {
+		j.PValue = finiteOrNil(p)
+	}
+	if lo, hi, err := ConfidenceInterval(r.Coefficient, r.N, r.Type, jsonConfidenceLevel); err == nil {
+		j.ConfidenceInterval = &confidenceInterval{Level: jsonConfidenceLevel, Lower: lo, Upper: hi}
+	}
+
+	return json.Marshal(j)
+}
+
+// MarshalJSON encodes the matrix in a stable schema holding the method, the
+// dimension and the coefficients, per-cell sample sizes and p-values as
+// slices of rows:
+//
+//	{
+//	  "method": "spearman",
+//	  "dim": 2,
+//	  "coefficients": [[1, 0.8], [0.8, 1]],
+//	  "n": [[10, 10], [10, 10]],
+//	  "pValues": [[0, 0.005], [0.005, 0]]
+//	}
+//
+// Undefined coefficients and p-values are null.
+func (m *Matrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.jsonSchema(nil))
+}
+
+// MarshalJSON encodes the matrix as Matrix.MarshalJSON does, with an
+// additional "labels" array giving the name of each variable in index order.
+func (m *LabeledMatrix) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.jsonSchema(m.labels))
+}
+
+// jsonSchema returns the JSON form of the matrix.
+func (m *Matrix) jsonSchema(labels []string) matrixJSONSchema {
+	coef := m.Coefficients()
+	pvalues := m.PValues()
+	j := matrixJSONSchema{
+		Method:       m.Type,
+		Dim:          m.dim,
+		Labels:       labels,
+		Coefficients: make([][]*float64, m.dim),
+		N:            m.Counts(),
+		PValues:      make([][]*float64, m.dim),
+	}
+	for i := range m.dim {
+		j.Coefficients[i] = make([]*float64, m.dim)
+		j.PValues[i] = make([]*float64, m.dim)
+		for k := range m.dim {
+			j.Coefficients[i][k] = finiteOrNil(coef[i][k])
+			j.PValues[i][k] = finiteOrNil(pvalues[i][k])
+		}
+	}
+
+	return j
+}
+
+// finiteOrNil returns a pointer to v, or nil if v is NaN or ±Inf, which JSON
+// cannot represent.
+func finiteOrNil(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+
+	return &v
+}
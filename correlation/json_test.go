@@ -0,0 +1,116 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+// decodeJSON marshals v and decodes the output into a generic map.
+func decodeJSON(t *testing.T, v any) map[string]any {
+	t.Helper()
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("json.Marshal() unexpected error: %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatalf("json.Unmarshal(%s) unexpected error: %v", data, err)
+	}
+
+	return out
+}
+
+func TestResultMarshalJSON(t *testing.T) {
+	r := Result{Type: Pearson, Coefficient: 0.5, N: 50, Imputed: 2, Clipped: 1}
+	got := decodeJSON(t, r)
+
+	wantP, err := PValue(0.5, 50, Pearson)
+	if err != nil {
+		t.Fatalf("PValue() unexpected error: %v", err)
+	}
+	lo, hi, err := ConfidenceInterval(0.5, 50, Pearson, 0.95)
+	if err != nil {
+		t.Fatalf("ConfidenceInterval() unexpected error: %v", err)
+	}
+
+	if got["method"] != "pearson" || got["coefficient"] != 0.5 || got["n"] != 50.0 ||
+		got["imputed"] != 2.0 || got["clipped"] != 1.0 || got["pValue"] != wantP {
+		t.Errorf("Result JSON = %v", got)
+	}
+	ci, ok := got["confidenceInterval"].(map[string]any)
+	if !ok || ci["level"] != 0.95 || ci["lower"] != lo || ci["upper"] != hi {
+		t.Errorf("Result JSON confidenceInterval = %v, want [%v, %v] at 0.95", got["confidenceInterval"], lo, hi)
+	}
+
+	// Goodman and Kruskal's gamma has no p-value or interval, and a
+	// propagated NaN has no coefficient.
+	got = decodeJSON(t, Result{Type: GoodmanKruskal, Coefficient: math.NaN(), N: 10, Imputed: 0, Clipped: 0})
+	for _, key := range []string{"coefficient", "pValue", "confidenceInterval"} {
+		if v, ok := got[key]; !ok || v != nil {
+			t.Errorf("Result JSON %s = %v, want null", key, v)
+		}
+	}
+	if got["method"] != "goodmankruskal" {
+		t.Errorf("Result JSON method = %v, want goodmankruskal", got["method"])
+	}
+
+	if _, err := json.Marshal(Result{Type: Type(99), Coefficient: 0, N: 0, Imputed: 0, Clipped: 0}); err == nil {
+		t.Errorf("json.Marshal() of an unknown type expected error but got none")
+	}
+}
+
+func TestMatrixMarshalJSON(t *testing.T) {
+	m, err := CorrelationMatrix([][]float64{{1, 2, 3, 4, 5}, {2, 1, 4, 3, 5}, {7, 7, 7, 7, 7}}, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelationMatrix() unexpected error: %v", err)
+	}
+
+	got := decodeJSON(t, m)
+	if got["method"] != "pearson" || got["dim"] != 3.0 {
+		t.Errorf("Matrix JSON header = %v, %v", got["method"], got["dim"])
+	}
+	if _, ok := got["labels"]; ok {
+		t.Errorf("Matrix JSON has labels, want none")
+	}
+
+	coef := got["coefficients"].([]any)
+	if row := coef[0].([]any); row[0] != 1.0 || row[1] != m.At(0, 1) || row[2] != nil {
+		t.Errorf("Matrix JSON coefficients row 0 = %v", row)
+	}
+	if row := got["n"].([]any)[1].([]any); row[2] != 5.0 {
+		t.Errorf("Matrix JSON n row 1 = %v", row)
+	}
+	p, err := m.PValue(0, 1)
+	if err != nil {
+		t.Fatalf("PValue() unexpected error: %v", err)
+	}
+	if row := got["pValues"].([]any)[1].([]any); row[0] != p || row[1] != 0.0 || row[2] != nil {
+		t.Errorf("Matrix JSON pValues row 1 = %v", row)
+	}
+
+	lm, err := CorrelateNamed(map[string][]float64{"b": {1, 2, 3}, "a": {3, 1, 2}}, Spearman)
+	if err != nil {
+		t.Fatalf("CorrelateNamed() unexpected error: %v", err)
+	}
+	got = decodeJSON(t, lm)
+	labels, ok := got["labels"].([]any)
+	if !ok || len(labels) != 2 || labels[0] != "a" || labels[1] != "b" || got["method"] != "spearman" {
+		t.Errorf("LabeledMatrix JSON = %v", got)
+	}
+}
@@ -0,0 +1,329 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// scapegoatAlpha is the weight balance of the rangeTree. A subtree may hold
+// at most this fraction of the nodes of its parent's subtree before it is
+// rebuilt.
+const scapegoatAlpha = 0.75
+
+// rangeTree indexes a set of points by x, and within every subtree by y, so
+// that the number of points below and to the left of any corner can be
+// counted in O(log² n) time.
+//
+// The tree over x is a scapegoat tree, which rebalances by rebuilding
+// subtrees rather than rotating, so the y index kept at each node stays valid
+// between rebuilds. Points are keyed by x and a sequence number that breaks
+// ties. Removed points are marked dead and the whole tree is rebuilt once
+// they outnumber the live ones, which keeps every operation O(log² n)
+// amortized.
+type rangeTree struct {
+	root *rangeNode
+	live int
+	dead int
+}
+
+// rangeNode is one point of a rangeTree.
+type rangeNode struct {
+	x, y float64
+	seq  uint64
+	live bool
+	// nodes is the number of nodes in the subtree, live or dead.
+	nodes       int
+	left, right *rangeNode
+	// ys holds the y values of the live points in the subtree.
+	ys ostree
+}
+
+// insert adds the point (x, y) with the given unique sequence number.
+func (t *rangeTree) insert(x, y float64, seq uint64) {
+	node := &rangeNode{x: x, y: y, seq: seq, live: true, nodes: 1, left: nil, right: nil, ys: ostree{root: nil, state: seq}}
+	node.ys.insert(y)
+	t.live++
+
+	var path []*rangeNode
+	link := &t.root
+	for *link != nil {
+		cur := *link
+		path = append(path, cur)
+		cur.nodes++
+		cur.ys.insert(y)
+		if x < cur.x || (x == cur.x && seq < cur.seq) {
+			link = &cur.left
+		} else {
+			link = &cur.right
+		}
+	}
+	*link = node
+
+	total := float64(t.live + t.dead)
+	if float64(len(path)) <= math.Log(total)/math.Log(1/scapegoatAlpha) {
+		return
+	}
+
+	// The new node is too deep, so some ancestor is out of balance. Rebuild
+	// the deepest such ancestor.
+	child := node
+	for i := len(path) - 1; i >= 0; i-- {
+		if float64(child.nodes) > scapegoatAlpha*float64(path[i].nodes) {
+			t.rebuildAt(path, i)
+
+			return
+		}
+		child = path[i]
+	}
+}
+
+// remove deletes the point (x, y) that was inserted with seq.
+func (t *rangeTree) remove(x, y float64, seq uint64) {
+	for cur := t.root; cur != nil; {
+		cur.ys.remove(y)
+		if x == cur.x && seq == cur.seq {
+			cur.live = false
+			t.live--
+			t.dead++
+
+			break
+		}
+		if x < cur.x || (x == cur.x && seq < cur.seq) {
+			cur = cur.left
+		} else {
+			cur = cur.right
+		}
+	}
+
+	if t.dead > t.live {
+		t.rebuildAt(nil, -1)
+	}
+}
+
+// count returns the number of live points whose x is less than xBound and
+// whose y is less than yBound, with either comparison including equality if
+// the corresponding inclusive flag is set.
+func (t *rangeTree) count(xBound float64, xInclusive bool, yBound float64, yInclusive bool) int {
+	n := 0
+	for cur := t.root; cur != nil; {
+		if cur.x < xBound || (xInclusive && cur.x == xBound) {
+			if cur.left != nil {
+				if yInclusive {
+					n += cur.left.ys.countLessEqual(yBound)
+				} else {
+					n += cur.left.ys.countLess(yBound)
+				}
+			}
+			if cur.live && (cur.y < yBound || (yInclusive && cur.y == yBound)) {
+				n++
+			}
+			cur = cur.right
+		} else {
+			cur = cur.left
+		}
+	}
+
+	return n
+}
+
+// rebuildAt replaces the subtree rooted at path[i] with a perfectly balanced
+// one holding only its live points. An index of -1 rebuilds the whole tree.
+func (t *rangeTree) rebuildAt(path []*rangeNode, i int) {
+	link := &t.root
+	if i > 0 {
+		parent := path[i-1]
+		if parent.left == path[i] {
+			link = &parent.left
+		} else {
+			link = &parent.right
+		}
+	}
+
+	var live []*rangeNode
+	live = collectLive(*link, live)
+	removed := nodeCount(*link) - len(live)
+	t.dead -= removed
+	for _, ancestor := range path[:max(i, 0)] {
+		ancestor.nodes -= removed
+	}
+	*link = buildBalanced(live)
+}
+
+// collectLive appends the live nodes of the subtree rooted at node to out in
+// key order.
+func collectLive(node *rangeNode, out []*rangeNode) []*rangeNode {
+	if node == nil {
+		return out
+	}
+	out = collectLive(node.left, out)
+	if node.live {
+		out = append(out, node)
+	}
+
+	return collectLive(node.right, out)
+}
+
+// buildBalanced links nodes, which are in key order, into a balanced tree
+// and rebuilds the y index of every subtree.
+func buildBalanced(nodes []*rangeNode) *rangeNode {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	mid := len(nodes) / 2
+	root := nodes[mid]
+	root.left = buildBalanced(nodes[:mid])
+	root.right = buildBalanced(nodes[mid+1:])
+	root.nodes = len(nodes)
+	root.ys = ostree{root: nil, state: root.seq}
+	for _, n := range nodes {
+		root.ys.insert(n.y)
+	}
+
+	return root
+}
+
+// nodeCount returns the number of nodes in the subtree rooted at node.
+func nodeCount(node *rangeNode) int {
+	if node == nil {
+		return 0
+	}
+
+	return node.nodes
+}
+
+// WindowedKendall maintains Kendall's tau-b over the most recent pairs of a
+// stream, up to a fixed window size. Once the window is full each new pair
+// evicts the oldest one.
+//
+// Rather than recounting every pair of the window after each update, which
+// takes O(n log n) time, the pairs are kept in a tree of order-statistic
+// trees, so the pairs that an arriving or departing point forms with the
+// rest of the window are counted in O(log² n) time. The counts are exact
+// integers, so no error accumulates over a long stream.
+//
+// A WindowedKendall is not safe for concurrent use.
+type WindowedKendall struct {
+	tree rangeTree
+	xs   []float64
+	ys   []float64
+	seqs []uint64
+	// next is the ring buffer position the next pair is written to.
+	next int
+	// seq is the sequence number given to the next pair.
+	seq uint64
+	// stats holds the pair counts of the window; the ranks are unused.
+	stats rankStats
+}
+
+// NewWindowedKendall returns a Kendall's tau-b correlator over a sliding
+// window of the given size.
+//
+// An error is returned if size is less than 2.
+func NewWindowedKendall(size int) (*WindowedKendall, error) {
+	if size < 2 {
+		return nil, errors.New("window size must be at least 2")
+	}
+
+	return &WindowedKendall{
+		tree:  rangeTree{root: nil, live: 0, dead: 0},
+		xs:    make([]float64, 0, size),
+		ys:    make([]float64, 0, size),
+		seqs:  make([]uint64, 0, size),
+		next:  0,
+		seq:   0,
+		stats: rankStats{xRanks: nil, yRanks: nil, pairs: 0, tiedX: 0, tiedY: 0, tiedXY: 0, discordant: 0},
+	}, nil
+}
+
+// Add includes the pair (x, y) in the window. If the window is full, the
+// oldest pair is evicted.
+//
+// An error is returned if either value is NaN or ±Inf.
+func (w *WindowedKendall) Add(x, y float64) error {
+	if !isFinite(x) || !isFinite(y) {
+		return errors.New("values must be finite")
+	}
+
+	if len(w.xs) == cap(w.xs) {
+		oldX, oldY := w.xs[w.next], w.ys[w.next]
+		w.tree.remove(oldX, oldY, w.seqs[w.next])
+		w.updatePairs(oldX, oldY, -1)
+		w.xs[w.next] = x
+		w.ys[w.next] = y
+		w.seqs[w.next] = w.seq
+		w.next = (w.next + 1) % len(w.xs)
+	} else {
+		w.xs = append(w.xs, x)
+		w.ys = append(w.ys, y)
+		w.seqs = append(w.seqs, w.seq)
+	}
+
+	w.updatePairs(x, y, 1)
+	w.tree.insert(x, y, w.seq)
+	w.seq++
+
+	return nil
+}
+
+// updatePairs adds sign times the pairs that (x, y) forms with every point
+// currently in the tree to the window's pair counts.
+func (w *WindowedKendall) updatePairs(x, y float64, sign int64) {
+	t := &w.tree
+	inf := math.Inf(1)
+	n := t.live
+	lessX := t.count(x, false, inf, true)
+	atMostX := t.count(x, true, inf, true)
+	lessY := t.count(inf, true, y, false)
+	atMostY := t.count(inf, true, y, true)
+	lessXAtMostY := t.count(x, false, y, true)
+	atMostXLessY := t.count(x, true, y, false)
+	lessXLessY := t.count(x, false, y, false)
+	atMostXAtMostY := t.count(x, true, y, true)
+
+	// Points with smaller x and larger y, or larger x and smaller y.
+	discordant := (lessX - lessXAtMostY) + (lessY - atMostXLessY)
+	tiedXY := atMostXAtMostY - lessXAtMostY - atMostXLessY + lessXLessY
+
+	w.stats.pairs += sign * int64(n)
+	w.stats.tiedX += sign * int64(atMostX-lessX)
+	w.stats.tiedY += sign * int64(atMostY-lessY)
+	w.stats.tiedXY += sign * int64(tiedXY)
+	w.stats.discordant += sign * int64(discordant)
+}
+
+// N returns the number of pairs currently in the window.
+func (w *WindowedKendall) N() int {
+	return len(w.xs)
+}
+
+// Size returns the capacity of the window.
+func (w *WindowedKendall) Size() int {
+	return cap(w.xs)
+}
+
+// Value returns Kendall's tau-b of the pairs currently in the window.
+//
+// An error is returned if there are fewer than 2 pairs or either series is
+// constant within the window.
+func (w *WindowedKendall) Value() (float64, error) {
+	if len(w.xs) < 2 {
+		return 0, errors.New("correlation requires at least 2 data points")
+	}
+
+	return clamped(w.stats.kendallTauB())
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestWindowedKendall(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+
+	tests := []struct {
+		name   string
+		size   int
+		levels int
+	}{
+		{name: "small window", size: 3, levels: 1000},
+		{name: "continuous", size: 50, levels: 0},
+		{name: "heavy ties", size: 40, levels: 4},
+		{name: "large window", size: 300, levels: 25},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, err := NewWindowedKendall(tt.size)
+			if err != nil {
+				t.Fatalf("NewWindowedKendall() unexpected error: %v", err)
+			}
+
+			var xs, ys []float64
+			draw := func() float64 {
+				if tt.levels == 0 {
+					return rng.NormFloat64()
+				}
+
+				return float64(rng.Intn(tt.levels))
+			}
+			for i := range 4 * tt.size {
+				x := draw()
+				y := 0.5*x + draw()
+				if err := w.Add(x, y); err != nil {
+					t.Fatalf("Add() unexpected error: %v", err)
+				}
+				xs = append(xs, x)
+				ys = append(ys, y)
+				start := max(0, len(xs)-tt.size)
+
+				if w.N() != len(xs)-start {
+					t.Fatalf("N() = %d, want %d", w.N(), len(xs)-start)
+				}
+				want, wantErr := KendallsTau(xs[start:], ys[start:])
+				got, err := w.Value()
+				if (err != nil) != (wantErr != nil) {
+					t.Fatalf("step %d: Value() error = %v, want %v", i, err, wantErr)
+				}
+				if err == nil && math.Abs(got-want) > 1e-12 {
+					t.Fatalf("step %d: Value() = %v, want %v", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestWindowedKendallErrors(t *testing.T) {
+	if _, err := NewWindowedKendall(1); err == nil {
+		t.Errorf("NewWindowedKendall(1) expected error but got none")
+	}
+
+	w, err := NewWindowedKendall(4)
+	if err != nil {
+		t.Fatalf("NewWindowedKendall() unexpected error: %v", err)
+	}
+	if w.Size() != 4 {
+		t.Errorf("Size() = %d, want 4", w.Size())
+	}
+	if err := w.Add(math.NaN(), 1); err == nil {
+		t.Errorf("Add(NaN) expected error but got none")
+	}
+	if err := w.Add(1, math.Inf(1)); err == nil {
+		t.Errorf("Add(Inf) expected error but got none")
+	}
+	if _, err := w.Value(); err == nil {
+		t.Errorf("Value() on an empty window expected error but got none")
+	}
+	for _, x := range []float64{1, 2, 3} {
+		if err := w.Add(x, 5); err != nil {
+			t.Fatalf("Add() unexpected error: %v", err)
+		}
+	}
+	if _, err := w.Value(); err == nil {
+		t.Errorf("Value() with constant y expected error but got none")
+	}
+}
+
+func BenchmarkWindowedKendall(b *testing.B) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	w, err := NewWindowedKendall(10000)
+	if err != nil {
+		b.Fatalf("NewWindowedKendall() unexpected error: %v", err)
+	}
+	for b.Loop() {
+		x := rng.NormFloat64()
+		if err := w.Add(x, x+rng.NormFloat64()); err != nil {
+			b.Fatalf("Add() unexpected error: %v", err)
+		}
+	}
+}
@@ -29,16 +29,26 @@ import (
 //   - 0 indicates no monotonic relationship
 //   - -1 indicates a perfect negative monotonic relationship
 //
-// An error is returned if the slices have different lengths or are empty.
+// The tau-b variant is computed, which adjusts for ties in either series so
+// that the coefficient can still reach ±1 on tied data:
+//
+//	τb = (C - D) / sqrt((n0 - n1)(n0 - n2))
+//
+// where C and D are the numbers of concordant and discordant pairs, n0 is
+// the number of pairs, and n1 and n2 are the numbers of pairs tied in x and
+// in y. Without ties it equals the original tau-a. The pairs are counted in
+// O(n log n) time using Knight's algorithm.
+//
+// An error is returned if the slices have different lengths or are empty,
+// contain NaN, or if either series is constant.
 func KendallsTau[T Numeric](x, y []T) (float64, error) {
-	return 0, errors.New("not implemented")
-}
+	st, err := numericRankStats(x, y)
+	if err != nil {
+		return 0, err
+	}
 
-// KendallsTauBig calculates Kendall's Tau correlation coefficient
-// between two datasets x and y of big number types (*big.Float or *big.Int).
-//
-// Kendall's Tau measures the ordinal association between two measured quantities.
-// It is based on the number of concordant and discordant pairs in the data.
+	return clamped(st.kendallTauB())
+}
 
 // KendallsTauBig calculates Kendall's Tau correlation coefficient
 // between two datasets x and y of big number types (*big.Float or *big.Int).
@@ -46,7 +56,12 @@ func KendallsTau[T Numeric](x, y []T) (float64, error) {
 // Kendall's Tau measures the ordinal association between two measured quantities.
 // It is based on the number of concordant and discordant pairs in the data.
 func KendallsTauBig[T BigNumeric](x, y []T) (float64, error) {
-	return 0, errors.New("not implemented")
+	st, err := bigRankStats(x, y)
+	if err != nil {
+		return 0, err
+	}
+
+	return clamped(st.kendallTauB())
 }
 
 // KendallsTauMixed calculates Kendall's Tau correlation coefficient
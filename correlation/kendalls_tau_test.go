@@ -15,10 +15,57 @@
 package correlation
 
 import (
+	"math"
+	"math/big"
 	"math/rand"
 	"testing"
 )
 
+func TestKendallsTau(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []int64
+		want float64
+	}{
+		{name: "perfect", x: []int64{1, 2, 3, 4}, y: []int64{10, 20, 30, 40}, want: 1},
+		{name: "reversed", x: []int64{1, 2, 3, 4}, y: []int64{4, 3, 2, 1}, want: -1},
+		// 11 concordant and 4 discordant of 15 pairs.
+		{name: "no ties", x: []int64{43, 21, 25, 42, 57, 59}, y: []int64{99, 65, 79, 75, 87, 81}, want: 7.0 / 15},
+		// C = 3, D = 0, 2 pairs tied in x and 1 in y of 6.
+		{name: "tau-b with ties", x: []int64{1, 1, 2, 2}, y: []int64{1, 2, 2, 3}, want: 3 / math.Sqrt(20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := KendallsTau(tt.x, tt.y)
+			if err != nil {
+				t.Fatalf("KendallsTau() unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("KendallsTau() = %v, want %v", got, tt.want)
+			}
+
+			bx := make([]*big.Int, len(tt.x))
+			by := make([]*big.Int, len(tt.y))
+			for i := range tt.x {
+				bx[i] = big.NewInt(tt.x[i])
+				by[i] = big.NewInt(tt.y[i])
+			}
+			got, err = KendallsTauBig(bx, by)
+			if err != nil {
+				t.Fatalf("KendallsTauBig() unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("KendallsTauBig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := KendallsTau([]float64{1, 2, 3}, []float64{5, 5, 5}); err == nil {
+		t.Errorf("KendallsTau() with constant y expected error but got none")
+	}
+}
+
 func BenchmarkKendallsTau100(b *testing.B) {
 	x := make([]float64, 100)
 	y := make([]float64, 100)
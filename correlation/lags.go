@@ -0,0 +1,116 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// LagCorrelation is Pearson's correlation between two series with one of
+// them shifted by Lag positions.
+type LagCorrelation struct {
+	// Lag is the shift applied: the coefficient correlates x[t] with
+	// y[t+Lag], so a positive lag means changes in x lead changes in y.
+	Lag int
+	// Coefficient is the correlation at this lag, or NaN if it is undefined.
+	Coefficient float64
+	// N is the number of overlapping pairs used at this lag.
+	N int
+}
+
+// LagScan holds the correlation at every lag examined by CrossCorrelateLags.
+type LagScan struct {
+	// Lags holds one entry per lag, ordered from -maxLag to +maxLag.
+	Lags []LagCorrelation
+	// Best is the entry with the largest absolute coefficient. Ties are
+	// broken in favour of the lag closest to zero, then the negative lag.
+	Best LagCorrelation
+}
+
+// CrossCorrelateLags computes Pearson's correlation between x and y at
+// every lag in [-maxLag, +maxLag] and reports the lag with the strongest
+// correlation, for lead/lag analysis between two time series sampled at the
+// same times.
+//
+// At lag k the coefficient is computed from the overlapping pairs
+// (x[t], y[t+k]), so each lag uses n - |k| pairs rather than wrapping around
+// or padding the series. Lags whose coefficient is undefined, such as when
+// the overlapping part of a series is constant, hold NaN and are never
+// chosen as Best.
+//
+// An error is returned if the slices differ in length, maxLag is negative,
+// the series are too short to leave at least 3 pairs at the largest lag, or
+// the coefficient is undefined at every lag.
+func CrossCorrelateLags[T Numeric](x, y []T, maxLag int) (LagScan, error) {
+	scan := LagScan{
+		Lags: nil,
+		Best: LagCorrelation{Lag: 0, Coefficient: math.NaN(), N: 0},
+	}
+
+	if len(x) != len(y) {
+		return scan, errors.New("input slices must have the same length")
+	}
+	if maxLag < 0 {
+		return scan, errors.New("maximum lag cannot be negative")
+	}
+	if len(x)-maxLag < 3 {
+		return scan, errors.New("series are too short for the maximum lag")
+	}
+
+	n := len(x)
+	scan.Lags = make([]LagCorrelation, 0, 2*maxLag+1)
+	for k := -maxLag; k <= maxLag; k++ {
+		xs, ys := x[max(0, -k):n-max(0, k)], y[max(0, k):n-max(0, -k)]
+		r, err := Pearsons(xs, ys)
+		if err != nil {
+			r = math.NaN()
+		}
+		lc := LagCorrelation{Lag: k, Coefficient: r, N: len(xs)}
+		scan.Lags = append(scan.Lags, lc)
+
+		if math.IsNaN(r) {
+			continue
+		}
+		if math.IsNaN(scan.Best.Coefficient) || strongerLag(lc, scan.Best) {
+			scan.Best = lc
+		}
+	}
+
+	if math.IsNaN(scan.Best.Coefficient) {
+		return scan, errors.New("correlation undefined at every lag")
+	}
+
+	return scan, nil
+}
+
+// strongerLag reports whether a should replace b as the best lag.
+func strongerLag(a, b LagCorrelation) bool {
+	ra, rb := math.Abs(a.Coefficient), math.Abs(b.Coefficient)
+	if ra != rb {
+		return ra > rb
+	}
+
+	return absInt(a.Lag) < absInt(b.Lag)
+}
+
+// absInt returns the absolute value of v.
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,114 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCrossCorrelateLags(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	const n, shift = 200, 3
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range n {
+		x[i] = rng.NormFloat64()
+	}
+	// y follows x three steps later, so x leads y.
+	for i := shift; i < n; i++ {
+		y[i] = x[i-shift]
+	}
+	for i := range shift {
+		y[i] = rng.NormFloat64()
+	}
+
+	tests := []struct {
+		name     string
+		x, y     []float64
+		wantLag  int
+		wantSign float64
+	}{
+		{name: "x leads y", x: x, y: y, wantLag: shift, wantSign: 1},
+		{name: "y leads x", x: y, y: x, wantLag: -shift, wantSign: 1},
+		{name: "inverted", x: x, y: negate(y), wantLag: shift, wantSign: -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scan, err := CrossCorrelateLags(tt.x, tt.y, 5)
+			if err != nil {
+				t.Fatalf("CrossCorrelateLags() unexpected error: %v", err)
+			}
+			if len(scan.Lags) != 11 || scan.Lags[0].Lag != -5 || scan.Lags[10].Lag != 5 {
+				t.Fatalf("CrossCorrelateLags() lags = %+v, want -5..5", scan.Lags)
+			}
+			if scan.Best.Lag != tt.wantLag {
+				t.Errorf("Best.Lag = %d, want %d", scan.Best.Lag, tt.wantLag)
+			}
+			if math.Abs(scan.Best.Coefficient-tt.wantSign) > 1e-12 {
+				t.Errorf("Best.Coefficient = %v, want %v", scan.Best.Coefficient, tt.wantSign)
+			}
+			if scan.Best.N != n-shift {
+				t.Errorf("Best.N = %d, want %d", scan.Best.N, n-shift)
+			}
+		})
+	}
+}
+
+func TestCrossCorrelateLagsZero(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{2, 1, 4, 3, 5}
+	scan, err := CrossCorrelateLags(x, y, 0)
+	if err != nil {
+		t.Fatalf("CrossCorrelateLags() unexpected error: %v", err)
+	}
+	want, _ := Pearsons(x, y)
+	if len(scan.Lags) != 1 || scan.Best.Coefficient != want || scan.Best.N != 5 {
+		t.Errorf("CrossCorrelateLags(maxLag 0) = %+v, want single lag with %v", scan, want)
+	}
+}
+
+func TestCrossCorrelateLagsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		x, y   []float64
+		maxLag int
+	}{
+		{name: "length mismatch", x: []float64{1, 2, 3, 4}, y: []float64{1, 2, 3}, maxLag: 1},
+		{name: "negative lag", x: []float64{1, 2, 3, 4}, y: []float64{1, 2, 3, 4}, maxLag: -1},
+		{name: "lag too large", x: []float64{1, 2, 3, 4}, y: []float64{1, 2, 3, 4}, maxLag: 2},
+		{name: "constant", x: []float64{1, 1, 1, 1}, y: []float64{1, 2, 3, 4}, maxLag: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CrossCorrelateLags(tt.x, tt.y, tt.maxLag); err == nil {
+				t.Errorf("CrossCorrelateLags() expected error but got none")
+			}
+		})
+	}
+}
+
+// negate returns a copy of data with every value negated.
+func negate(data []float64) []float64 {
+	out := make([]float64, len(data))
+	for i, v := range data {
+		out[i] = -v
+	}
+
+	return out
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// LaTeXOption configures the output of the LaTeX table writers.
+type LaTeXOption func(*latexOptions)
+
+// latexOptions holds the settings accumulated from a list of LaTeXOption values.
+type latexOptions struct {
+	decimals int
+	stars    bool
+	labels   []string
+	caption  string
+	label    string
+}
+
+// defaultLaTeXOptions returns the settings used when no LaTeXOption is supplied.
+func defaultLaTeXOptions() latexOptions {
+	return latexOptions{
+		decimals: 2,
+		stars:    true,
+		labels:   nil,
+		caption:  "",
+		label:    "",
+	}
+}
+
+// WithLaTeXDecimals sets the number of decimal places used for coefficients
+// and p-values. The default is 2.
+func WithLaTeXDecimals(n int) LaTeXOption {
+	return func(o *latexOptions) {
+		o.decimals = n
+	}
+}
+
+// WithLaTeXStars sets whether significance stars are appended to the
+// coefficients. When enabled (the default), * marks p < .05, ** p < .01 and
+// *** p < .001, and a note explaining the stars is added below the table.
+func WithLaTeXStars(enabled bool) LaTeXOption {
+	return func(o *latexOptions) {
+		o.stars = enabled
+	}
+}
+
+// WithLaTeXLabels sets the variable names used for the rows and columns of a
+// matrix table. By default the variables are numbered from 1.
+func WithLaTeXLabels(labels ...string) LaTeXOption {
+	return func(o *latexOptions) {
+		o.labels = labels
+	}
+}
+
+// WithLaTeXCaption wraps the table in a table float with the given caption.
+func WithLaTeXCaption(caption string) LaTeXOption {
+	return func(o *latexOptions) {
+		o.caption = caption
+	}
+}
+
+// WithLaTeXLabel sets the \label used to cross reference the table. It is
+// only emitted when a caption is also set.
+func WithLaTeXLabel(label string) LaTeXOption {
+	return func(o *latexOptions) {
+		o.label = label
+	}
+}
+
+// WriteLaTeX writes the matrix to w as a LaTeX tabular showing the lower
+// triangle of coefficients. The output uses the rules from the booktabs
+// package.
+//
+// An error is returned if the number of labels does not match the matrix
+// dimension or if writing to w fails.
+func (m *Matrix) WriteLaTeX(w io.Writer, opts ...LaTeXOption) error {
+	o := defaultLaTeXOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	labels := o.labels
+	if labels == nil {
+		labels = make([]string, m.dim)
+		for i := range labels {
+			labels[i] = strconv.Itoa(i + 1)
+		}
+	}
+	if len(labels) != m.dim {
+		return errors.New("number of labels must match the matrix dimension")
+	}
+
+	var b strings.Builder
+	beginTable(&b, o)
+
+	b.WriteString("\\begin{tabular}{l" + strings.Repeat("r", m.dim) + "}\n")
+	b.WriteString("\\toprule\n")
+	for _, l := range labels {
+		b.WriteString(" & " + escapeLaTeX(l))
+	}
+	b.WriteString(" \\\\\n\\midrule\n")
+
+	for i := range m.dim {
+		b.WriteString(escapeLaTeX(labels[i]))
+		for j := range m.dim {
+			b.WriteString(" & ")
+			switch {
+			case j > i:
+				// Upper triangle is left empty.
+			case i == j:
+				b.WriteString("1")
+			default:
+				b.WriteString(latexCell(m.At(i, j), m.N(i, j), m.Type, o))
+			}
+		}
+		b.WriteString(" \\\\\n")
+	}
+
+	b.WriteString("\\bottomrule\n\\end{tabular}\n")
+	endTable(&b, o)
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// WriteLaTeX writes the result to w as a single row LaTeX tabular listing
+// the method, coefficient, n and p-value. The output uses the rules from the
+// booktabs package.
+//
+// An error is returned if writing to w fails.
+func (r Result) WriteLaTeX(w io.Writer, opts ...LaTeXOption) error {
+	o := defaultLaTeXOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	p := "--"
+	if pv, err := PValue(r.Coefficient, r.N, r.Type); err == nil {
+		p = formatLaTeXNumber(pv, o.decimals+1)
+	}
+
+	var b strings.Builder
+	beginTable(&b, o)
+	b.WriteString("\\begin{tabular}{lrrr}\n")
+	b.WriteString("\\toprule\n")
+	b.WriteString("Method & $r$ & $n$ & $p$ \\\\\n")
+	b.WriteString("\\midrule\n")
+	b.WriteString(escapeLaTeX(r.Type.String()) + " & " + latexCell(r.Coefficient, r.N, r.Type, o) +
+		" & " + strconv.Itoa(r.N) + " & " + p + " \\\\\n")
+	b.WriteString("\\bottomrule\n\\end{tabular}\n")
+	endTable(&b, o)
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// beginTable opens the table float if a caption was requested.
+func beginTable(b *strings.Builder, o latexOptions) {
+	if o.caption == "" {
+		return
+	}
+	b.WriteString("\\begin{table}[htbp]\n\\centering\n")
+	b.WriteString("\\caption{" + escapeLaTeX(o.caption) + "}\n")
+	if o.label != "" {
+		b.WriteString("\\label{" + o.label + "}\n")
+	}
+}
+
+// endTable writes the significance note and closes the table float if one
+// was opened.
+func endTable(b *strings.Builder, o latexOptions) {
+	if o.stars {
+		b.WriteString("\\par\\smallskip{\\footnotesize $^{*}p<.05$; $^{**}p<.01$; $^{***}p<.001$}\n")
+	}
+	if o.caption != "" {
+		b.WriteString("\\end{table}\n")
+	}
+}
+
+// latexCell formats a coefficient, adding significance stars when enabled
+// and a p-value can be computed for it.
+func latexCell(r float64, n int, t Type, o latexOptions) string {
+	if math.IsNaN(r) {
+		return "--"
+	}
+
+	s := formatLaTeXNumber(r, o.decimals)
+	if !o.stars {
+		return s
+	}
+
+	p, err := PValue(r, n, t)
+	if err != nil {
+		return s
+	}
+
+	return s + significanceStars(p)
+}
+
+// significanceStars returns the conventional star markers for the p-value p.
+func significanceStars(p float64) string {
+	switch {
+	case p < 0.001:
+		return "$^{***}$"
+	case p < 0.01:
+		return "$^{**}$"
+	case p < 0.05:
+		return "$^{*}$"
+	default:
+		return ""
+	}
+}
+
+// formatLaTeXNumber formats v with the given number of decimals, using a
+// proper minus sign for negative values.
+func formatLaTeXNumber(v float64, decimals int) string {
+	s := strconv.FormatFloat(v, 'f', decimals, 64)
+	if strings.HasPrefix(s, "-") {
+		return "$-$" + s[1:]
+	}
+
+	return s
+}
+
+// latexEscaper replaces the characters that have special meaning in LaTeX.
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+)
+
+// escapeLaTeX escapes s for use as LaTeX text.
+func escapeLaTeX(s string) string {
+	return latexEscaper.Replace(s)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMatrixWriteLaTeX(t *testing.T) {
+	m := newMatrix(3, Pearson)
+	m.set(0, 0, 1, 30)
+	m.set(1, 1, 1, 30)
+	m.set(2, 2, 1, 30)
+	m.set(0, 1, 0.8123, 30)
+	m.set(0, 2, -0.05, 30)
+	m.set(1, 2, 0.41, 30)
+
+	var b strings.Builder
+	if err := m.WriteLaTeX(&b, WithLaTeXLabels("height_cm", "weight", "age"), WithLaTeXDecimals(3)); err != nil {
+		t.Fatalf("WriteLaTeX() unexpected error: %v", err)
+	}
+
+	want := `\begin{tabular}{lrrr}
+\toprule
+ & height\_cm & weight & age \\
+\midrule
+height\_cm & 1 &  &  \\
+weight & 0.812$^{***}$ & 1 &  \\
+age & $-$0.050 & 0.410$^{*}$ & 1 \\
+\bottomrule
+\end{tabular}
+\par\smallskip{\footnotesize $^{*}p<.05$; $^{**}p<.01$; $^{***}p<.001$}
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteLaTeX() =\n%s\nwant\n%s", got, want)
+	}
+
+	b.Reset()
+	if err := m.WriteLaTeX(&b, WithLaTeXStars(false), WithLaTeXCaption("Correlations"), WithLaTeXLabel("tab:corr")); err != nil {
+		t.Fatalf("WriteLaTeX() unexpected error: %v", err)
+	}
+	got := b.String()
+	for _, s := range []string{"\\begin{table}[htbp]", "\\caption{Correlations}", "\\label{tab:corr}", "2 & 0.81 & 1 &  \\\\", "\\end{table}"} {
+		if !strings.Contains(got, s) {
+			t.Errorf("WriteLaTeX() output missing %q:\n%s", s, got)
+		}
+	}
+	if strings.Contains(got, "$^{*") {
+		t.Errorf("WriteLaTeX() with stars disabled contains stars:\n%s", got)
+	}
+
+	if err := m.WriteLaTeX(&b, WithLaTeXLabels("a", "b")); err == nil {
+		t.Errorf("WriteLaTeX() with wrong number of labels expected error but got none")
+	}
+}
+
+func TestResultWriteLaTeX(t *testing.T) {
+	r := Result{
+		Type:        Pearson,
+		Coefficient: 0.5,
+		N:           20,
+		Imputed:     0,
+		Clipped:     0,
+	}
+
+	var b strings.Builder
+	if err := r.WriteLaTeX(&b, WithLaTeXStars(false)); err != nil {
+		t.Fatalf("WriteLaTeX() unexpected error: %v", err)
+	}
+
+	want := `\begin{tabular}{lrrr}
+\toprule
+Method & $r$ & $n$ & $p$ \\
+\midrule
+Pearson & 0.50 & 20 & 0.025 \\
+\bottomrule
+\end{tabular}
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteLaTeX() =\n%s\nwant\n%s", got, want)
+	}
+}
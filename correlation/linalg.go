@@ -0,0 +1,160 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// The helpers in this file provide the small amount of dense linear algebra
+// needed by the matrix level tests. They operate on square [][]float64 values
+// and are not intended to compete with a dedicated linear algebra package.
+
+// singularTolerance is the pivot magnitude below which a matrix is treated
+// as singular.
+const singularTolerance = 1e-12
+
+// cloneSquare returns a deep copy of the square matrix a.
+func cloneSquare(a [][]float64) [][]float64 {
+	out := make([][]float64, len(a))
+	for i := range a {
+		out[i] = make([]float64, len(a[i]))
+		copy(out[i], a[i])
+	}
+
+	return out
+}
+
+// identity returns the n×n identity matrix.
+func identity(n int) [][]float64 {
+	out := make([][]float64, n)
+	for i := range n {
+		out[i] = make([]float64, n)
+		out[i][i] = 1
+	}
+
+	return out
+}
+
+// invertMatrix returns the inverse of the square matrix a using Gauss-Jordan
+// elimination with partial pivoting. The input is not modified.
+//
+// An error is returned if the matrix is singular.
+func invertMatrix(a [][]float64) ([][]float64, error) {
+	n := len(a)
+	work := cloneSquare(a)
+	inv := identity(n)
+
+	for col := range n {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(work[row][col]) > math.Abs(work[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(work[pivot][col]) < singularTolerance {
+			return nil, errors.New("matrix is singular")
+		}
+		work[col], work[pivot] = work[pivot], work[col]
+		inv[col], inv[pivot] = inv[pivot], inv[col]
+
+		scale := 1 / work[col][col]
+		for k := range n {
+			work[col][k] *= scale
+			inv[col][k] *= scale
+		}
+
+		for row := range n {
+			if row == col || work[row][col] == 0 {
+				continue
+			}
+			f := work[row][col]
+			for k := range n {
+				work[row][k] -= f * work[col][k]
+				inv[row][k] -= f * inv[col][k]
+			}
+		}
+	}
+
+	return inv, nil
+}
+
+// logDeterminant returns the natural log of the determinant of the square
+// matrix a, which must be positive definite (as covariance and correlation
+// matrices are). The input is not modified.
+//
+// An error is returned if the matrix is singular or has a non-positive
+// determinant.
+func logDeterminant(a [][]float64) (float64, error) {
+	n := len(a)
+	work := cloneSquare(a)
+	logDet := 0.0
+	sign := 1.0
+
+	for col := range n {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(work[row][col]) > math.Abs(work[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(work[pivot][col]) < singularTolerance {
+			return 0, errors.New("matrix is singular")
+		}
+		if pivot != col {
+			work[col], work[pivot] = work[pivot], work[col]
+			sign = -sign
+		}
+
+		p := work[col][col]
+		if p < 0 {
+			sign = -sign
+		}
+		logDet += math.Log(math.Abs(p))
+
+		for row := col + 1; row < n; row++ {
+			f := work[row][col] / p
+			for k := col; k < n; k++ {
+				work[row][k] -= f * work[col][k]
+			}
+		}
+	}
+
+	if sign < 0 {
+		return 0, errors.New("matrix determinant is negative")
+	}
+
+	return logDet, nil
+}
+
+// multiplySquare returns the matrix product a·b of two n×n matrices.
+func multiplySquare(a, b [][]float64) [][]float64 {
+	n := len(a)
+	out := make([][]float64, n)
+	for i := range n {
+		out[i] = make([]float64, n)
+		for k := range n {
+			if a[i][k] == 0 {
+				continue
+			}
+			for j := range n {
+				out[i][j] += a[i][k] * b[k][j]
+			}
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,65 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInvertMatrix(t *testing.T) {
+	a := [][]float64{
+		{4, 7, 2},
+		{3, 6, 1},
+		{2, 5, 3},
+	}
+
+	inv, err := invertMatrix(a)
+	if err != nil {
+		t.Fatalf("invertMatrix() unexpected error: %v", err)
+	}
+
+	prod := multiplySquare(a, inv)
+	for i := range prod {
+		for j := range prod[i] {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.Abs(prod[i][j]-want) > 1e-12 {
+				t.Errorf("(a * inv)[%d][%d] = %v, want %v", i, j, prod[i][j], want)
+			}
+		}
+	}
+
+	if _, err := invertMatrix([][]float64{{1, 2}, {2, 4}}); err == nil {
+		t.Errorf("invertMatrix() of singular matrix expected error but got none")
+	}
+}
+
+func TestLogDeterminant(t *testing.T) {
+	// det = 4*6 - 2*2 = 20
+	got, err := logDeterminant([][]float64{{4, 2}, {2, 6}})
+	if err != nil {
+		t.Fatalf("logDeterminant() unexpected error: %v", err)
+	}
+	if math.Abs(got-math.Log(20)) > 1e-12 {
+		t.Errorf("logDeterminant() = %v, want %v", got, math.Log(20))
+	}
+
+	if _, err := logDeterminant([][]float64{{0, 1}, {1, 0}}); err == nil {
+		t.Errorf("logDeterminant() of negative determinant expected error but got none")
+	}
+}
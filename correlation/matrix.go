@@ -0,0 +1,434 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// Matrix is a symmetric matrix of correlation coefficients between a set of
+// variables.
+//
+// Alongside every coefficient the matrix records the number of observation
+// pairs that were used to compute it. When missing values are handled by
+// pairwise deletion each cell may be based on a different n, so p-values
+// are always computed from the per-cell count rather than a single global n.
+type Matrix struct {
+	// Type is the correlation coefficient used to fill the matrix.
+	Type Type
+
+	dim    int
+	coef   []float64
+	counts []int
+}
+
+// newMatrix returns a dim×dim Matrix of the given type with all
+// coefficients and counts set to zero.
+func newMatrix(dim int, correlationType Type) *Matrix {
+	return &Matrix{
+		Type:   correlationType,
+		dim:    dim,
+		coef:   make([]float64, dim*dim),
+		counts: make([]int, dim*dim),
+	}
+}
+
+// set stores the coefficient and count for the cell (i, j) and its mirror (j, i).
+func (m *Matrix) set(i, j int, r float64, n int) {
+	m.coef[i*m.dim+j] = r
+	m.coef[j*m.dim+i] = r
+	m.counts[i*m.dim+j] = n
+	m.counts[j*m.dim+i] = n
+}
+
+// Dim returns the number of variables (rows and columns) in the matrix.
+func (m *Matrix) Dim() int {
+	return m.dim
+}
+
+// At returns the correlation coefficient between variables i and j.
+// Cells where the coefficient is undefined, such as when fewer than two
+// complete pairs were available, hold NaN.
+//
+// At panics if i or j is out of range.
+func (m *Matrix) At(i, j int) float64 {
+	m.checkIndex(i, j)
+
+	return m.coef[i*m.dim+j]
+}
+
+// N returns the number of complete observation pairs used to compute the
+// coefficient between variables i and j.
+//
+// N panics if i or j is out of range.
+func (m *Matrix) N(i, j int) int {
+	m.checkIndex(i, j)
+
+	return m.counts[i*m.dim+j]
+}
+
+// Coefficients returns a copy of the coefficients as a slice of rows.
+func (m *Matrix) Coefficients() [][]float64 {
+	out := make([][]float64, m.dim)
+	for i := range m.dim {
+		out[i] = make([]float64, m.dim)
+		copy(out[i], m.coef[i*m.dim:(i+1)*m.dim])
+	}
+
+	return out
+}
+
+// Counts returns a copy of the per-cell sample sizes as a slice of rows,
+// parallel to Coefficients.
+func (m *Matrix) Counts() [][]int {
+	out := make([][]int, m.dim)
+	for i := range m.dim {
+		out[i] = make([]int, m.dim)
+		copy(out[i], m.counts[i*m.dim:(i+1)*m.dim])
+	}
+
+	return out
+}
+
+// PValue returns the two-sided p-value for the null hypothesis of no
+// association between variables i and j, using the sample size recorded
+// for that cell.
+//
+// An error is returned if the coefficient is undefined or the cell has too
+// few observations for the test.
+func (m *Matrix) PValue(i, j int) (float64, error) {
+	r := m.At(i, j)
+	if math.IsNaN(r) {
+		return 0, errors.New("p-value undefined: coefficient is NaN")
+	}
+
+	return PValue(r, m.N(i, j), m.Type)
+}
+
+// PValues returns the matrix of two-sided p-values, each computed with the
+// sample size of its own cell. Cells whose p-value cannot be computed hold NaN.
+// The diagonal is always 0.
+func (m *Matrix) PValues() [][]float64 {
+	out := make([][]float64, m.dim)
+	for i := range m.dim {
+		out[i] = make([]float64, m.dim)
+	}
+
+	for i := range m.dim {
+		for j := i + 1; j < m.dim; j++ {
+			p, err := m.PValue(i, j)
+			if err != nil {
+				p = math.NaN()
+			}
+			out[i][j] = p
+			out[j][i] = p
+		}
+	}
+
+	return out
+}
+
+// checkIndex panics if either index falls outside the matrix.
+func (m *Matrix) checkIndex(i, j int) {
+	if i < 0 || i >= m.dim || j < 0 || j >= m.dim {
+		panic("correlation: matrix index (" + strconv.Itoa(i) + ", " + strconv.Itoa(j) +
+			") out of range for dimension " + strconv.Itoa(m.dim))
+	}
+}
+
+// PairwiseCorrelationMatrix computes the correlation matrix of the given
+// columns, treating NaN values as missing and using pairwise deletion.
+//
+// Each cell (i, j) is computed from only those rows where both column i and
+// column j are present, and the number of rows used is recorded in the
+// Matrix so that Matrix.N and Matrix.PValue reflect the per-cell sample size.
+// Cells with fewer than two complete pairs, or whose coefficient is otherwise
+// undefined (e.g. zero variance after deletion), hold NaN. The diagonal is 1
+// with n equal to the number of present values in that column.
+//
+// An error is returned if there are fewer than two columns, if the columns
+// differ in length, or if the correlation type is not supported.
+func PairwiseCorrelationMatrix(cols [][]float64, correlationType Type) (*Matrix, error) {
+	if len(cols) < 2 {
+		return nil, errors.New("correlation matrix requires at least 2 columns")
+	}
+
+	rows := len(cols[0])
+	for _, col := range cols[1:] {
+		if len(col) != rows {
+			return nil, errors.New("columns must have the same length")
+		}
+	}
+
+	if !correlationType.valid() {
+		return nil, errors.New("unsupported correlation type")
+	}
+
+	m := newMatrix(len(cols), correlationType)
+	xs := make([]float64, 0, rows)
+	ys := make([]float64, 0, rows)
+
+	for i := range cols {
+		present := 0
+		for _, v := range cols[i] {
+			if !math.IsNaN(v) {
+				present++
+			}
+		}
+		m.set(i, i, 1, present)
+
+		for j := i + 1; j < len(cols); j++ {
+			xs = xs[:0]
+			ys = ys[:0]
+			for k := range rows {
+				if math.IsNaN(cols[i][k]) || math.IsNaN(cols[j][k]) {
+					continue
+				}
+				xs = append(xs, cols[i][k])
+				ys = append(ys, cols[j][k])
+			}
+
+			r, err := Correlate(xs, ys, correlationType)
+			if err != nil {
+				r = math.NaN()
+			}
+			m.set(i, j, r, len(xs))
+		}
+	}
+
+	return m, nil
+}
+
+// CorrelationMatrix computes the correlation matrix of the given columns,
+// which must all be complete and of the same length. Element (i, j) of the
+// result is the correlation between column i and column j, and every cell
+// records the full column length as its sample size.
+//
+// The work is shared between pairs wherever possible: for Pearson each
+// column is centred and scaled once, and each coefficient is then a single
+// dot product, with the products taken tile by tile as in a blocked matrix
+// multiply so that wide matrices stay cache friendly; for Spearman each
+// column is ranked once. Kendall's tau and
+// Goodman and Kruskal's gamma are computed pair by pair.
+//
+// Cells whose coefficient is undefined, such as those involving a constant
+// column, hold NaN, as do Pearson cells involving a column that contains
+// NaN. Use PairwiseCorrelationMatrix for data with missing values.
+//
+// An error is returned if there are fewer than two columns, if the columns
+// differ in length or have fewer than 2 values, or if the correlation type
+// is not supported.
+func CorrelationMatrix(cols [][]float64, correlationType Type) (*Matrix, error) {
+	if len(cols) < 2 {
+		return nil, errors.New("correlation matrix requires at least 2 columns")
+	}
+
+	rows := len(cols[0])
+	for _, col := range cols[1:] {
+		if len(col) != rows {
+			return nil, errors.New("columns must have the same length")
+		}
+	}
+	if rows < 2 {
+		return nil, errors.New("correlation requires at least 2 data points")
+	}
+
+	if !correlationType.valid() {
+		return nil, errors.New("unsupported correlation type")
+	}
+
+	m := newMatrix(len(cols), correlationType)
+	for i := range cols {
+		m.set(i, i, 1, rows)
+	}
+
+	switch correlationType {
+	case Pearson:
+		fillDotProducts(m, cols, rows)
+	case Spearman:
+		ranked := make([][]float64, len(cols))
+		for i, col := range cols {
+			if slices.ContainsFunc(col, math.IsNaN) {
+				ranked[i] = slices.Repeat([]float64{math.NaN()}, rows)
+
+				continue
+			}
+			ranked[i] = make([]float64, rows)
+			order := make([]int, rows)
+			for k := range order {
+				order[k] = k
+			}
+			byValue := func(a, b int) int { return cmp.Compare(col[a], col[b]) }
+			slices.SortFunc(order, byValue)
+			assignRanks(order, ranked[i], byValue)
+		}
+		fillDotProducts(m, ranked, rows)
+	default:
+		for i := range cols {
+			for j := i + 1; j < len(cols); j++ {
+				r, err := Correlate(cols[i], cols[j], correlationType)
+				if err != nil {
+					r = math.NaN()
+				}
+				m.set(i, j, r, rows)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+// fillDotProducts fills the off diagonal cells of m with the Pearson
+// correlations of cols, by standardizing each column once and taking dot
+// products of the standardized columns.
+//
+// A column whose sum of squared deviations overflows, or underflows below
+// the normal range, cannot be standardized in float64, so each pair
+// involving it is computed by Correlate instead, which escalates to
+// big.Float where needed.
+func fillDotProducts(m *Matrix, cols [][]float64, rows int) {
+	z := make([][]float64, len(cols))
+	exact := make([]bool, len(cols))
+	for i, col := range cols {
+		if slices.ContainsFunc(col, func(v float64) bool { return !isFinite(v) }) ||
+			!slices.ContainsFunc(col, func(v float64) bool { return v != col[0] }) {
+			// Constant columns, and columns containing NaN or ±Inf, have no
+			// defined correlation with anything.
+			continue
+		}
+
+		mean := 0.0
+		for _, v := range col {
+			mean += v
+		}
+		mean /= float64(rows)
+
+		ss := 0.0
+		for _, v := range col {
+			ss += (v - mean) * (v - mean)
+		}
+
+		if !(ss >= minNormalFloat64 && ss <= math.MaxFloat64) {
+			exact[i] = true
+
+			continue
+		}
+		scale := 1 / math.Sqrt(ss)
+		z[i] = make([]float64, rows)
+		for k, v := range col {
+			z[i][k] = (v - mean) * scale
+		}
+	}
+
+	// The dot products are accumulated in the upper triangle of the
+	// coefficients, then clamped and mirrored.
+	blockDotProducts(z, m.coef, rows)
+	for i := range cols {
+		for j := i + 1; j < len(cols); j++ {
+			switch {
+			case (z[i] == nil && !exact[i]) || (z[j] == nil && !exact[j]):
+				m.set(i, j, math.NaN(), rows)
+			case exact[i] || exact[j]:
+				r, err := Correlate(cols[i], cols[j], Pearson)
+				if err != nil {
+					r = math.NaN()
+				}
+				m.set(i, j, r, rows)
+			default:
+				m.set(i, j, clampUnit(m.coef[i*m.dim+j]), rows)
+			}
+		}
+	}
+}
+
+// The tile sizes of blockDotProducts. A tile holds the segments of
+// matrixBlockRows rows of matrixBlockCols columns, 256KiB, so a pair of
+// tiles stays in cache while every dot product between them is taken.
+const (
+	matrixBlockCols = 32
+	matrixBlockRows = 1024
+)
+
+// blockDotProducts adds the dot product of columns i and j of z to
+// dots[i*len(z)+j] for every i < j where neither column is nil.
+//
+// Taking each dot product in turn streams both columns through the cache
+// once per pair, which for thousands of columns makes memory the bottleneck.
+// Instead the columns are split into tiles of matrixBlockCols columns by
+// matrixBlockRows rows, as in a blocked matrix multiply, and all the partial
+// dot products between two tiles are taken while both are in cache.
+func blockDotProducts(z [][]float64, dots []float64, rows int) {
+	d := len(z)
+	js := make([]int, 0, matrixBlockCols)
+	for lo := 0; lo < rows; lo += matrixBlockRows {
+		hi := min(lo+matrixBlockRows, rows)
+		for ib := 0; ib < d; ib += matrixBlockCols {
+			ie := min(ib+matrixBlockCols, d)
+			for jb := ib; jb < d; jb += matrixBlockCols {
+				je := min(jb+matrixBlockCols, d)
+				for i := ib; i < ie; i++ {
+					if z[i] == nil {
+						continue
+					}
+					js = js[:0]
+					for j := max(jb, i+1); j < je; j++ {
+						if z[j] != nil {
+							js = append(js, j)
+						}
+					}
+					dotRow(z, dots[i*d:(i+1)*d], z[i][lo:hi], js, lo)
+				}
+			}
+		}
+	}
+}
+
+// dotRow adds to row[j] the dot product of zi with the segment of column j
+// of z starting at row lo, for each j in js. Four columns are taken at a
+// time, so that each value of zi is loaded once for all four and the four
+// sums proceed independently.
+func dotRow(z [][]float64, row, zi []float64, js []int, lo int) {
+	n := len(zi)
+	for len(js) >= 4 {
+		z0 := z[js[0]][lo : lo+n]
+		z1 := z[js[1]][lo : lo+n]
+		z2 := z[js[2]][lo : lo+n]
+		z3 := z[js[3]][lo : lo+n]
+		var s0, s1, s2, s3 float64
+		for k, v := range zi {
+			s0 += v * z0[k]
+			s1 += v * z1[k]
+			s2 += v * z2[k]
+			s3 += v * z3[k]
+		}
+		row[js[0]] += s0
+		row[js[1]] += s1
+		row[js[2]] += s2
+		row[js[3]] += s3
+		js = js[4:]
+	}
+	for _, j := range js {
+		zj := z[j][lo : lo+n]
+		s := 0.0
+		for k, v := range zi {
+			s += v * zj[k]
+		}
+		row[j] += s
+	}
+}
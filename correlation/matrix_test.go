@@ -0,0 +1,319 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPairwiseCorrelationMatrix(t *testing.T) {
+	nan := math.NaN()
+	cols := [][]float64{
+		{1, 2, 3, 4, 5, 6, 7, 8},
+		{2, 4, 6, 8, 10, 12, 14, nan},
+		{8, 7, nan, 5, 4, nan, 2, 1},
+	}
+
+	m, err := PairwiseCorrelationMatrix(cols, Pearson)
+	if err != nil {
+		t.Fatalf("PairwiseCorrelationMatrix() unexpected error: %v", err)
+	}
+
+	if m.Dim() != 3 {
+		t.Fatalf("Dim() = %d, want 3", m.Dim())
+	}
+
+	wantN := [][]int{
+		{8, 7, 6},
+		{7, 7, 5},
+		{6, 5, 6},
+	}
+	gotN := m.Counts()
+	for i := range wantN {
+		for j := range wantN[i] {
+			if gotN[i][j] != wantN[i][j] {
+				t.Errorf("Counts()[%d][%d] = %d, want %d", i, j, gotN[i][j], wantN[i][j])
+			}
+			if m.N(i, j) != wantN[i][j] {
+				t.Errorf("N(%d, %d) = %d, want %d", i, j, m.N(i, j), wantN[i][j])
+			}
+		}
+	}
+
+	if got := m.At(0, 1); math.Abs(got-1) > 1e-12 {
+		t.Errorf("At(0, 1) = %v, want 1", got)
+	}
+	if got := m.At(2, 0); math.Abs(got+1) > 1e-12 {
+		t.Errorf("At(2, 0) = %v, want -1", got)
+	}
+	if m.At(1, 2) != m.At(2, 1) {
+		t.Errorf("matrix is not symmetric: At(1, 2) = %v, At(2, 1) = %v", m.At(1, 2), m.At(2, 1))
+	}
+	for i := range m.Dim() {
+		if m.At(i, i) != 1 {
+			t.Errorf("At(%d, %d) = %v, want 1", i, i, m.At(i, i))
+		}
+	}
+}
+
+func TestMatrixPValueUsesCellN(t *testing.T) {
+	nan := math.NaN()
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	y := []float64{2, 1, 4, 3, 6, 5, 8, 7, 10, 9}
+	z := []float64{2, 1, 4, 3, nan, nan, nan, nan, nan, 9}
+
+	m, err := PairwiseCorrelationMatrix([][]float64{x, y, z}, Pearson)
+	if err != nil {
+		t.Fatalf("PairwiseCorrelationMatrix() unexpected error: %v", err)
+	}
+
+	for _, cell := range [][2]int{{0, 1}, {0, 2}, {1, 2}} {
+		i, j := cell[0], cell[1]
+		want, err := PValue(m.At(i, j), m.N(i, j), Pearson)
+		if err != nil {
+			t.Fatalf("PValue() unexpected error: %v", err)
+		}
+
+		got, err := m.PValue(i, j)
+		if err != nil {
+			t.Fatalf("Matrix.PValue(%d, %d) unexpected error: %v", i, j, err)
+		}
+		if got != want {
+			t.Errorf("Matrix.PValue(%d, %d) = %v, want %v (n = %d)", i, j, got, want, m.N(i, j))
+		}
+
+		if p := m.PValues()[i][j]; p != want {
+			t.Errorf("PValues()[%d][%d] = %v, want %v", i, j, p, want)
+		}
+	}
+
+	// The x/z cell only has 5 pairs so its p-value must be larger than one
+	// computed with the full n of 10.
+	full, _ := PValue(m.At(0, 2), 10, Pearson)
+	cell, _ := m.PValue(0, 2)
+	if cell <= full {
+		t.Errorf("Matrix.PValue(0, 2) = %v, expected larger than full-n p-value %v", cell, full)
+	}
+}
+
+func TestPairwiseCorrelationMatrixUndefinedCells(t *testing.T) {
+	nan := math.NaN()
+	cols := [][]float64{
+		{1, 2, nan, nan},
+		{nan, nan, 3, 4},
+	}
+
+	m, err := PairwiseCorrelationMatrix(cols, Pearson)
+	if err != nil {
+		t.Fatalf("PairwiseCorrelationMatrix() unexpected error: %v", err)
+	}
+	if !math.IsNaN(m.At(0, 1)) {
+		t.Errorf("At(0, 1) = %v, want NaN", m.At(0, 1))
+	}
+	if m.N(0, 1) != 0 {
+		t.Errorf("N(0, 1) = %d, want 0", m.N(0, 1))
+	}
+	if _, err := m.PValue(0, 1); err == nil {
+		t.Errorf("PValue(0, 1) expected error but got none")
+	}
+	if p := m.PValues()[0][1]; !math.IsNaN(p) {
+		t.Errorf("PValues()[0][1] = %v, want NaN", p)
+	}
+}
+
+func TestPairwiseCorrelationMatrixErrors(t *testing.T) {
+	if _, err := PairwiseCorrelationMatrix([][]float64{{1, 2, 3}}, Pearson); err == nil {
+		t.Errorf("expected error for a single column but got none")
+	}
+	if _, err := PairwiseCorrelationMatrix([][]float64{{1, 2, 3}, {1, 2}}, Pearson); err == nil {
+		t.Errorf("expected error for different length columns but got none")
+	}
+	if _, err := PairwiseCorrelationMatrix([][]float64{{1, 2, 3}, {1, 2, 3}}, Type(999)); err == nil {
+		t.Errorf("expected error for unsupported type but got none")
+	}
+}
+
+func TestCorrelationMatrix(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	const rows = 60
+	cols := make([][]float64, 4)
+	for i := range cols {
+		cols[i] = make([]float64, rows)
+	}
+	for k := range rows {
+		a := rng.NormFloat64()
+		cols[0][k] = a
+		cols[1][k] = math.Exp(a) + 0.3*rng.NormFloat64()
+		cols[2][k] = float64(rng.Intn(5)) // ties
+		cols[3][k] = -a + rng.NormFloat64()
+	}
+
+	for _, ct := range []Type{Pearson, Spearman, KendallTau, GoodmanKruskal} {
+		t.Run(ct.String(), func(t *testing.T) {
+			m, err := CorrelationMatrix(cols, ct)
+			if err != nil {
+				t.Fatalf("CorrelationMatrix() unexpected error: %v", err)
+			}
+			for i := range cols {
+				for j := range cols {
+					want := 1.0
+					if i != j {
+						want, err = Correlate(cols[i], cols[j], ct)
+						if err != nil {
+							t.Fatalf("Correlate() unexpected error: %v", err)
+						}
+					}
+					if got := m.At(i, j); math.Abs(got-want) > 1e-12 {
+						t.Errorf("At(%d, %d) = %v, want %v", i, j, got, want)
+					}
+					if m.N(i, j) != rows {
+						t.Errorf("N(%d, %d) = %d, want %d", i, j, m.N(i, j), rows)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCorrelationMatrixUndefinedCells(t *testing.T) {
+	cols := [][]float64{
+		{1, 2, 3, 4},
+		{5, 5, 5, 5},
+		{1, math.NaN(), 2, 3},
+	}
+
+	for _, ct := range []Type{Pearson, Spearman} {
+		m, err := CorrelationMatrix(cols, ct)
+		if err != nil {
+			t.Fatalf("CorrelationMatrix(%v) unexpected error: %v", ct, err)
+		}
+		if !math.IsNaN(m.At(0, 1)) || !math.IsNaN(m.At(0, 2)) || !math.IsNaN(m.At(1, 2)) {
+			t.Errorf("CorrelationMatrix(%v) = %v, want NaN off the diagonal", ct, m.Coefficients())
+		}
+	}
+}
+
+func TestCorrelationMatrixOverflow(t *testing.T) {
+	// The sums of squares of columns near 1e200 overflow float64, so those
+	// pairs must be computed exactly rather than standardized to zeros.
+	x := []float64{1e200, 2e200, 3e200, 4e200, 5e200, 6e200}
+	y := []float64{2, 1, 4, 3, 6, 5}
+	z := []float64{-3e200, -1e200, -2e200, -6e200, -4e200, -5e200}
+	cols := [][]float64{x, y, z}
+
+	m, err := CorrelationMatrix(cols, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelationMatrix() unexpected error: %v", err)
+	}
+	for i := range cols {
+		for j := i + 1; j < len(cols); j++ {
+			want, err := Correlate(cols[i], cols[j], Pearson)
+			if err != nil {
+				t.Fatalf("Correlate() unexpected error: %v", err)
+			}
+			if got := m.At(i, j); math.Abs(got-want) > 1e-12 || want == 0 {
+				t.Errorf("At(%d, %d) = %v, want %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestCorrelationMatrixErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		cols [][]float64
+		ct   Type
+	}{
+		{name: "one column", cols: [][]float64{{1, 2, 3}}, ct: Pearson},
+		{name: "ragged", cols: [][]float64{{1, 2, 3}, {1, 2}}, ct: Pearson},
+		{name: "one row", cols: [][]float64{{1}, {2}}, ct: Pearson},
+		{name: "bad type", cols: [][]float64{{1, 2, 3}, {3, 2, 1}}, ct: Type(99)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CorrelationMatrix(tt.cols, tt.ct); err == nil {
+				t.Errorf("CorrelationMatrix() expected error but got none")
+			}
+		})
+	}
+}
+
+func TestBlockDotProducts(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	// Sizes that leave partial tiles in both directions, with some columns
+	// missing.
+	const d = 2*matrixBlockCols + 7
+	rows := 2*matrixBlockRows + 13
+	z := make([][]float64, d)
+	for i := range z {
+		if i%11 == 5 {
+			continue
+		}
+		z[i] = make([]float64, rows)
+		for k := range z[i] {
+			z[i][k] = rng.NormFloat64()
+		}
+	}
+
+	dots := make([]float64, d*d)
+	blockDotProducts(z, dots, rows)
+
+	for i := range d {
+		for j := range d {
+			want := 0.0
+			if j > i && z[i] != nil && z[j] != nil {
+				for k := range rows {
+					want += z[i][k] * z[j][k]
+				}
+			}
+			if got := dots[i*d+j]; math.Abs(got-want) > 1e-9 {
+				t.Errorf("dot(%d, %d) = %v, want %v", i, j, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkCorrelationMatrix(b *testing.B) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	cols := make([][]float64, 20)
+	for i := range cols {
+		cols[i] = make([]float64, 1000)
+		for k := range cols[i] {
+			cols[i][k] = rng.NormFloat64()
+		}
+	}
+
+	for b.Loop() {
+		_, _ = CorrelationMatrix(cols, Pearson)
+	}
+}
+
+func BenchmarkCorrelationMatrixWide(b *testing.B) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	cols := make([][]float64, 300)
+	for i := range cols {
+		cols[i] = make([]float64, 10000)
+		for k := range cols[i] {
+			cols[i][k] = rng.NormFloat64()
+		}
+	}
+
+	for b.Loop() {
+		_, _ = CorrelationMatrix(cols, Pearson)
+	}
+}
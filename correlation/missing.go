@@ -0,0 +1,284 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// NaNPolicy determines how NaN and ±Inf values in the inputs are treated.
+type NaNPolicy int
+
+const (
+	// Propagate returns a NaN coefficient if any input value is NaN or ±Inf.
+	// This is the default value.
+	Propagate NaNPolicy = iota
+	// ErrorOnNaN returns an error identifying the first NaN or ±Inf value.
+	ErrorOnNaN
+	// OmitPairwise drops every (x[i], y[i]) pair where either value is NaN
+	// or ±Inf before calculating the coefficient.
+	OmitPairwise
+)
+
+// String returns the string representation of the NaNPolicy.
+func (p NaNPolicy) String() string {
+	switch p {
+	case Propagate:
+		return "Propagate"
+	case ErrorOnNaN:
+		return "ErrorOnNaN"
+	case OmitPairwise:
+		return "OmitPairwise"
+	default:
+		return "Unknown"
+	}
+}
+
+// isFinite reports whether v is neither NaN nor ±Inf. Integer types are
+// always finite.
+func isFinite[T Numeric](v T) bool {
+	f := float64(v)
+
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
+
+// hasNaN reports whether any value in data is NaN.
+func hasNaN[T Numeric](data []T) bool {
+	for _, v := range data {
+		if math.IsNaN(float64(v)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasInf reports whether any value in data is ±Inf.
+func hasInf[T Numeric](data []T) bool {
+	for _, v := range data {
+		if math.IsInf(float64(v), 0) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// nonFiniteError returns an error describing the non-finite value v found
+// in the named input at index i.
+func nonFiniteError(name string, i int, v float64) error {
+	kind := "Inf"
+	if math.IsNaN(v) {
+		kind = "NaN"
+	}
+
+	return errors.New(name + " contains " + kind + " at index " + strconv.Itoa(i))
+}
+
+// applyNaNPolicy applies the policy p to the paired inputs. It returns the
+// (possibly filtered) inputs and reports whether the result should simply be
+// NaN because the policy is Propagate and a non-finite value was found.
+//
+// The inputs are only copied when pairs need to be removed.
+func applyNaNPolicy[T Numeric](x, y []T, p NaNPolicy) ([]T, []T, bool, error) {
+	switch p {
+	case Propagate:
+		for i := range x {
+			if !isFinite(x[i]) || !isFinite(y[i]) {
+				return x, y, true, nil
+			}
+		}
+
+		return x, y, false, nil
+	case ErrorOnNaN:
+		for i := range x {
+			if !isFinite(x[i]) {
+				return nil, nil, false, nonFiniteError("x", i, float64(x[i]))
+			}
+			if !isFinite(y[i]) {
+				return nil, nil, false, nonFiniteError("y", i, float64(y[i]))
+			}
+		}
+
+		return x, y, false, nil
+	case OmitPairwise:
+		keep := 0
+		for i := range x {
+			if isFinite(x[i]) && isFinite(y[i]) {
+				keep++
+			}
+		}
+		if keep == len(x) {
+			return x, y, false, nil
+		}
+
+		fx := make([]T, 0, keep)
+		fy := make([]T, 0, keep)
+		for i := range x {
+			if isFinite(x[i]) && isFinite(y[i]) {
+				fx = append(fx, x[i])
+				fy = append(fy, y[i])
+			}
+		}
+
+		return fx, fy, false, nil
+	default:
+		return nil, nil, false, errors.New("unsupported NaN policy")
+	}
+}
+
+// CorrelatePairwise calculates the specified correlation coefficient between
+// x and y after dropping every (x[i], y[i]) pair where either value is NaN or
+// ±Inf, so callers need not filter the inputs themselves. It returns the
+// coefficient and the number of pairs it was computed from.
+//
+// It is shorthand for CorrelateWithOptions with WithNaNPolicy(OmitPairwise).
+//
+// Returns an error if the slices have different lengths or are empty, if
+// fewer than 2 complete pairs remain, if the coefficient is undefined for
+// the remaining pairs, or if the correlation type is not supported.
+func CorrelatePairwise[T Numeric](x, y []T, correlationType Type) (float64, int, error) {
+	res, err := CorrelateWithOptions(x, y, correlationType, WithNaNPolicy(OmitPairwise))
+	if err != nil {
+		return 0, res.N, err
+	}
+
+	return res.Coefficient, res.N, nil
+}
+
+// ImputationMethod determines how missing (NaN) values are filled in before
+// a correlation is calculated.
+type ImputationMethod int
+
+const (
+	// ImputeNone leaves missing values in place. This is the default value.
+	ImputeNone ImputationMethod = iota
+	// ImputeMean replaces missing values with the mean of the present values
+	// in the same series.
+	ImputeMean
+	// ImputeMedian replaces missing values with the median of the present
+	// values in the same series.
+	ImputeMedian
+	// ImputeLinear replaces missing values by linear interpolation between
+	// the nearest present neighbors in the same series, using the index as
+	// the interpolation axis. Leading and trailing missing values take the
+	// nearest present value.
+	ImputeLinear
+)
+
+// String returns the string representation of the ImputationMethod.
+func (m ImputationMethod) String() string {
+	switch m {
+	case ImputeNone:
+		return "None"
+	case ImputeMean:
+		return "Mean"
+	case ImputeMedian:
+		return "Median"
+	case ImputeLinear:
+		return "Linear"
+	default:
+		return "Unknown"
+	}
+}
+
+// impute fills the NaN values of data in place using method m, and returns
+// the number of values that were filled.
+//
+// An error is returned if the method is unknown or data has no present values
+// to impute from.
+func impute(data []float64, m ImputationMethod) (int, error) {
+	present := make([]float64, 0, len(data))
+	for _, v := range data {
+		if !math.IsNaN(v) {
+			present = append(present, v)
+		}
+	}
+	missing := len(data) - len(present)
+	if missing == 0 {
+		return 0, nil
+	}
+	if len(present) == 0 {
+		return 0, errors.New("all values are missing")
+	}
+
+	switch m {
+	case ImputeNone:
+		return 0, nil
+	case ImputeMean:
+		fill := 0.0
+		for _, v := range present {
+			fill += v
+		}
+		fill /= float64(len(present))
+		fillMissing(data, fill)
+	case ImputeMedian:
+		slices.Sort(present)
+		mid := len(present) / 2
+		fill := present[mid]
+		if len(present)%2 == 0 {
+			fill = (present[mid-1] + present[mid]) / 2
+		}
+		fillMissing(data, fill)
+	case ImputeLinear:
+		interpolateMissing(data)
+	default:
+		return 0, errors.New("unsupported imputation method")
+	}
+
+	return missing, nil
+}
+
+// fillMissing replaces every NaN in data with v.
+func fillMissing(data []float64, v float64) {
+	for i := range data {
+		if math.IsNaN(data[i]) {
+			data[i] = v
+		}
+	}
+}
+
+// interpolateMissing replaces runs of NaN in data by linear interpolation
+// between the present values on either side. Runs at either end take the
+// value of their single present neighbor. data must contain at least one
+// present value.
+func interpolateMissing(data []float64) {
+	prev := -1
+	for i := range data {
+		if math.IsNaN(data[i]) {
+			continue
+		}
+
+		switch {
+		case prev == -1:
+			for k := range i {
+				data[k] = data[i]
+			}
+		case i-prev > 1:
+			step := (data[i] - data[prev]) / float64(i-prev)
+			for k := prev + 1; k < i; k++ {
+				data[k] = data[prev] + step*float64(k-prev)
+			}
+		}
+		prev = i
+	}
+
+	for k := prev + 1; k < len(data); k++ {
+		data[k] = data[prev]
+	}
+}
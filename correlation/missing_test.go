@@ -0,0 +1,304 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rsned/stats/datasets"
+)
+
+func TestCorrelateWithOptionsNaNPolicy(t *testing.T) {
+	nan := math.NaN()
+	inf := math.Inf(1)
+
+	tests := []struct {
+		name    string
+		x       []float64
+		y       []float64
+		policy  NaNPolicy
+		want    float64
+		wantN   int
+		wantNaN bool
+		wantErr string
+	}{
+		{
+			name:  "clean data is unaffected",
+			x:     []float64{1, 2, 3, 4, 5},
+			y:     []float64{2, 4, 6, 8, 10},
+			want:  1,
+			wantN: 5,
+		},
+		{
+			name:    "propagate NaN",
+			x:       []float64{1, 2, nan, 4, 5},
+			y:       []float64{2, 4, 6, 8, 10},
+			policy:  Propagate,
+			wantN:   5,
+			wantNaN: true,
+		},
+		{
+			name:    "propagate Inf",
+			x:       []float64{1, 2, 3, 4, 5},
+			y:       []float64{2, 4, inf, 8, 10},
+			policy:  Propagate,
+			wantN:   5,
+			wantNaN: true,
+		},
+		{
+			name:    "error on NaN in x",
+			x:       []float64{1, 2, nan, 4, 5},
+			y:       []float64{2, 4, 6, 8, 10},
+			policy:  ErrorOnNaN,
+			wantErr: "x contains NaN at index 2",
+		},
+		{
+			name:    "error on Inf in y",
+			x:       []float64{1, 2, 3, 4, 5},
+			y:       []float64{2, 4, 6, math.Inf(-1), 10},
+			policy:  ErrorOnNaN,
+			wantErr: "y contains Inf at index 3",
+		},
+		{
+			name:   "omit pairwise",
+			x:      []float64{1, 2, nan, 4, 5, 6},
+			y:      []float64{2, 4, 6, 8, inf, 12},
+			policy: OmitPairwise,
+			want:   1,
+			wantN:  4,
+		},
+		{
+			name:    "omit pairwise leaves too few",
+			x:       []float64{1, nan, 3},
+			y:       []float64{nan, 4, nan},
+			policy:  OmitPairwise,
+			wantErr: "empty",
+		},
+		{
+			name:    "unknown policy",
+			x:       []float64{1, 2, 3},
+			y:       []float64{1, 2, 3},
+			policy:  NaNPolicy(99),
+			wantErr: "unsupported NaN policy",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CorrelateWithOptions(tt.x, tt.y, Pearson, WithNaNPolicy(tt.policy))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("CorrelateWithOptions() error = %v, want error containing %q", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("CorrelateWithOptions() unexpected error: %v", err)
+			}
+			if got.N != tt.wantN {
+				t.Errorf("CorrelateWithOptions() N = %d, want %d", got.N, tt.wantN)
+			}
+			if tt.wantNaN {
+				if !math.IsNaN(got.Coefficient) {
+					t.Errorf("CorrelateWithOptions() = %v, want NaN", got.Coefficient)
+				}
+
+				return
+			}
+			if math.Abs(got.Coefficient-tt.want) > 1e-12 {
+				t.Errorf("CorrelateWithOptions() = %v, want %v", got.Coefficient, tt.want)
+			}
+		})
+	}
+}
+
+func TestCorrelatePairwise(t *testing.T) {
+	x := []float64{1, 2, math.NaN(), 4, 5, math.Inf(1), 7}
+	y := []float64{2, 4, 6, math.Inf(-1), 10, 12, 15}
+	cleanX := []float64{1, 2, 5, 7}
+	cleanY := []float64{2, 4, 10, 15}
+
+	for _, ct := range []Type{Pearson, Spearman, KendallTau, GoodmanKruskal} {
+		got, n, err := CorrelatePairwise(x, y, ct)
+		if err != nil {
+			t.Fatalf("CorrelatePairwise(%v) unexpected error: %v", ct, err)
+		}
+		want, err := Correlate(cleanX, cleanY, ct)
+		if err != nil {
+			t.Fatalf("Correlate(%v) unexpected error: %v", ct, err)
+		}
+		if n != 4 || got != want {
+			t.Errorf("CorrelatePairwise(%v) = %v, %d, want %v, 4", ct, got, n, want)
+		}
+	}
+
+	tests := []struct {
+		name string
+		x, y []float64
+	}{
+		{name: "length mismatch", x: []float64{1, 2, 3}, y: []float64{1, 2}},
+		{name: "empty", x: []float64{}, y: []float64{}},
+		{name: "one complete pair", x: []float64{1, math.NaN(), 3}, y: []float64{1, 2, math.NaN()}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := CorrelatePairwise(tt.x, tt.y, Pearson); err == nil {
+				t.Errorf("CorrelatePairwise() expected error but got none")
+			}
+		})
+	}
+}
+
+func TestCorrelateWithOptionsIntegers(t *testing.T) {
+	got, err := CorrelateWithOptions([]int{1, 2, 3, 4}, []int{4, 3, 2, 1}, Pearson, WithNaNPolicy(ErrorOnNaN))
+	if err != nil {
+		t.Fatalf("CorrelateWithOptions() unexpected error: %v", err)
+	}
+	if math.Abs(got.Coefficient+1) > 1e-12 || got.N != 4 {
+		t.Errorf("CorrelateWithOptions() = %+v, want coefficient -1 with N 4", got)
+	}
+}
+
+func TestImpute(t *testing.T) {
+	nan := math.NaN()
+
+	tests := []struct {
+		name       string
+		data       []float64
+		method     ImputationMethod
+		want       []float64
+		wantFilled int
+	}{
+		{
+			name:       "mean",
+			data:       []float64{1, nan, 3, 8},
+			method:     ImputeMean,
+			want:       []float64{1, 4, 3, 8},
+			wantFilled: 1,
+		},
+		{
+			name:       "median odd",
+			data:       []float64{1, nan, 3, 8, nan},
+			method:     ImputeMedian,
+			want:       []float64{1, 3, 3, 8, 3},
+			wantFilled: 2,
+		},
+		{
+			name:       "median even",
+			data:       []float64{1, nan, 3, 8, 10},
+			method:     ImputeMedian,
+			want:       []float64{1, 5.5, 3, 8, 10},
+			wantFilled: 1,
+		},
+		{
+			name:       "linear interior and edges",
+			data:       []float64{nan, 2, nan, nan, 8, nan},
+			method:     ImputeLinear,
+			want:       []float64{2, 2, 4, 6, 8, 8},
+			wantFilled: 4,
+		},
+		{
+			name:       "nothing missing",
+			data:       []float64{1, 2, 3},
+			method:     ImputeMean,
+			want:       []float64{1, 2, 3},
+			wantFilled: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filled, err := impute(tt.data, tt.method)
+			if err != nil {
+				t.Fatalf("impute() unexpected error: %v", err)
+			}
+			if filled != tt.wantFilled {
+				t.Errorf("impute() filled = %d, want %d", filled, tt.wantFilled)
+			}
+			for i := range tt.want {
+				if math.Abs(tt.data[i]-tt.want[i]) > 1e-12 {
+					t.Errorf("impute() data = %v, want %v", tt.data, tt.want)
+
+					break
+				}
+			}
+		})
+	}
+
+	if _, err := impute([]float64{nan, nan}, ImputeMean); err == nil {
+		t.Errorf("impute() of all missing values expected error but got none")
+	}
+}
+
+func TestCorrelateWithOptionsImputation(t *testing.T) {
+	nan := math.NaN()
+	x := []float64{1, 2, nan, 4, 5, 6}
+	y := []float64{2, nan, 6, 8, 10, 12}
+
+	got, err := CorrelateWithOptions(x, y, Pearson, WithImputation(ImputeLinear))
+	if err != nil {
+		t.Fatalf("CorrelateWithOptions() unexpected error: %v", err)
+	}
+	if math.Abs(got.Coefficient-1) > 1e-12 {
+		t.Errorf("CorrelateWithOptions() = %v, want 1", got.Coefficient)
+	}
+	if got.Imputed != 2 || got.N != 6 {
+		t.Errorf("CorrelateWithOptions() Imputed = %d, N = %d, want 2 and 6", got.Imputed, got.N)
+	}
+	if !math.IsNaN(x[2]) || !math.IsNaN(y[1]) {
+		t.Errorf("CorrelateWithOptions() modified its inputs")
+	}
+
+	// Inf is not a missing value so it is still subject to the NaN policy.
+	x[0] = math.Inf(1)
+	if _, err := CorrelateWithOptions(x, y, Pearson, WithImputation(ImputeMean), WithNaNPolicy(ErrorOnNaN)); err == nil {
+		t.Errorf("CorrelateWithOptions() with Inf and ErrorOnNaN expected error but got none")
+	}
+
+	if _, err := CorrelateWithOptions([]float64{nan, nan}, []float64{1, 2}, Pearson, WithImputation(ImputeMean)); err == nil {
+		t.Errorf("CorrelateWithOptions() with all missing x expected error but got none")
+	}
+}
+
+func TestImputationOnMissingDatasets(t *testing.T) {
+	// The results documented by the builtin datasets with missing values.
+	tests := []struct {
+		d      datasets.Dataset
+		method ImputationMethod
+		policy NaNPolicy
+		want   float64
+	}{
+		{d: datasets.AirPassengersGaps, method: ImputeNone, policy: OmitPairwise, want: 0.9241},
+		{d: datasets.AirPassengersGaps, method: ImputeMean, policy: Propagate, want: 0.9065},
+		{d: datasets.AirPassengersGaps, method: ImputeLinear, policy: Propagate, want: 0.9233},
+		{d: datasets.OldFaithfulMissing, method: ImputeNone, policy: OmitPairwise, want: 0.9004},
+		{d: datasets.OldFaithfulMissing, method: ImputeMean, policy: Propagate, want: 0.8730},
+		{d: datasets.IrisPetalMissing, method: ImputeNone, policy: OmitPairwise, want: 0.9727},
+		{d: datasets.IrisPetalMissing, method: ImputeMean, policy: Propagate, want: 0.8845},
+	}
+
+	for _, test := range tests {
+		got, err := CorrelateWithOptions(test.d.X, test.d.Y, Pearson, WithImputation(test.method), WithNaNPolicy(test.policy))
+		if err != nil {
+			t.Fatalf("%s with %v unexpected error: %v", test.d.Name, test.method, err)
+		}
+		if math.Abs(got.Coefficient-test.want) > 5e-5 {
+			t.Errorf("%s with %v = %v, want %v", test.d.Name, test.method, got.Coefficient, test.want)
+		}
+	}
+}
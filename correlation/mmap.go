@@ -0,0 +1,100 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+	"os"
+	"unsafe"
+)
+
+// MappedColumn is a column of float64 values stored in a file and mapped
+// into memory rather than read, so that columns larger than memory can be
+// correlated directly: the operating system pages the values in as the
+// calculation reaches them and can drop them again under memory pressure.
+//
+// The file holds the values back to back as IEEE 754 float64 in the
+// machine's native byte order, as written by binary.Write with
+// binary.NativeEndian. Files in another byte order can be streamed with
+// BinaryValueReader and CorrelateChunked instead.
+//
+// Memory mapping is supported on Unix systems.
+type MappedColumn struct {
+	data   []byte
+	values []float64
+}
+
+// OpenMappedColumn maps the float64 column stored in the file at path.
+// The column must be closed once no longer used.
+//
+// An error is returned if the file cannot be opened or mapped, or if its
+// size is not a whole number of float64 values.
+func OpenMappedColumn(path string) (*MappedColumn, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size := info.Size()
+	if size%8 != 0 {
+		return nil, errors.New("column file size is not a multiple of 8 bytes")
+	}
+	if size > math.MaxInt {
+		return nil, errors.New("column file is too large to map on this platform")
+	}
+	if size == 0 {
+		return &MappedColumn{data: nil, values: []float64{}}, nil
+	}
+
+	data, err := mapFile(f, int(size))
+	if err != nil {
+		return nil, err
+	}
+
+	// The mapping starts on a page boundary, so it is aligned for float64.
+	values := unsafe.Slice((*float64)(unsafe.Pointer(&data[0])), len(data)/8)
+
+	return &MappedColumn{data: data, values: values}, nil
+}
+
+// Values returns the values of the column. The slice refers directly to
+// the mapped file: it is read only, writing to it crashes the program, and
+// it must not be used after Close.
+func (c *MappedColumn) Values() []float64 {
+	return c.values
+}
+
+// Len returns the number of values in the column.
+func (c *MappedColumn) Len() int {
+	return len(c.values)
+}
+
+// Close unmaps the column. Closing a column more than once has no effect.
+func (c *MappedColumn) Close() error {
+	data := c.data
+	c.data = nil
+	c.values = nil
+	if data == nil {
+		return nil
+	}
+
+	return unmapFile(data)
+}
@@ -0,0 +1,32 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build !unix
+
+package correlation
+
+import (
+	"errors"
+	"os"
+)
+
+// mapFile reports that memory mapping is not available.
+func mapFile(*os.File, int) ([]byte, error) {
+	return nil, errors.New("memory mapped columns are not supported on this platform")
+}
+
+// unmapFile does nothing, as nothing can be mapped.
+func unmapFile([]byte) error {
+	return nil
+}
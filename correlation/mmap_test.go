@@ -0,0 +1,130 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build unix
+
+package correlation
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeColumn writes values to a new file in dir in native byte order and
+// returns its path.
+func writeColumn(t *testing.T, dir, name string, values []float64) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("os.Create() unexpected error: %v", err)
+	}
+	if err := binary.Write(f, binary.NativeEndian, values); err != nil {
+		t.Fatalf("binary.Write() unexpected error: %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() unexpected error: %v", err)
+	}
+
+	return path
+}
+
+func TestMappedColumn(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	x := make([]float64, 10000)
+	y := make([]float64, 10000)
+	for i := range x {
+		x[i] = rng.NormFloat64()
+		y[i] = x[i] + rng.NormFloat64()
+	}
+	dir := t.TempDir()
+
+	cx, err := OpenMappedColumn(writeColumn(t, dir, "x.f64", x))
+	if err != nil {
+		t.Fatalf("OpenMappedColumn() unexpected error: %v", err)
+	}
+	defer cx.Close()
+	cy, err := OpenMappedColumn(writeColumn(t, dir, "y.f64", y))
+	if err != nil {
+		t.Fatalf("OpenMappedColumn() unexpected error: %v", err)
+	}
+	defer cy.Close()
+
+	if cx.Len() != len(x) {
+		t.Fatalf("Len() = %d, want %d", cx.Len(), len(x))
+	}
+	for i, v := range cx.Values() {
+		if v != x[i] {
+			t.Fatalf("Values()[%d] = %v, want %v", i, v, x[i])
+		}
+	}
+
+	for _, ct := range []Type{Pearson, KendallTau} {
+		want, err := Correlate(x, y, ct)
+		if err != nil {
+			t.Fatalf("Correlate(%v) unexpected error: %v", ct, err)
+		}
+		got, err := Correlate(cx.Values(), cy.Values(), ct)
+		if err != nil {
+			t.Fatalf("Correlate(%v) of mapped columns unexpected error: %v", ct, err)
+		}
+		if got != want {
+			t.Errorf("Correlate(%v) of mapped columns = %v, want %v", ct, got, want)
+		}
+	}
+
+	if err := cx.Close(); err != nil {
+		t.Errorf("Close() unexpected error: %v", err)
+	}
+	if err := cx.Close(); err != nil {
+		t.Errorf("second Close() unexpected error: %v", err)
+	}
+	if cx.Len() != 0 {
+		t.Errorf("Len() after Close() = %d, want 0", cx.Len())
+	}
+}
+
+func TestOpenMappedColumnErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	empty := filepath.Join(dir, "empty.f64")
+	if err := os.WriteFile(empty, nil, 0o600); err != nil {
+		t.Fatalf("os.WriteFile() unexpected error: %v", err)
+	}
+	c, err := OpenMappedColumn(empty)
+	if err != nil {
+		t.Fatalf("OpenMappedColumn() of an empty file unexpected error: %v", err)
+	}
+	if c.Len() != 0 {
+		t.Errorf("Len() of an empty file = %d, want 0", c.Len())
+	}
+	if err := c.Close(); err != nil {
+		t.Errorf("Close() of an empty column unexpected error: %v", err)
+	}
+
+	partial := filepath.Join(dir, "partial.f64")
+	if err := os.WriteFile(partial, make([]byte, 12), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() unexpected error: %v", err)
+	}
+	if _, err := OpenMappedColumn(partial); err == nil {
+		t.Errorf("OpenMappedColumn() of a partial value = nil error, want error")
+	}
+
+	if _, err := OpenMappedColumn(filepath.Join(dir, "missing.f64")); err == nil {
+		t.Errorf("OpenMappedColumn() of a missing file = nil error, want error")
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// LabeledMatrix is a correlation Matrix whose variables are identified by
+// name as well as by index.
+type LabeledMatrix struct {
+	*Matrix
+
+	labels []string
+	index  map[string]int
+}
+
+// CorrelateNamed computes the correlation matrix of a set of named series,
+// as is convenient when the series to compare come from configuration
+// rather than code. The variables are ordered by name, so index i of the
+// matrix is Labels()[i].
+//
+// If any series contains NaN, missing values are handled by pairwise
+// deletion as in PairwiseCorrelationMatrix; otherwise the matrix is computed
+// as by CorrelationMatrix.
+//
+// An error is returned if there are fewer than two series, the series differ
+// in length, or the correlation type is not supported.
+func CorrelateNamed(series map[string][]float64, correlationType Type) (*LabeledMatrix, error) {
+	labels := make([]string, 0, len(series))
+	for name := range series {
+		labels = append(labels, name)
+	}
+	slices.Sort(labels)
+
+	cols := make([][]float64, len(labels))
+	missing := false
+	index := make(map[string]int, len(labels))
+	for i, name := range labels {
+		cols[i] = series[name]
+		index[name] = i
+		missing = missing || slices.ContainsFunc(cols[i], math.IsNaN)
+	}
+
+	var m *Matrix
+	var err error
+	if missing {
+		m, err = PairwiseCorrelationMatrix(cols, correlationType)
+	} else {
+		m, err = CorrelationMatrix(cols, correlationType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &LabeledMatrix{Matrix: m, labels: labels, index: index}, nil
+}
+
+// Labels returns a copy of the variable names in index order.
+func (m *LabeledMatrix) Labels() []string {
+	return slices.Clone(m.labels)
+}
+
+// Index returns the index of the named variable and whether it exists.
+func (m *LabeledMatrix) Index(name string) (int, bool) {
+	i, ok := m.index[name]
+
+	return i, ok
+}
+
+// Get returns the correlation coefficient between the named variables.
+//
+// An error is returned if either name is unknown.
+func (m *LabeledMatrix) Get(a, b string) (float64, error) {
+	i, j, err := m.indexes(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.At(i, j), nil
+}
+
+// NByName returns the number of observation pairs used to compute the
+// coefficient between the named variables.
+//
+// An error is returned if either name is unknown.
+func (m *LabeledMatrix) NByName(a, b string) (int, error) {
+	i, j, err := m.indexes(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.N(i, j), nil
+}
+
+// PValueByName returns the two-sided p-value of the coefficient between the
+// named variables, as Matrix.PValue.
+//
+// An error is returned if either name is unknown or the p-value cannot be
+// computed.
+func (m *LabeledMatrix) PValueByName(a, b string) (float64, error) {
+	i, j, err := m.indexes(a, b)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.PValue(i, j)
+}
+
+// Row returns the coefficients between the named variable and every
+// variable, keyed by name.
+//
+// An error is returned if the name is unknown.
+func (m *LabeledMatrix) Row(name string) (map[string]float64, error) {
+	i, ok := m.index[name]
+	if !ok {
+		return nil, unknownSeriesError(name)
+	}
+
+	row := make(map[string]float64, len(m.labels))
+	for j, other := range m.labels {
+		row[other] = m.At(i, j)
+	}
+
+	return row, nil
+}
+
+// indexes returns the indexes of the named variables.
+func (m *LabeledMatrix) indexes(a, b string) (int, int, error) {
+	i, ok := m.index[a]
+	if !ok {
+		return 0, 0, unknownSeriesError(a)
+	}
+	j, ok := m.index[b]
+	if !ok {
+		return 0, 0, unknownSeriesError(b)
+	}
+
+	return i, j, nil
+}
+
+// unknownSeriesError returns the error for a name that is not in the matrix.
+func unknownSeriesError(name string) error {
+	return errors.New("unknown series " + strconv.Quote(name))
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestCorrelateNamed(t *testing.T) {
+	series := map[string][]float64{
+		"temperature": {10, 12, 15, 19, 24},
+		"sales":       {200, 230, 260, 330, 400},
+		"rainfall":    {30, 25, 28, 12, 8},
+	}
+
+	m, err := CorrelateNamed(series, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateNamed() unexpected error: %v", err)
+	}
+
+	if want := []string{"rainfall", "sales", "temperature"}; !slices.Equal(m.Labels(), want) {
+		t.Errorf("Labels() = %v, want %v", m.Labels(), want)
+	}
+	if i, ok := m.Index("sales"); !ok || i != 1 {
+		t.Errorf("Index(sales) = %d, %v, want 1, true", i, ok)
+	}
+	if _, ok := m.Index("wind"); ok {
+		t.Errorf("Index(wind) reported a missing series as present")
+	}
+
+	want, err := Correlate(series["sales"], series["temperature"], Pearson)
+	if err != nil {
+		t.Fatalf("Correlate() unexpected error: %v", err)
+	}
+	for _, pair := range [][2]string{{"sales", "temperature"}, {"temperature", "sales"}} {
+		got, err := m.Get(pair[0], pair[1])
+		if err != nil {
+			t.Fatalf("Get(%s, %s) unexpected error: %v", pair[0], pair[1], err)
+		}
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("Get(%s, %s) = %v, want %v", pair[0], pair[1], got, want)
+		}
+	}
+
+	if n, err := m.NByName("sales", "rainfall"); err != nil || n != 5 {
+		t.Errorf("NByName() = %d, %v, want 5, nil", n, err)
+	}
+	p, err := m.PValueByName("sales", "temperature")
+	if err != nil {
+		t.Fatalf("PValueByName() unexpected error: %v", err)
+	}
+	if wantP, _ := PValue(m.At(1, 2), 5, Pearson); p != wantP {
+		t.Errorf("PValueByName() = %v, want %v", p, wantP)
+	}
+
+	row, err := m.Row("rainfall")
+	if err != nil {
+		t.Fatalf("Row() unexpected error: %v", err)
+	}
+	if len(row) != 3 || row["rainfall"] != 1 || row["sales"] >= 0 {
+		t.Errorf("Row(rainfall) = %v", row)
+	}
+
+	if _, err := m.Get("sales", "wind"); err == nil {
+		t.Errorf("Get() with an unknown name expected error but got none")
+	}
+	if _, err := m.NByName("wind", "sales"); err == nil {
+		t.Errorf("NByName() with an unknown name expected error but got none")
+	}
+	if _, err := m.PValueByName("wind", "sales"); err == nil {
+		t.Errorf("PValueByName() with an unknown name expected error but got none")
+	}
+	if _, err := m.Row("wind"); err == nil {
+		t.Errorf("Row() with an unknown name expected error but got none")
+	}
+}
+
+func TestCorrelateNamedMissing(t *testing.T) {
+	m, err := CorrelateNamed(map[string][]float64{
+		"a": {1, 2, math.NaN(), 4, 5},
+		"b": {2, 4, 6, 8, 11},
+	}, Spearman)
+	if err != nil {
+		t.Fatalf("CorrelateNamed() unexpected error: %v", err)
+	}
+	if r, _ := m.Get("a", "b"); r != 1 {
+		t.Errorf("Get(a, b) = %v, want 1", r)
+	}
+	if n, _ := m.NByName("a", "b"); n != 4 {
+		t.Errorf("NByName(a, b) = %d, want 4", n)
+	}
+}
+
+func TestCorrelateNamedErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		series map[string][]float64
+		typ    Type
+	}{
+		{name: "single series", series: map[string][]float64{"a": {1, 2, 3}}, typ: Pearson},
+		{name: "length mismatch", series: map[string][]float64{"a": {1, 2, 3}, "b": {1, 2}}, typ: Pearson},
+		{name: "unsupported type", series: map[string][]float64{"a": {1, 2, 3}, "b": {3, 2, 1}}, typ: Type(99)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CorrelateNamed(tt.series, tt.typ); err == nil {
+				t.Errorf("CorrelateNamed() expected error but got none")
+			}
+		})
+	}
+}
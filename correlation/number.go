@@ -0,0 +1,120 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"cmp"
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+// Number is implemented by caller defined numeric types, such as decimal or
+// fixed-point money types, that can report their exact value. The
+// BigFloat method of shopspring/decimal's Decimal satisfies it.
+type Number interface {
+	// BigFloat returns the value of the number. The result is not modified.
+	BigFloat() *big.Float
+}
+
+// Float64Number is implemented by caller defined numeric types that can
+// report their value as a float64 cheaply.
+type Float64Number interface {
+	// Float64 returns the value of the number, rounded to float64.
+	Float64() float64
+}
+
+// CorrelateNumbers calculates the specified correlation coefficient between
+// two slices of a caller defined Number type, without the caller first
+// copying them into slices of a built in type. The values are read through
+// BigFloat and correlated as by CorrelateBig, so no precision is lost.
+//
+// Returns an error if the slices have different lengths, are empty, contain
+// a nil value, or if the correlation type is not supported.
+func CorrelateNumbers[T Number](x, y []T, correlationType Type, opts ...BigOption) (float64, error) {
+	if len(x) != len(y) {
+		return 0, errors.New("slices must have the same length")
+	}
+	if len(x) == 0 {
+		return 0, errors.New("slices cannot be empty")
+	}
+
+	bx := make([]*big.Float, len(x))
+	by := make([]*big.Float, len(y))
+	for i := range x {
+		bx[i] = x[i].BigFloat()
+		if bx[i] == nil {
+			return 0, errors.New("x contains nil at index " + strconv.Itoa(i))
+		}
+		by[i] = y[i].BigFloat()
+		if by[i] == nil {
+			return 0, errors.New("y contains nil at index " + strconv.Itoa(i))
+		}
+	}
+
+	return CorrelateBig(bx, by, correlationType, opts...)
+}
+
+// CorrelateFloat64Numbers calculates the specified correlation coefficient
+// between two slices of a caller defined Float64Number type. The values are
+// read through Float64 as they are needed, so the inputs are never copied.
+// Pearson's coefficient is accumulated with Welford's updates and the rank
+// coefficients compare the values in place.
+//
+// Returns an error if the slices have different lengths, are empty, contain
+// NaN or ±Inf, or if the correlation type is not supported.
+func CorrelateFloat64Numbers[T Float64Number](x, y []T, correlationType Type) (float64, error) {
+	if len(x) != len(y) {
+		return 0, errors.New("slices must have the same length")
+	}
+	if len(x) == 0 {
+		return 0, errors.New("slices cannot be empty")
+	}
+	if !correlationType.valid() {
+		return 0, errors.New("unsupported correlation type")
+	}
+
+	var stats comoments
+	for i := range x {
+		fx, fy := x[i].Float64(), y[i].Float64()
+		if !isFinite(fx) {
+			return 0, nonFiniteError("x", i, fx)
+		}
+		if !isFinite(fy) {
+			return 0, nonFiniteError("y", i, fy)
+		}
+		stats.add(fx, fy)
+	}
+
+	if correlationType == Pearson {
+		return stats.value()
+	}
+	if err := checkRankInputs(len(x), len(y)); err != nil {
+		return 0, err
+	}
+
+	st := rankAnalysis(len(x),
+		func(i, j int) int { return cmp.Compare(x[i].Float64(), x[j].Float64()) },
+		func(i, j int) int { return cmp.Compare(y[i].Float64(), y[j].Float64()) },
+	)
+	switch correlationType {
+	case Spearman:
+		return clamped(st.spearman())
+	case KendallTau:
+		return clamped(st.kendallTauB())
+	default:
+		return clamped(st.gamma())
+	}
+}
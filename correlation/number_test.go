@@ -0,0 +1,119 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// cents is a fixed-point money amount used to exercise the Number
+// interfaces.
+type cents int64
+
+func (c cents) BigFloat() *big.Float {
+	f := new(big.Float).SetInt64(int64(c))
+
+	return f.Quo(f, big.NewFloat(100))
+}
+
+func (c cents) Float64() float64 {
+	return float64(c) / 100
+}
+
+// nilNumber is a Number whose value is missing.
+type nilNumber struct{}
+
+func (nilNumber) BigFloat() *big.Float {
+	return nil
+}
+
+// floatNumber is a Float64Number that can hold any float64.
+type floatNumber float64
+
+func (f floatNumber) Float64() float64 {
+	return float64(f)
+}
+
+func TestCorrelateNumbers(t *testing.T) {
+	x := []cents{1999, 2450, 1200, 9999, 5000, 3100}
+	y := []cents{150, 210, 95, 800, 460, 210}
+	fx := make([]float64, len(x))
+	fy := make([]float64, len(y))
+	for i := range x {
+		fx[i] = x[i].Float64()
+		fy[i] = y[i].Float64()
+	}
+
+	for _, ct := range []Type{Pearson, Spearman, KendallTau, GoodmanKruskal} {
+		want, err := Correlate(fx, fy, ct)
+		if err != nil {
+			t.Fatalf("Correlate(%v) unexpected error: %v", ct, err)
+		}
+
+		got, err := CorrelateNumbers(x, y, ct)
+		if err != nil {
+			t.Fatalf("CorrelateNumbers(%v) unexpected error: %v", ct, err)
+		}
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("CorrelateNumbers(%v) = %v, want %v", ct, got, want)
+		}
+
+		got, err = CorrelateFloat64Numbers(x, y, ct)
+		if err != nil {
+			t.Fatalf("CorrelateFloat64Numbers(%v) unexpected error: %v", ct, err)
+		}
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("CorrelateFloat64Numbers(%v) = %v, want %v", ct, got, want)
+		}
+	}
+}
+
+func TestCorrelateNumbersErrors(t *testing.T) {
+	if _, err := CorrelateNumbers([]cents{1, 2}, []cents{1}, Pearson); err == nil {
+		t.Errorf("CorrelateNumbers() with mismatched lengths expected error but got none")
+	}
+	if _, err := CorrelateNumbers([]cents{}, []cents{}, Pearson); err == nil {
+		t.Errorf("CorrelateNumbers() with empty input expected error but got none")
+	}
+	if _, err := CorrelateNumbers([]Number{cents(1), nilNumber{}}, []Number{cents(1), cents(2)}, Pearson); err == nil {
+		t.Errorf("CorrelateNumbers() with a nil value expected error but got none")
+	}
+	if _, err := CorrelateNumbers([]cents{1, 2}, []cents{2, 1}, Type(99)); err == nil {
+		t.Errorf("CorrelateNumbers() with an unsupported type expected error but got none")
+	}
+
+	tests := []struct {
+		name string
+		x, y []floatNumber
+		typ  Type
+	}{
+		{name: "length mismatch", x: []floatNumber{1, 2}, y: []floatNumber{1}, typ: Pearson},
+		{name: "empty", x: []floatNumber{}, y: []floatNumber{}, typ: Pearson},
+		{name: "unsupported type", x: []floatNumber{1, 2}, y: []floatNumber{2, 1}, typ: Type(99)},
+		{name: "NaN", x: []floatNumber{1, floatNumber(math.NaN())}, y: []floatNumber{2, 1}, typ: Spearman},
+		{name: "Inf", x: []floatNumber{1, 2}, y: []floatNumber{floatNumber(math.Inf(1)), 1}, typ: Pearson},
+		{name: "single value", x: []floatNumber{1}, y: []floatNumber{2}, typ: KendallTau},
+		{name: "constant", x: []floatNumber{1, 1, 1}, y: []floatNumber{1, 2, 3}, typ: Pearson},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CorrelateFloat64Numbers(tt.x, tt.y, tt.typ); err == nil {
+				t.Errorf("CorrelateFloat64Numbers() expected error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// comoments accumulates the sufficient statistics for Pearson's correlation
+// of a stream of (x, y) pairs using Welford's updating algorithm, which
+// avoids the catastrophic cancellation of the raw sums of squares approach.
+// Pairs can also be removed, reversing the update.
+type comoments struct {
+	n     int
+	meanX float64
+	meanY float64
+	// m2x and m2y are the sums of squared deviations from the mean.
+	m2x float64
+	m2y float64
+	// cxy is the sum of the products of the deviations from the means.
+	cxy float64
+}
+
+// add includes the pair (x, y) in the statistics.
+func (c *comoments) add(x, y float64) {
+	c.n++
+	nf := float64(c.n)
+	dx := x - c.meanX
+	dy := y - c.meanY
+	c.meanX += dx / nf
+	c.meanY += dy / nf
+	c.m2x += dx * (x - c.meanX)
+	c.m2y += dy * (y - c.meanY)
+	c.cxy += dx * (y - c.meanY)
+}
+
+// remove excludes a pair (x, y) that was previously added.
+func (c *comoments) remove(x, y float64) {
+	if c.n <= 1 {
+		*c = comoments{}
+
+		return
+	}
+
+	// Undo add: with the means after the pair was added (current) and before
+	// it was added (previous),
+	//   cxy_prev = cxy - (x - meanX_prev) * (y - meanY)
+	nf := float64(c.n - 1)
+	prevX := c.meanX - (x-c.meanX)/nf
+	prevY := c.meanY - (y-c.meanY)/nf
+	c.m2x -= (x - prevX) * (x - c.meanX)
+	c.m2y -= (y - prevY) * (y - c.meanY)
+	c.cxy -= (x - prevX) * (y - c.meanY)
+	c.meanX = prevX
+	c.meanY = prevY
+	c.n--
+}
+
+// merge combines the statistics of o into c, as if every pair added to o had
+// been added to c, using the pairwise update of Chan, Golub and LeVeque (1979).
+func (c *comoments) merge(o comoments) {
+	if o.n == 0 {
+		return
+	}
+	if c.n == 0 {
+		*c = o
+
+		return
+	}
+
+	na := float64(c.n)
+	nb := float64(o.n)
+	n := na + nb
+	dx := o.meanX - c.meanX
+	dy := o.meanY - c.meanY
+	f := na * nb / n
+
+	c.m2x += o.m2x + dx*dx*f
+	c.m2y += o.m2y + dy*dy*f
+	c.cxy += o.cxy + dx*dy*f
+	c.meanX += dx * nb / n
+	c.meanY += dy * nb / n
+	c.n += o.n
+}
+
+// value returns the Pearson correlation of the accumulated pairs.
+func (c *comoments) value() (float64, error) {
+	if c.n < 2 {
+		return 0, errors.New("correlation requires at least 2 data points")
+	}
+	if c.m2x <= 0 || c.m2y <= 0 {
+		return 0, errors.New("correlation undefined: one or both variables have zero variance")
+	}
+
+	return clampUnit(c.cxy / math.Sqrt(c.m2x*c.m2y)), nil
+}
+
+// OnlineCorrelator maintains Pearson's correlation over a stream of pairs
+// without storing them, using Welford's numerically stable updates. Pairs can
+// be removed again, for example when a record is retracted or expires from
+// a window that the caller manages.
+//
+// The zero value is an empty correlator ready to use. An OnlineCorrelator is
+// not safe for concurrent use.
+type OnlineCorrelator struct {
+	stats comoments
+}
+
+// Add includes the pair (x, y) in the correlation.
+//
+// An error is returned if either value is NaN or ±Inf.
+func (o *OnlineCorrelator) Add(x, y float64) error {
+	if !isFinite(x) || !isFinite(y) {
+		return errors.New("values must be finite")
+	}
+	o.stats.add(x, y)
+
+	return nil
+}
+
+// Remove excludes a pair (x, y) that was previously added. Removing a pair
+// that was never added silently corrupts the statistics.
+//
+// An error is returned if the correlator is empty or if either value is NaN
+// or ±Inf.
+func (o *OnlineCorrelator) Remove(x, y float64) error {
+	if o.stats.n == 0 {
+		return errors.New("cannot remove from an empty correlator")
+	}
+	if !isFinite(x) || !isFinite(y) {
+		return errors.New("values must be finite")
+	}
+	o.stats.remove(x, y)
+
+	return nil
+}
+
+// Merge adds every pair included in other to o, leaving other unchanged.
+// The result is the same, up to rounding, as adding all of the pairs to a
+// single correlator, so shards of a data set can be accumulated
+// independently, for example by separate workers, and then combined.
+func (o *OnlineCorrelator) Merge(other *OnlineCorrelator) {
+	o.stats.merge(other.stats)
+}
+
+// N returns the number of pairs currently included.
+func (o *OnlineCorrelator) N() int {
+	return o.stats.n
+}
+
+// Value returns the Pearson correlation of the pairs currently included.
+//
+// An error is returned if there are fewer than 2 pairs or either series has
+// zero variance.
+func (o *OnlineCorrelator) Value() (float64, error) {
+	return o.stats.value()
+}
+
+// WindowedCorrelator maintains Pearson's correlation over the most recent
+// pairs of a stream, up to a fixed window size. Once the window is full
+// each new pair evicts the oldest one.
+//
+// A WindowedCorrelator is not safe for concurrent use.
+type WindowedCorrelator struct {
+	stats comoments
+	xs    []float64
+	ys    []float64
+	// next is the ring buffer position the next pair is written to.
+	next int
+}
+
+// NewWindowedCorrelator returns a correlator over a sliding window of the
+// given size.
+//
+// An error is returned if size is less than 2.
+func NewWindowedCorrelator(size int) (*WindowedCorrelator, error) {
+	if size < 2 {
+		return nil, errors.New("window size must be at least 2")
+	}
+
+	return &WindowedCorrelator{
+		stats: comoments{},
+		xs:    make([]float64, 0, size),
+		ys:    make([]float64, 0, size),
+		next:  0,
+	}, nil
+}
+
+// Add includes the pair (x, y) in the window. If the window is full, the
+// oldest pair is evicted.
+//
+// An error is returned if either value is NaN or ±Inf.
+func (w *WindowedCorrelator) Add(x, y float64) error {
+	if !isFinite(x) || !isFinite(y) {
+		return errors.New("values must be finite")
+	}
+	w.push(x, y)
+
+	return nil
+}
+
+// push adds the pair (x, y) to the window and returns the evicted pair, if
+// any. The values must already have been checked to be finite.
+func (w *WindowedCorrelator) push(x, y float64) (float64, float64, bool) {
+	if len(w.xs) < cap(w.xs) {
+		w.xs = append(w.xs, x)
+		w.ys = append(w.ys, y)
+		w.stats.add(x, y)
+
+		return 0, 0, false
+	}
+
+	oldX, oldY := w.xs[w.next], w.ys[w.next]
+	w.xs[w.next] = x
+	w.ys[w.next] = y
+	w.next = (w.next + 1) % len(w.xs)
+
+	if w.next == 0 {
+		// Removing values accumulates rounding error over a long stream,
+		// so rebuild the statistics from the buffer once per full cycle.
+		w.stats = comoments{}
+		for i := range w.xs {
+			w.stats.add(w.xs[i], w.ys[i])
+		}
+	} else {
+		w.stats.remove(oldX, oldY)
+		w.stats.add(x, y)
+	}
+
+	return oldX, oldY, true
+}
+
+// N returns the number of pairs currently in the window.
+func (w *WindowedCorrelator) N() int {
+	return w.stats.n
+}
+
+// Size returns the capacity of the window.
+func (w *WindowedCorrelator) Size() int {
+	return cap(w.xs)
+}
+
+// Value returns the Pearson correlation of the pairs currently in the window.
+//
+// An error is returned if there are fewer than 2 pairs or either series has
+// zero variance within the window.
+func (w *WindowedCorrelator) Value() (float64, error) {
+	return w.stats.value()
+}
@@ -0,0 +1,194 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestComomentsAddRemove(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	xs := make([]float64, 200)
+	ys := make([]float64, 200)
+	for i := range xs {
+		xs[i] = rng.NormFloat64()*3 + 1e6
+		ys[i] = 0.3*xs[i] + rng.NormFloat64()
+	}
+
+	var c comoments
+	for i := range xs {
+		c.add(xs[i], ys[i])
+	}
+	// Remove the first half, leaving the statistics of the second half.
+	for i := range 100 {
+		c.remove(xs[i], ys[i])
+	}
+
+	got, err := c.value()
+	if err != nil {
+		t.Fatalf("value() unexpected error: %v", err)
+	}
+	want, err := pearsonsTwoPass(xs[100:], ys[100:])
+	if err != nil {
+		t.Fatalf("pearsonsTwoPass() unexpected error: %v", err)
+	}
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("value() after removals = %v, want %v", got, want)
+	}
+
+	c.remove(1, 1)
+	for range 200 {
+		c.remove(1, 1)
+	}
+	if c.n != 0 {
+		t.Errorf("n after removing everything = %d, want 0", c.n)
+	}
+}
+
+func TestOnlineCorrelator(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6}
+	y := []float64{2, 1, 4, 3, 7, 5}
+
+	var o OnlineCorrelator
+	if _, err := o.Value(); err == nil {
+		t.Errorf("Value() on empty correlator expected error but got none")
+	}
+	for i := range x {
+		if err := o.Add(x[i], y[i]); err != nil {
+			t.Fatalf("Add() unexpected error: %v", err)
+		}
+	}
+	if o.N() != len(x) {
+		t.Errorf("N() = %d, want %d", o.N(), len(x))
+	}
+	got, err := o.Value()
+	if err != nil {
+		t.Fatalf("Value() unexpected error: %v", err)
+	}
+	want, _ := Pearsons(x, y)
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("Value() = %v, want %v", got, want)
+	}
+
+	// Retract the first two pairs.
+	for i := range 2 {
+		if err := o.Remove(x[i], y[i]); err != nil {
+			t.Fatalf("Remove() unexpected error: %v", err)
+		}
+	}
+	got, err = o.Value()
+	if err != nil {
+		t.Fatalf("Value() unexpected error: %v", err)
+	}
+	want, _ = Pearsons(x[2:], y[2:])
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("Value() after Remove = %v, want %v", got, want)
+	}
+
+	if err := o.Add(math.NaN(), 1); err == nil {
+		t.Errorf("Add(NaN) expected error but got none")
+	}
+	if err := o.Remove(1, math.Inf(1)); err == nil {
+		t.Errorf("Remove(Inf) expected error but got none")
+	}
+	var empty OnlineCorrelator
+	if err := empty.Remove(1, 2); err == nil {
+		t.Errorf("Remove() on empty correlator expected error but got none")
+	}
+}
+
+func TestOnlineCorrelatorMerge(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	const n = 1000
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range n {
+		x[i] = rng.NormFloat64()*5 + 1e4
+		y[i] = -0.4*x[i] + rng.NormFloat64()
+	}
+	want, _ := pearsonsTwoPass(x, y)
+
+	// Split into uneven shards, including an empty one.
+	bounds := []int{0, 3, 3, 400, 999, n}
+	var total OnlineCorrelator
+	for s := range len(bounds) - 1 {
+		var shard OnlineCorrelator
+		for i := bounds[s]; i < bounds[s+1]; i++ {
+			if err := shard.Add(x[i], y[i]); err != nil {
+				t.Fatalf("Add() unexpected error: %v", err)
+			}
+		}
+		total.Merge(&shard)
+	}
+
+	if total.N() != n {
+		t.Errorf("N() after Merge = %d, want %d", total.N(), n)
+	}
+	got, err := total.Value()
+	if err != nil {
+		t.Fatalf("Value() unexpected error: %v", err)
+	}
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("Value() after Merge = %v, want %v", got, want)
+	}
+}
+
+func TestWindowedCorrelator(t *testing.T) {
+	w, err := NewWindowedCorrelator(5)
+	if err != nil {
+		t.Fatalf("NewWindowedCorrelator() unexpected error: %v", err)
+	}
+
+	if _, err := w.Value(); err == nil {
+		t.Errorf("Value() on empty window expected error but got none")
+	}
+
+	xs := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}
+	ys := []float64{5, 3, 4, 1, 2, 9, 7, 8, 6, 10, 12, 11, 2}
+	for i := range xs {
+		if err := w.Add(xs[i], ys[i]); err != nil {
+			t.Fatalf("Add() unexpected error: %v", err)
+		}
+
+		lo := max(0, i-4)
+		if w.N() != i+1-lo {
+			t.Errorf("N() after %d adds = %d, want %d", i+1, w.N(), i+1-lo)
+		}
+		if i < 1 {
+			continue
+		}
+
+		got, err := w.Value()
+		if err != nil {
+			t.Fatalf("Value() unexpected error: %v", err)
+		}
+		want, _ := pearsonsTwoPass(xs[lo:i+1], ys[lo:i+1])
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("Value() after %d adds = %v, want %v", i+1, got, want)
+		}
+	}
+
+	if w.Size() != 5 {
+		t.Errorf("Size() = %d, want 5", w.Size())
+	}
+	if err := w.Add(math.NaN(), 1); err == nil {
+		t.Errorf("Add(NaN) expected error but got none")
+	}
+	if _, err := NewWindowedCorrelator(1); err == nil {
+		t.Errorf("NewWindowedCorrelator(1) expected error but got none")
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// Option configures the behavior of CorrelateWithOptions.
+type Option func(*options)
+
+// options holds the settings accumulated from a list of Option values.
+type options struct {
+	nanPolicy  NaNPolicy
+	imputation ImputationMethod
+	clip       bool
+	clipX      Bounds
+	clipY      Bounds
+	noClamp    bool
+	workers    int
+}
+
+// defaultOptions returns the settings used when no Option is supplied.
+func defaultOptions() options {
+	return options{
+		nanPolicy:  Propagate,
+		imputation: ImputeNone,
+		clip:       false,
+		clipX:      Bounds{Lo: 0, Hi: 0},
+		clipY:      Bounds{Lo: 0, Hi: 0},
+		noClamp:    false,
+		workers:    1,
+	}
+}
+
+// WithNaNPolicy sets how NaN and ±Inf values in the inputs are handled.
+func WithNaNPolicy(p NaNPolicy) Option {
+	return func(o *options) {
+		o.nanPolicy = p
+	}
+}
+
+// WithImputation fills missing (NaN) values in each series using the given
+// method before the NaN policy is applied. The number of values filled in is
+// reported in Result.Imputed.
+//
+// Imputation works on float64 copies of the inputs; the originals are never
+// modified.
+func WithImputation(m ImputationMethod) Option {
+	return func(o *options) {
+		o.imputation = m
+	}
+}
+
+// WithClipping limits every finite value of x to xBounds and of y to
+// yBounds before the correlation is computed. The number of values that
+// were moved is reported in Result.Clipped.
+//
+// Clipping is applied after imputation, so filled in values are bounded
+// too. NaN and ±Inf values are left for the NaN policy to handle.
+//
+// Clipping works on float64 copies of the inputs; the originals are never
+// modified.
+func WithClipping(xBounds, yBounds Bounds) Option {
+	return func(o *options) {
+		o.clip = true
+		o.clipX = xBounds
+		o.clipY = yBounds
+	}
+}
+
+// WithoutClamping returns the coefficient exactly as computed, instead of
+// clamping it to [-1, 1]. This is useful when studying the rounding error of
+// the algorithms, where a value just outside the interval is informative.
+func WithoutClamping() Option {
+	return func(o *options) {
+		o.noClamp = true
+	}
+}
+
+// WithParallelism spreads the calculation over up to n goroutines, each
+// working on a contiguous chunk of the inputs. For Pearson's coefficient each
+// accumulates the statistics of its chunk, which are then combined. For the
+// rank based coefficients each sorts its chunk and counts the discordant
+// pairs within it, and the chunks are then merged. It pays off for inputs of
+// a million or more pairs; shorter inputs use fewer goroutines, down to one.
+// The default is 1.
+//
+// The chunked Pearson result can differ from the sequential one by rounding
+// error. The pair counts of the rank based coefficients are exact, so their
+// results are the same for any n.
+func WithParallelism(n int) Option {
+	return func(o *options) {
+		o.workers = n
+	}
+}
+
+// CorrelateWithOptions calculates the specified correlation coefficient
+// between two datasets x and y of any numeric type, applying the given
+// options before the calculation.
+//
+// It returns a Result holding the coefficient, the number of pairs that
+// were actually used, and the number of values that were imputed or clipped.
+//
+// Returns an error if the slices have different lengths, are empty, if the
+// correlation type is not supported, or if an option rejects the inputs.
+func CorrelateWithOptions[T Numeric](x, y []T, correlationType Type, opts ...Option) (Result, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	res := Result{
+		Type:        correlationType,
+		Coefficient: 0,
+		N:           0,
+		Imputed:     0,
+		Clipped:     0,
+	}
+
+	if len(x) != len(y) {
+		return res, errors.New("slices must have the same length")
+	}
+	if len(x) == 0 {
+		return res, errors.New("slices cannot be empty")
+	}
+
+	if o.workers < 1 {
+		return res, errors.New("parallelism must be at least 1")
+	}
+	if o.clip && (!o.clipX.valid() || !o.clipY.valid()) {
+		return res, errors.New("clipping bounds must be finite with Lo less than Hi")
+	}
+
+	if o.imputation == ImputeNone && !o.clip {
+		return correlatePrepared(x, y, correlationType, o, res)
+	}
+
+	fx := make([]float64, len(x))
+	fy := make([]float64, len(y))
+	for i := range x {
+		fx[i] = float64(x[i])
+		fy[i] = float64(y[i])
+	}
+
+	if o.imputation != ImputeNone {
+		nx, err := impute(fx, o.imputation)
+		if err != nil {
+			return res, errors.New("imputing x: " + err.Error())
+		}
+		ny, err := impute(fy, o.imputation)
+		if err != nil {
+			return res, errors.New("imputing y: " + err.Error())
+		}
+		res.Imputed = nx + ny
+	}
+
+	if o.clip {
+		res.Clipped = clipFinite(fx, o.clipX) + clipFinite(fy, o.clipY)
+	}
+
+	return correlatePrepared(fx, fy, correlationType, o, res)
+}
+
+// correlatePrepared applies the NaN policy in o to the inputs and computes
+// the coefficient, filling in the remaining fields of res.
+func correlatePrepared[T Numeric](x, y []T, correlationType Type, o options, res Result) (Result, error) {
+	x, y, propagate, err := applyNaNPolicy(x, y, o.nanPolicy)
+	if err != nil {
+		return res, err
+	}
+	res.N = len(x)
+	if propagate {
+		res.Coefficient = math.NaN()
+
+		return res, nil
+	}
+
+	var r float64
+	if o.workers > 1 {
+		r, err = correlateParallel(x, y, correlationType, o.workers)
+	} else {
+		r, err = correlateUnclamped(x, y, correlationType)
+	}
+	if err != nil {
+		return res, err
+	}
+	if !o.noClamp {
+		r = clampUnit(r)
+	}
+	res.Coefficient = r
+
+	return res, nil
+}
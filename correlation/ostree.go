@@ -0,0 +1,202 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+// ostree is an order-statistic tree over a multiset of float64 values,
+// implemented as a treap. Equal values share a node with a count, and every
+// node records the number of values in its subtree so that rank queries,
+// insertions and removals all take O(log n) expected time.
+//
+// The zero value is an empty tree ready to use.
+type ostree struct {
+	root *osNode
+	// state drives the splitmix64 generator for node priorities.
+	state uint64
+}
+
+// osNode is one distinct value in an ostree.
+type osNode struct {
+	key         float64
+	count       int
+	size        int
+	priority    uint64
+	left, right *osNode
+}
+
+// len returns the number of values in the tree, counting duplicates.
+func (t *ostree) len() int {
+	return nodeSize(t.root)
+}
+
+// insert adds one copy of v to the tree.
+func (t *ostree) insert(v float64) {
+	t.root = t.insertAt(t.root, v)
+}
+
+// remove deletes one copy of v from the tree and reports whether it was
+// present.
+func (t *ostree) remove(v float64) bool {
+	var found bool
+	t.root, found = removeAt(t.root, v)
+
+	return found
+}
+
+// countLess returns the number of values in the tree that are less than v.
+func (t *ostree) countLess(v float64) int {
+	n := 0
+	for node := t.root; node != nil; {
+		if node.key < v {
+			n += nodeSize(node.left) + node.count
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+
+	return n
+}
+
+// countLessEqual returns the number of values in the tree that are less than
+// or equal to v.
+func (t *ostree) countLessEqual(v float64) int {
+	n := 0
+	for node := t.root; node != nil; {
+		if node.key <= v {
+			n += nodeSize(node.left) + node.count
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+
+	return n
+}
+
+// nextPriority returns the next pseudo-random node priority.
+func (t *ostree) nextPriority() uint64 {
+	t.state += 0x9e3779b97f4a7c15
+	z := t.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+
+	return z ^ (z >> 31)
+}
+
+// insertAt adds v to the subtree rooted at node and returns the new root of
+// the subtree, rotating the new node up to restore the heap order of the
+// priorities.
+func (t *ostree) insertAt(node *osNode, v float64) *osNode {
+	if node == nil {
+		return &osNode{key: v, count: 1, size: 1, priority: t.nextPriority(), left: nil, right: nil}
+	}
+
+	node.size++
+	switch {
+	case v < node.key:
+		node.left = t.insertAt(node.left, v)
+		if node.left.priority > node.priority {
+			node = rotateRight(node)
+		}
+	case v > node.key:
+		node.right = t.insertAt(node.right, v)
+		if node.right.priority > node.priority {
+			node = rotateLeft(node)
+		}
+	default:
+		node.count++
+	}
+
+	return node
+}
+
+// removeAt deletes one copy of v from the subtree rooted at node and returns
+// the new root of the subtree and whether v was found.
+func removeAt(node *osNode, v float64) (*osNode, bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	var found bool
+	switch {
+	case v < node.key:
+		node.left, found = removeAt(node.left, v)
+	case v > node.key:
+		node.right, found = removeAt(node.right, v)
+	default:
+		if node.count == 1 {
+			return mergeNodes(node.left, node.right), true
+		}
+		node.count--
+		found = true
+	}
+	if found {
+		node.size--
+	}
+
+	return node, found
+}
+
+// mergeNodes joins two subtrees where every key in a is less than every key
+// in b.
+func mergeNodes(a, b *osNode) *osNode {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority > b.priority {
+		a.right = mergeNodes(a.right, b)
+		a.size = nodeSize(a.left) + a.count + nodeSize(a.right)
+
+		return a
+	}
+	b.left = mergeNodes(a, b.left)
+	b.size = nodeSize(b.left) + b.count + nodeSize(b.right)
+
+	return b
+}
+
+// rotateRight lifts the left child of node into its place.
+func rotateRight(node *osNode) *osNode {
+	l := node.left
+	node.left = l.right
+	l.right = node
+	node.size = nodeSize(node.left) + node.count + nodeSize(node.right)
+	l.size = nodeSize(l.left) + l.count + node.size
+
+	return l
+}
+
+// rotateLeft lifts the right child of node into its place.
+func rotateLeft(node *osNode) *osNode {
+	r := node.right
+	node.right = r.left
+	r.left = node
+	node.size = nodeSize(node.left) + node.count + nodeSize(node.right)
+	r.size = node.size + r.count + nodeSize(r.right)
+
+	return r
+}
+
+// nodeSize returns the number of values in the subtree rooted at node.
+func nodeSize(node *osNode) int {
+	if node == nil {
+		return 0
+	}
+
+	return node.size
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestOstree(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	var tree ostree
+	var values []float64
+	for range 2000 {
+		v := float64(rng.Intn(50))
+		if len(values) > 0 && rng.Intn(3) == 0 {
+			i := rng.Intn(len(values))
+			if !tree.remove(values[i]) {
+				t.Fatalf("remove(%v) = false, want true", values[i])
+			}
+			values = slices.Delete(values, i, i+1)
+		} else {
+			tree.insert(v)
+			values = append(values, v)
+		}
+
+		if tree.len() != len(values) {
+			t.Fatalf("len() = %d, want %d", tree.len(), len(values))
+		}
+		q := float64(rng.Intn(52)) - 1
+		less, lessEqual := 0, 0
+		for _, v := range values {
+			if v < q {
+				less++
+			}
+			if v <= q {
+				lessEqual++
+			}
+		}
+		if got := tree.countLess(q); got != less {
+			t.Fatalf("countLess(%v) = %d, want %d", q, got, less)
+		}
+		if got := tree.countLessEqual(q); got != lessEqual {
+			t.Fatalf("countLessEqual(%v) = %d, want %d", q, got, lessEqual)
+		}
+	}
+
+	if tree.remove(100) {
+		t.Errorf("remove(100) = true for a value never inserted")
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math/big"
+)
+
+// overflowPrecision is the working precision, in bits, of the statistics of
+// chunks whose float64 statistics overflow. The inputs are native numbers,
+// so it matches the default precision of the big.Float calculations.
+const overflowPrecision = 64
+
+// pearsonAccumulator accumulates the statistics for Pearson's correlation
+// chunk by chunk in float64. Once a chunk overflows float64, the statistics
+// so far move to big.Float and only the chunks that overflow are recomputed
+// there; the rest are still accumulated in float64 and merged in.
+//
+// The zero value holds no pairs and is ready to use.
+type pearsonAccumulator struct {
+	stats     compensatedMoments
+	escalated *bigMoments
+}
+
+// addPearsonChunk adds the pairs of x and y to p. It reports false, leaving
+// p unchanged, if the chunk holds a NaN or an infinite value.
+func addPearsonChunk[T Numeric](p *pearsonAccumulator, x, y []T) bool {
+	var saved compensatedMoments
+	if p.escalated == nil {
+		saved = p.stats
+		accumulateMoments(&p.stats, x, y)
+		if p.stats.finite() {
+			return true
+		}
+		p.stats = saved
+	} else {
+		var chunk compensatedMoments
+		accumulateMoments(&chunk, x, y)
+		if chunk.finite() {
+			p.escalated.merge(newBigMoments(&chunk))
+
+			return true
+		}
+	}
+
+	if hasNaN(x) || hasNaN(y) || hasInf(x) || hasInf(y) {
+		return false
+	}
+	if p.escalated == nil {
+		p.escalated = newBigMoments(&saved)
+	}
+	p.escalated.merge(chunkBigMoments(x, y))
+
+	return true
+}
+
+// count returns the number of pairs accumulated.
+func (p *pearsonAccumulator) count() int {
+	if p.escalated != nil {
+		return p.escalated.n
+	}
+
+	return p.stats.n
+}
+
+// value returns the Pearson correlation of the accumulated pairs, without
+// clamping.
+func (p *pearsonAccumulator) value() (float64, error) {
+	if p.escalated != nil {
+		return p.escalated.value()
+	}
+
+	return p.stats.value()
+}
+
+// bigMoments holds the same statistics as comoments in big.Float, for data
+// whose squared deviations do not fit in a float64.
+type bigMoments struct {
+	n     int
+	meanX *big.Float
+	meanY *big.Float
+	m2x   *big.Float
+	m2y   *big.Float
+	cxy   *big.Float
+}
+
+// newBigMoments returns the statistics of s in big.Float.
+func newBigMoments(s *compensatedMoments) *bigMoments {
+	return &bigMoments{
+		n:     s.n,
+		meanX: newOverflowFloat().SetFloat64(s.meanX),
+		meanY: newOverflowFloat().SetFloat64(s.meanY),
+		m2x:   newOverflowFloat().SetFloat64(s.m2x.value()),
+		m2y:   newOverflowFloat().SetFloat64(s.m2y.value()),
+		cxy:   newOverflowFloat().SetFloat64(s.cxy.value()),
+	}
+}
+
+// newOverflowFloat returns a zero big.Float at overflowPrecision.
+func newOverflowFloat() *big.Float {
+	return new(big.Float).SetPrec(overflowPrecision)
+}
+
+// chunkBigMoments returns the statistics of a chunk of finite pairs,
+// computed with the two pass algorithm in big.Float.
+func chunkBigMoments[T Numeric](x, y []T) *bigMoments {
+	xs := make([]*big.Float, len(x))
+	ys := make([]*big.Float, len(y))
+	sumX := newOverflowFloat()
+	sumY := newOverflowFloat()
+	for i := range x {
+		xs[i] = newOverflowFloat().SetFloat64(float64(x[i]))
+		ys[i] = newOverflowFloat().SetFloat64(float64(y[i]))
+		sumX.Add(sumX, xs[i])
+		sumY.Add(sumY, ys[i])
+	}
+
+	nf := newOverflowFloat().SetInt64(int64(len(x)))
+	m := &bigMoments{
+		n:     len(x),
+		meanX: sumX.Quo(sumX, nf),
+		meanY: sumY.Quo(sumY, nf),
+		m2x:   newOverflowFloat(),
+		m2y:   newOverflowFloat(),
+		cxy:   newOverflowFloat(),
+	}
+
+	dx := newOverflowFloat()
+	dy := newOverflowFloat()
+	temp := newOverflowFloat()
+	for i := range xs {
+		dx.Sub(xs[i], m.meanX)
+		dy.Sub(ys[i], m.meanY)
+		m.m2x.Add(m.m2x, temp.Mul(dx, dx))
+		m.m2y.Add(m.m2y, temp.Mul(dy, dy))
+		m.cxy.Add(m.cxy, temp.Mul(dx, dy))
+	}
+
+	return m
+}
+
+// merge combines the statistics of o into m with the pairwise update of
+// Chan, Golub and LeVeque, as comoments.merge does in float64.
+func (m *bigMoments) merge(o *bigMoments) {
+	if o.n == 0 {
+		return
+	}
+	if m.n == 0 {
+		*m = *o
+
+		return
+	}
+
+	na := newOverflowFloat().SetInt64(int64(m.n))
+	nb := newOverflowFloat().SetInt64(int64(o.n))
+	n := newOverflowFloat().SetInt64(int64(m.n + o.n))
+	f := newOverflowFloat().Mul(na, nb)
+	f.Quo(f, n)
+	w := newOverflowFloat().Quo(nb, n)
+
+	dx := newOverflowFloat().Sub(o.meanX, m.meanX)
+	dy := newOverflowFloat().Sub(o.meanY, m.meanY)
+	temp := newOverflowFloat()
+
+	m.m2x.Add(m.m2x, o.m2x)
+	m.m2x.Add(m.m2x, temp.Mul(temp.Mul(dx, dx), f))
+	m.m2y.Add(m.m2y, o.m2y)
+	m.m2y.Add(m.m2y, temp.Mul(temp.Mul(dy, dy), f))
+	m.cxy.Add(m.cxy, o.cxy)
+	m.cxy.Add(m.cxy, temp.Mul(temp.Mul(dx, dy), f))
+	m.meanX.Add(m.meanX, temp.Mul(dx, w))
+	m.meanY.Add(m.meanY, temp.Mul(dy, w))
+	m.n += o.n
+}
+
+// value returns the Pearson correlation of the accumulated pairs, without
+// clamping.
+func (m *bigMoments) value() (float64, error) {
+	if m.m2x.Sign() <= 0 || m.m2y.Sign() <= 0 {
+		return 0, errors.New("correlation undefined: one or both variables have zero variance")
+	}
+
+	den := newOverflowFloat().Mul(m.m2x, m.m2y)
+	den.Sqrt(den)
+	r, _ := newOverflowFloat().Quo(m.cxy, den).Float64()
+
+	return r, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPearsonSinglePassChunkOverflow(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+
+	// Three chunks of ordinary values with one chunk in the middle whose
+	// squared deviations overflow float64.
+	n := 4 * contextCheckInterval
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range x {
+		x[i] = rng.NormFloat64()
+		y[i] = x[i] + rng.NormFloat64()
+		if i/contextCheckInterval == 2 {
+			x[i] *= 1e200
+			y[i] *= 1e200
+		}
+	}
+
+	bigX, err := mixedToBig(x)
+	if err != nil {
+		t.Fatalf("mixedToBig() unexpected error: %v", err)
+	}
+	bigY, err := mixedToBig(y)
+	if err != nil {
+		t.Fatalf("mixedToBig() unexpected error: %v", err)
+	}
+	want, err := pearsonsBigContext(context.Background(), bigX, bigY, 256)
+	if err != nil {
+		t.Fatalf("pearsonsBigContext() unexpected error: %v", err)
+	}
+
+	got, err := pearsonsSinglePass(x, y)
+	if err != nil {
+		t.Fatalf("pearsonsSinglePass() unexpected error: %v", err)
+	}
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("pearsonsSinglePass() = %v, want %v", got, want)
+	}
+}
+
+func TestPearsonSinglePassOverflowFirstChunk(t *testing.T) {
+	x := []float64{1e300, -1e300, 1e300, -1e300}
+	y := []float64{1, -1, 2, -2}
+
+	got, err := pearsonsSinglePass(x, y)
+	if err != nil {
+		t.Fatalf("pearsonsSinglePass() unexpected error: %v", err)
+	}
+	want := 3 / math.Sqrt(10)
+	if math.Abs(got-want) > 1e-15 {
+		t.Errorf("pearsonsSinglePass() = %v, want %v", got, want)
+	}
+}
+
+func TestBigMomentsMerge(t *testing.T) {
+	x := []float64{1, 4, 2, 8, 5, 7, 3}
+	y := []float64{2, 3, 1, 9, 4, 8, 6}
+
+	whole := chunkBigMoments(x, y)
+	for split := 1; split < len(x); split++ {
+		merged := chunkBigMoments(x[:split], y[:split])
+		merged.merge(chunkBigMoments(x[split:], y[split:]))
+
+		want, err := whole.value()
+		if err != nil {
+			t.Fatalf("value() unexpected error: %v", err)
+		}
+		got, err := merged.value()
+		if err != nil {
+			t.Fatalf("split %d: value() unexpected error: %v", split, err)
+		}
+		if math.Abs(got-want) > 1e-15 {
+			t.Errorf("split %d: merged value() = %v, want %v", split, got, want)
+		}
+	}
+}
+
+func TestBigMomentsFromFloat(t *testing.T) {
+	x := []float64{1, 4, 2, 8, 5, 7, 3}
+	y := []float64{2, 3, 1, 9, 4, 8, 6}
+
+	var s compensatedMoments
+	accumulateMoments(&s, x[:4], y[:4])
+	m := newBigMoments(&s)
+	m.merge(chunkBigMoments(x[4:], y[4:]))
+
+	got, err := m.value()
+	if err != nil {
+		t.Fatalf("value() unexpected error: %v", err)
+	}
+	want, err := pearsonsTwoPass(x, y)
+	if err != nil {
+		t.Fatalf("pearsonsTwoPass() unexpected error: %v", err)
+	}
+	if math.Abs(got-want) > 1e-15 {
+		t.Errorf("value() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,241 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"sync"
+)
+
+// parallelMinChunk is the fewest pairs worth handing to a goroutine. Below
+// this the cost of starting and joining the workers outweighs the gain.
+const parallelMinChunk = 1 << 16
+
+// pearsonsParallel calculates Pearson's correlation by splitting the pairs
+// into contiguous chunks, accumulating the sufficient statistics of each
+// chunk in its own goroutine with chunkComoments, and combining them with the pairwise update
+// of Chan, Golub and LeVeque.
+//
+// At most workers goroutines are used, and fewer if the inputs are too
+// short to give each at least parallelMinChunk pairs. The 64-bit integer
+// types, whose sums are accumulated exactly, and inputs whose statistics
+// overflow are handed to pearsonsSinglePass instead.
+func pearsonsParallel[T Numeric](x, y []T, workers int) (float64, error) {
+	workers = min(workers, len(x)/parallelMinChunk)
+	if workers < 2 || len(x) != len(y) {
+		return pearsonsSinglePass(x, y)
+	}
+	switch any(x).(type) {
+	case []int64, []int, []uint64, []uint:
+		return pearsonsSinglePass(x, y)
+	}
+
+	parts := make([]comoments, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		lo := w * len(x) / workers
+		hi := (w + 1) * len(x) / workers
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			parts[w] = chunkComoments(x[lo:hi], y[lo:hi])
+		}()
+	}
+	wg.Wait()
+
+	total := parts[0]
+	for _, p := range parts[1:] {
+		total.merge(p)
+	}
+	// Let the sequential path report zero variance and recover from
+	// overflow with its big.Float fallback.
+	if !isFinite(total.m2x*total.m2y) || !isFinite(total.cxy) || total.m2x <= 0 || total.m2y <= 0 {
+		return pearsonsSinglePass(x, y)
+	}
+
+	return total.cxy / math.Sqrt(total.m2x*total.m2y), nil
+}
+
+// chunkComoments returns the statistics of one chunk of pairs. The sums are
+// taken of the deviations from the first pair, which keeps them small when
+// the data sits far from zero without the divisions of a Welford update.
+func chunkComoments[T Numeric](x, y []T) comoments {
+	kx, ky := float64(x[0]), float64(y[0])
+	var sx, sy, sxx, syy, sxy float64
+	for i := range x {
+		dx := float64(x[i]) - kx
+		dy := float64(y[i]) - ky
+		sx += dx
+		sy += dy
+		sxx += dx * dx
+		syy += dy * dy
+		sxy += dx * dy
+	}
+
+	n := float64(len(x))
+
+	return comoments{
+		n:     len(x),
+		meanX: kx + sx/n,
+		meanY: ky + sy/n,
+		m2x:   sxx - sx*sx/n,
+		m2y:   syy - sy*sy/n,
+		cxy:   sxy - sx*sy/n,
+	}
+}
+
+// correlateParallel is correlateUnclamped with the calculation spread over
+// up to workers goroutines.
+func correlateParallel[T Numeric](x, y []T, correlationType Type, workers int) (float64, error) {
+	if correlationType == Pearson {
+		return pearsonsParallel(x, y, workers)
+	}
+	if !correlationType.valid() {
+		return 0, errors.New("unsupported correlation type")
+	}
+
+	st, err := numericRankStatsWorkers(x, y, workers)
+	if err != nil {
+		return 0, err
+	}
+	switch correlationType {
+	case Spearman:
+		return st.spearman()
+	case KendallTau:
+		return st.kendallTauB()
+	default:
+		return st.gamma()
+	}
+}
+
+// sortWorkers returns how many goroutines to use to sort n values given up
+// to workers, keeping each at least parallelMinChunk values.
+func sortWorkers(n, workers int) int {
+	return max(1, min(workers, n/parallelMinChunk))
+}
+
+// chunkBounds returns the boundaries of parts contiguous chunks of n
+// values, so that chunk i is [bounds[i], bounds[i+1]).
+func chunkBounds(n, parts int) []int {
+	bounds := make([]int, parts+1)
+	for i := range bounds {
+		bounds[i] = i * n / parts
+	}
+
+	return bounds
+}
+
+// parallelSortFunc sorts order by cmpFn with up to workers goroutines, each
+// sorting a contiguous chunk, and then merges the chunks. buf is scratch
+// space of the same length as order. Elements that compare equal may end up
+// in any order.
+func parallelSortFunc(order, buf []int, workers int, cmpFn func(a, b int) int) {
+	workers = sortWorkers(len(order), workers)
+	if workers < 2 {
+		slices.SortFunc(order, cmpFn)
+
+		return
+	}
+
+	bounds := chunkBounds(len(order), workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slices.SortFunc(order[bounds[w]:bounds[w+1]], cmpFn)
+		}()
+	}
+	wg.Wait()
+
+	mergeChunks(order, buf, bounds, cmpFn)
+}
+
+// parallelMergeCountSwaps is mergeCountSwaps with up to workers goroutines,
+// each sorting a contiguous chunk, before the chunks are merged. The count
+// of swaps is exact, so it is the same for any number of workers.
+func parallelMergeCountSwaps(order, buf []int, workers int, cmpFn func(i, j int) int) int64 {
+	workers = sortWorkers(len(order), workers)
+	if workers < 2 {
+		return mergeCountSwaps(order, buf, cmpFn)
+	}
+
+	bounds := chunkBounds(len(order), workers)
+	counts := make([]int64, workers)
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			lo, hi := bounds[w], bounds[w+1]
+			counts[w] = mergeCountSwaps(order[lo:hi], buf[lo:hi], cmpFn)
+		}()
+	}
+	wg.Wait()
+
+	swaps := mergeChunks(order, buf, bounds, cmpFn)
+	for _, c := range counts {
+		swaps += c
+	}
+
+	return swaps
+}
+
+// mergeChunks merges the sorted chunks of order delimited by bounds, in
+// rounds that merge neighbouring chunks concurrently, and returns the number
+// of pairs moved past each other. buf is scratch space of the same length
+// as order.
+func mergeChunks(order, buf []int, bounds []int, cmpFn func(i, j int) int) int64 {
+	var swaps int64
+	src, dst := order, buf
+	for len(bounds) > 2 {
+		pairs := (len(bounds) - 1) / 2
+		counts := make([]int64, pairs)
+		var wg sync.WaitGroup
+		for p := range pairs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				counts[p] = mergeRuns(src, dst, bounds[2*p], bounds[2*p+1], bounds[2*p+2], cmpFn)
+			}()
+		}
+		// An odd chunk out is carried over to the next round as it is.
+		if (len(bounds)-1)%2 == 1 {
+			lo, hi := bounds[len(bounds)-2], bounds[len(bounds)-1]
+			copy(dst[lo:hi], src[lo:hi])
+		}
+		wg.Wait()
+
+		for _, c := range counts {
+			swaps += c
+		}
+		next := make([]int, 0, pairs+2)
+		for i := 0; i < len(bounds); i += 2 {
+			next = append(next, bounds[i])
+		}
+		if next[len(next)-1] != bounds[len(bounds)-1] {
+			next = append(next, bounds[len(bounds)-1])
+		}
+		bounds = next
+		src, dst = dst, src
+	}
+	if len(order) > 0 && &src[0] != &order[0] {
+		copy(order, src)
+	}
+
+	return swaps
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"strconv"
+	"testing"
+)
+
+func TestPearsonsParallel(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	const n = 4*parallelMinChunk + 123
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range n {
+		x[i] = 5 + 10*rng.NormFloat64()
+		y[i] = 0.03*x[i] + rng.NormFloat64()
+	}
+	want, err := pearsonsTwoPass(x, y)
+	if err != nil {
+		t.Fatalf("pearsonsTwoPass() unexpected error: %v", err)
+	}
+
+	for _, workers := range []int{1, 2, 3, 8} {
+		res, err := CorrelateWithOptions(x, y, Pearson, WithParallelism(workers))
+		if err != nil {
+			t.Fatalf("CorrelateWithOptions(WithParallelism(%d)) unexpected error: %v", workers, err)
+		}
+		if math.Abs(res.Coefficient-want) > 1e-9 || res.N != n {
+			t.Errorf("CorrelateWithOptions(WithParallelism(%d)) = %v, n %d, want %v, n %d", workers, res.Coefficient, res.N, want, n)
+		}
+	}
+
+	// Exactly accumulated integer inputs fall back to the sequential path.
+	ix := make([]int64, n)
+	iy := make([]int64, n)
+	for i := range n {
+		ix[i] = int64(i)
+		iy[i] = int64(i % 1000)
+	}
+	got, err := pearsonsParallel(ix, iy, 4)
+	if err != nil {
+		t.Fatalf("pearsonsParallel() unexpected error: %v", err)
+	}
+	if wantInt, _ := pearsonsSinglePass(ix, iy); got != wantInt {
+		t.Errorf("pearsonsParallel() of int64 = %v, want %v", got, wantInt)
+	}
+
+	constant := make([]float64, n)
+	if _, err := pearsonsParallel(x, constant, 4); err == nil {
+		t.Errorf("pearsonsParallel() with constant y expected error but got none")
+	}
+	if _, err := CorrelateWithOptions(x, y, Pearson, WithParallelism(0)); err == nil {
+		t.Errorf("CorrelateWithOptions(WithParallelism(0)) expected error but got none")
+	}
+}
+
+func TestRankParallel(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	const n = 3*parallelMinChunk + 77
+	// Small integer ranges give plenty of ties in x, in y and in both.
+	x := make([]int, n)
+	y := make([]int, n)
+	for i := range n {
+		x[i] = rng.Intn(500)
+		y[i] = x[i]/3 + rng.Intn(200)
+	}
+
+	for _, ct := range []Type{Spearman, KendallTau, GoodmanKruskal} {
+		want, err := Correlate(x, y, ct)
+		if err != nil {
+			t.Fatalf("Correlate(%v) unexpected error: %v", ct, err)
+		}
+		for _, workers := range []int{2, 3, 8} {
+			res, err := CorrelateWithOptions(x, y, ct, WithParallelism(workers))
+			if err != nil {
+				t.Fatalf("CorrelateWithOptions(%v, WithParallelism(%d)) unexpected error: %v", ct, workers, err)
+			}
+			if res.Coefficient != want {
+				t.Errorf("CorrelateWithOptions(%v, WithParallelism(%d)) = %v, want %v", ct, workers, res.Coefficient, want)
+			}
+		}
+	}
+}
+
+func TestMergeChunks(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	vals := make([]int, 103)
+	for i := range vals {
+		vals[i] = rng.Intn(40)
+	}
+	cmpFn := func(i, j int) int { return vals[i] - vals[j] }
+
+	// Brute force inversion count of the original order.
+	var want int64
+	for i := range vals {
+		for j := i + 1; j < len(vals); j++ {
+			if vals[j] < vals[i] {
+				want++
+			}
+		}
+	}
+
+	for _, parts := range []int{1, 2, 3, 5, 8} {
+		order := make([]int, len(vals))
+		for i := range order {
+			order[i] = i
+		}
+		buf := make([]int, len(vals))
+		bounds := chunkBounds(len(order), parts)
+		var got int64
+		for c := range parts {
+			lo, hi := bounds[c], bounds[c+1]
+			got += mergeCountSwaps(order[lo:hi], buf[lo:hi], cmpFn)
+		}
+		got += mergeChunks(order, buf, bounds, cmpFn)
+
+		if got != want {
+			t.Errorf("%d chunks: swaps = %d, want %d", parts, got, want)
+		}
+		for i := 1; i < len(order); i++ {
+			if vals[order[i-1]] > vals[order[i]] {
+				t.Fatalf("%d chunks: order not sorted at %d", parts, i)
+			}
+		}
+	}
+}
+
+func BenchmarkKendallParallel(b *testing.B) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	const n = 1 << 21
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range n {
+		x[i] = rng.Float64()
+		y[i] = x[i] + rng.Float64()
+	}
+
+	for _, workers := range []int{1, 4} {
+		b.Run("workers="+strconv.Itoa(workers), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := CorrelateWithOptions(x, y, KendallTau, WithParallelism(workers)); err != nil {
+					b.Fatalf("CorrelateWithOptions() unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkPearsonsParallel(b *testing.B) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	const n = 1 << 22
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range n {
+		x[i] = rng.Float64()
+		y[i] = x[i] + rng.Float64()
+	}
+
+	for _, workers := range []int{1, 4} {
+		b.Run("workers="+strconv.Itoa(workers), func(b *testing.B) {
+			for b.Loop() {
+				if _, err := CorrelateWithOptions(x, y, Pearson, WithParallelism(workers)); err != nil {
+					b.Fatalf("CorrelateWithOptions() unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package correlation
 
 import (
+	"context"
 	"errors"
 	"math"
 	"math/big"
@@ -28,9 +29,19 @@ import (
 //   - 0 indicates no linear relationship
 //   - -1 indicates a perfect negative linear relationship
 //
+// For int, int64, uint and uint64 inputs the sums are accumulated exactly in
+// fixed width integer arithmetic, so large values do not lose precision by
+// passing through float64.
+//
 // An error is returned if the slices have different lengths or are empty.
 func Pearsons[T Numeric](x, y []T) (float64, error) {
-	return pearsonsSinglePass(x, y)
+	return clamped(pearsonsSinglePass(x, y))
+}
+
+// PearsonsContext is like Pearsons but periodically checks ctx and abandons
+// the calculation, returning ctx.Err(), once ctx is done.
+func PearsonsContext[T Numeric](ctx context.Context, x, y []T) (float64, error) {
+	return clamped(pearsonsSinglePassContext(ctx, x, y))
 }
 
 // correlatePearsonTwoPass calculates Pearson's correlation using the classic two-pass algorithm.
@@ -81,8 +92,15 @@ func pearsonsTwoPass[T Numeric](x, y []T) (float64, error) {
 // This is more numerically efficient as it computes the correlation in one iteration
 // through the data without requiring separate passes for means and correlation.
 //
-// The incoming values in X must be ordered.
+// The incoming values in X must be ordered, as datasets.Dataset.SortByX
+// orders them.
 func pearsonsSinglePass[T Numeric](x, y []T) (float64, error) {
+	return pearsonsSinglePassContext(context.Background(), x, y)
+}
+
+// pearsonsSinglePassContext is pearsonsSinglePass with periodic checks for
+// cancellation of ctx.
+func pearsonsSinglePassContext[T Numeric](ctx context.Context, x, y []T) (float64, error) {
 	if len(x) == 0 || len(y) == 0 {
 		return 0, errors.New("input slices cannot be empty")
 	}
@@ -96,51 +114,59 @@ func pearsonsSinglePass[T Numeric](x, y []T) (float64, error) {
 		return 0, errors.New("correlation requires at least 2 data points")
 	}
 
-	// Single-pass algorithm using Welford's online algorithm approach
-	var sumX, sumY, sumXY, sumXX, sumYY float64
-
-	for i := range n {
-		fx := float64(x[i])
-		fy := float64(y[i])
-
-		sumX += fx
-		sumY += fy
-		sumXY += fx * fy
-		sumXX += fx * fx
-		sumYY += fy * fy
+	// Squares of 64-bit integers lose precision in float64 long before the
+	// sums overflow, so accumulate them exactly instead.
+	switch xs := any(x).(type) {
+	case []int64:
+		return pearsonsSignedExact(ctx, xs, any(y).([]int64))
+	case []int:
+		return pearsonsSignedExact(ctx, xs, any(y).([]int))
+	case []uint64:
+		return pearsonsUnsignedExact(ctx, xs, any(y).([]uint64))
+	case []uint:
+		return pearsonsUnsignedExact(ctx, xs, any(y).([]uint))
 	}
 
-	// We need to check if any of these blew past math.MaxFloat64
-	if math.IsInf(sumX, 0) || math.IsInf(sumY, 0) || math.IsInf(sumXY, 0) || math.IsInf(sumXX, 0) || math.IsInf(sumYY, 0) {
-		bigX, err := mixedToBig(x)
-		if err != nil {
-			return 0, errors.New("Pearson's calculation needs to convert to big, but conversion failed: " + err.Error())
-		}
-		bigY, err := mixedToBig(y)
-		if err != nil {
-			return 0, errors.New("Pearson's calculation needs to convert to big, but conversion failed: " + err.Error())
+	// Single-pass algorithm using Welford's updates, in chunks.
+	var acc pearsonAccumulator
+
+	for lo := 0; lo < n; lo += contextCheckInterval {
+		if err := ctx.Err(); err != nil {
+			return 0, err
 		}
 
-		return PearsonsBig(bigX, bigY)
-	}
+		hi := min(lo+contextCheckInterval, n)
+		xs, ys := x[lo:hi], y[lo:hi]
+		if addPearsonChunk(&acc, xs, ys) {
+			continue
+		}
 
-	nf := float64(n)
+		// A NaN makes the result NaN. Infinite values are left to the
+		// big.Float algorithm, which decides whether they leave the
+		// coefficient defined.
+		if hasNaN(xs) || hasNaN(ys) {
+			return math.NaN(), nil
+		}
 
-	// Calculate numerator: sum(xy) - n*mean(x)*mean(y)
-	numerator := sumXY - (sumX*sumY)/nf
+		return pearsonsBigFallback(ctx, x, y)
+	}
 
-	// Calculate denominators: sqrt((sum(x²) - n*mean(x)²) * (sum(y²) - n*mean(y)²))
-	varX := sumXX - (sumX*sumX)/nf
-	varY := sumYY - (sumY*sumY)/nf
+	return acc.value()
+}
 
-	// Check for zero variance
-	if varX <= 0 || varY <= 0 {
-		return 0, errors.New("correlation undefined: one or both variables have zero variance")
+// pearsonsBigFallback converts x and y to big.Float and calculates Pearson's
+// correlation over all of them.
+func pearsonsBigFallback[T Numeric](ctx context.Context, x, y []T) (float64, error) {
+	bigX, err := mixedToBig(x)
+	if err != nil {
+		return 0, errors.New("Pearson's calculation needs to convert to big, but conversion failed: " + err.Error())
+	}
+	bigY, err := mixedToBig(y)
+	if err != nil {
+		return 0, errors.New("Pearson's calculation needs to convert to big, but conversion failed: " + err.Error())
 	}
 
-	denominator := math.Sqrt(varX * varY)
-
-	return numerator / denominator, nil
+	return pearsonsBigContext(ctx, bigX, bigY, bigInputPrecision(bigX, bigY))
 }
 
 // PearsonsBig calculates Pearson's product-moment correlation coefficient
@@ -152,51 +178,92 @@ func pearsonsSinglePass[T Numeric](x, y []T) (float64, error) {
 //   - 0 indicates no linear relationship
 //   - -1 indicates a perfect negative linear relationship
 //
-// An error is returned if the slices have different lengths or are empty.
-func PearsonsBig[T BigNumeric](x, y []T) (float64, error) {
+// The sums and products are accumulated at the working precision, which by
+// default is the largest precision among the inputs and can be set with
+// WithPrecision.
+//
+// *big.Float inputs are read in place rather than copied, so they must not
+// be modified until PearsonsBig returns. They are never written.
+//
+// An error is returned if the slices have different lengths or are empty,
+// or if an option has an invalid value.
+func PearsonsBig[T BigNumeric](x, y []T, opts ...BigOption) (float64, error) {
+	return PearsonsBigContext(context.Background(), x, y, opts...)
+}
+
+// PearsonsBigContext is like PearsonsBig but periodically checks ctx and
+// abandons the calculation, returning ctx.Err(), once ctx is done.
+func PearsonsBigContext[T BigNumeric](ctx context.Context, x, y []T, opts ...BigOption) (float64, error) {
+	prec, err := workingPrecision(x, y, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	return clamped(pearsonsBigContext(ctx, x, y, prec))
+}
+
+// pearsonsBigContext implements PearsonsBig at the working precision prec
+// with periodic checks for cancellation of ctx.
+func pearsonsBigContext[T BigNumeric](ctx context.Context, x, y []T, prec uint) (float64, error) {
+	r, err := pearsonsBigFloat(ctx, x, y, prec)
+	if err != nil {
+		return 0, err
+	}
+	result, _ := r.Float64()
+
+	return result, nil
+}
+
+// pearsonsBigFloat calculates Pearson's correlation in big.Float arithmetic
+// with prec bits of precision and returns it unrounded.
+func pearsonsBigFloat[T BigNumeric](ctx context.Context, x, y []T, prec uint) (*big.Float, error) {
 	if len(x) == 0 || len(y) == 0 {
-		return 0, errors.New("input slices cannot be empty")
+		return nil, errors.New("input slices cannot be empty")
 	}
 
 	if len(x) != len(y) {
-		return 0, errors.New("input slices must have the same length")
+		return nil, errors.New("input slices must have the same length")
 	}
 
 	n := len(x)
 	if n == 1 {
-		return 0, errors.New("correlation requires at least 2 data points")
+		return nil, errors.New("correlation requires at least 2 data points")
 	}
 
-	// Convert all inputs to *big.Float for consistent arithmetic
-	xVals := make([]*big.Float, n)
-	yVals := make([]*big.Float, n)
-
-	for i := 0; i < n; i++ {
-		switch v := any(x[i]).(type) {
-		case *big.Float:
-			xVals[i] = new(big.Float).Copy(v)
-		case *big.Int:
-			xVals[i] = new(big.Float).SetInt(v)
-		}
-
-		switch v := any(y[i]).(type) {
-		case *big.Float:
-			yVals[i] = new(big.Float).Copy(v)
-		case *big.Int:
-			yVals[i] = new(big.Float).SetInt(v)
-		}
+	// *big.Float inputs are only read, so they are used in place. *big.Int
+	// inputs are converted, into values from a pool to spare the garbage
+	// collector on large inputs.
+	xVals, xBuf, err := bigFloatsOf(ctx, x)
+	if err != nil {
+		return nil, err
+	}
+	if xBuf != nil {
+		defer putBigFloats(xBuf)
+	}
+	yVals, yBuf, err := bigFloatsOf(ctx, y)
+	if err != nil {
+		return nil, err
+	}
+	if yBuf != nil {
+		defer putBigFloats(yBuf)
 	}
 
 	// Single-pass algorithm using big.Float arithmetic
-	sumX := new(big.Float)
-	sumY := new(big.Float)
-	sumXY := new(big.Float)
-	sumXX := new(big.Float)
-	sumYY := new(big.Float)
+	sumX := new(big.Float).SetPrec(prec)
+	sumY := new(big.Float).SetPrec(prec)
+	sumXY := new(big.Float).SetPrec(prec)
+	sumXX := new(big.Float).SetPrec(prec)
+	sumYY := new(big.Float).SetPrec(prec)
 
-	temp := new(big.Float)
+	temp := new(big.Float).SetPrec(prec)
 
 	for i := range n {
+		if i%contextCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
 		fx := xVals[i]
 		fy := yVals[i]
 
@@ -215,61 +282,57 @@ func PearsonsBig[T BigNumeric](x, y []T) (float64, error) {
 		sumYY.Add(sumYY, temp)
 	}
 
-	nf := new(big.Float).SetInt64(int64(n))
+	nf := new(big.Float).SetPrec(prec).SetInt64(int64(n))
 
 	// Calculate numerator: sumXY - (sumX * sumY) / n
-	numerator := new(big.Float)
+	numerator := new(big.Float).SetPrec(prec)
 	temp.Mul(sumX, sumY)
 	temp.Quo(temp, nf)
 
 	// Check for infinity cases that would cause "subtraction of infinities with equal signs"
 	if sumXY.IsInf() && temp.IsInf() && sumXY.Signbit() == temp.Signbit() {
 		// Both are infinite with same sign - correlation is undefined
-		return 0, errors.New("correlation undefined: infinite values with same sign detected")
+		return nil, errors.New("correlation undefined: infinite values with same sign detected")
 	}
 	numerator.Sub(sumXY, temp)
 
 	// Calculate varX: sumXX - (sumX * sumX) / n
-	varX := new(big.Float)
+	varX := new(big.Float).SetPrec(prec)
 	temp.Mul(sumX, sumX)
 	temp.Quo(temp, nf)
 
 	// Check for infinity cases in variance calculation
 	if sumXX.IsInf() && temp.IsInf() && sumXX.Signbit() == temp.Signbit() {
-		return 0, errors.New("correlation undefined: infinite variance detected in X")
+		return nil, errors.New("correlation undefined: infinite variance detected in X")
 	}
 	varX.Sub(sumXX, temp)
 
 	// Calculate varY: sumYY - (sumY * sumY) / n
-	varY := new(big.Float)
+	varY := new(big.Float).SetPrec(prec)
 	temp.Mul(sumY, sumY)
 	temp.Quo(temp, nf)
 
 	// Check for infinity cases in variance calculation
 	if sumYY.IsInf() && temp.IsInf() && sumYY.Signbit() == temp.Signbit() {
-		return 0, errors.New("correlation undefined: infinite variance detected in Y")
+		return nil, errors.New("correlation undefined: infinite variance detected in Y")
 	}
 	varY.Sub(sumYY, temp)
 
 	// Check for zero variance
 	zero := new(big.Float)
 	if varX.Cmp(zero) <= 0 || varY.Cmp(zero) <= 0 {
-		return 0, errors.New("correlation undefined: one or both variables have zero variance")
+		return nil, errors.New("correlation undefined: one or both variables have zero variance")
 	}
 
 	// Calculate denominator: sqrt(varX * varY)
-	denominator := new(big.Float)
+	denominator := new(big.Float).SetPrec(prec)
 	denominator.Mul(varX, varY)
 	denominator.Sqrt(denominator)
 
 	// Calculate correlation: numerator / denominator
-	correlation := new(big.Float)
-	correlation.Quo(numerator, denominator)
-
-	// Convert to float64 for return
-	result, _ := correlation.Float64()
+	correlation := new(big.Float).SetPrec(prec)
 
-	return result, nil
+	return correlation.Quo(numerator, denominator), nil
 }
 
 // PearsonsMixed calculates Pearson's product-moment correlation coefficient
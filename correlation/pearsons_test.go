@@ -133,6 +133,57 @@ func TestPearsonSinglePassVsTwoPassRandomData(t *testing.T) {
 	}
 }
 
+func TestPearsonSinglePassLargeOffset(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+
+	// Raw sums of squares lose every significant digit of the variance once
+	// the offset squared swamps it; the Welford updates should not.
+	offsets := []float64{0, 1e4, 1e8, 1e10}
+
+	for _, offset := range offsets {
+		t.Run(fmt.Sprintf("offset_%g", offset), func(t *testing.T) {
+			x := make([]float64, 1000)
+			y := make([]float64, 1000)
+			for i := range x {
+				x[i] = offset + rng.Float64()
+				y[i] = x[i] + 0.5*rng.Float64()
+			}
+
+			got, err := pearsonsSinglePass(x, y)
+			if err != nil {
+				t.Fatalf("pearsonsSinglePass() unexpected error: %v", err)
+			}
+			want, err := pearsonsTwoPass(x, y)
+			if err != nil {
+				t.Fatalf("pearsonsTwoPass() unexpected error: %v", err)
+			}
+			if math.Abs(got-want) > 1e-6 {
+				t.Errorf("pearsonsSinglePass() = %v, two pass = %v", got, want)
+			}
+		})
+	}
+}
+
+func TestPearsonSinglePassNonFinite(t *testing.T) {
+	got, err := pearsonsSinglePass([]float64{1, math.NaN(), 3}, []float64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("pearsonsSinglePass() with NaN unexpected error: %v", err)
+	}
+	if !math.IsNaN(got) {
+		t.Errorf("pearsonsSinglePass() with NaN = %v, want NaN", got)
+	}
+
+	// Values whose squared deviations overflow float64 are recomputed in
+	// big.Float.
+	got, err = pearsonsSinglePass([]float64{-1e200, 0, 1e200}, []float64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("pearsonsSinglePass() with huge values unexpected error: %v", err)
+	}
+	if math.Abs(got-1) > 1e-12 {
+		t.Errorf("pearsonsSinglePass() with huge values = %v, want 1", got)
+	}
+}
+
 func TestPearsonVsPearsonBig(t *testing.T) {
 	tests := []struct {
 		name string
@@ -0,0 +1,80 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math/big"
+	"strconv"
+)
+
+// BigOption configures the big.Float calculations of the *Big functions.
+type BigOption func(*bigOptions)
+
+// bigOptions holds the settings accumulated from a list of BigOption values.
+type bigOptions struct {
+	// prec is the working precision in bits, or 0 to derive it from the
+	// inputs.
+	prec uint
+}
+
+// WithPrecision sets the working precision, in bits, of the sums,
+// intermediate products and result of a big.Float calculation. By default
+// the working precision is the largest precision among the inputs, and at
+// least 64 bits, so 1024-bit inputs are computed with 1024-bit arithmetic.
+func WithPrecision(prec uint) BigOption {
+	return func(o *bigOptions) {
+		o.prec = prec
+	}
+}
+
+// workingPrecision applies opts and returns the working precision for a
+// calculation over x and y.
+//
+// An error is returned if an explicit precision is out of range.
+func workingPrecision[T BigNumeric](x, y []T, opts []BigOption) (uint, error) {
+	o := bigOptions{prec: 0}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.prec == 0 {
+		return bigInputPrecision(x, y), nil
+	}
+	if o.prec > big.MaxPrec {
+		return 0, errors.New("precision must be between 1 and " + strconv.FormatUint(big.MaxPrec, 10) + " bits")
+	}
+
+	return o.prec, nil
+}
+
+// bigInputPrecision returns the working precision for big inputs: the
+// largest precision among them, and at least 64 bits. A *big.Int counts as
+// having as many bits of precision as it has significant bits.
+func bigInputPrecision[T BigNumeric](x, y []T) uint {
+	var prec uint = 64
+	for _, data := range [][]T{x, y} {
+		for _, v := range data {
+			switch v := any(v).(type) {
+			case *big.Float:
+				prec = max(prec, v.Prec())
+			case *big.Int:
+				prec = max(prec, uint(v.BitLen()))
+			}
+		}
+	}
+
+	return prec
+}
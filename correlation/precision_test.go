@@ -0,0 +1,98 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// nearOne returns 1 + k·2⁻²⁰⁰ for k = 1..n at the given precision. The
+// values are distinct only with more than 200 bits of precision.
+func nearOne(n int, prec uint) []*big.Float {
+	out := make([]*big.Float, n)
+	for k := range n {
+		step := new(big.Float).SetPrec(prec).SetMantExp(big.NewFloat(float64(k+1)), -200)
+		out[k] = new(big.Float).SetPrec(prec).Add(new(big.Float).SetPrec(prec).SetInt64(1), step)
+	}
+
+	return out
+}
+
+func TestPearsonsBigPrecision(t *testing.T) {
+	x := nearOne(5, 1024)
+	y := []*big.Float{big.NewFloat(1), big.NewFloat(2), big.NewFloat(3), big.NewFloat(4), big.NewFloat(5)}
+
+	// The y values have only 53 bits of precision, so the accumulators
+	// must take their precision from x rather than the first operand.
+	got, err := PearsonsBig(y, x)
+	if err != nil {
+		t.Fatalf("PearsonsBig() unexpected error: %v", err)
+	}
+	if got != 1 {
+		t.Errorf("PearsonsBig() = %v, want 1", got)
+	}
+	got, err = CorrelateBig(x, y, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateBig() unexpected error: %v", err)
+	}
+	if got != 1 {
+		t.Errorf("CorrelateBig() = %v, want 1", got)
+	}
+
+	// At 64 bits every x rounds to 1 and the variance vanishes.
+	if _, err := PearsonsBig(x, y, WithPrecision(64)); err == nil {
+		t.Errorf("PearsonsBig(WithPrecision(64)) expected a zero variance error but got none")
+	}
+	// On 32-bit platforms no uint exceeds big.MaxPrec.
+	if tooWide := uint64(big.MaxPrec) + 1; tooWide <= math.MaxUint {
+		if _, err := PearsonsBig(x, y, WithPrecision(uint(tooWide))); err == nil {
+			t.Errorf("PearsonsBig(WithPrecision(MaxPrec+1)) expected error but got none")
+		}
+	}
+
+	exact, err := CorrelateBigExact(x, y, Pearson, WithPrecision(512))
+	if err != nil {
+		t.Fatalf("CorrelateBigExact() unexpected error: %v", err)
+	}
+	if exact.Prec() != 512 {
+		t.Errorf("CorrelateBigExact(WithPrecision(512)) precision = %d, want 512", exact.Prec())
+	}
+}
+
+func TestBigInputPrecision(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []*big.Float
+		want uint
+	}{
+		{name: "float64 values", x: []*big.Float{big.NewFloat(1)}, y: []*big.Float{big.NewFloat(2)}, want: 64},
+		{name: "wide y", x: []*big.Float{big.NewFloat(1)}, y: []*big.Float{new(big.Float).SetPrec(512)}, want: 512},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bigInputPrecision(tt.x, tt.y); got != tt.want {
+				t.Errorf("bigInputPrecision() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	wide := new(big.Int).Lsh(big.NewInt(1), 99)
+	if got := bigInputPrecision([]*big.Int{big.NewInt(1)}, []*big.Int{wide}); got != 100 {
+		t.Errorf("bigInputPrecision() of a 100-bit *big.Int = %d, want 100", got)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Bounds is a closed interval [Lo, Hi] that values are clipped into.
+type Bounds struct {
+	Lo float64
+	Hi float64
+}
+
+// valid reports whether b is a finite, non-empty interval.
+func (b Bounds) valid() bool {
+	return isFinite(b.Lo) && isFinite(b.Hi) && b.Lo < b.Hi
+}
+
+// clip returns v limited to the interval b.
+func (b Bounds) clip(v float64) float64 {
+	return math.Min(math.Max(v, b.Lo), b.Hi)
+}
+
+// clipFinite limits the finite values of data to b in place and returns the
+// number of values that changed.
+func clipFinite(data []float64, b Bounds) int {
+	changed := 0
+	for i, v := range data {
+		if !isFinite(v) {
+			continue
+		}
+		if c := b.clip(v); c != v {
+			data[i] = c
+			changed++
+		}
+	}
+
+	return changed
+}
+
+// PearsonsPrivate returns an ε-differentially private estimate of Pearson's
+// correlation coefficient between x and y.
+//
+// The bounds must be chosen independently of the data, since they are part
+// of the privacy guarantee. Each value is clipped into its bounds and then
+// rescaled onto [0, 1], which leaves the correlation unchanged. Under that
+// scaling adding or removing one pair changes each of the sufficient
+// statistics n, Σx, Σy, Σx², Σy² and Σxy by at most 1, so the privacy
+// budget is split evenly between the six and each is released with Laplace
+// noise of scale 6/ε. The coefficient is then computed from the noisy
+// statistics and clamped to [-1, 1].
+//
+// Smaller ε gives stronger privacy and noisier estimates; the noise in the
+// result shrinks roughly in proportion to 1/(ε·n), so small samples need a
+// generous budget to give a useful answer. Values far outside the bounds are
+// clipped, which biases the estimate toward zero.
+//
+// rng supplies the noise. If it is nil, a source seeded from the current
+// time is used. Publishing more than one estimate computed on the same data
+// spends the budget once per release.
+//
+// An error is returned if the slices are empty or differ in length, if they
+// contain NaN or ±Inf, if either Bounds is empty or not finite, if epsilon
+// is not positive, or if the noisy variance of either series is not positive.
+func PearsonsPrivate(x, y []float64, xBounds, yBounds Bounds, epsilon float64, rng *rand.Rand) (float64, error) {
+	if len(x) == 0 || len(y) == 0 {
+		return 0, errors.New("input slices cannot be empty")
+	}
+	if len(x) != len(y) {
+		return 0, errors.New("input slices must have the same length")
+	}
+	if !xBounds.valid() || !yBounds.valid() {
+		return 0, errors.New("bounds must be finite with Lo less than Hi")
+	}
+	if !(epsilon > 0) || math.IsInf(epsilon, 1) {
+		return 0, errors.New("epsilon must be positive and finite")
+	}
+	if rng == nil {
+		rng = rand.New(rand.NewSource(rand.Int63()))
+	}
+
+	var sumX, sumY, sumXX, sumYY, sumXY float64
+	for i := range x {
+		if !isFinite(x[i]) {
+			return 0, nonFiniteError("x", i, x[i])
+		}
+		if !isFinite(y[i]) {
+			return 0, nonFiniteError("y", i, y[i])
+		}
+		u := (xBounds.clip(x[i]) - xBounds.Lo) / (xBounds.Hi - xBounds.Lo)
+		v := (yBounds.clip(y[i]) - yBounds.Lo) / (yBounds.Hi - yBounds.Lo)
+		sumX += u
+		sumY += v
+		sumXX += u * u
+		sumYY += v * v
+		sumXY += u * v
+	}
+
+	// Each statistic has sensitivity 1 and receives ε/6 of the budget.
+	scale := 6 / epsilon
+	n := float64(len(x)) + laplace(rng, scale)
+	sumX += laplace(rng, scale)
+	sumY += laplace(rng, scale)
+	sumXX += laplace(rng, scale)
+	sumYY += laplace(rng, scale)
+	sumXY += laplace(rng, scale)
+
+	varX := n*sumXX - sumX*sumX
+	varY := n*sumYY - sumY*sumY
+	if !(n > 1) || !(varX > 0) || !(varY > 0) {
+		return 0, errors.New("noisy variance is not positive; increase epsilon or the sample size")
+	}
+
+	r := (n*sumXY - sumX*sumY) / math.Sqrt(varX*varY)
+
+	return math.Max(-1, math.Min(1, r)), nil
+}
+
+// laplace returns a sample from the Laplace distribution with mean 0 and the
+// given scale.
+func laplace(rng *rand.Rand, scale float64) float64 {
+	// ExpFloat64 has mean 1; a random sign turns it into a Laplace variate.
+	e := rng.ExpFloat64() * scale
+	if rng.Intn(2) == 0 {
+		return -e
+	}
+
+	return e
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPearsonsPrivate(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	const n = 20000
+	x := make([]float64, n)
+	y := make([]float64, n)
+	for i := range n {
+		a := rng.Float64()
+		x[i] = a
+		y[i] = 0.6*a + 0.4*rng.Float64()
+	}
+	want, err := Pearsons(x, y)
+	if err != nil {
+		t.Fatalf("Pearsons() unexpected error: %v", err)
+	}
+
+	unit := Bounds{Lo: 0, Hi: 1}
+	tests := []struct {
+		name    string
+		epsilon float64
+		tol     float64
+	}{
+		{name: "generous budget", epsilon: 100, tol: 0.01},
+		{name: "moderate budget", epsilon: 5, tol: 0.1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PearsonsPrivate(x, y, unit, unit, tt.epsilon, rand.New(rand.NewSource(1)))
+			if err != nil {
+				t.Fatalf("PearsonsPrivate() unexpected error: %v", err)
+			}
+			if math.Abs(got-want) > tt.tol {
+				t.Errorf("PearsonsPrivate(ε=%v) = %v, want %v ± %v", tt.epsilon, got, want, tt.tol)
+			}
+		})
+	}
+
+	a, _ := PearsonsPrivate(x, y, unit, unit, 1, rand.New(rand.NewSource(9)))
+	b, _ := PearsonsPrivate(x, y, unit, unit, 1, rand.New(rand.NewSource(9)))
+	if a != b {
+		t.Errorf("PearsonsPrivate() with the same source gave %v and %v", a, b)
+	}
+}
+
+func TestPearsonsPrivateClamped(t *testing.T) {
+	// A tiny sample with a small budget is dominated by noise, but the
+	// estimate must stay a valid coefficient whenever one is returned.
+	x := []float64{1, 2, 3, 4, 5}
+	y := []float64{2, 4, 5, 4, 5}
+	b := Bounds{Lo: 0, Hi: 10}
+	rng := rand.New(rand.NewSource(getSeed()))
+	for range 200 {
+		r, err := PearsonsPrivate(x, y, b, b, 0.5, rng)
+		if err != nil {
+			continue
+		}
+		if r < -1 || r > 1 {
+			t.Fatalf("PearsonsPrivate() = %v, outside [-1, 1]", r)
+		}
+	}
+}
+
+func TestPearsonsPrivateErrors(t *testing.T) {
+	unit := Bounds{Lo: 0, Hi: 1}
+	tests := []struct {
+		name    string
+		x, y    []float64
+		xb, yb  Bounds
+		epsilon float64
+	}{
+		{name: "empty", x: []float64{}, y: []float64{}, xb: unit, yb: unit, epsilon: 1},
+		{name: "length mismatch", x: []float64{1, 2}, y: []float64{1}, xb: unit, yb: unit, epsilon: 1},
+		{name: "NaN input", x: []float64{1, math.NaN()}, y: []float64{1, 2}, xb: unit, yb: unit, epsilon: 1},
+		{name: "empty bounds", x: []float64{1, 2}, y: []float64{1, 2}, xb: Bounds{Lo: 1, Hi: 1}, yb: unit, epsilon: 1},
+		{name: "infinite bounds", x: []float64{1, 2}, y: []float64{1, 2}, xb: unit, yb: Bounds{Lo: 0, Hi: math.Inf(1)}, epsilon: 1},
+		{name: "zero epsilon", x: []float64{1, 2}, y: []float64{1, 2}, xb: unit, yb: unit, epsilon: 0},
+		{name: "NaN epsilon", x: []float64{1, 2}, y: []float64{1, 2}, xb: unit, yb: unit, epsilon: math.NaN()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := PearsonsPrivate(tt.x, tt.y, tt.xb, tt.yb, tt.epsilon, nil); err == nil {
+				t.Errorf("PearsonsPrivate() expected error but got none")
+			}
+		})
+	}
+}
+
+func TestCorrelateWithOptionsClipping(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 100}
+	y := []float64{2, 4, 6, 8, -50}
+	b := Bounds{Lo: 0, Hi: 10}
+
+	res, err := CorrelateWithOptions(x, y, Pearson, WithClipping(b, b))
+	if err != nil {
+		t.Fatalf("CorrelateWithOptions() unexpected error: %v", err)
+	}
+	if res.Clipped != 2 {
+		t.Errorf("CorrelateWithOptions() Clipped = %d, want 2", res.Clipped)
+	}
+	want, _ := Pearsons([]float64{1, 2, 3, 4, 10}, []float64{2, 4, 6, 8, 0})
+	if math.Abs(res.Coefficient-want) > 1e-12 {
+		t.Errorf("CorrelateWithOptions() = %v, want %v", res.Coefficient, want)
+	}
+	if x[4] != 100 || y[4] != -50 {
+		t.Errorf("CorrelateWithOptions() modified its inputs")
+	}
+
+	// Infinite values are left for the NaN policy.
+	inf := []float64{1, 2, 3, math.Inf(1)}
+	if _, err := CorrelateWithOptions(inf, []float64{1, 2, 3, 4}, Pearson,
+		WithClipping(b, b), WithNaNPolicy(ErrorOnNaN)); err == nil {
+		t.Errorf("CorrelateWithOptions() with Inf and ErrorOnNaN expected error but got none")
+	}
+
+	// Integer inputs are clipped on float64 copies.
+	res, err = CorrelateWithOptions([]int{1, 2, 3, 50}, []int{1, 2, 3, 4}, Pearson,
+		WithClipping(Bounds{Lo: 0, Hi: 4}, b))
+	if err != nil {
+		t.Fatalf("CorrelateWithOptions() unexpected error: %v", err)
+	}
+	if res.Clipped != 1 || math.Abs(res.Coefficient-1) > 1e-12 {
+		t.Errorf("CorrelateWithOptions() = %+v, want coefficient 1 with 1 clipped", res)
+	}
+
+	if _, err := CorrelateWithOptions(x, y, Pearson, WithClipping(Bounds{Lo: 5, Hi: 1}, b)); err == nil {
+		t.Errorf("CorrelateWithOptions() with empty bounds expected error but got none")
+	}
+}
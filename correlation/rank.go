@@ -0,0 +1,317 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// rankStats holds the ordering information shared by the rank based
+// coefficients, so that Spearman's rho, Kendall's tau and Goodman and
+// Kruskal's gamma can all be computed from a single pair of sorts.
+type rankStats struct {
+	// xRanks and yRanks are the 1-based ranks of the values, with tied
+	// values given the average of the ranks they span.
+	xRanks []float64
+	yRanks []float64
+	// pairs is the total number of pairs, n(n-1)/2.
+	pairs int64
+	// tiedX and tiedY count the pairs tied in x and in y, and tiedXY the
+	// pairs tied in both.
+	tiedX  int64
+	tiedY  int64
+	tiedXY int64
+	// discordant counts the pairs ordered differently by x and y.
+	discordant int64
+}
+
+// rankAnalysis computes the rankStats of n pairs, where cmpX(i, j) and
+// cmpY(i, j) compare the x and y values at indexes i and j.
+//
+// It uses the O(n log n) method of Knight (1966): the pairs are sorted by x
+// (breaking ties by y), and the discordant pairs are then counted as the
+// number of swaps a stable merge sort needs to put the y values in order.
+// Both sorts also give the orderings needed to rank each series.
+func rankAnalysis(n int, cmpX, cmpY func(i, j int) int) rankStats {
+	return rankAnalysisWorkers(n, 1, cmpX, cmpY)
+}
+
+// rankAnalysisWorkers is rankAnalysis with both sorts spread over up to
+// workers goroutines. The counts are exact, so the result does not depend
+// on the number of workers.
+func rankAnalysisWorkers(n, workers int, cmpX, cmpY func(i, j int) int) rankStats {
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	buf := make([]int, n)
+	parallelSortFunc(order, buf, workers, func(a, b int) int {
+		if c := cmpX(a, b); c != 0 {
+			return c
+		}
+
+		return cmpY(a, b)
+	})
+
+	st := rankStats{
+		xRanks:     make([]float64, n),
+		yRanks:     make([]float64, n),
+		pairs:      int64(n) * int64(n-1) / 2,
+		tiedX:      0,
+		tiedY:      0,
+		tiedXY:     0,
+		discordant: 0,
+	}
+	st.tiedX = assignRanks(order, st.xRanks, cmpX)
+	st.tiedXY = countTies(order, func(a, b int) bool { return cmpX(a, b) == 0 && cmpY(a, b) == 0 })
+
+	st.discordant = parallelMergeCountSwaps(order, buf, workers, cmpY)
+	st.tiedY = assignRanks(order, st.yRanks, cmpY)
+
+	return st
+}
+
+// assignRanks gives each run of equal values in the sorted order the
+// average of the ranks it spans, and returns the number of tied pairs.
+func assignRanks(order []int, ranks []float64, cmpFn func(i, j int) int) int64 {
+	var ties int64
+	for i := 0; i < len(order); {
+		j := i + 1
+		for j < len(order) && cmpFn(order[i], order[j]) == 0 {
+			j++
+		}
+		// Positions i..j-1 share the average of ranks i+1..j.
+		avg := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			ranks[order[k]] = avg
+		}
+		run := int64(j - i)
+		ties += run * (run - 1) / 2
+		i = j
+	}
+
+	return ties
+}
+
+// countTies returns the number of tied pairs among adjacent runs of the
+// sorted order for which equal reports true.
+func countTies(order []int, equal func(a, b int) bool) int64 {
+	var ties int64
+	for i := 0; i < len(order); {
+		j := i + 1
+		for j < len(order) && equal(order[i], order[j]) {
+			j++
+		}
+		run := int64(j - i)
+		ties += run * (run - 1) / 2
+		i = j
+	}
+
+	return ties
+}
+
+// mergeCountSwaps stably sorts order by cmpFn using a bottom up merge sort
+// and returns the number of inversions, that is pairs moved past each other.
+// buf must be the same length as order.
+func mergeCountSwaps(order, buf []int, cmpFn func(i, j int) int) int64 {
+	var swaps int64
+	n := len(order)
+	src, dst := order, buf
+	for width := 1; width < n; width *= 2 {
+		for lo := 0; lo < n; lo += 2 * width {
+			swaps += mergeRuns(src, dst, lo, min(lo+width, n), min(lo+2*width, n), cmpFn)
+		}
+		src, dst = dst, src
+	}
+	if n > 0 && &src[0] != &order[0] {
+		copy(order, src)
+	}
+
+	return swaps
+}
+
+// mergeRuns stably merges the sorted runs src[lo:mid] and src[mid:hi] into
+// dst[lo:hi] and returns the number of pairs moved past each other.
+func mergeRuns(src, dst []int, lo, mid, hi int, cmpFn func(i, j int) int) int64 {
+	var swaps int64
+	i, j, k := lo, mid, lo
+	for i < mid && j < hi {
+		if cmpFn(src[j], src[i]) < 0 {
+			// src[j] jumps ahead of every remaining element on the left.
+			dst[k] = src[j]
+			swaps += int64(mid - i)
+			j++
+		} else {
+			dst[k] = src[i]
+			i++
+		}
+		k++
+	}
+	k += copy(dst[k:], src[i:mid])
+	copy(dst[k:], src[j:hi])
+
+	return swaps
+}
+
+// spearman returns Spearman's rho, the Pearson correlation of the ranks.
+func (st rankStats) spearman() (float64, error) {
+	return pearsonsTwoPass(st.xRanks, st.yRanks)
+}
+
+// kendallTauB returns Kendall's tau-b,
+//
+//	τb = (C - D) / sqrt((n0 - n1)(n0 - n2))
+//
+// where n0 is the number of pairs and n1 and n2 the pairs tied in x and y.
+func (st rankStats) kendallTauB() (float64, error) {
+	den := float64(st.pairs-st.tiedX) * float64(st.pairs-st.tiedY)
+	if den <= 0 {
+		return 0, errors.New("correlation undefined: one or both variables have zero variance")
+	}
+
+	return float64(st.concordant()-st.discordant) / math.Sqrt(den), nil
+}
+
+// bigSpearman returns Spearman's rho with prec bits of precision. The
+// doubled ranks are integers, so their Pearson correlation is formed
+// exactly and only the final division and square root are rounded.
+func (st rankStats) bigSpearman(prec uint) (*big.Float, error) {
+	var s integerSums
+	s.n = len(st.xRanks)
+	for i := range st.xRanks {
+		xi, yi := int64(2*st.xRanks[i]), int64(2*st.yRanks[i])
+		s.sumX.addInt64(xi)
+		s.sumY.addInt64(yi)
+		s.sumXX.addMulInt64(xi, xi)
+		s.sumYY.addMulInt64(yi, yi)
+		s.sumXY.addMulInt64(xi, yi)
+	}
+
+	return s.bigValue(prec)
+}
+
+// bigKendallTauB returns Kendall's tau-b with prec bits of precision.
+func (st rankStats) bigKendallTauB(prec uint) (*big.Float, error) {
+	den := new(big.Int).Mul(big.NewInt(st.pairs-st.tiedX), big.NewInt(st.pairs-st.tiedY))
+	if den.Sign() <= 0 {
+		return nil, errors.New("correlation undefined: one or both variables have zero variance")
+	}
+
+	return bigRatioOfRoot(big.NewInt(st.concordant()-st.discordant), den, prec), nil
+}
+
+// bigGamma returns Goodman and Kruskal's gamma with prec bits of precision.
+func (st rankStats) bigGamma(prec uint) (*big.Float, error) {
+	untied := st.concordant() + st.discordant
+	if untied == 0 {
+		return nil, errors.New("correlation undefined: every pair is tied")
+	}
+
+	r := new(big.Float).SetPrec(prec).SetInt64(st.concordant() - st.discordant)
+
+	return r.Quo(r, new(big.Float).SetPrec(prec).SetInt64(untied)), nil
+}
+
+// gamma returns Goodman and Kruskal's gamma, (C - D) / (C + D).
+func (st rankStats) gamma() (float64, error) {
+	untied := st.concordant() + st.discordant
+	if untied == 0 {
+		return 0, errors.New("correlation undefined: every pair is tied")
+	}
+
+	return float64(st.concordant()-st.discordant) / float64(untied), nil
+}
+
+// concordant returns the number of pairs ordered the same way by x and y.
+// Every pair is tied in x, tied in y, concordant or discordant, with the
+// pairs tied in both counted in both tied totals.
+func (st rankStats) concordant() int64 {
+	return st.pairs - st.tiedX - st.tiedY + st.tiedXY - st.discordant
+}
+
+// numericRankStats validates x and y and returns their rankStats.
+func numericRankStats[T Numeric](x, y []T) (rankStats, error) {
+	return numericRankStatsWorkers(x, y, 1)
+}
+
+// numericRankStatsWorkers is numericRankStats with the sorts spread over up
+// to workers goroutines.
+func numericRankStatsWorkers[T Numeric](x, y []T, workers int) (rankStats, error) {
+	if err := checkRankInputs(len(x), len(y)); err != nil {
+		return rankStats{}, err
+	}
+	for i := range x {
+		if math.IsNaN(float64(x[i])) {
+			return rankStats{}, nonFiniteError("x", i, float64(x[i]))
+		}
+		if math.IsNaN(float64(y[i])) {
+			return rankStats{}, nonFiniteError("y", i, float64(y[i]))
+		}
+	}
+
+	return rankAnalysisWorkers(len(x), workers,
+		func(i, j int) int { return compareNumeric(x[i], x[j]) },
+		func(i, j int) int { return compareNumeric(y[i], y[j]) },
+	), nil
+}
+
+// bigRankStats validates x and y and returns their rankStats.
+func bigRankStats[T BigNumeric](x, y []T) (rankStats, error) {
+	if err := checkRankInputs(len(x), len(y)); err != nil {
+		return rankStats{}, err
+	}
+
+	bx := make([]*big.Float, len(x))
+	by := make([]*big.Float, len(y))
+	for i := range x {
+		bx[i] = bigNumericToBigFloat(x[i])
+		by[i] = bigNumericToBigFloat(y[i])
+	}
+
+	return rankAnalysis(len(x),
+		func(i, j int) int { return bx[i].Cmp(bx[j]) },
+		func(i, j int) int { return by[i].Cmp(by[j]) },
+	), nil
+}
+
+// checkRankInputs checks the lengths of the inputs to a rank correlation.
+func checkRankInputs(nx, ny int) error {
+	if nx == 0 || ny == 0 {
+		return errors.New("input slices cannot be empty")
+	}
+	if nx != ny {
+		return errors.New("input slices must have the same length")
+	}
+	if nx == 1 {
+		return errors.New("correlation requires at least 2 data points")
+	}
+
+	return nil
+}
+
+// compareNumeric returns -1, 0 or 1 as a is less than, equal to or greater
+// than b. The values must not be NaN.
+func compareNumeric[T Numeric](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestMergeCountSwaps(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	for _, n := range []int{0, 1, 2, 7, 64, 100} {
+		vals := make([]int, n)
+		for i := range vals {
+			vals[i] = rng.Intn(10) // plenty of ties
+		}
+
+		// Brute force count of strictly inverted pairs.
+		var want int64
+		for i := range n {
+			for j := i + 1; j < n; j++ {
+				if vals[i] > vals[j] {
+					want++
+				}
+			}
+		}
+
+		order := make([]int, n)
+		for i := range order {
+			order[i] = i
+		}
+		got := mergeCountSwaps(order, make([]int, n), func(i, j int) int { return cmp.Compare(vals[i], vals[j]) })
+		if got != want {
+			t.Errorf("mergeCountSwaps(n=%d) = %d, want %d", n, got, want)
+		}
+		if !slices.IsSortedFunc(order, func(a, b int) int { return cmp.Compare(vals[a], vals[b]) }) {
+			t.Errorf("mergeCountSwaps(n=%d) left order unsorted", n)
+		}
+	}
+}
+
+func TestRankStatsCounts(t *testing.T) {
+	x := []int{1, 1, 2, 2, 3}
+	y := []int{1, 2, 2, 1, 3}
+	st, err := numericRankStats(x, y)
+	if err != nil {
+		t.Fatalf("numericRankStats() unexpected error: %v", err)
+	}
+
+	// Pairs by brute force: 10 in total, 2 tied in x, 2 tied in y, none in
+	// both, 5 concordant and 1 discordant.
+	if st.pairs != 10 || st.tiedX != 2 || st.tiedY != 2 || st.tiedXY != 0 {
+		t.Errorf("tie counts = %+v", st)
+	}
+	if st.concordant() != 5 || st.discordant != 1 {
+		t.Errorf("concordant, discordant = %d, %d, want 5, 1", st.concordant(), st.discordant)
+	}
+	if !slices.Equal(st.xRanks, []float64{1.5, 1.5, 3.5, 3.5, 5}) {
+		t.Errorf("xRanks = %v", st.xRanks)
+	}
+	if !slices.Equal(st.yRanks, []float64{1.5, 3.5, 3.5, 1.5, 5}) {
+		t.Errorf("yRanks = %v", st.yRanks)
+	}
+}
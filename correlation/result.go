@@ -0,0 +1,33 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+// Result holds a correlation coefficient together with the details of how
+// it was computed.
+type Result struct {
+	// Type is the correlation coefficient that was calculated.
+	Type Type
+	// Coefficient is the calculated correlation coefficient.
+	Coefficient float64
+	// N is the number of (x, y) pairs actually used in the calculation,
+	// after any pairs were omitted by the NaN policy.
+	N int
+	// Imputed is the total number of missing values, across both x and y,
+	// that were filled in by the imputation option.
+	Imputed int
+	// Clipped is the total number of values, across both x and y, that were
+	// moved into range by the clipping option.
+	Clipped int
+}
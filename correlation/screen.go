@@ -0,0 +1,152 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"runtime"
+	"slices"
+	"sync"
+)
+
+// ScreenOption configures CorrelateOneToMany.
+type ScreenOption func(*screenOptions)
+
+// screenOptions holds the settings of a one-to-many screen.
+type screenOptions struct {
+	workers int
+	alpha   float64
+}
+
+// WithWorkers sets how many candidates are correlated concurrently. The
+// default is runtime.GOMAXPROCS(0).
+func WithWorkers(n int) ScreenOption {
+	return func(o *screenOptions) {
+		o.workers = n
+	}
+}
+
+// WithMaxPValue keeps only the candidates whose two-sided p-value is at most
+// alpha. Candidates whose p-value cannot be computed, such as those
+// correlated with GoodmanKruskal, are dropped. By default no filtering is
+// done.
+func WithMaxPValue(alpha float64) ScreenOption {
+	return func(o *screenOptions) {
+		o.alpha = alpha
+	}
+}
+
+// CandidateCorrelation is the correlation of one candidate series with the
+// target in a one-to-many screen.
+type CandidateCorrelation struct {
+	// Index is the position of the candidate in the input.
+	Index int
+	// Coefficient is the correlation between the target and the candidate.
+	Coefficient float64
+	// PValue is the two-sided p-value of Coefficient, or NaN if it is not
+	// available for the correlation type or sample size.
+	PValue float64
+}
+
+// CorrelateOneToMany correlates target with each of the candidates
+// concurrently, as is done when screening many features against a single
+// response. The results are sorted by decreasing absolute coefficient, with
+// ties broken by candidate index.
+//
+// Candidates whose coefficient is undefined, for example because they are
+// constant, are left out of the results rather than failing the screen.
+//
+// An error is returned if a candidate's length differs from the target's,
+// the correlation type is not supported, or an option has an invalid value.
+func CorrelateOneToMany[T Numeric](target []T, candidates [][]T, correlationType Type, opts ...ScreenOption) ([]CandidateCorrelation, error) {
+	o := screenOptions{
+		workers: runtime.GOMAXPROCS(0),
+		alpha:   1,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if !correlationType.valid() {
+		return nil, errors.New("unsupported correlation type")
+	}
+	if o.workers < 1 {
+		return nil, errors.New("workers must be at least 1")
+	}
+	if o.alpha <= 0 || o.alpha > 1 || math.IsNaN(o.alpha) {
+		return nil, errors.New("maximum p-value must be in the interval (0, 1]")
+	}
+	for _, c := range candidates {
+		if len(c) != len(target) {
+			return nil, errors.New("candidates must have the same length as the target")
+		}
+	}
+
+	found := make([]CandidateCorrelation, len(candidates))
+	ok := make([]bool, len(candidates))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(o.workers, len(candidates)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				found[i], ok[i] = screenCandidate(target, candidates[i], i, correlationType, o.alpha)
+			}
+		}()
+	}
+	for i := range candidates {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	out := make([]CandidateCorrelation, 0, len(candidates))
+	for i, c := range found {
+		if ok[i] {
+			out = append(out, c)
+		}
+	}
+	slices.SortFunc(out, func(a, b CandidateCorrelation) int {
+		if c := cmp.Compare(math.Abs(b.Coefficient), math.Abs(a.Coefficient)); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Index, b.Index)
+	})
+
+	return out, nil
+}
+
+// screenCandidate correlates one candidate with the target and reports
+// whether it belongs in the results.
+func screenCandidate[T Numeric](target, candidate []T, index int, correlationType Type, alpha float64) (CandidateCorrelation, bool) {
+	r, err := Correlate(target, candidate, correlationType)
+	if err != nil || math.IsNaN(r) {
+		return CandidateCorrelation{}, false
+	}
+
+	p, err := PValue(r, len(target), correlationType)
+	if err != nil {
+		p = math.NaN()
+	}
+	if alpha < 1 && !(p <= alpha) {
+		return CandidateCorrelation{}, false
+	}
+
+	return CandidateCorrelation{Index: index, Coefficient: r, PValue: p}, true
+}
@@ -0,0 +1,121 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestCorrelateOneToMany(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	const n = 200
+	target := make([]float64, n)
+	strong := make([]float64, n)
+	weak := make([]float64, n)
+	noise := make([]float64, n)
+	inverse := make([]float64, n)
+	constant := make([]float64, n)
+	for i := range n {
+		target[i] = rng.NormFloat64()
+		strong[i] = target[i] + 0.1*rng.NormFloat64()
+		weak[i] = target[i] + 2*rng.NormFloat64()
+		noise[i] = rng.NormFloat64()
+		inverse[i] = -target[i] + 0.5*rng.NormFloat64()
+		constant[i] = 3
+	}
+	candidates := [][]float64{noise, weak, constant, strong, inverse}
+
+	for _, workers := range []int{1, 3, 16} {
+		got, err := CorrelateOneToMany(target, candidates, Pearson, WithWorkers(workers))
+		if err != nil {
+			t.Fatalf("CorrelateOneToMany() unexpected error: %v", err)
+		}
+		if len(got) != 4 {
+			t.Fatalf("CorrelateOneToMany() returned %d results, want 4 (constant left out)", len(got))
+		}
+		if got[0].Index != 3 || got[1].Index != 4 || got[2].Index != 1 {
+			t.Errorf("CorrelateOneToMany() order = %v, want strong, inverse, weak first", got)
+		}
+		for _, c := range got {
+			want, err := Correlate(target, candidates[c.Index], Pearson)
+			if err != nil {
+				t.Fatalf("Correlate() unexpected error: %v", err)
+			}
+			if c.Coefficient != want {
+				t.Errorf("candidate %d coefficient = %v, want %v", c.Index, c.Coefficient, want)
+			}
+			wantP, err := PValue(want, n, Pearson)
+			if err != nil {
+				t.Fatalf("PValue() unexpected error: %v", err)
+			}
+			if c.PValue != wantP {
+				t.Errorf("candidate %d p-value = %v, want %v", c.Index, c.PValue, wantP)
+			}
+		}
+	}
+
+	got, err := CorrelateOneToMany(target, candidates, Pearson, WithMaxPValue(1e-6))
+	if err != nil {
+		t.Fatalf("CorrelateOneToMany() unexpected error: %v", err)
+	}
+	for _, c := range got {
+		if c.Index == 0 || c.PValue > 1e-6 {
+			t.Errorf("CorrelateOneToMany(WithMaxPValue) kept %+v", c)
+		}
+	}
+
+	got, err = CorrelateOneToMany(target, candidates, GoodmanKruskal, WithMaxPValue(0.05))
+	if err != nil {
+		t.Fatalf("CorrelateOneToMany() unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("CorrelateOneToMany(GoodmanKruskal, WithMaxPValue) = %v, want none", got)
+	}
+
+	got, err = CorrelateOneToMany(target, candidates, GoodmanKruskal)
+	if err != nil {
+		t.Fatalf("CorrelateOneToMany() unexpected error: %v", err)
+	}
+	if len(got) != 4 || !math.IsNaN(got[0].PValue) {
+		t.Errorf("CorrelateOneToMany(GoodmanKruskal) = %v, want 4 results with NaN p-values", got)
+	}
+}
+
+func TestCorrelateOneToManyErrors(t *testing.T) {
+	target := []float64{1, 2, 3, 4}
+	candidates := [][]float64{{4, 3, 2, 1}}
+	tests := []struct {
+		name       string
+		candidates [][]float64
+		typ        Type
+		opts       []ScreenOption
+	}{
+		{name: "length mismatch", candidates: [][]float64{{1, 2, 3}}, typ: Pearson},
+		{name: "unsupported type", candidates: candidates, typ: Type(99)},
+		{name: "no workers", candidates: candidates, typ: Pearson, opts: []ScreenOption{WithWorkers(0)}},
+		{name: "zero p-value", candidates: candidates, typ: Pearson, opts: []ScreenOption{WithMaxPValue(0)}},
+		{name: "NaN p-value", candidates: candidates, typ: Pearson, opts: []ScreenOption{WithMaxPValue(math.NaN())}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CorrelateOneToMany(target, tt.candidates, tt.typ, tt.opts...); err == nil {
+				t.Errorf("CorrelateOneToMany() expected error but got none")
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/rsned/stats/datasets"
+)
+
+// sourceBatchSize is the number of pairs requested from a DataSource at a
+// time.
+const sourceBatchSize = 4096
+
+// CorrelateSeq calculates the specified correlation coefficient over a
+// sequence of (x, y) pairs, such as one produced by a generator, a database
+// cursor or a file reader.
+//
+// Pearson's correlation is computed in a single streaming pass with constant
+// memory, so the pairs are never materialized. The rank based coefficients
+// need the whole dataset at once, so for those the pairs are first collected
+// into slices.
+//
+// Returns an error if the sequence is empty, has fewer than 2 pairs, or if
+// the correlation type is not supported.
+func CorrelateSeq(pairs iter.Seq2[float64, float64], correlationType Type) (float64, error) {
+	if !correlationType.valid() {
+		return 0, errors.New("unsupported correlation type")
+	}
+
+	if correlationType != Pearson {
+		var xs, ys []float64
+		for x, y := range pairs {
+			xs = append(xs, x)
+			ys = append(ys, y)
+		}
+		if len(xs) == 0 {
+			return 0, errors.New("sequence cannot be empty")
+		}
+
+		return Correlate(xs, ys, correlationType)
+	}
+
+	var c comoments
+	for x, y := range pairs {
+		c.add(x, y)
+	}
+	if c.n == 0 {
+		return 0, errors.New("sequence cannot be empty")
+	}
+
+	return c.value()
+}
+
+// CorrelateSource calculates the specified correlation coefficient over the
+// pairs supplied by src, reading them in batches until it is exhausted.
+//
+// As with CorrelateSeq, Pearson's correlation is computed in a streaming
+// pass with constant memory, while the rank based coefficients first read
+// all of the pairs into memory.
+//
+// Returns an error if src fails, supplies fewer than 2 pairs, or if the
+// correlation type is not supported.
+func CorrelateSource(src datasets.DataSource, correlationType Type) (float64, error) {
+	if !correlationType.valid() {
+		return 0, errors.New("unsupported correlation type")
+	}
+
+	if correlationType != Pearson {
+		xs, ys, err := datasets.ReadAll(src, sourceBatchSize)
+		if err != nil {
+			return 0, err
+		}
+		if len(xs) == 0 {
+			return 0, errors.New("data source cannot be empty")
+		}
+
+		return Correlate(xs, ys, correlationType)
+	}
+
+	var c comoments
+	err := datasets.Each(src, sourceBatchSize, func(x, y []float64) error {
+		for i := range x {
+			c.add(x[i], y[i])
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if c.n == 0 {
+		return 0, errors.New("data source cannot be empty")
+	}
+
+	return c.value()
+}
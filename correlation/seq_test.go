@@ -0,0 +1,189 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"io"
+	"iter"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/rsned/stats/datasets"
+)
+
+// pairsOf returns a sequence over the paired values of x and y.
+func pairsOf(x, y []float64) iter.Seq2[float64, float64] {
+	return func(yield func(float64, float64) bool) {
+		for i := range x {
+			if !yield(x[i], y[i]) {
+				return
+			}
+		}
+	}
+}
+
+// testSource is a DataSource over in-memory slices, which fails with err
+// once the pairs are exhausted if err is set.
+type testSource struct {
+	x, y []float64
+	err  error
+}
+
+// Next implements datasets.DataSource.
+func (s *testSource) Next(batch int) ([]float64, []float64, error) {
+	n := min(batch, len(s.x))
+	x, y := s.x[:n], s.y[:min(n, len(s.y))]
+	s.x, s.y = s.x[n:], s.y[len(y):]
+	if len(s.x) == 0 {
+		if s.err != nil {
+			return x, y, s.err
+		}
+
+		return x, y, io.EOF
+	}
+
+	return x, y, nil
+}
+
+func TestCorrelateSeq(t *testing.T) {
+	for _, d := range datasets.AnscombeQuartet.Data {
+		t.Run(d.Name, func(t *testing.T) {
+			want, err := Correlate(d.X, d.Y, Pearson)
+			if err != nil {
+				t.Fatalf("Correlate() unexpected error: %v", err)
+			}
+			got, err := CorrelateSeq(pairsOf(d.X, d.Y), Pearson)
+			if err != nil {
+				t.Fatalf("CorrelateSeq() unexpected error: %v", err)
+			}
+			if math.Abs(got-want) > 1e-12 {
+				t.Errorf("CorrelateSeq() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCorrelateSeqGenerator(t *testing.T) {
+	// An unbounded generator that the caller stops reading from itself.
+	rng := rand.New(rand.NewSource(getSeed()))
+	gen := func(yield func(float64, float64) bool) {
+		for i := range 10000 {
+			x := float64(i)
+			if !yield(x, 3*x+rng.NormFloat64()) {
+				return
+			}
+		}
+	}
+
+	got, err := CorrelateSeq(gen, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateSeq() unexpected error: %v", err)
+	}
+	if got < 0.999 {
+		t.Errorf("CorrelateSeq() = %v, want > 0.999", got)
+	}
+}
+
+func TestCorrelateSeqErrors(t *testing.T) {
+	empty := func(func(float64, float64) bool) {}
+
+	if _, err := CorrelateSeq(empty, Pearson); err == nil {
+		t.Errorf("CorrelateSeq() of empty sequence expected error but got none")
+	}
+	if _, err := CorrelateSeq(empty, Spearman); err == nil {
+		t.Errorf("CorrelateSeq() of empty sequence expected error but got none")
+	}
+	if _, err := CorrelateSeq(pairsOf([]float64{1}, []float64{2}), Pearson); err == nil {
+		t.Errorf("CorrelateSeq() of one pair expected error but got none")
+	}
+	if _, err := CorrelateSeq(pairsOf([]float64{1, 2}, []float64{2, 3}), Type(999)); err == nil {
+		t.Errorf("CorrelateSeq() with unsupported type expected error but got none")
+	}
+}
+
+func TestCorrelateSource(t *testing.T) {
+	rng := rand.New(rand.NewSource(getSeed()))
+	const n = 3*sourceBatchSize + 17
+	d := datasets.Dataset{
+		Name:        "random",
+		Description: "",
+		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           make([]float64, n),
+		Y:           make([]float64, n),
+		Valid:       nil,
+	}
+	for i := range n {
+		d.X[i] = rng.NormFloat64()
+		d.Y[i] = 0.5*d.X[i] + rng.NormFloat64()
+	}
+
+	for _, ct := range []Type{Pearson, Spearman, KendallTau, GoodmanKruskal} {
+		t.Run(ct.String(), func(t *testing.T) {
+			want, err := Correlate(d.X, d.Y, ct)
+			if err != nil {
+				t.Fatalf("Correlate() unexpected error: %v", err)
+			}
+			got, err := CorrelateSource(d.Source(), ct)
+			if err != nil {
+				t.Fatalf("CorrelateSource() unexpected error: %v", err)
+			}
+			if math.Abs(got-want) > 1e-12 {
+				t.Errorf("CorrelateSource() = %v, want %v", got, want)
+			}
+		})
+	}
+
+	var empty datasets.Dataset
+	if _, err := CorrelateSource(empty.Source(), Pearson); err == nil {
+		t.Errorf("CorrelateSource() with empty source expected error but got none")
+	}
+	if _, err := CorrelateSource(empty.Source(), Spearman); err == nil {
+		t.Errorf("CorrelateSource() with empty source expected error but got none")
+	}
+	if _, err := CorrelateSource(d.Source(), Type(99)); err == nil {
+		t.Errorf("CorrelateSource() with bad type expected error but got none")
+	}
+}
+
+func TestCorrelateSourceErrors(t *testing.T) {
+	x := []float64{1, 2, 3, 4}
+	failed := errors.New("connection lost")
+	tests := []struct {
+		name    string
+		src     func() datasets.DataSource
+		wantErr string
+	}{
+		{name: "failing source", src: func() datasets.DataSource { return &testSource{x: x, y: x, err: failed} }, wantErr: "connection lost"},
+		{name: "ragged batch", src: func() datasets.DataSource { return &testSource{x: x, y: x[:2], err: nil} }, wantErr: "different lengths"},
+	}
+
+	for _, test := range tests {
+		for _, ct := range []Type{Pearson, Spearman} {
+			t.Run(test.name+"/"+ct.String(), func(t *testing.T) {
+				_, err := CorrelateSource(test.src(), ct)
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Errorf("CorrelateSource() error = %v, want one containing %q", err, test.wantErr)
+				}
+			})
+		}
+	}
+}
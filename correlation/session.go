@@ -0,0 +1,347 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/rsned/stats/datasets"
+)
+
+// sessionVersion is the version of the session file format, bumped whenever
+// the layout changes incompatibly. Version 2 names correlation types, where
+// version 1 gave their numeric values; both are read.
+const sessionVersion = 2
+
+// Settings is the serializable form of the options used in an analysis.
+type Settings struct {
+	// NaNPolicy is the policy applied to NaN and ±Inf values.
+	NaNPolicy NaNPolicy
+	// Imputation is the method used to fill in missing values.
+	Imputation ImputationMethod
+	// ClipX and ClipY, when non-nil, are the bounds values are clipped to.
+	// Either both or neither must be set.
+	ClipX, ClipY *Bounds
+}
+
+// Options returns the Option values equivalent to the settings, for use with
+// CorrelateWithOptions.
+func (s Settings) Options() []Option {
+	opts := []Option{WithNaNPolicy(s.NaNPolicy), WithImputation(s.Imputation)}
+	if s.ClipX != nil && s.ClipY != nil {
+		opts = append(opts, WithClipping(*s.ClipX, *s.ClipY))
+	}
+
+	return opts
+}
+
+// Session is the complete context of an analysis: the data, the settings it
+// was run with and the results computed from it. A session can be saved to
+// a single file and loaded again, so interactive and notebook style work can
+// be resumed later or shared with others.
+type Session struct {
+	// Datasets holds the data being analyzed.
+	Datasets []datasets.Dataset
+	// Settings holds the options the analysis uses.
+	Settings Settings
+	// Results holds computed coefficients by a caller chosen name.
+	Results map[string]Result
+	// Matrices holds computed correlation matrices by a caller chosen name.
+	Matrices map[string]*Matrix
+}
+
+// NewSession returns an empty session with the default settings.
+func NewSession() *Session {
+	return &Session{
+		Datasets: nil,
+		Settings: Settings{NaNPolicy: Propagate, Imputation: ImputeNone, ClipX: nil, ClipY: nil},
+		Results:  map[string]Result{},
+		Matrices: map[string]*Matrix{},
+	}
+}
+
+// sessionFloat is a float64 that encodes NaN as null and ±Inf as the
+// strings "+Inf" and "-Inf", none of which JSON numbers can represent.
+type sessionFloat float64
+
+// MarshalJSON implements json.Marshaler.
+func (f sessionFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	switch {
+	case math.IsNaN(v):
+		return []byte("null"), nil
+	case math.IsInf(v, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-Inf"`), nil
+	}
+
+	return strconv.AppendFloat(nil, v, 'g', -1, 64), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *sessionFloat) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "null":
+		*f = sessionFloat(math.NaN())
+	case `"+Inf"`:
+		*f = sessionFloat(math.Inf(1))
+	case `"-Inf"`:
+		*f = sessionFloat(math.Inf(-1))
+	default:
+		v, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			return errors.New("invalid session number " + string(data))
+		}
+		*f = sessionFloat(v)
+	}
+
+	return nil
+}
+
+// sessionType is a Type that decodes from either its name, as written since
+// session version 2, or its numeric value, as written by version 1.
+type sessionType Type
+
+// MarshalJSON implements json.Marshaler.
+func (t sessionType) MarshalJSON() ([]byte, error) {
+	return json.Marshal(Type(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (t *sessionType) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, (*Type)(t))
+	}
+
+	n, err := strconv.Atoi(string(data))
+	if err != nil || !Type(n).valid() {
+		return errors.New("invalid session correlation type " + string(data))
+	}
+	*t = sessionType(n)
+
+	return nil
+}
+
+// sessionJSON is the JSON form of a Session.
+type sessionJSON struct {
+	Version  int                      `json:"version"`
+	Settings settingsJSON             `json:"settings"`
+	Datasets []datasetJSON            `json:"datasets"`
+	Results  map[string]resultJSON    `json:"results"`
+	Matrices map[string]sessionMatrix `json:"matrices"`
+}
+
+// settingsJSON is the JSON form of Settings.
+type settingsJSON struct {
+	NaNPolicy  NaNPolicy        `json:"nanPolicy"`
+	Imputation ImputationMethod `json:"imputation"`
+	ClipX      *[2]sessionFloat `json:"clipX,omitempty"`
+	ClipY      *[2]sessionFloat `json:"clipY,omitempty"`
+}
+
+// datasetJSON is the JSON form of a datasets.Dataset.
+type datasetJSON struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Attribution string         `json:"attribution,omitempty"`
+	XLabel      string         `json:"xLabel,omitempty"`
+	YLabel      string         `json:"yLabel,omitempty"`
+	XUnits      string         `json:"xUnits,omitempty"`
+	YUnits      string         `json:"yUnits,omitempty"`
+	X           []sessionFloat `json:"x"`
+	Y           []sessionFloat `json:"y"`
+	Valid       []bool         `json:"valid,omitempty"`
+}
+
+// resultJSON is the JSON form of a Result.
+type resultJSON struct {
+	Type        sessionType  `json:"type"`
+	Coefficient sessionFloat `json:"coefficient"`
+	N           int          `json:"n"`
+	Imputed     int          `json:"imputed"`
+	Clipped     int          `json:"clipped"`
+}
+
+// sessionMatrix is the JSON form of a Matrix.
+type sessionMatrix struct {
+	Type         sessionType    `json:"type"`
+	Dim          int            `json:"dim"`
+	Coefficients []sessionFloat `json:"coefficients"`
+	Counts       []int          `json:"counts"`
+}
+
+// Save writes the session to w as JSON.
+//
+// An error is returned if a matrix is nil or writing fails.
+func (s *Session) Save(w io.Writer) error {
+	j := sessionJSON{
+		Version:  sessionVersion,
+		Settings: settingsJSON{NaNPolicy: s.Settings.NaNPolicy, Imputation: s.Settings.Imputation, ClipX: boundsJSON(s.Settings.ClipX), ClipY: boundsJSON(s.Settings.ClipY)},
+		Datasets: make([]datasetJSON, len(s.Datasets)),
+		Results:  make(map[string]resultJSON, len(s.Results)),
+		Matrices: make(map[string]sessionMatrix, len(s.Matrices)),
+	}
+	for i, d := range s.Datasets {
+		j.Datasets[i] = datasetJSON{
+			Name:        d.Name,
+			Description: d.Description,
+			Attribution: d.Attribution,
+			XLabel:      d.XLabel,
+			YLabel:      d.YLabel,
+			XUnits:      d.XUnits,
+			YUnits:      d.YUnits,
+			X:           toSessionFloats(d.X),
+			Y:           toSessionFloats(d.Y),
+			Valid:       d.Valid,
+		}
+	}
+	for name, r := range s.Results {
+		j.Results[name] = resultJSON{Type: sessionType(r.Type), Coefficient: sessionFloat(r.Coefficient), N: r.N, Imputed: r.Imputed, Clipped: r.Clipped}
+	}
+	for name, m := range s.Matrices {
+		if m == nil {
+			return errors.New("session matrix " + strconv.Quote(name) + " is nil")
+		}
+		j.Matrices[name] = sessionMatrix{Type: sessionType(m.Type), Dim: m.dim, Coefficients: toSessionFloats(m.coef), Counts: m.counts}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(j)
+}
+
+// LoadSession reads a session written by Session.Save.
+//
+// An error is returned if the data is not a valid session or was written by
+// an unsupported version.
+func LoadSession(r io.Reader) (*Session, error) {
+	var j sessionJSON
+	if err := json.NewDecoder(r).Decode(&j); err != nil {
+		return nil, err
+	}
+	if j.Version < 1 || j.Version > sessionVersion {
+		return nil, errors.New("unsupported session version " + strconv.Itoa(j.Version))
+	}
+	if (j.Settings.ClipX == nil) != (j.Settings.ClipY == nil) {
+		return nil, errors.New("invalid session: clipping bounds must be set for both x and y")
+	}
+
+	s := NewSession()
+	s.Settings = Settings{
+		NaNPolicy:  j.Settings.NaNPolicy,
+		Imputation: j.Settings.Imputation,
+		ClipX:      boundsFromJSON(j.Settings.ClipX),
+		ClipY:      boundsFromJSON(j.Settings.ClipY),
+	}
+	for _, d := range j.Datasets {
+		if len(d.X) != len(d.Y) {
+			return nil, errors.New("invalid session: dataset " + strconv.Quote(d.Name) + " has x and y of different lengths")
+		}
+		s.Datasets = append(s.Datasets, datasets.Dataset{
+			Name:        d.Name,
+			Description: d.Description,
+			Attribution: d.Attribution,
+			XLabel:      d.XLabel,
+			YLabel:      d.YLabel,
+			XUnits:      d.XUnits,
+			YUnits:      d.YUnits,
+			X:           fromSessionFloats(d.X),
+			Y:           fromSessionFloats(d.Y),
+			Valid:       d.Valid,
+		})
+	}
+	for name, r := range j.Results {
+		s.Results[name] = Result{Type: Type(r.Type), Coefficient: float64(r.Coefficient), N: r.N, Imputed: r.Imputed, Clipped: r.Clipped}
+	}
+	for name, m := range j.Matrices {
+		if m.Dim < 0 || len(m.Coefficients) != m.Dim*m.Dim || len(m.Counts) != m.Dim*m.Dim {
+			return nil, errors.New("invalid session: matrix " + strconv.Quote(name) + " has the wrong number of cells")
+		}
+		s.Matrices[name] = &Matrix{Type: Type(m.Type), dim: m.Dim, coef: fromSessionFloats(m.Coefficients), counts: m.Counts}
+	}
+
+	return s, nil
+}
+
+// SaveFile writes the session to the named file, creating or truncating it.
+func (s *Session) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := s.Save(f); err != nil {
+		f.Close()
+
+		return err
+	}
+
+	return f.Close()
+}
+
+// LoadSessionFile reads a session from the named file.
+func LoadSessionFile(path string) (*Session, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadSession(f)
+}
+
+// boundsJSON returns the JSON form of optional bounds.
+func boundsJSON(b *Bounds) *[2]sessionFloat {
+	if b == nil {
+		return nil
+	}
+
+	return &[2]sessionFloat{sessionFloat(b.Lo), sessionFloat(b.Hi)}
+}
+
+// boundsFromJSON returns the optional bounds encoded by boundsJSON.
+func boundsFromJSON(b *[2]sessionFloat) *Bounds {
+	if b == nil {
+		return nil
+	}
+
+	return &Bounds{Lo: float64(b[0]), Hi: float64(b[1])}
+}
+
+// toSessionFloats converts values for encoding.
+func toSessionFloats(values []float64) []sessionFloat {
+	out := make([]sessionFloat, len(values))
+	for i, v := range values {
+		out[i] = sessionFloat(v)
+	}
+
+	return out
+}
+
+// fromSessionFloats converts decoded values back to float64.
+func fromSessionFloats(values []sessionFloat) []float64 {
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i] = float64(v)
+	}
+
+	return out
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"bytes"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rsned/stats/datasets"
+)
+
+// sameFloats reports whether a and b hold the same values, treating NaNs as
+// equal.
+func sameFloats(a, b []float64) bool {
+	return slices.EqualFunc(a, b, func(x, y float64) bool {
+		return x == y || (math.IsNaN(x) && math.IsNaN(y))
+	})
+}
+
+func TestSessionRoundTrip(t *testing.T) {
+	s := NewSession()
+	s.Datasets = append(s.Datasets, datasets.Dataset{
+		Name:        "sample",
+		Description: "values with gaps",
+		Attribution: "test",
+		XLabel:      "dose",
+		YLabel:      "response",
+		XUnits:      "mg",
+		YUnits:      "",
+		X:           []float64{1, 2, math.NaN(), 4, math.Inf(1)},
+		Y:           []float64{2, 4, 6, math.Inf(-1), 0.1},
+		Valid:       []bool{true, true, false, true, true},
+	})
+	lo, hi := Bounds{Lo: 0, Hi: 10}, Bounds{Lo: -1, Hi: 1}
+	s.Settings = Settings{NaNPolicy: OmitPairwise, Imputation: ImputeMean, ClipX: &lo, ClipY: &hi}
+	s.Results["pearson"] = Result{Type: Pearson, Coefficient: 0.25, N: 3, Imputed: 1, Clipped: 2}
+
+	m, err := CorrelationMatrix([][]float64{{1, 2, 3, 4}, {2, 1, 4, 3}, {5, 5, 5, 5}}, Spearman)
+	if err != nil {
+		t.Fatalf("CorrelationMatrix() unexpected error: %v", err)
+	}
+	s.Matrices["ranks"] = m
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := s.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile() unexpected error: %v", err)
+	}
+	got, err := LoadSessionFile(path)
+	if err != nil {
+		t.Fatalf("LoadSessionFile() unexpected error: %v", err)
+	}
+
+	if len(got.Datasets) != 1 {
+		t.Fatalf("loaded %d datasets, want 1", len(got.Datasets))
+	}
+	d := got.Datasets[0]
+	if d.Name != "sample" || d.Description != "values with gaps" || d.Attribution != "test" {
+		t.Errorf("loaded dataset metadata = %q, %q, %q", d.Name, d.Description, d.Attribution)
+	}
+	if d.XLabel != "dose" || d.YLabel != "response" || d.XUnits != "mg" || d.YUnits != "" {
+		t.Errorf("loaded dataset labels = %q, %q, %q, %q", d.XLabel, d.YLabel, d.XUnits, d.YUnits)
+	}
+	if !sameFloats(d.X, s.Datasets[0].X) || !sameFloats(d.Y, s.Datasets[0].Y) {
+		t.Errorf("loaded dataset values = %v, %v, want %v, %v", d.X, d.Y, s.Datasets[0].X, s.Datasets[0].Y)
+	}
+	if !slices.Equal(d.Valid, s.Datasets[0].Valid) {
+		t.Errorf("loaded dataset Valid = %v, want %v", d.Valid, s.Datasets[0].Valid)
+	}
+
+	st := got.Settings
+	if st.NaNPolicy != OmitPairwise || st.Imputation != ImputeMean || st.ClipX == nil || *st.ClipX != lo || st.ClipY == nil || *st.ClipY != hi {
+		t.Errorf("loaded settings = %+v", st)
+	}
+	if len(st.Options()) != 3 {
+		t.Errorf("Options() returned %d options, want 3", len(st.Options()))
+	}
+
+	if r := got.Results["pearson"]; r != s.Results["pearson"] {
+		t.Errorf("loaded result = %+v, want %+v", r, s.Results["pearson"])
+	}
+
+	gm := got.Matrices["ranks"]
+	if gm == nil || gm.Type != Spearman || gm.Dim() != 3 {
+		t.Fatalf("loaded matrix = %+v", gm)
+	}
+	for i := range 3 {
+		if !sameFloats(gm.Coefficients()[i], m.Coefficients()[i]) || !slices.Equal(gm.Counts()[i], m.Counts()[i]) {
+			t.Errorf("loaded matrix row %d = %v %v, want %v %v", i, gm.Coefficients()[i], gm.Counts()[i], m.Coefficients()[i], m.Counts()[i])
+		}
+	}
+}
+
+func TestSessionEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewSession().Save(&buf); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	got, err := LoadSession(&buf)
+	if err != nil {
+		t.Fatalf("LoadSession() unexpected error: %v", err)
+	}
+	if len(got.Datasets) != 0 || len(got.Results) != 0 || len(got.Matrices) != 0 || got.Settings.ClipX != nil {
+		t.Errorf("LoadSession() = %+v, want an empty session", got)
+	}
+}
+
+func TestSessionVersion1(t *testing.T) {
+	// Version 1 files give correlation types by their numeric values.
+	data := `{"version":1,"results":{"r":{"type":1,"coefficient":0.5,"n":4}},` +
+		`"matrices":{"m":{"type":2,"dim":1,"coefficients":[1],"counts":[4]}}}`
+	got, err := LoadSession(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("LoadSession() of a version 1 session unexpected error: %v", err)
+	}
+	if r := got.Results["r"]; r.Type != Spearman || r.Coefficient != 0.5 || r.N != 4 {
+		t.Errorf("loaded result = %+v, want a Spearman coefficient of 0.5 from 4 pairs", r)
+	}
+	if m := got.Matrices["m"]; m == nil || m.Type != KendallTau {
+		t.Errorf("loaded matrix = %+v, want a KendallTau matrix", m)
+	}
+
+	// Saving writes the current version, with the types named.
+	var buf bytes.Buffer
+	if err := got.Save(&buf); err != nil {
+		t.Fatalf("Save() unexpected error: %v", err)
+	}
+	for _, want := range []string{`"version": 2`, `"type": "spearman"`, `"type": "kendalltau"`} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Save() wrote %s, want it to contain %s", buf.String(), want)
+		}
+	}
+}
+
+func TestSessionErrors(t *testing.T) {
+	s := NewSession()
+	s.Matrices["missing"] = nil
+	if err := s.Save(&bytes.Buffer{}); err == nil {
+		t.Errorf("Save() with a nil matrix expected error but got none")
+	}
+
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "malformed", data: `{"version":`},
+		{name: "wrong version", data: `{"version":99}`},
+		{name: "one-sided clipping", data: `{"version":1,"settings":{"clipX":[0,1]}}`},
+		{name: "ragged dataset", data: `{"version":1,"datasets":[{"name":"d","x":[1,2],"y":[1]}]}`},
+		{name: "bad number", data: `{"version":1,"datasets":[{"name":"d","x":["one"],"y":[1]}]}`},
+		{name: "short matrix", data: `{"version":1,"matrices":{"m":{"type":0,"dim":2,"coefficients":[1,0,0],"counts":[1,1,1,1]}}}`},
+		{name: "unknown type", data: `{"version":1,"results":{"r":{"type":7,"coefficient":0.5}}}`},
+		{name: "unknown type name", data: `{"version":2,"results":{"r":{"type":"cosine","coefficient":0.5}}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := LoadSession(strings.NewReader(tt.data)); err == nil {
+				t.Errorf("LoadSession() expected error but got none")
+			}
+		})
+	}
+
+	if _, err := LoadSessionFile(filepath.Join(t.TempDir(), "absent.json")); err == nil {
+		t.Errorf("LoadSessionFile() of a missing file expected error but got none")
+	}
+}
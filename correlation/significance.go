@@ -0,0 +1,416 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// Alternative represents the alternative hypothesis used in a significance test.
+type Alternative int
+
+const (
+	// TwoSided tests whether the population value differs from the null value
+	// in either direction. This is the default value.
+	TwoSided Alternative = iota
+	// Less tests whether the population value is less than the null value.
+	Less
+	// Greater tests whether the population value is greater than the null value.
+	Greater
+)
+
+// String returns the string representation of the Alternative.
+func (a Alternative) String() string {
+	switch a {
+	case TwoSided:
+		return "two-sided"
+	case Less:
+		return "less"
+	case Greater:
+		return "greater"
+	default:
+		return "Unknown"
+	}
+}
+
+// FisherZ returns the Fisher z-transformation of the correlation coefficient r,
+// z = atanh(r) = 0.5 * ln((1+r)/(1-r)).
+//
+// The transformed value is approximately normally distributed with standard
+// error 1/sqrt(n-3), which makes it the usual basis for tests and confidence
+// intervals on Pearson's r.
+func FisherZ(r float64) float64 {
+	return math.Atanh(r)
+}
+
+// FisherZInverse maps a Fisher z value back to the correlation scale, r = tanh(z).
+func FisherZInverse(z float64) float64 {
+	return math.Tanh(z)
+}
+
+// FisherZTest tests the null hypothesis that the population correlation rho
+// equals rho0, given a correlation coefficient r observed on n pairs.
+//
+// The test statistic is
+//
+//	z = (atanh(r) - atanh(rho0)) * sqrt(n-3)
+//
+// which is compared against the standard normal distribution. Setting rho0 to
+// 0 gives the familiar test of no correlation, but any published benchmark
+// value in (-1, 1) may be used.
+//
+// It returns the z statistic and the p-value for the requested alternative.
+//
+// An error is returned if n is less than 4 or if r or rho0 fall outside (-1, 1).
+func FisherZTest(r, rho0 float64, n int, alt Alternative) (float64, float64, error) {
+	if n < 4 {
+		return 0, 0, errors.New("Fisher z test requires at least 4 data points")
+	}
+	if math.IsNaN(r) || r <= -1 || r >= 1 {
+		return 0, 0, errors.New("correlation coefficient must be in the open interval (-1, 1)")
+	}
+	if math.IsNaN(rho0) || rho0 <= -1 || rho0 >= 1 {
+		return 0, 0, errors.New("null correlation must be in the open interval (-1, 1)")
+	}
+
+	z := (FisherZ(r) - FisherZ(rho0)) * math.Sqrt(float64(n-3))
+
+	p, err := normalPValue(z, alt)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return z, p, nil
+}
+
+// normalCDF returns the cumulative distribution function of the standard
+// normal distribution at x.
+func normalCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+// normalPValue returns the p-value of the standard normal statistic z
+// under the given alternative hypothesis.
+func normalPValue(z float64, alt Alternative) (float64, error) {
+	switch alt {
+	case TwoSided:
+		return math.Erfc(math.Abs(z) / math.Sqrt2), nil
+	case Less:
+		return normalCDF(z), nil
+	case Greater:
+		return normalCDF(-z), nil
+	default:
+		return 0, errors.New("unsupported alternative hypothesis")
+	}
+}
+
+// PValue returns the two-sided p-value for the null hypothesis of no
+// association, given a coefficient r of the given correlation type that was
+// observed on n pairs.
+//
+// Pearson and Spearman coefficients use the t statistic
+// t = r * sqrt((n-2)/(1-r²)) with n-2 degrees of freedom. Kendall's Tau uses
+// the large sample normal approximation z = 3τ*sqrt(n(n-1))/sqrt(2(2n+5)).
+//
+// Callers working with pairwise deleted data must supply the n that was
+// actually used to compute r, not the length of the original inputs.
+//
+// An error is returned if n is too small for the test, r is outside [-1, 1],
+// or no p-value approximation is available for the correlation type.
+func PValue(r float64, n int, correlationType Type) (float64, error) {
+	if math.IsNaN(r) || r < -1 || r > 1 {
+		return 0, errors.New("correlation coefficient must be in the interval [-1, 1]")
+	}
+
+	switch correlationType {
+	case Pearson, Spearman:
+		if n < 3 {
+			return 0, errors.New("p-value requires at least 3 data points")
+		}
+		den := 1 - r*r
+		if den <= 0 {
+			return 0, nil
+		}
+		df := float64(n - 2)
+		t := r * math.Sqrt(df/den)
+
+		return studentTTwoSided(t, df), nil
+	case KendallTau:
+		if n < 2 {
+			return 0, errors.New("p-value requires at least 2 data points")
+		}
+		nf := float64(n)
+		z := 3 * r * math.Sqrt(nf*(nf-1)) / math.Sqrt(2*(2*nf+5))
+
+		return normalPValue(z, TwoSided)
+	default:
+		return 0, errors.New("p-value not supported for correlation type " + correlationType.String())
+	}
+}
+
+// studentTTwoSided returns the two-sided tail probability P(|T| > |t|) for
+// Student's t distribution with df degrees of freedom.
+func studentTTwoSided(t, df float64) float64 {
+	if math.IsInf(t, 0) {
+		return 0
+	}
+
+	return regularizedIncompleteBeta(df/(df+t*t), df/2, 0.5)
+}
+
+// regularizedIncompleteBeta returns I_x(a, b), the regularized incomplete
+// beta function, evaluated with the continued fraction expansion from
+// Numerical Recipes (section 6.4).
+func regularizedIncompleteBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+
+	lga, _ := math.Lgamma(a)
+	lgb, _ := math.Lgamma(b)
+	lgab, _ := math.Lgamma(a + b)
+	front := math.Exp(lgab - lga - lgb + a*math.Log(x) + b*math.Log1p(-x))
+
+	// The continued fraction converges rapidly for x < (a+1)/(a+b+2),
+	// otherwise use the symmetry relation I_x(a,b) = 1 - I_{1-x}(b,a).
+	if x < (a+1)/(a+b+2) {
+		return front * betaContinuedFraction(x, a, b) / a
+	}
+
+	return 1 - front*betaContinuedFraction(1-x, b, a)/b
+}
+
+// betaContinuedFraction evaluates the continued fraction for the incomplete
+// beta function using the modified Lentz method.
+func betaContinuedFraction(x, a, b float64) float64 {
+	const (
+		maxIterations = 300
+		epsilon       = 1e-15
+		tiny          = 1e-300
+	)
+
+	qab := a + b
+	qap := a + 1
+	qam := a - 1
+	c := 1.0
+	d := 1 - qab*x/qap
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+
+	for m := 1; m <= maxIterations; m++ {
+		mf := float64(m)
+		m2 := 2 * mf
+
+		// Even step of the recurrence.
+		aa := mf * (b - mf) * x / ((qam + m2) * (a + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+
+		// Odd step of the recurrence.
+		aa = -(a + mf) * (qab + mf) * x / ((a + m2) * (qap + m2))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+
+		if math.Abs(del-1) < epsilon {
+			break
+		}
+	}
+
+	return h
+}
+
+// ChiSquareResult holds the outcome of a test whose statistic follows a
+// chi-square distribution under the null hypothesis.
+type ChiSquareResult struct {
+	// Statistic is the value of the chi-square test statistic.
+	Statistic float64
+	// DF is the degrees of freedom of the reference distribution.
+	DF float64
+	// PValue is the upper tail probability of Statistic.
+	PValue float64
+}
+
+// chiSquareSurvival returns P(X > x) for a chi-square random variable X
+// with df degrees of freedom.
+func chiSquareSurvival(x, df float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	if math.IsInf(x, 1) {
+		return 0
+	}
+
+	return regularizedGammaQ(df/2, x/2)
+}
+
+// regularizedGammaQ returns Q(a, x) = 1 - P(a, x), the regularized upper
+// incomplete gamma function, using the series expansion for x < a+1 and
+// the continued fraction otherwise (Numerical Recipes section 6.2).
+func regularizedGammaQ(a, x float64) float64 {
+	const (
+		maxIterations = 500
+		epsilon       = 1e-15
+		tiny          = 1e-300
+	)
+
+	lga, _ := math.Lgamma(a)
+	front := math.Exp(-x + a*math.Log(x) - lga)
+
+	if x < a+1 {
+		sum := 1 / a
+		del := sum
+		ap := a
+		for range maxIterations {
+			ap++
+			del *= x / ap
+			sum += del
+			if math.Abs(del) < math.Abs(sum)*epsilon {
+				break
+			}
+		}
+
+		return 1 - sum*front
+	}
+
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i <= maxIterations; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < epsilon {
+			break
+		}
+	}
+
+	return front * h
+}
+
+// FisherZCompare tests the null hypothesis that two correlation coefficients,
+// r1 observed on n1 pairs and r2 observed on n2 pairs from independent
+// samples, estimate the same population correlation.
+//
+// The test statistic is
+//
+//	z = (atanh(r1) - atanh(r2)) / sqrt(1/(n1-3) + 1/(n2-3))
+//
+// which is compared against the standard normal distribution.
+//
+// It returns the z statistic and the p-value for the requested alternative.
+//
+// An error is returned if either sample has fewer than 4 points or either
+// coefficient falls outside (-1, 1).
+func FisherZCompare(r1 float64, n1 int, r2 float64, n2 int, alt Alternative) (float64, float64, error) {
+	if n1 < 4 || n2 < 4 {
+		return 0, 0, errors.New("Fisher z comparison requires at least 4 data points in each sample")
+	}
+	if math.IsNaN(r1) || r1 <= -1 || r1 >= 1 || math.IsNaN(r2) || r2 <= -1 || r2 >= 1 {
+		return 0, 0, errors.New("correlation coefficients must be in the open interval (-1, 1)")
+	}
+
+	se := math.Sqrt(1/float64(n1-3) + 1/float64(n2-3))
+	z := (FisherZ(r1) - FisherZ(r2)) / se
+
+	p, err := normalPValue(z, alt)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return z, p, nil
+}
+
+// ConfidenceInterval returns the lower and upper bounds of a two-sided
+// confidence interval at the given level, such as 0.95, for the population
+// correlation, given a coefficient r of the given correlation type that was
+// observed on n pairs.
+//
+// The interval is formed on the Fisher z scale and mapped back with tanh.
+// Pearson's r uses the standard error 1/sqrt(n-3). Spearman's rho and
+// Kendall's tau use the standard errors sqrt(1.06/(n-3)) and
+// sqrt(0.437/(n-4)) of Fieller, Hartley and Pearson (1957).
+//
+// An error is returned if n is too small, r is outside [-1, 1], the level is
+// not in (0, 1), or no interval is available for the correlation type.
+func ConfidenceInterval(r float64, n int, correlationType Type, level float64) (float64, float64, error) {
+	if math.IsNaN(r) || r < -1 || r > 1 {
+		return 0, 0, errors.New("correlation coefficient must be in the interval [-1, 1]")
+	}
+	if math.IsNaN(level) || level <= 0 || level >= 1 {
+		return 0, 0, errors.New("confidence level must be in the open interval (0, 1)")
+	}
+
+	var variance float64
+	switch correlationType {
+	case Pearson:
+		if n < 4 {
+			return 0, 0, errors.New("confidence interval requires at least 4 data points")
+		}
+		variance = 1 / float64(n-3)
+	case Spearman:
+		if n < 4 {
+			return 0, 0, errors.New("confidence interval requires at least 4 data points")
+		}
+		variance = 1.06 / float64(n-3)
+	case KendallTau:
+		if n < 5 {
+			return 0, 0, errors.New("confidence interval requires at least 5 data points")
+		}
+		variance = 0.437 / float64(n-4)
+	default:
+		return 0, 0, errors.New("confidence interval not supported for correlation type " + correlationType.String())
+	}
+
+	// The two-sided normal critical value, Φ⁻¹((1+level)/2).
+	half := math.Sqrt2 * math.Erfinv(level) * math.Sqrt(variance)
+	z := FisherZ(r)
+
+	return FisherZInverse(z - half), FisherZInverse(z + half), nil
+}
@@ -0,0 +1,266 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFisherZRoundTrip(t *testing.T) {
+	for _, r := range []float64{-0.99, -0.5, 0, 0.25, 0.816, 0.999} {
+		if got := FisherZInverse(FisherZ(r)); math.Abs(got-r) > 1e-12 {
+			t.Errorf("FisherZInverse(FisherZ(%v)) = %v, want %v", r, got, r)
+		}
+	}
+}
+
+func TestFisherZTest(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     float64
+		rho0  float64
+		n     int
+		alt   Alternative
+		wantZ float64
+		wantP float64
+	}{
+		{
+			name:  "above benchmark two-sided",
+			r:     0.5,
+			rho0:  0.3,
+			n:     50,
+			alt:   TwoSided,
+			wantZ: 1.643894,
+			wantP: 0.100198,
+		},
+		{
+			name:  "above benchmark greater",
+			r:     0.5,
+			rho0:  0.3,
+			n:     50,
+			alt:   Greater,
+			wantZ: 1.643894,
+			wantP: 0.050099,
+		},
+		{
+			name:  "below benchmark two-sided",
+			r:     0.2,
+			rho0:  0.6,
+			n:     30,
+			alt:   TwoSided,
+			wantZ: -2.548269,
+			wantP: 0.010826,
+		},
+		{
+			name:  "below benchmark less",
+			r:     0.2,
+			rho0:  0.6,
+			n:     30,
+			alt:   Less,
+			wantZ: -2.548269,
+			wantP: 0.005413,
+		},
+		{
+			name:  "zero null",
+			r:     0.4,
+			rho0:  0,
+			n:     28,
+			alt:   TwoSided,
+			wantZ: 2.118245,
+			wantP: 0.034154,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			z, p, err := FisherZTest(tt.r, tt.rho0, tt.n, tt.alt)
+			if err != nil {
+				t.Fatalf("FisherZTest() unexpected error: %v", err)
+			}
+			if math.Abs(z-tt.wantZ) > 1e-6 {
+				t.Errorf("FisherZTest() z = %v, want %v", z, tt.wantZ)
+			}
+			if math.Abs(p-tt.wantP) > 1e-6 {
+				t.Errorf("FisherZTest() p = %v, want %v", p, tt.wantP)
+			}
+		})
+	}
+}
+
+func TestFisherZTestErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		r    float64
+		rho0 float64
+		n    int
+		alt  Alternative
+	}{
+		{name: "too few points", r: 0.5, rho0: 0.3, n: 3, alt: TwoSided},
+		{name: "r of one", r: 1, rho0: 0.3, n: 10, alt: TwoSided},
+		{name: "r is NaN", r: math.NaN(), rho0: 0.3, n: 10, alt: TwoSided},
+		{name: "rho0 of minus one", r: 0.5, rho0: -1, n: 10, alt: TwoSided},
+		{name: "bad alternative", r: 0.5, rho0: 0.3, n: 10, alt: Alternative(99)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := FisherZTest(tt.r, tt.rho0, tt.n, tt.alt); err == nil {
+				t.Errorf("FisherZTest(%v, %v, %d, %v) expected error but got none", tt.r, tt.rho0, tt.n, tt.alt)
+			}
+		})
+	}
+}
+
+func TestPValue(t *testing.T) {
+	// Reference values are the two-sided critical points of Student's t,
+	// converted back to r via r = t / sqrt(t² + df).
+	rFromT := func(tv float64, n int) float64 {
+		return tv / math.Sqrt(tv*tv+float64(n-2))
+	}
+
+	tests := []struct {
+		name     string
+		r        float64
+		n        int
+		corrType Type
+		want     float64
+	}{
+		{name: "t 0.05 df 10", r: rFromT(2.228139, 12), n: 12, corrType: Pearson, want: 0.05},
+		{name: "t 0.01 df 10", r: rFromT(3.169273, 12), n: 12, corrType: Pearson, want: 0.01},
+		{name: "t 0.05 df 5 negative", r: -rFromT(2.570582, 7), n: 7, corrType: Spearman, want: 0.05},
+		{name: "zero correlation", r: 0, n: 20, corrType: Pearson, want: 1},
+		{name: "perfect correlation", r: 1, n: 5, corrType: Pearson, want: 0},
+		{name: "kendall normal approximation", r: 0.5, n: 10, corrType: KendallTau, want: 0.0441713},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PValue(tt.r, tt.n, tt.corrType)
+			if err != nil {
+				t.Fatalf("PValue() unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-5 {
+				t.Errorf("PValue(%v, %d, %v) = %v, want %v", tt.r, tt.n, tt.corrType, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := PValue(0.5, 2, Pearson); err == nil {
+		t.Errorf("PValue() with n=2 expected error but got none")
+	}
+	if _, err := PValue(1.5, 10, Pearson); err == nil {
+		t.Errorf("PValue() with r=1.5 expected error but got none")
+	}
+	if _, err := PValue(0.5, 10, GoodmanKruskal); err == nil {
+		t.Errorf("PValue() for Goodman and Kruskal expected error but got none")
+	}
+}
+
+func TestChiSquareSurvival(t *testing.T) {
+	tests := []struct {
+		x    float64
+		df   float64
+		want float64
+	}{
+		{x: 3.841459, df: 1, want: 0.05},
+		{x: 5.991465, df: 2, want: 0.05},
+		{x: 11.070498, df: 5, want: 0.05},
+		{x: 23.209251, df: 10, want: 0.01},
+		{x: 2, df: 2, want: math.Exp(-1)},
+		{x: 0, df: 3, want: 1},
+	}
+
+	for _, tt := range tests {
+		if got := chiSquareSurvival(tt.x, tt.df); math.Abs(got-tt.want) > 1e-6 {
+			t.Errorf("chiSquareSurvival(%v, %v) = %v, want %v", tt.x, tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestFisherZCompare(t *testing.T) {
+	// Equal sample sizes: z = (atanh(r1) - atanh(r2)) / sqrt(2/(n-3)).
+	z, p, err := FisherZCompare(0.6, 50, 0.3, 50, TwoSided)
+	if err != nil {
+		t.Fatalf("FisherZCompare() unexpected error: %v", err)
+	}
+	wantZ := (math.Atanh(0.6) - math.Atanh(0.3)) / math.Sqrt(2.0/47)
+	if math.Abs(z-wantZ) > 1e-12 {
+		t.Errorf("FisherZCompare() z = %v, want %v", z, wantZ)
+	}
+	if math.Abs(p-math.Erfc(wantZ/math.Sqrt2)) > 1e-12 {
+		t.Errorf("FisherZCompare() p = %v, want %v", p, math.Erfc(wantZ/math.Sqrt2))
+	}
+
+	if _, _, err := FisherZCompare(0.6, 3, 0.3, 50, TwoSided); err == nil {
+		t.Errorf("FisherZCompare() with n1=3 expected error but got none")
+	}
+	if _, _, err := FisherZCompare(0.6, 50, -1, 50, TwoSided); err == nil {
+		t.Errorf("FisherZCompare() with r2=-1 expected error but got none")
+	}
+}
+
+func TestConfidenceInterval(t *testing.T) {
+	tests := []struct {
+		name   string
+		r      float64
+		n      int
+		typ    Type
+		level  float64
+		lo, hi float64
+	}{
+		// atanh(0.5) ± 1.959964/sqrt(47), mapped back with tanh.
+		{name: "Pearson 95%", r: 0.5, n: 50, typ: Pearson, level: 0.95, lo: 0.257488, hi: 0.683256},
+		{name: "Pearson zero", r: 0, n: 28, typ: Pearson, level: 0.95, lo: -0.373077, hi: 0.373077},
+		{name: "Spearman", r: 0.5, n: 50, typ: Spearman, level: 0.95, lo: 0.249579, hi: 0.687736},
+		{name: "Kendall", r: 0.3, n: 40, typ: KendallTau, level: 0.90, lo: 0.127596, hi: 0.454807},
+		{name: "perfect", r: 1, n: 10, typ: Pearson, level: 0.99, lo: 1, hi: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lo, hi, err := ConfidenceInterval(tt.r, tt.n, tt.typ, tt.level)
+			if err != nil {
+				t.Fatalf("ConfidenceInterval() unexpected error: %v", err)
+			}
+			if math.Abs(lo-tt.lo) > 1e-6 || math.Abs(hi-tt.hi) > 1e-6 {
+				t.Errorf("ConfidenceInterval() = [%v, %v], want [%v, %v]", lo, hi, tt.lo, tt.hi)
+			}
+		})
+	}
+
+	errorTests := []struct {
+		name  string
+		r     float64
+		n     int
+		typ   Type
+		level float64
+	}{
+		{name: "r out of range", r: 1.5, n: 10, typ: Pearson, level: 0.95},
+		{name: "NaN r", r: math.NaN(), n: 10, typ: Pearson, level: 0.95},
+		{name: "level zero", r: 0.5, n: 10, typ: Pearson, level: 0},
+		{name: "level one", r: 0.5, n: 10, typ: Pearson, level: 1},
+		{name: "small n Pearson", r: 0.5, n: 3, typ: Pearson, level: 0.95},
+		{name: "small n Kendall", r: 0.5, n: 4, typ: KendallTau, level: 0.95},
+		{name: "gamma", r: 0.5, n: 50, typ: GoodmanKruskal, level: 0.95},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ConfidenceInterval(tt.r, tt.n, tt.typ, tt.level); err == nil {
+				t.Errorf("ConfidenceInterval() expected error but got none")
+			}
+		})
+	}
+}
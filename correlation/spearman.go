@@ -34,7 +34,7 @@ import (
 //
 // Key Features:
 //   - Rank-based correlation: Converts input data to ranks and then applies
-//     Pearson correlation to theranks
+//     Pearson correlation to the ranks
 //   - Tied value handling: Uses fractional ranking (average of tied ranks)
 //     which is the standard approach
 //   - Monotonic relationship detection: Correctly identifies monotonic
@@ -58,7 +58,12 @@ import (
 // (Pearson) and monotonic correlation (Spearman), making it suitable for
 // analyzing ranked data and non-linear monotonic relationships.
 func Spearmans[T Numeric](x, y []T) (float64, error) {
-	return 0, errors.New("not implemented")
+	st, err := numericRankStats(x, y)
+	if err != nil {
+		return 0, err
+	}
+
+	return clamped(st.spearman())
 }
 
 // SpearmansBig calculates Spearman's rank correlation coefficient
@@ -67,8 +72,13 @@ func Spearmans[T Numeric](x, y []T) (float64, error) {
 // Spearman's rank correlation measures the monotonic relationship
 // between two measured quantities. It is based on the ranks of the
 // data rather than the actual values.
-func SpearmansBig[T BigNumeric](_, _ []T) (float64, error) {
-	return 0, errors.New("not implemented")
+func SpearmansBig[T BigNumeric](x, y []T) (float64, error) {
+	st, err := bigRankStats(x, y)
+	if err != nil {
+		return 0, err
+	}
+
+	return clamped(st.spearman())
 }
 
 // SpearmansMixed calculates Spearman's rank correlation coefficient
@@ -15,10 +15,57 @@
 package correlation
 
 import (
+	"math"
+	"math/big"
 	"math/rand"
 	"testing"
 )
 
+func TestSpearmans(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []float64
+		want float64
+	}{
+		{name: "monotonic non-linear", x: []float64{1, 2, 3, 4, 5}, y: []float64{1, 8, 27, 64, 125}, want: 1},
+		{name: "reversed", x: []float64{1, 2, 3, 4, 5}, y: []float64{50, 40, 30, 20, 10}, want: -1},
+		// Ranks (4,1,2,3,5,6) and (6,1,3,2,5,4) give Σd² = 10.
+		{name: "no ties", x: []float64{43, 21, 25, 42, 57, 59}, y: []float64{99, 65, 79, 75, 87, 81}, want: 5.0 / 7},
+		// Average ranks (1.5,1.5,3,4) and (1,2.5,2.5,4).
+		{name: "ties", x: []float64{1, 1, 2, 3}, y: []float64{1, 2, 2, 3}, want: 5.0 / 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Spearmans(tt.x, tt.y)
+			if err != nil {
+				t.Fatalf("Spearmans() unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("Spearmans() = %v, want %v", got, tt.want)
+			}
+
+			bx := make([]*big.Float, len(tt.x))
+			by := make([]*big.Float, len(tt.y))
+			for i := range tt.x {
+				bx[i] = big.NewFloat(tt.x[i])
+				by[i] = big.NewFloat(tt.y[i])
+			}
+			got, err = SpearmansBig(bx, by)
+			if err != nil {
+				t.Fatalf("SpearmansBig() unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("SpearmansBig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := Spearmans([]float64{2, 2, 2}, []float64{1, 2, 3}); err == nil {
+		t.Errorf("Spearmans() with constant x expected error but got none")
+	}
+}
+
 func BenchmarkSpearmans(b *testing.B) {
 	const limit = 10000
 	x := make([]float64, limit)
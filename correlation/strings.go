@@ -0,0 +1,269 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// NumberFormat describes how numbers are written in a column of string
+// input, so that values exported from spreadsheets and reports can be parsed
+// without cleaning them first. The zero value accepts plain decimal numbers
+// such as "42", "-3.5", "6.02e23" or "1e-400".
+type NumberFormat struct {
+	// DecimalSeparator separates the integer and fractional parts. The zero
+	// value means '.'. Set it to ',' for values such as "1,5" or "1,2E+400".
+	DecimalSeparator rune
+	// GroupSeparators lists the characters used to group digits, such as
+	// "," in "1,234,567" or " " and "'" in other locales. They are removed
+	// before parsing and must not include the decimal separator.
+	GroupSeparators string
+	// Percent allows a trailing '%', which divides the value by 100 so that
+	// "12.5%" parses as 0.125. Values without the sign are not scaled.
+	Percent bool
+	// CurrencySymbols lists symbols or codes, such as "$", "€" or "USD",
+	// that are removed when they appear before or after the number,
+	// including after a leading sign as in "-$5".
+	CurrencySymbols []string
+}
+
+// validate checks that the format is unambiguous.
+func (f NumberFormat) validate() error {
+	if strings.ContainsRune(f.GroupSeparators, f.decimal()) {
+		return errors.New("group separators must not include the decimal separator")
+	}
+	for _, c := range f.CurrencySymbols {
+		if c == "" {
+			return errors.New("currency symbols cannot be empty")
+		}
+	}
+
+	return nil
+}
+
+// decimal returns the decimal separator, applying the default.
+func (f NumberFormat) decimal() rune {
+	if f.DecimalSeparator == 0 {
+		return '.'
+	}
+
+	return f.DecimalSeparator
+}
+
+// normalize rewrites s in the plain form accepted by strconv.ParseFloat and
+// big.Float.SetString, and reports whether it carried a percent sign.
+func (f NumberFormat) normalize(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+
+	percent := false
+	if f.Percent {
+		if t, ok := strings.CutSuffix(s, "%"); ok {
+			s = strings.TrimSpace(t)
+			percent = true
+		}
+	}
+
+	sign := ""
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+	for _, c := range f.CurrencySymbols {
+		if t, ok := strings.CutPrefix(s, c); ok {
+			s = strings.TrimSpace(t)
+		}
+		if t, ok := strings.CutSuffix(s, c); ok {
+			s = strings.TrimSpace(t)
+		}
+	}
+	if sign == "" && len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		sign, s = s[:1], s[1:]
+	}
+
+	for _, g := range f.GroupSeparators {
+		s = strings.ReplaceAll(s, string(g), "")
+	}
+	if d := f.decimal(); d != '.' {
+		if strings.ContainsRune(s, '.') {
+			// A '.' that is neither the decimal nor a group separator is
+			// invalid, so make sure it is rejected rather than reinterpreted.
+			return "", percent
+		}
+		s = strings.ReplaceAll(s, string(d), ".")
+	}
+
+	return sign + s, percent
+}
+
+// CorrelateStrings calculates the specified correlation coefficient between
+// two datasets x and y given as decimal strings, such as "42", "-3.5",
+// "6.02e23" or "1e-400".
+//
+// Values are parsed as float64 where that is lossless. If any value overflows
+// float64, underflows it to zero, or falls in the subnormal range, both
+// datasets are parsed as *big.Float instead and the calculation is done with
+// CorrelateBig. Leading and trailing whitespace is ignored.
+//
+// Returns an error if the slices have different lengths, are empty, if any
+// value cannot be parsed, or if the correlation type is not supported.
+func CorrelateStrings(x, y []string, correlationType Type) (float64, error) {
+	var plain NumberFormat
+
+	return CorrelateStringsFormat(x, y, plain, plain, correlationType)
+}
+
+// CorrelateStringsFormat is like CorrelateStrings, but parses the values of
+// x and y using their own NumberFormat, so columns written with percent
+// signs, currency symbols, digit grouping or a decimal comma can be
+// correlated directly.
+//
+// Returns an error if either format is invalid, or for any of the reasons
+// CorrelateStrings does.
+func CorrelateStringsFormat(x, y []string, xFormat, yFormat NumberFormat, correlationType Type) (float64, error) {
+	if len(x) != len(y) {
+		return 0, errors.New("slices must have the same length")
+	}
+	if len(x) == 0 {
+		return 0, errors.New("slices cannot be empty")
+	}
+	if err := xFormat.validate(); err != nil {
+		return 0, errors.New("x format: " + err.Error())
+	}
+	if err := yFormat.validate(); err != nil {
+		return 0, errors.New("y format: " + err.Error())
+	}
+
+	fx, xFits, err := parseFloats(x, "x", xFormat)
+	if err != nil {
+		return 0, err
+	}
+	fy, yFits, err := parseFloats(y, "y", yFormat)
+	if err != nil {
+		return 0, err
+	}
+
+	// big.Float has no NaN, so data with a NaN is left to the float64
+	// calculation, whose result depends only on where the NaNs are.
+	if xFits && yFits || hasNaN(fx) || hasNaN(fy) {
+		return Correlate(fx, fy, correlationType)
+	}
+
+	bx, err := parseBigFloats(x, "x", xFormat)
+	if err != nil {
+		return 0, err
+	}
+	by, err := parseBigFloats(y, "y", yFormat)
+	if err != nil {
+		return 0, err
+	}
+
+	return CorrelateBig(bx, by, correlationType)
+}
+
+// parseFloats parses data, written in the given format, as float64 values.
+// It reports whether every value was represented without overflow or loss of
+// range; when it was not, the returned values must not be used and the
+// caller should switch to big.Float.
+func parseFloats(data []string, name string, format NumberFormat) ([]float64, bool, error) {
+	out := make([]float64, len(data))
+	fits := true
+
+	for i := range data {
+		s, percent := format.normalize(data[i])
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			var numErr *strconv.NumError
+			if errors.As(err, &numErr) && errors.Is(numErr.Err, strconv.ErrRange) {
+				fits = false
+
+				continue
+			}
+
+			return nil, false, invalidNumberError(name, i, data[i])
+		}
+		if percent {
+			f /= 100
+		}
+
+		switch {
+		case math.IsNaN(f) || math.IsInf(f, 0):
+			// Explicit "NaN" and "Inf" values are passed through as is.
+		case f == 0:
+			// ParseFloat silently rounds values below the subnormal range to
+			// zero, so confirm the input really was zero.
+			b, ok := new(big.Float).SetPrec(decimalPrecision(s)).SetString(s)
+			if ok && b.Sign() != 0 {
+				fits = false
+			}
+		case math.Abs(f) < minNormalFloat64:
+			fits = false
+		}
+		out[i] = f
+	}
+
+	return out, fits, nil
+}
+
+// parseBigFloats parses data, written in the given format, as *big.Float
+// values.
+func parseBigFloats(data []string, name string, format NumberFormat) ([]*big.Float, error) {
+	hundred := big.NewFloat(100)
+	out := make([]*big.Float, len(data))
+	for i := range data {
+		s, percent := format.normalize(data[i])
+		b, ok := new(big.Float).SetPrec(decimalPrecision(s)).SetString(s)
+		if !ok {
+			return nil, invalidNumberError(name, i, data[i])
+		}
+		if percent {
+			b.Quo(b, hundred)
+		}
+		out[i] = b
+	}
+
+	return out, nil
+}
+
+// decimalPrecision returns the precision in bits to parse the number s
+// with: log2(10) bits for each decimal digit of its mantissa, or 4 for each
+// hexadecimal one, and 32 guard bits, but never less than the 64 bits that
+// big.Float would use by default.
+func decimalPrecision(s string) uint {
+	s = strings.TrimLeft(s, "+-")
+	bitsPerDigit, exp := math.Log2(10), "eE"
+	if len(s) > 1 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		s = s[2:]
+		bitsPerDigit, exp = 4, "pP"
+	}
+	if i := strings.IndexAny(s, exp); i >= 0 {
+		s = s[:i]
+	}
+	digits := len(s) - strings.Count(s, ".") - strings.Count(s, "_")
+
+	return max(64, uint(math.Ceil(float64(digits)*bitsPerDigit))+32)
+}
+
+// minNormalFloat64 is the smallest positive normal float64 value. Values
+// below it lose precision as subnormals.
+const minNormalFloat64 = 0x1p-1022
+
+// invalidNumberError returns an error describing an unparseable value in
+// the named input.
+func invalidNumberError(name string, i int, s string) error {
+	return errors.New("invalid number " + strconv.Quote(s) + " at index " + strconv.Itoa(i) + " in " + name)
+}
@@ -0,0 +1,266 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCorrelateStrings(t *testing.T) {
+	tests := []struct {
+		name     string
+		x        []string
+		y        []string
+		expected float64
+		wantErr  bool
+	}{
+		{
+			name:     "plain decimals",
+			x:        []string{"43", "21", "25", "42", "57", "59"},
+			y:        []string{"99", "65", "79", "75", "87", "81"},
+			expected: 0.529,
+		},
+		{
+			name:     "scientific notation within float64",
+			x:        []string{"1e3", "2.0E3", " 3e+3 ", "4000", "5e3"},
+			y:        []string{"-1e-3", "-2e-3", "-3e-3", "-4e-3", "-5e-3"},
+			expected: -1,
+		},
+		{
+			name:     "values beyond float64 range",
+			x:        []string{"1e1000", "2e1000", "3e1000", "4e1000", "5e1000"},
+			y:        []string{"3e1000", "6e1000", "7e1000", "3e1000", "9e1000"},
+			expected: 0.545705,
+		},
+		{
+			name:     "values below float64 range",
+			x:        []string{"1e-400", "2e-400", "3e-400", "4e-400", "5e-400"},
+			y:        []string{"2", "4", "6", "8", "10"},
+			expected: 1,
+		},
+		{
+			name:     "subnormal values",
+			x:        []string{"1e-310", "2e-310", "3e-310", "4e-310", "5e-310"},
+			y:        []string{"5", "4", "3", "2", "1"},
+			expected: -1,
+		},
+		{
+			name:    "invalid number",
+			x:       []string{"1", "two", "3"},
+			y:       []string{"1", "2", "3"},
+			wantErr: true,
+		},
+		{
+			name:    "different lengths",
+			x:       []string{"1", "2", "3"},
+			y:       []string{"1", "2"},
+			wantErr: true,
+		},
+		{
+			name:    "empty",
+			x:       []string{},
+			y:       []string{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CorrelateStrings(tt.x, tt.y, Pearson)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("CorrelateStrings() expected error but got none")
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("CorrelateStrings() unexpected error: %v", err)
+			}
+			if math.Abs(got-tt.expected) > 0.001 {
+				t.Errorf("CorrelateStrings() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseFloatsFits(t *testing.T) {
+	tests := []struct {
+		in   []string
+		fits bool
+	}{
+		{in: []string{"1", "-2.5", "0", "-0", "0.0e10"}, fits: true},
+		{in: []string{"1", "1e309"}, fits: false},
+		{in: []string{"1", "-1e-330"}, fits: false},
+		{in: []string{"1", "1e-320"}, fits: false},
+		{in: []string{"2.2250738585072014e-308"}, fits: true},
+	}
+
+	for _, tt := range tests {
+		_, fits, err := parseFloats(tt.in, "x", NumberFormat{})
+		if err != nil {
+			t.Fatalf("parseFloats(%v) unexpected error: %v", tt.in, err)
+		}
+		if fits != tt.fits {
+			t.Errorf("parseFloats(%v) fits = %v, want %v", tt.in, fits, tt.fits)
+		}
+	}
+}
+
+func TestCorrelateStringsFormat(t *testing.T) {
+	want, err := Pearsons([]float64{0.125, 0.5, 0.25, 1}, []float64{1234.5, 5000, 2500.25, 9999})
+	if err != nil {
+		t.Fatalf("Pearsons() unexpected error: %v", err)
+	}
+
+	percent := NumberFormat{DecimalSeparator: 0, GroupSeparators: "", Percent: true, CurrencySymbols: nil}
+	euro := NumberFormat{DecimalSeparator: ',', GroupSeparators: ". ", Percent: false, CurrencySymbols: []string{"€", "EUR"}}
+	dollar := NumberFormat{DecimalSeparator: '.', GroupSeparators: ",", Percent: false, CurrencySymbols: []string{"$"}}
+
+	tests := []struct {
+		name    string
+		x, y    []string
+		xf, yf  NumberFormat
+		wantErr bool
+	}{
+		{
+			name: "percent and dollars",
+			x:    []string{"12.5%", "50 %", "25%", "1"},
+			y:    []string{"$1,234.50", "$5,000", " $2,500.25 ", "9999"},
+			xf:   percent,
+			yf:   dollar,
+		},
+		{
+			name: "decimal comma and euros",
+			x:    []string{"0,125", "0,5", "0,25", "1"},
+			y:    []string{"1.234,5 €", "EUR 5 000", "2.500,25€", "9.999"},
+			xf:   euro,
+			yf:   euro,
+		},
+		{
+			name:    "percent not enabled",
+			x:       []string{"12.5%", "50%", "25%", "1"},
+			y:       []string{"1", "2", "3", "4"},
+			wantErr: true,
+		},
+		{
+			name:    "stray point with decimal comma",
+			x:       []string{"0,125", "0.5", "0,25", "1"},
+			y:       []string{"1", "2", "3", "4"},
+			xf:      NumberFormat{DecimalSeparator: ',', GroupSeparators: " ", Percent: false, CurrencySymbols: nil},
+			wantErr: true,
+		},
+		{
+			name:    "decimal separator used for grouping",
+			x:       []string{"1", "2", "3", "4"},
+			y:       []string{"1", "2", "3", "4"},
+			xf:      NumberFormat{DecimalSeparator: ',', GroupSeparators: ",", Percent: false, CurrencySymbols: nil},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CorrelateStringsFormat(tt.x, tt.y, tt.xf, tt.yf, Pearson)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("CorrelateStringsFormat() = %v, expected error but got none", got)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("CorrelateStringsFormat() unexpected error: %v", err)
+			}
+			if math.Abs(got-want) > 1e-12 {
+				t.Errorf("CorrelateStringsFormat() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCorrelateStringsFormatBig(t *testing.T) {
+	// "1,2E+400" with a decimal comma overflows float64 and needs big.Float.
+	euro := NumberFormat{DecimalSeparator: ',', GroupSeparators: "", Percent: true, CurrencySymbols: nil}
+	x := []string{"1,2E+400", "2,4E+400", "3,1E+400", "4,8E+400"}
+	y := []string{"-1%", "-2%", "-3%", "-5%"}
+
+	got, err := CorrelateStringsFormat(x, y, euro, euro, Pearson)
+	if err != nil {
+		t.Fatalf("CorrelateStringsFormat() unexpected error: %v", err)
+	}
+	want, _ := Pearsons([]float64{1.2, 2.4, 3.1, 4.8}, []float64{-1, -2, -3, -5})
+	if math.Abs(got-want) > 1e-12 {
+		t.Errorf("CorrelateStringsFormat() = %v, want %v", got, want)
+	}
+}
+
+func TestDecimalPrecision(t *testing.T) {
+	tests := []struct {
+		in   string
+		want uint
+	}{
+		{in: "1e400", want: 64},
+		{in: "NaN", want: 64},
+		{in: "-1.00000000000000000000000000000000000001e400", want: 162},
+		{in: "0x1.0000000000000000000001p-2000", want: 124},
+	}
+
+	for _, tt := range tests {
+		if got := decimalPrecision(tt.in); got != tt.want {
+			t.Errorf("decimalPrecision(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseBigFloatsDigits(t *testing.T) {
+	// The values differ only in the 34th digit, which the default 64 bits
+	// of a big.Float cannot hold.
+	in := []string{
+		"1.000000000000000000000000000000001e+400",
+		"1.000000000000000000000000000000002e+400",
+		"-1.000000000000000000000000000000003e-400",
+	}
+	got, err := parseBigFloats(in, "x", NumberFormat{})
+	if err != nil {
+		t.Fatalf("parseBigFloats() unexpected error: %v", err)
+	}
+	if got[0].Cmp(got[1]) == 0 {
+		t.Errorf("parseBigFloats() rounded %s and %s to the same value", in[0], in[1])
+	}
+	for i, v := range got {
+		if s := v.Text('e', 33); s != in[i] {
+			t.Errorf("parseBigFloats(%s) = %s with precision %d", in[i], s, v.Prec())
+		}
+	}
+}
+
+func TestCorrelateStringsBigNaN(t *testing.T) {
+	// A NaN is treated the same whether or not the other values fit in a
+	// float64.
+	for _, x := range [][]string{
+		{"1", "NaN", "3", "4"},
+		{"1e400", "NaN", "3e400", "4e400"},
+	} {
+		y := []string{"2", "1", "5", "3"}
+		if got, err := CorrelateStrings(x, y, Pearson); err != nil || !math.IsNaN(got) {
+			t.Errorf("CorrelateStrings(%v, Pearson) = %v, %v, want NaN", x, got, err)
+		}
+		if _, err := CorrelateStrings(x, y, Spearman); err == nil {
+			t.Errorf("CorrelateStrings(%v, Spearman) expected error but got none", x)
+		}
+	}
+}
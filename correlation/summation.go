@@ -0,0 +1,106 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+)
+
+// neumaierSum is a running float64 sum with Neumaier's improvement of Kahan
+// compensated summation. The rounding error of every addition is collected
+// in a separate compensation term, so the error of the total stays on the
+// order of one rounding no matter how many values are added, instead of
+// growing with their number.
+//
+// The zero value is an empty sum ready to use.
+type neumaierSum struct {
+	sum float64
+	c   float64
+}
+
+// add adds v to the sum.
+func (s *neumaierSum) add(v float64) {
+	t := s.sum + v
+	if math.Abs(s.sum) >= math.Abs(v) {
+		s.c += (s.sum - t) + v
+	} else {
+		s.c += (v - t) + s.sum
+	}
+	s.sum = t
+}
+
+// value returns the compensated total.
+func (s *neumaierSum) value() float64 {
+	return s.sum + s.c
+}
+
+// compensatedMoments accumulates the statistics for Pearson's correlation
+// with Welford's updates of the means and of the sums of squared and cross
+// deviations from them, which avoids the cancellation of raw sums of
+// squares when the mean is large relative to the spread. The deviation sums
+// are compensated so their rounding error does not grow with n.
+//
+// The zero value holds no pairs and is ready to use.
+type compensatedMoments struct {
+	n     int
+	meanX float64
+	meanY float64
+	m2x   neumaierSum
+	m2y   neumaierSum
+	cxy   neumaierSum
+}
+
+// accumulateMoments adds the pairs of x and y to s.
+func accumulateMoments[T Numeric](s *compensatedMoments, x, y []T) {
+	// Work on local copies so the statistics can stay in registers.
+	n, meanX, meanY := s.n, s.meanX, s.meanY
+	m2x, m2y, cxy := s.m2x, s.m2y, s.cxy
+	for i := range x {
+		fx := float64(x[i])
+		fy := float64(y[i])
+
+		n++
+		nf := float64(n)
+		dx := fx - meanX
+		dy := fy - meanY
+		meanX += dx / nf
+		meanY += dy / nf
+		m2x.add(dx * (fx - meanX))
+		m2y.add(dy * (fy - meanY))
+		cxy.add(dx * (fy - meanY))
+	}
+	*s = compensatedMoments{n: n, meanX: meanX, meanY: meanY, m2x: m2x, m2y: m2y, cxy: cxy}
+}
+
+// finite reports whether every statistic is finite, that is, whether no
+// input was NaN or ±Inf and nothing overflowed float64.
+func (s *compensatedMoments) finite() bool {
+	return isFinite(s.meanX) && isFinite(s.meanY) &&
+		isFinite(s.m2x.value()) && isFinite(s.m2y.value()) && isFinite(s.cxy.value())
+}
+
+// value returns the Pearson correlation of the accumulated pairs, without
+// clamping. The statistics must be finite.
+func (s *compensatedMoments) value() (float64, error) {
+	varX, varY := s.m2x.value(), s.m2y.value()
+	if varX <= 0 || varY <= 0 {
+		return 0, errors.New("correlation undefined: one or both variables have zero variance")
+	}
+
+	// Taking the roots separately keeps the denominator finite when the
+	// product of the variances would overflow.
+	return s.cxy.value() / (math.Sqrt(varX) * math.Sqrt(varY)), nil
+}
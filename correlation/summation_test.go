@@ -0,0 +1,76 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNeumaierSum(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		want   float64
+	}{
+		{
+			name:   "empty",
+			values: nil,
+			want:   0,
+		},
+		{
+			name:   "large value cancels",
+			values: []float64{1, 1e100, 1, -1e100},
+			want:   2,
+		},
+		{
+			name:   "small values after a large one",
+			values: []float64{1e16, 1, 1, 1, 1, -1e16},
+			want:   4,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var s neumaierSum
+			for _, v := range test.values {
+				s.add(v)
+			}
+			if got := s.value(); got != test.want {
+				t.Errorf("value() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNeumaierSumManyValues(t *testing.T) {
+	// 0.1 is not exactly representable, so naive summation drifts away
+	// from the correctly rounded total as more terms are added.
+	const n = 1000000
+	var s neumaierSum
+	naive := 0.0
+	for range n {
+		s.add(0.1)
+		naive += 0.1
+	}
+	want := 100000.0
+	if got := s.value(); math.Abs(got-want) > 1e-9 {
+		t.Errorf("value() = %v, want %v", got, want)
+	}
+	if math.Abs(s.value()-want) >= math.Abs(naive-want) {
+		t.Errorf("compensated error %v is not below naive error %v",
+			math.Abs(s.value()-want), math.Abs(naive-want))
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Column is a named series of values in a Table, stored either as float64
+// or, when the values do not fit in float64, as *big.Float.
+type Column struct {
+	// Name identifies the column.
+	Name string
+
+	floats []float64
+	bigs   []*big.Float
+}
+
+// FloatColumn returns a column backed by float64 values.
+func FloatColumn(name string, data []float64) Column {
+	return Column{Name: name, floats: data, bigs: nil}
+}
+
+// BigColumn returns a column backed by *big.Float values.
+func BigColumn(name string, data []*big.Float) Column {
+	return Column{Name: name, floats: nil, bigs: data}
+}
+
+// ParseColumn parses data written in the given format into a column. The
+// column is backed by float64 when every value fits without loss of range,
+// as described for CorrelateStrings, and by *big.Float otherwise.
+//
+// An error is returned if the format is invalid or a value cannot be parsed.
+func ParseColumn(name string, data []string, format NumberFormat) (Column, error) {
+	if err := format.validate(); err != nil {
+		return Column{}, err
+	}
+
+	floats, fits, err := parseFloats(data, name, format)
+	if err != nil {
+		return Column{}, err
+	}
+	if fits {
+		return FloatColumn(name, floats), nil
+	}
+
+	bigs, err := parseBigFloats(data, name, format)
+	if err != nil {
+		return Column{}, err
+	}
+
+	return BigColumn(name, bigs), nil
+}
+
+// Len returns the number of values in the column.
+func (c Column) Len() int {
+	if c.bigs != nil {
+		return len(c.bigs)
+	}
+
+	return len(c.floats)
+}
+
+// IsBig reports whether the column is backed by *big.Float values.
+func (c Column) IsBig() bool {
+	return c.bigs != nil
+}
+
+// asBig returns the values of the column as *big.Float.
+func (c Column) asBig() []*big.Float {
+	if c.bigs != nil {
+		return c.bigs
+	}
+
+	out := make([]*big.Float, len(c.floats))
+	for i, v := range c.floats {
+		out[i] = big.NewFloat(v)
+	}
+
+	return out
+}
+
+// Table is a set of equal length columns, each of which may be backed by
+// float64 or *big.Float independently.
+type Table struct {
+	columns []Column
+}
+
+// NewTable returns a table of the given columns.
+//
+// An error is returned if there are no columns, the columns differ in
+// length, or a float64 column contains NaN, which big.Float cannot
+// represent when the column is paired with a big one.
+func NewTable(columns ...Column) (*Table, error) {
+	if len(columns) == 0 {
+		return nil, errors.New("table requires at least 1 column")
+	}
+	for _, c := range columns {
+		if c.Len() != columns[0].Len() {
+			return nil, errors.New("column " + strconv.Quote(c.Name) + " has a different length")
+		}
+		for i, v := range c.floats {
+			if math.IsNaN(v) {
+				return nil, errors.New("column " + strconv.Quote(c.Name) + " contains NaN at index " + strconv.Itoa(i))
+			}
+		}
+	}
+
+	return &Table{columns: columns}, nil
+}
+
+// NumColumns returns the number of columns in the table.
+func (t *Table) NumColumns() int {
+	return len(t.columns)
+}
+
+// Column returns column i of the table.
+func (t *Table) Column(i int) Column {
+	return t.columns[i]
+}
+
+// CorrelationMatrix computes the correlation matrix of the columns of the
+// table.
+//
+// Each pair of columns is computed with the arithmetic it needs: pairs of
+// float64 columns use float64 arithmetic, and only pairs involving a big
+// column are computed with big.Float, so a single out of range column does
+// not slow down the rest of the table. When every column is float64 this is
+// the same as CorrelationMatrix.
+//
+// Cells whose coefficient is undefined hold NaN.
+//
+// An error is returned if the table has fewer than 2 columns or rows, or if
+// the correlation type is not supported.
+func (t *Table) CorrelationMatrix(correlationType Type) (*Matrix, error) {
+	anyBig := false
+	floats := make([][]float64, len(t.columns))
+	for i, c := range t.columns {
+		anyBig = anyBig || c.IsBig()
+		floats[i] = c.floats
+	}
+	if !anyBig {
+		return CorrelationMatrix(floats, correlationType)
+	}
+
+	if len(t.columns) < 2 {
+		return nil, errors.New("correlation matrix requires at least 2 columns")
+	}
+	rows := t.columns[0].Len()
+	if rows < 2 {
+		return nil, errors.New("correlation requires at least 2 data points")
+	}
+	if !correlationType.valid() {
+		return nil, errors.New("unsupported correlation type")
+	}
+
+	// Convert each column to big.Float at most once, and only if it is
+	// paired with a big column.
+	bigs := make([][]*big.Float, len(t.columns))
+	asBig := func(i int) []*big.Float {
+		if bigs[i] == nil {
+			bigs[i] = t.columns[i].asBig()
+		}
+
+		return bigs[i]
+	}
+
+	m := newMatrix(len(t.columns), correlationType)
+	for i := range t.columns {
+		m.set(i, i, 1, rows)
+		for j := i + 1; j < len(t.columns); j++ {
+			var r float64
+			var err error
+			if t.columns[i].IsBig() || t.columns[j].IsBig() {
+				r, err = CorrelateBig(asBig(i), asBig(j), correlationType)
+			} else {
+				r, err = Correlate(floats[i], floats[j], correlationType)
+			}
+			if err != nil {
+				r = math.NaN()
+			}
+			m.set(i, j, r, rows)
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestTableCorrelationMatrix(t *testing.T) {
+	a := []float64{1, 2, 3, 4, 5, 6}
+	b := []float64{2, 1, 4, 3, 6, 5}
+	c := []float64{6, 4, 5, 3, 1, 2}
+
+	// The big column holds c scaled far beyond float64, which does not change
+	// its correlation with anything.
+	huge, err := ParseColumn("huge", []string{"6e400", "4e400", "5e400", "3e400", "1e400", "2e400"}, NumberFormat{})
+	if err != nil {
+		t.Fatalf("ParseColumn() unexpected error: %v", err)
+	}
+	if !huge.IsBig() {
+		t.Fatalf("ParseColumn() of values beyond float64 should be big")
+	}
+	small, err := ParseColumn("b", []string{"2", "1", "4", "3", "6", "5"}, NumberFormat{})
+	if err != nil {
+		t.Fatalf("ParseColumn() unexpected error: %v", err)
+	}
+	if small.IsBig() {
+		t.Fatalf("ParseColumn() of small values should not be big")
+	}
+
+	mixed, err := NewTable(FloatColumn("a", a), small, huge)
+	if err != nil {
+		t.Fatalf("NewTable() unexpected error: %v", err)
+	}
+	if mixed.NumColumns() != 3 || mixed.Column(2).Name != "huge" {
+		t.Errorf("NewTable() columns = %d, want 3 with huge last", mixed.NumColumns())
+	}
+
+	for _, ct := range []Type{Pearson, Spearman, KendallTau, GoodmanKruskal} {
+		t.Run(ct.String(), func(t *testing.T) {
+			want, err := CorrelationMatrix([][]float64{a, b, c}, ct)
+			if err != nil {
+				t.Fatalf("CorrelationMatrix() unexpected error: %v", err)
+			}
+			got, err := mixed.CorrelationMatrix(ct)
+			if err != nil {
+				t.Fatalf("Table.CorrelationMatrix() unexpected error: %v", err)
+			}
+			for i := range 3 {
+				for j := range 3 {
+					if math.Abs(got.At(i, j)-want.At(i, j)) > 1e-12 {
+						t.Errorf("At(%d, %d) = %v, want %v", i, j, got.At(i, j), want.At(i, j))
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTableCorrelationMatrixOverflow(t *testing.T) {
+	// An all-float table goes through CorrelationMatrix, whose sums of
+	// squares overflow for values near 1e200.
+	x := []float64{1e200, 2e200, 3e200, 4e200, 5e200, 6e200}
+	y := []float64{2, 1, 4, 3, 6, 5}
+	table, err := NewTable(FloatColumn("x", x), FloatColumn("y", y))
+	if err != nil {
+		t.Fatalf("NewTable() unexpected error: %v", err)
+	}
+
+	want, err := Correlate(x, y, Pearson)
+	if err != nil {
+		t.Fatalf("Correlate() unexpected error: %v", err)
+	}
+	m, err := table.CorrelationMatrix(Pearson)
+	if err != nil {
+		t.Fatalf("Table.CorrelationMatrix() unexpected error: %v", err)
+	}
+	if got := m.At(0, 1); math.Abs(got-want) > 1e-12 || want == 0 {
+		t.Errorf("At(0, 1) = %v, want %v", got, want)
+	}
+}
+
+func TestNewTableErrors(t *testing.T) {
+	if _, err := NewTable(); err == nil {
+		t.Errorf("NewTable() with no columns expected error but got none")
+	}
+	if _, err := NewTable(FloatColumn("a", []float64{1, 2}), BigColumn("b", []*big.Float{big.NewFloat(1)})); err == nil {
+		t.Errorf("NewTable() with ragged columns expected error but got none")
+	}
+	if _, err := NewTable(FloatColumn("a", []float64{1, math.NaN()})); err == nil {
+		t.Errorf("NewTable() with NaN expected error but got none")
+	}
+	if _, err := ParseColumn("a", []string{"1", "x"}, NumberFormat{}); err == nil {
+		t.Errorf("ParseColumn() with invalid number expected error but got none")
+	}
+
+	one, _ := NewTable(BigColumn("a", []*big.Float{big.NewFloat(1), big.NewFloat(2)}))
+	if _, err := one.CorrelationMatrix(Pearson); err == nil {
+		t.Errorf("CorrelationMatrix() of one column expected error but got none")
+	}
+}
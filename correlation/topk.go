@@ -0,0 +1,236 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+)
+
+// PairCorrelation identifies a pair of columns and the correlation between them.
+type PairCorrelation struct {
+	// I and J are the column indexes of the pair, with I < J.
+	I, J int
+	// Coefficient is the Pearson correlation between the two columns.
+	Coefficient float64
+	// N is the number of observations the coefficient is based on.
+	N int
+}
+
+// TopKOption configures a TopKTracker.
+type TopKOption func(*TopKTracker)
+
+// WithCandidates sets how many candidate pairs survive each pruning pass.
+// Larger values make the tracker more accurate at the cost of memory and
+// time per observation. The default is 10*k. Values smaller than k are
+// raised to k.
+func WithCandidates(n int) TopKOption {
+	return func(t *TopKTracker) {
+		t.candidates = n
+	}
+}
+
+// WithPruneInterval sets how many observations are consumed between pruning
+// passes. The first pass happens after this many observations, so it also
+// acts as the warm up period during which every pair is tracked. The
+// default is 1000.
+func WithPruneInterval(n int) TopKOption {
+	return func(t *TopKTracker) {
+		t.pruneInterval = n
+	}
+}
+
+// trackedPair holds the running co-moment of one candidate pair.
+type trackedPair struct {
+	i, j int
+	// cxy is Σ (x - mean(x)) * (y - mean(y)) over all observations so far.
+	cxy float64
+}
+
+// TopKTracker consumes a stream of multivariate observations and maintains
+// an approximate list of the k most strongly correlated (by |r|) pairs of
+// columns.
+//
+// Column means and sums of squared deviations are updated incrementally with
+// Welford's algorithm, as are the co-moments of every candidate pair. Until
+// the first pruning pass all p(p-1)/2 pairs are candidates; each pass then
+// keeps only the strongest candidates and stops tracking the rest. The
+// coefficients reported for surviving pairs are exact over the whole stream,
+// but a pair that was pruned early is never reconsidered, so a relationship
+// that only emerges later in the stream can be missed.
+//
+// A TopKTracker is not safe for concurrent use.
+type TopKTracker struct {
+	k             int
+	candidates    int
+	pruneInterval int
+
+	n     int
+	means []float64
+	m2    []float64
+	pairs []trackedPair
+
+	// delta holds the per column deviation from the previous mean for the
+	// observation being added, reused across calls to Observe.
+	delta []float64
+}
+
+// NewTopKTracker returns a tracker for observations with the given number of
+// columns that reports the k most correlated pairs.
+//
+// An error is returned if there are fewer than 2 columns, k is not positive,
+// or an option has an invalid value.
+func NewTopKTracker(columns, k int, opts ...TopKOption) (*TopKTracker, error) {
+	if columns < 2 {
+		return nil, errors.New("top-k tracker requires at least 2 columns")
+	}
+	if k < 1 {
+		return nil, errors.New("k must be positive")
+	}
+
+	t := &TopKTracker{
+		k:             k,
+		candidates:    10 * k,
+		pruneInterval: 1000,
+		n:             0,
+		means:         make([]float64, columns),
+		m2:            make([]float64, columns),
+		pairs:         make([]trackedPair, 0, columns*(columns-1)/2),
+		delta:         make([]float64, columns),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	if t.pruneInterval < 1 {
+		return nil, errors.New("prune interval must be positive")
+	}
+	t.candidates = max(t.candidates, t.k)
+
+	for i := range columns {
+		for j := i + 1; j < columns; j++ {
+			t.pairs = append(t.pairs, trackedPair{i: i, j: j, cxy: 0})
+		}
+	}
+
+	return t, nil
+}
+
+// Observe adds one observation (one value per column) to the tracker.
+//
+// An error is returned if the row has the wrong number of columns or
+// contains NaN or ±Inf; in that case the tracker is left unchanged.
+func (t *TopKTracker) Observe(row []float64) error {
+	if len(row) != len(t.means) {
+		return errors.New("observation has the wrong number of columns")
+	}
+	for _, v := range row {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return errors.New("observation contains a non-finite value")
+		}
+	}
+
+	t.n++
+	nf := float64(t.n)
+	for c, v := range row {
+		d := v - t.means[c]
+		t.delta[c] = d
+		t.means[c] += d / nf
+		t.m2[c] += d * (v - t.means[c])
+	}
+
+	// Co-moment update: C += (x - oldMeanX) * (y - newMeanY).
+	for p := range t.pairs {
+		tp := &t.pairs[p]
+		tp.cxy += t.delta[tp.i] * (row[tp.j] - t.means[tp.j])
+	}
+
+	if t.n%t.pruneInterval == 0 && len(t.pairs) > t.candidates {
+		t.prune()
+	}
+
+	return nil
+}
+
+// N returns the number of observations consumed so far.
+func (t *TopKTracker) N() int {
+	return t.n
+}
+
+// TopK returns up to k pairs with the largest absolute correlation, ordered
+// from strongest to weakest. Pairs whose coefficient is undefined (such as
+// when a column has zero variance) are omitted.
+func (t *TopKTracker) TopK() []PairCorrelation {
+	all := make([]PairCorrelation, 0, len(t.pairs))
+	for _, tp := range t.pairs {
+		r := t.coefficient(tp)
+		if math.IsNaN(r) {
+			continue
+		}
+		all = append(all, PairCorrelation{I: tp.i, J: tp.j, Coefficient: r, N: t.n})
+	}
+
+	sortByStrength(all)
+	if len(all) > t.k {
+		all = all[:t.k]
+	}
+
+	return all
+}
+
+// coefficient returns the current Pearson correlation of the tracked pair,
+// or NaN if it is undefined.
+func (t *TopKTracker) coefficient(tp trackedPair) float64 {
+	den := t.m2[tp.i] * t.m2[tp.j]
+	if t.n < 2 || den <= 0 {
+		return math.NaN()
+	}
+
+	return clampUnit(tp.cxy / math.Sqrt(den))
+}
+
+// prune keeps only the strongest candidate pairs.
+func (t *TopKTracker) prune() {
+	slices.SortFunc(t.pairs, func(a, b trackedPair) int {
+		ra := math.Abs(t.coefficient(a))
+		rb := math.Abs(t.coefficient(b))
+		// Undefined coefficients sort last.
+		if math.IsNaN(ra) {
+			ra = -1
+		}
+		if math.IsNaN(rb) {
+			rb = -1
+		}
+
+		return cmp.Compare(rb, ra)
+	})
+	t.pairs = slices.Clone(t.pairs[:t.candidates])
+}
+
+// sortByStrength orders pairs by decreasing absolute coefficient, breaking
+// ties by column indexes so the order is deterministic.
+func sortByStrength(pairs []PairCorrelation) {
+	slices.SortFunc(pairs, func(a, b PairCorrelation) int {
+		if c := cmp.Compare(math.Abs(b.Coefficient), math.Abs(a.Coefficient)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(a.I, b.I); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.J, b.J)
+	})
+}
@@ -0,0 +1,134 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package correlation
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestTopKTracker(t *testing.T) {
+	const (
+		columns = 20
+		rows    = 3000
+	)
+
+	rng := rand.New(rand.NewSource(getSeed()))
+	data := make([][]float64, columns)
+	for c := range data {
+		data[c] = make([]float64, rows)
+	}
+	for r := range rows {
+		for c := range columns {
+			data[c][r] = rng.NormFloat64()*10 + 1000
+		}
+		// Column 7 tracks column 3 closely, column 12 moves against column 5.
+		data[7][r] = data[3][r]*2 + rng.NormFloat64()
+		data[12][r] = -data[5][r] + rng.NormFloat64()*5
+	}
+
+	tracker, err := NewTopKTracker(columns, 2, WithCandidates(5), WithPruneInterval(500))
+	if err != nil {
+		t.Fatalf("NewTopKTracker() unexpected error: %v", err)
+	}
+
+	row := make([]float64, columns)
+	for r := range rows {
+		for c := range columns {
+			row[c] = data[c][r]
+		}
+		if err := tracker.Observe(row); err != nil {
+			t.Fatalf("Observe() unexpected error: %v", err)
+		}
+	}
+
+	if tracker.N() != rows {
+		t.Errorf("N() = %d, want %d", tracker.N(), rows)
+	}
+
+	top := tracker.TopK()
+	if len(top) != 2 {
+		t.Fatalf("TopK() returned %d pairs, want 2", len(top))
+	}
+
+	want := [][2]int{{3, 7}, {5, 12}}
+	for idx, pc := range top {
+		if pc.I != want[idx][0] || pc.J != want[idx][1] {
+			t.Errorf("TopK()[%d] = (%d, %d), want (%d, %d)", idx, pc.I, pc.J, want[idx][0], want[idx][1])
+		}
+
+		batch, err := Pearsons(data[pc.I], data[pc.J])
+		if err != nil {
+			t.Fatalf("Pearsons() unexpected error: %v", err)
+		}
+		if math.Abs(pc.Coefficient-batch) > 1e-9 {
+			t.Errorf("TopK()[%d] coefficient = %v, want %v", idx, pc.Coefficient, batch)
+		}
+		if pc.N != rows {
+			t.Errorf("TopK()[%d] N = %d, want %d", idx, pc.N, rows)
+		}
+	}
+	if top[1].Coefficient >= 0 {
+		t.Errorf("TopK()[1] coefficient = %v, want negative", top[1].Coefficient)
+	}
+}
+
+func TestTopKTrackerErrors(t *testing.T) {
+	if _, err := NewTopKTracker(1, 1); err == nil {
+		t.Errorf("NewTopKTracker() with 1 column expected error but got none")
+	}
+	if _, err := NewTopKTracker(3, 0); err == nil {
+		t.Errorf("NewTopKTracker() with k=0 expected error but got none")
+	}
+	if _, err := NewTopKTracker(3, 1, WithPruneInterval(0)); err == nil {
+		t.Errorf("NewTopKTracker() with prune interval 0 expected error but got none")
+	}
+
+	tracker, err := NewTopKTracker(3, 1)
+	if err != nil {
+		t.Fatalf("NewTopKTracker() unexpected error: %v", err)
+	}
+	if err := tracker.Observe([]float64{1, 2}); err == nil {
+		t.Errorf("Observe() with short row expected error but got none")
+	}
+	if err := tracker.Observe([]float64{1, math.NaN(), 3}); err == nil {
+		t.Errorf("Observe() with NaN expected error but got none")
+	}
+	if tracker.N() != 0 {
+		t.Errorf("N() = %d after rejected observations, want 0", tracker.N())
+	}
+	if got := tracker.TopK(); len(got) != 0 {
+		t.Errorf("TopK() on empty tracker = %v, want none", got)
+	}
+}
+
+func BenchmarkTopKTrackerObserve(b *testing.B) {
+	const columns = 200
+	rng := rand.New(rand.NewSource(getSeed()))
+	tracker, err := NewTopKTracker(columns, 10)
+	if err != nil {
+		b.Fatalf("NewTopKTracker() unexpected error: %v", err)
+	}
+	row := make([]float64, columns)
+
+	b.ResetTimer()
+	for b.Loop() {
+		for c := range row {
+			row[c] = rng.Float64()
+		}
+		_ = tracker.Observe(row)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// correlatedDataset returns n pairs with population correlation rho.
+func correlatedDataset(n int, rho float64) Dataset {
+	rng := rand.New(rand.NewSource(42))
+	d := Dataset{
+		Name:        "synthetic",
+		Description: "bivariate normal sample",
+		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           make([]float64, n),
+		Y:           make([]float64, n),
+		Valid:       nil,
+	}
+	for i := range n {
+		a := rng.NormFloat64()
+		b := rng.NormFloat64()
+		d.X[i] = 10 + 2*a
+		d.Y[i] = 5 + rho*a + math.Sqrt(1-rho*rho)*b
+	}
+
+	return d
+}
+
+func TestAddNoise(t *testing.T) {
+	d := correlatedDataset(20000, 0.8)
+	before := pearson(d.X, d.Y)
+
+	tests := []struct {
+		name  string
+		ratio float64
+	}{
+		{name: "no noise", ratio: 0},
+		{name: "light noise", ratio: 0.25},
+		{name: "heavy noise", ratio: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := d.AddNoise(tt.ratio, 7)
+			if err != nil {
+				t.Fatalf("AddNoise() unexpected error: %v", err)
+			}
+			want := before * NoiseAttenuation(tt.ratio)
+			if r := pearson(got.X, got.Y); math.Abs(r-want) > 0.02 {
+				t.Errorf("AddNoise(%v) correlation = %v, want about %v", tt.ratio, r, want)
+			}
+		})
+	}
+
+	a, _ := d.AddNoise(0.5, 3)
+	b, _ := d.AddNoise(0.5, 3)
+	if !slices.Equal(a.X, b.X) || !slices.Equal(a.Y, b.Y) {
+		t.Errorf("AddNoise() with the same seed gave different results")
+	}
+	if d.X[0] == a.X[0] {
+		t.Errorf("AddNoise() did not perturb the values")
+	}
+
+	if _, err := d.AddNoise(-1, 1); err == nil {
+		t.Errorf("AddNoise() with negative ratio expected error but got none")
+	}
+}
+
+func TestRankSwap(t *testing.T) {
+	d := correlatedDataset(5000, 0.7)
+	before := pearson(d.X, d.Y)
+
+	got, err := d.RankSwap(0.05, 11)
+	if err != nil {
+		t.Fatalf("RankSwap() unexpected error: %v", err)
+	}
+
+	// Marginals are preserved exactly.
+	for _, pair := range [][2][]float64{{d.X, got.X}, {d.Y, got.Y}} {
+		want := slices.Sorted(slices.Values(pair[0]))
+		if !slices.Equal(want, slices.Sorted(slices.Values(pair[1]))) {
+			t.Errorf("RankSwap() changed the marginal distribution")
+		}
+	}
+
+	if slices.Equal(d.X, got.X) {
+		t.Errorf("RankSwap() did not swap any values")
+	}
+	if r := pearson(got.X, got.Y); math.Abs(r-before) > 0.05 {
+		t.Errorf("RankSwap() correlation = %v, want about %v", r, before)
+	}
+
+	for _, p := range []float64{-0.1, 1.5, math.NaN()} {
+		if _, err := d.RankSwap(p, 1); err == nil {
+			t.Errorf("RankSwap(%v) expected error but got none", p)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// Float64Array is the read access to an Apache Arrow float64 array that
+// FromArrow needs. It is satisfied by *array.Float64 from
+// github.com/apache/arrow-go/v18/arrow/array, including slices of arrays,
+// without this package depending on Arrow.
+type Float64Array interface {
+	// Len returns the number of elements.
+	Len() int
+	// NullN returns the number of null elements.
+	NullN() int
+	// IsNull reports whether element i is null.
+	IsNull(i int) bool
+	// Float64Values returns the values, with unspecified values in the
+	// null slots.
+	Float64Values() []float64
+}
+
+// Record is the read access to an Apache Arrow record batch that
+// FromArrowRecord and FrameFromArrow need. It is satisfied by arrow.Record
+// from github.com/apache/arrow-go/v18/arrow, with A being arrow.Array, so
+// a record batch can be passed as it is.
+type Record[A any] interface {
+	// NumCols returns the number of columns.
+	NumCols() int64
+	// ColumnName returns the name of column i.
+	ColumnName(i int) string
+	// Column returns column i, which must be a Float64Array to be read.
+	Column(i int) A
+}
+
+// Float64Builder is the write access to an Apache Arrow float64 array
+// builder that ToArrow needs. It is satisfied by *array.Float64Builder
+// from github.com/apache/arrow-go/v18/arrow/array.
+type Float64Builder interface {
+	// AppendValues appends the values v, with the elements that valid
+	// marks false appended as nulls. A nil valid appends no nulls.
+	AppendValues(v []float64, valid []bool)
+}
+
+// FromArrow returns a Dataset whose X and Y hold the values of the Arrow
+// arrays x and y, such as two columns of a record batch:
+//
+//	x, _ := rec.Column(0).(*array.Float64)
+//	y, _ := rec.Column(1).(*array.Float64)
+//	d, err := datasets.FromArrow(x, y)
+//
+// An array without nulls is not copied: the series shares the memory of
+// the array, so the array must stay retained while the Dataset is in use
+// and neither may be modified. An array with nulls is copied, with NaN in
+// place of each null, and the pairs with a null are marked missing in
+// Valid.
+//
+// An error is returned if the arrays have different lengths or an array
+// returns the wrong number of values.
+func FromArrow(x, y Float64Array) (Dataset, error) {
+	if x.Len() != y.Len() {
+		return Dataset{}, &ValidationError{Dataset: "", Series: "", Index: -1, Err: ErrLengthMismatch}
+	}
+	xs, err := arrowValues(x)
+	if err != nil {
+		return Dataset{}, err
+	}
+	ys, err := arrowValues(y)
+	if err != nil {
+		return Dataset{}, err
+	}
+
+	var valid []bool
+	if x.NullN() > 0 || y.NullN() > 0 {
+		valid = make([]bool, len(xs))
+		for i := range valid {
+			valid[i] = !x.IsNull(i) && !y.IsNull(i)
+		}
+	}
+
+	return Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: xs, Y: ys, Valid: valid}, nil
+}
+
+// FromArrowRecord returns a Dataset holding the float64 columns named x and
+// y of the Arrow record batch rec, read as by FromArrow, with XLabel and
+// YLabel set to the column names:
+//
+//	d, err := datasets.FromArrowRecord(rec, "height", "weight")
+//
+// An error is returned if a column is not found or is not a float64 array,
+// or the columns cannot be read by FromArrow.
+func FromArrowRecord[A any](rec Record[A], x, y string) (Dataset, error) {
+	xa, err := arrowColumn(rec, x)
+	if err != nil {
+		return Dataset{}, err
+	}
+	ya, err := arrowColumn(rec, y)
+	if err != nil {
+		return Dataset{}, err
+	}
+
+	d, err := FromArrow(xa, ya)
+	if err != nil {
+		return Dataset{}, err
+	}
+	d.XLabel, d.YLabel = x, y
+
+	return d, nil
+}
+
+// FrameFromArrow returns a Frame holding every column of the Arrow record
+// batch rec under its name. The columns are read as by FromArrow, but as a
+// Frame has no validity mask, nulls are only marked by their NaN values.
+//
+// An error is returned if a column is not a float64 array, or the columns
+// cannot make a Frame.
+func FrameFromArrow[A any](rec Record[A]) (*Frame, error) {
+	n := int(rec.NumCols())
+	names := make([]string, n)
+	cols := make([][]float64, n)
+	for i := range n {
+		names[i] = rec.ColumnName(i)
+		a, ok := any(rec.Column(i)).(Float64Array)
+		if !ok {
+			return nil, errors.New("arrow column " + strconv.Quote(names[i]) + " is not a float64 array")
+		}
+		vals, err := arrowValues(a)
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = vals
+	}
+
+	return NewFrame(names, cols)
+}
+
+// ToArrow appends X to the Arrow array builder x and Y to y, with the
+// pairs that Valid marks missing appended as nulls, so the dataset can be
+// written as two columns of a record batch:
+//
+//	b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+//	err := d.ToArrow(b.Field(0).(*array.Float64Builder), b.Field(1).(*array.Float64Builder))
+//	rec := b.NewRecord()
+//
+// An error is returned if X and Y have different lengths, or Valid is set
+// and has a different length from them.
+func (d Dataset) ToArrow(x, y Float64Builder) error {
+	if len(d.X) != len(d.Y) || (d.Valid != nil && len(d.Valid) != len(d.X)) {
+		return &ValidationError{Dataset: d.Name, Series: "", Index: -1, Err: ErrLengthMismatch}
+	}
+	x.AppendValues(d.X, d.Valid)
+	y.AppendValues(d.Y, d.Valid)
+
+	return nil
+}
+
+// ToArrow appends each column of the frame to the Arrow array builder at
+// the same position in cols, such as the fields of a record builder whose
+// schema lists the columns in order. NaN values are appended as values,
+// not nulls.
+//
+// An error is returned if there is not one builder per column.
+func (f *Frame) ToArrow(cols ...Float64Builder) error {
+	if len(cols) != len(f.cols) {
+		return errors.New("ToArrow needs one builder per frame column")
+	}
+	for i, b := range cols {
+		b.AppendValues(f.cols[i], nil)
+	}
+
+	return nil
+}
+
+// arrowColumn returns the float64 column of rec named name.
+func arrowColumn[A any](rec Record[A], name string) (Float64Array, error) {
+	for i := range int(rec.NumCols()) {
+		if rec.ColumnName(i) != name {
+			continue
+		}
+		a, ok := any(rec.Column(i)).(Float64Array)
+		if !ok {
+			return nil, errors.New("arrow column " + strconv.Quote(name) + " is not a float64 array")
+		}
+
+		return a, nil
+	}
+
+	return nil, errors.New("arrow column " + strconv.Quote(name) + " not found")
+}
+
+// arrowValues returns the values of a, shared if it has no nulls and
+// otherwise copied with NaN for the nulls.
+func arrowValues(a Float64Array) ([]float64, error) {
+	vals := a.Float64Values()
+	if len(vals) != a.Len() {
+		return nil, errors.New("arrow array has the wrong number of values")
+	}
+	if a.NullN() == 0 {
+		return vals, nil
+	}
+
+	out := make([]float64, len(vals))
+	for i, v := range vals {
+		if a.IsNull(i) {
+			v = math.NaN()
+		}
+		out[i] = v
+	}
+
+	return out, nil
+}
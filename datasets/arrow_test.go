@@ -0,0 +1,234 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeArrow is a Float64Array with an optional validity mask, standing in
+// for an Arrow array.
+type fakeArrow struct {
+	values []float64
+	valid  []bool
+	length int
+}
+
+func newFakeArrow(values []float64, valid []bool) *fakeArrow {
+	return &fakeArrow{values: values, valid: valid, length: len(values)}
+}
+
+func (a *fakeArrow) Len() int                 { return a.length }
+func (a *fakeArrow) IsNull(i int) bool        { return a.valid != nil && !a.valid[i] }
+func (a *fakeArrow) Float64Values() []float64 { return a.values }
+
+func (a *fakeArrow) NullN() int {
+	n := 0
+	for i := range a.values {
+		if a.IsNull(i) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// fakeArray stands in for the arrow.Array interface that the columns of a
+// record batch have.
+type fakeArray interface {
+	Len() int
+}
+
+// fakeRecord stands in for an Arrow record batch.
+type fakeRecord struct {
+	names []string
+	cols  []fakeArray
+}
+
+func (r fakeRecord) NumCols() int64          { return int64(len(r.cols)) }
+func (r fakeRecord) ColumnName(i int) string { return r.names[i] }
+func (r fakeRecord) Column(i int) fakeArray  { return r.cols[i] }
+
+// fakeInt64Array is a column of another type than float64.
+type fakeInt64Array struct{}
+
+func (fakeInt64Array) Len() int { return 3 }
+
+// fakeBuilder is a Float64Builder recording what was appended.
+type fakeBuilder struct {
+	values []float64
+	nulls  []bool
+}
+
+func (b *fakeBuilder) AppendValues(v []float64, valid []bool) {
+	for i, x := range v {
+		b.values = append(b.values, x)
+		b.nulls = append(b.nulls, valid != nil && !valid[i])
+	}
+}
+
+func TestFromArrow(t *testing.T) {
+	x := newFakeArrow([]float64{1, 2, 3}, nil)
+	y := newFakeArrow([]float64{4, 99, 6}, []bool{true, false, true})
+
+	d, err := FromArrow(x, y)
+	if err != nil {
+		t.Fatalf("FromArrow() unexpected error: %v", err)
+	}
+	if want := []float64{1, 2, 3}; !equalNaN(d.X, want) {
+		t.Errorf("FromArrow() X = %v, want %v", d.X, want)
+	}
+	if want := []float64{4, math.NaN(), 6}; !equalNaN(d.Y, want) {
+		t.Errorf("FromArrow() Y = %v, want %v", d.Y, want)
+	}
+	if want := []bool{true, false, true}; !slices.Equal(d.Valid, want) {
+		t.Errorf("FromArrow() Valid = %v, want %v", d.Valid, want)
+	}
+
+	// X has no nulls so shares the array's memory; Y is a copy.
+	if &d.X[0] != &x.values[0] {
+		t.Errorf("FromArrow() copied an array without nulls")
+	}
+	if &d.Y[0] == &y.values[0] {
+		t.Errorf("FromArrow() shared an array with nulls")
+	}
+}
+
+func TestFromArrowErrors(t *testing.T) {
+	short := newFakeArrow([]float64{1, 2}, nil)
+	short.length = 3
+
+	tests := []struct {
+		name string
+		x    Float64Array
+		y    Float64Array
+	}{
+		{
+			name: "different lengths",
+			x:    newFakeArrow([]float64{1, 2}, nil),
+			y:    newFakeArrow([]float64{1}, nil),
+		},
+		{
+			name: "wrong number of values",
+			x:    short,
+			y:    newFakeArrow([]float64{1, 2, 3}, nil),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := FromArrow(test.x, test.y); err == nil {
+				t.Errorf("FromArrow() expected error but got none")
+			}
+		})
+	}
+
+	_, err := FromArrow(newFakeArrow([]float64{1, 2}, nil), newFakeArrow([]float64{1}, nil))
+	if !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("FromArrow() error = %v, want %v", err, ErrLengthMismatch)
+	}
+}
+
+func TestFromArrowRecord(t *testing.T) {
+	rec := fakeRecord{
+		names: []string{"id", "height", "weight"},
+		cols: []fakeArray{
+			fakeInt64Array{},
+			newFakeArrow([]float64{150, 160, 170}, nil),
+			newFakeArrow([]float64{50, 0, 70}, []bool{true, false, true}),
+		},
+	}
+
+	d, err := FromArrowRecord(rec, "height", "weight")
+	if err != nil {
+		t.Fatalf("FromArrowRecord() unexpected error: %v", err)
+	}
+	if d.XLabel != "height" || d.YLabel != "weight" || !slices.Equal(d.X, []float64{150, 160, 170}) {
+		t.Errorf("FromArrowRecord() = %+v, want the height and weight columns", d)
+	}
+	if want := []bool{true, false, true}; !slices.Equal(d.Valid, want) {
+		t.Errorf("FromArrowRecord() Valid = %v, want %v", d.Valid, want)
+	}
+
+	for _, test := range []struct{ x, y, wantErr string }{
+		{x: "height", y: "age", wantErr: "not found"},
+		{x: "id", y: "weight", wantErr: "not a float64 array"},
+	} {
+		if _, err := FromArrowRecord(rec, test.x, test.y); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("FromArrowRecord(%q, %q) error = %v, want one containing %q", test.x, test.y, err, test.wantErr)
+		}
+	}
+
+	if _, err := FrameFromArrow(rec); err == nil || !strings.Contains(err.Error(), "not a float64 array") {
+		t.Errorf("FrameFromArrow() with an int64 column error = %v, want a type error", err)
+	}
+	rec.names, rec.cols = rec.names[1:], rec.cols[1:]
+	f, err := FrameFromArrow(rec)
+	if err != nil {
+		t.Fatalf("FrameFromArrow() unexpected error: %v", err)
+	}
+	if w, _ := f.Column("weight"); !slices.Equal(f.Names(), []string{"height", "weight"}) || !equalNaN(w, []float64{50, math.NaN(), 70}) {
+		t.Errorf("FrameFromArrow() = %v with weight %v, want the height and weight columns", f.Names(), w)
+	}
+}
+
+func TestDatasetToArrow(t *testing.T) {
+	d := Dataset{Name: "d", X: []float64{1, math.NaN(), 3}, Y: []float64{4, 5, math.NaN()}, Valid: []bool{true, true, false}}
+	var x, y fakeBuilder
+	if err := d.ToArrow(&x, &y); err != nil {
+		t.Fatalf("ToArrow() unexpected error: %v", err)
+	}
+	if !equalNaN(x.values, d.X) || !equalNaN(y.values, d.Y) {
+		t.Errorf("ToArrow() appended %v and %v, want %v and %v", x.values, y.values, d.X, d.Y)
+	}
+	// Only the pair marked missing is null; the NaN of the valid pair is a
+	// value.
+	if want := []bool{false, false, true}; !slices.Equal(x.nulls, want) || !slices.Equal(y.nulls, want) {
+		t.Errorf("ToArrow() nulls = %v and %v, want %v", x.nulls, y.nulls, want)
+	}
+
+	// The round trip through Arrow keeps the mask, and the nulls come back
+	// as NaN.
+	back, err := FromArrow(newFakeArrow(x.values, slices.Clone(d.Valid)), newFakeArrow(y.values, slices.Clone(d.Valid)))
+	if err != nil || !equalNaN(back.X, []float64{1, math.NaN(), math.NaN()}) || !slices.Equal(back.Valid, d.Valid) {
+		t.Errorf("FromArrow() of ToArrow() = %+v, %v, want the mask %v", back, err, d.Valid)
+	}
+
+	d.Y = d.Y[:2]
+	if err := d.ToArrow(&x, &y); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("ToArrow() of ragged series error = %v, want %v", err, ErrLengthMismatch)
+	}
+}
+
+func TestFrameToArrow(t *testing.T) {
+	f, err := NewFrame([]string{"a", "b"}, [][]float64{{1, 2}, {3, 4}})
+	if err != nil {
+		t.Fatalf("NewFrame() unexpected error: %v", err)
+	}
+	var a, b fakeBuilder
+	if err := f.ToArrow(&a, &b); err != nil {
+		t.Fatalf("ToArrow() unexpected error: %v", err)
+	}
+	if !slices.Equal(a.values, []float64{1, 2}) || !slices.Equal(b.values, []float64{3, 4}) {
+		t.Errorf("ToArrow() appended %v and %v, want [1 2] and [3 4]", a.values, b.values)
+	}
+	if err := f.ToArrow(&a); err == nil {
+		t.Errorf("ToArrow() with too few builders expected error but got none")
+	}
+}
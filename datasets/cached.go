@@ -0,0 +1,114 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"slices"
+	"sync"
+)
+
+// Cached holds a dataset whose Summary is computed on first use and kept
+// until the values change, for analysis loops that ask for the statistics
+// of the same data many times. The values are changed only through Set and
+// Append, which discard the kept Summary, so it is never out of date.
+//
+// A Cached is safe for concurrent use.
+type Cached struct {
+	mu sync.Mutex
+	d  Dataset
+	// summary is the Summary of d, or nil if it has not been computed
+	// since d last changed.
+	summary *Summary
+}
+
+// NewCached returns a Cached holding a copy of d.
+func NewCached(d Dataset) *Cached {
+	d.X = slices.Clone(d.X)
+	d.Y = slices.Clone(d.Y)
+	d.Valid = slices.Clone(d.Valid)
+
+	return &Cached{mu: sync.Mutex{}, d: d, summary: nil}
+}
+
+// Dataset returns a copy of the dataset held by c.
+func (c *Cached) Dataset() Dataset {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	d := c.d
+	d.X = slices.Clone(c.d.X)
+	d.Y = slices.Clone(c.d.Y)
+	d.Valid = slices.Clone(c.d.Valid)
+
+	return d
+}
+
+// Len returns the number of pairs.
+func (c *Cached) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.d.X)
+}
+
+// Summary returns the descriptive statistics of the dataset, as
+// Dataset.Summary does, computing them only if the values have changed
+// since the last call.
+func (c *Cached) Summary() Summary {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.summary == nil {
+		s := c.d.Summary()
+		c.summary = &s
+	}
+
+	return *c.summary
+}
+
+// Set sets pair i to (x, y), marking it present if the dataset has a Valid
+// mask.
+//
+// It panics if i is out of range.
+func (c *Cached) Set(i int, x, y float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if i < 0 || i >= len(c.d.X) || i >= len(c.d.Y) {
+		panic("datasets: cached pair index out of range")
+	}
+	c.d.X[i] = x
+	c.d.Y[i] = y
+	if c.d.Valid != nil {
+		c.d.Valid[i] = true
+	}
+	c.summary = nil
+}
+
+// Append adds points to the end of the dataset, marking them present if
+// the dataset has a Valid mask.
+func (c *Cached) Append(points ...Point) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, p := range points {
+		c.d.X = append(c.d.X, p.X)
+		c.d.Y = append(c.d.Y, p.Y)
+		if c.d.Valid != nil {
+			c.d.Valid = append(c.d.Valid, true)
+		}
+	}
+	c.summary = nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestCached(t *testing.T) {
+	d := AnscombeI
+	d.X = slices.Clone(d.X)
+	c := NewCached(d)
+	if c.summary != nil {
+		t.Fatalf("NewCached() computed the summary before it was asked for")
+	}
+	if got, want := c.Summary(), d.Summary(); got != want {
+		t.Errorf("Summary() = %+v, want %+v", got, want)
+	}
+	if c.summary == nil {
+		t.Fatalf("Summary() did not keep the summary")
+	}
+
+	// The held values are a copy.
+	d.X[0] = 100
+	if got := c.Dataset().X[0]; got != 10 {
+		t.Errorf("Dataset().X[0] after changing the original = %v, want 10", got)
+	}
+	c.Dataset().Y[0] = 100
+	if got := c.Dataset().Y[0]; got != 8.04 {
+		t.Errorf("Dataset().Y[0] after changing a copy = %v, want 8.04", got)
+	}
+
+	c.Set(0, 100, 8.04)
+	if c.summary != nil {
+		t.Errorf("Set() kept the old summary")
+	}
+	if got, want := c.Summary(), d.Summary(); got != want {
+		t.Errorf("Summary() after Set() = %+v, want %+v", got, want)
+	}
+
+	c.Append(Point{X: 1, Y: 2}, Point{X: 3, Y: 4})
+	d = d.Append(Point{X: 1, Y: 2}, Point{X: 3, Y: 4})
+	if got := c.Len(); got != 13 {
+		t.Errorf("Len() after Append() = %d, want 13", got)
+	}
+	if got, want := c.Summary(), d.Summary(); got != want {
+		t.Errorf("Summary() after Append() = %+v, want %+v", got, want)
+	}
+}
+
+func TestCachedValid(t *testing.T) {
+	c := NewCached(OldFaithfulMissing)
+	c.Set(5, 3, 70)
+	c.Append(Point{X: 2, Y: 50})
+	d := c.Dataset()
+	if !d.Valid[5] || !d.Valid[272] || len(d.Valid) != 273 {
+		t.Errorf("Valid after Set() and Append() = %v, %v with length %d, want true, true with length 273",
+			d.Valid[5], d.Valid[272], len(d.Valid))
+	}
+}
+
+func TestCachedSetPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Set() out of range did not panic")
+		}
+	}()
+	NewCached(AnscombeI).Set(11, 0, 0)
+}
+
+func TestCachedConcurrent(t *testing.T) {
+	c := NewCached(DatasaurusDino)
+	want := DatasaurusDino.Summary()
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := c.Summary(); got != want {
+				t.Errorf("Summary() = %+v, want %+v", got, want)
+			}
+		}()
+	}
+	wg.Wait()
+}
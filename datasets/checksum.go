@@ -0,0 +1,110 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"math"
+	"strings"
+)
+
+// checksumVersion starts the data hashed by Checksum, so that a change to
+// the canonical form changes every checksum.
+const checksumVersion = "datasets.Checksum v1\n"
+
+// canonicalNaN is the bits that Checksum hashes for every NaN.
+const canonicalNaN = 0x7ff8000000000000
+
+// Checksum returns the SHA-256 checksum, in lowercase hexadecimal, of the
+// values of the dataset, for checking that data read in different runs or
+// places is the same. Record the checksum of a dataset an analysis was
+// written against and pass it to Verify to detect any change to it.
+//
+// Only the values are covered, not the name or other metadata. They are
+// hashed in a canonical form, in which every NaN is the same, -0 equals 0,
+// and the values of the pairs that Valid marks missing are left out, so a
+// nil Valid and one that is all true give the same checksum.
+func (d Dataset) Checksum() string {
+	h := sha256.New()
+	h.Write([]byte(checksumVersion))
+	writeSeries(h, d.X, d.Valid)
+	writeSeries(h, d.Y, d.Valid)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify returns an error if the Checksum of the dataset is not sum, which
+// may be in either case.
+func (d Dataset) Verify(sum string) error {
+	return verifyChecksum(d.Checksum(), sum)
+}
+
+// Checksum returns the SHA-256 checksum, in lowercase hexadecimal, of the
+// checksums of the datasets of the collection in order, which changes if
+// any dataset changes or the datasets are reordered.
+func (ds Datasets) Checksum() string {
+	h := sha256.New()
+	h.Write([]byte(checksumVersion))
+	for _, d := range ds.Data {
+		h.Write([]byte(d.Checksum()))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Verify returns an error if the Checksum of the collection is not sum,
+// which may be in either case.
+func (ds Datasets) Verify(sum string) error {
+	return verifyChecksum(ds.Checksum(), sum)
+}
+
+// verifyChecksum returns an error if got is not want, ignoring case.
+func verifyChecksum(got, want string) error {
+	if got != strings.ToLower(want) {
+		return errors.New("checksum " + got + " does not match " + want)
+	}
+
+	return nil
+}
+
+// writeSeries writes the canonical form of vals to h: the number of values
+// and then each value, as a 0 byte if valid marks it missing, or a 1 byte
+// and its IEEE 754 bits, with NaN and -0 canonicalized, in big-endian
+// order.
+func writeSeries(h hash.Hash, vals []float64, valid []bool) {
+	var buf [9]byte
+	h.Write(binary.BigEndian.AppendUint64(buf[:0], uint64(len(vals))))
+	for i, v := range vals {
+		if i < len(valid) && !valid[i] {
+			h.Write([]byte{0})
+
+			continue
+		}
+		bits := math.Float64bits(v)
+		switch {
+		case math.IsNaN(v):
+			bits = canonicalNaN
+		case v == 0:
+			bits = 0
+		}
+		buf[0] = 1
+		binary.BigEndian.PutUint64(buf[1:], bits)
+		h.Write(buf[:])
+	}
+}
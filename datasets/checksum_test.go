@@ -0,0 +1,112 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestChecksumBuiltin(t *testing.T) {
+	// Pinned checksums detect any change to the bundled data.
+	if got, want := AnscombeI.Checksum(), "43c79fe682e64ead17cdd6e19cbeebbbe46b8d0cf5152002708850d14f5888bc"; got != want {
+		t.Errorf("AnscombeI.Checksum() = %s, want %s", got, want)
+	}
+	if got, want := AnscombeQuartet.Checksum(), "d2aa7d73fcbc5532e9a6674f21cf7d973f7dbf8349a66b007ae9f484e44232c6"; got != want {
+		t.Errorf("AnscombeQuartet.Checksum() = %s, want %s", got, want)
+	}
+}
+
+func TestChecksumCanonical(t *testing.T) {
+	base := Dataset{Name: "a", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "",
+		X: []float64{1, 0, math.NaN()}, Y: []float64{4, 5, 6}, Valid: nil}
+	sum := base.Checksum()
+
+	same := []struct {
+		name string
+		d    Dataset
+	}{
+		{name: "metadata", d: Dataset{Name: "b", Description: "other", Attribution: "x", XLabel: "l", YLabel: "m", XUnits: "kg", YUnits: "m",
+			X: []float64{1, 0, math.NaN()}, Y: []float64{4, 5, 6}, Valid: nil}},
+		{name: "negative zero and NaN payload", d: Dataset{Name: "a", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "",
+			X: []float64{1, math.Copysign(0, -1), math.Float64frombits(0x7ff0000000000123)}, Y: []float64{4, 5, 6}, Valid: nil}},
+		{name: "all valid", d: Dataset{Name: "a", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "",
+			X: []float64{1, 0, math.NaN()}, Y: []float64{4, 5, 6}, Valid: []bool{true, true, true}}},
+	}
+	for _, test := range same {
+		if got := test.d.Checksum(); got != sum {
+			t.Errorf("Checksum() with different %s = %s, want %s", test.name, got, sum)
+		}
+	}
+
+	different := []struct {
+		name string
+		d    Dataset
+	}{
+		{name: "value", d: Dataset{Name: "a", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "",
+			X: []float64{1, 0, math.NaN()}, Y: []float64{4, 5, 6.000000000000001}, Valid: nil}},
+		{name: "swapped series", d: Dataset{Name: "a", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "",
+			X: []float64{4, 5, 6}, Y: []float64{1, 0, math.NaN()}, Valid: nil}},
+		{name: "moved value", d: Dataset{Name: "a", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "",
+			X: []float64{1, 0}, Y: []float64{math.NaN(), 4, 5, 6}, Valid: nil}},
+		{name: "missing pair", d: Dataset{Name: "a", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "",
+			X: []float64{1, 0, math.NaN()}, Y: []float64{4, 5, 6}, Valid: []bool{true, false, true}}},
+	}
+	for _, test := range different {
+		if got := test.d.Checksum(); got == sum {
+			t.Errorf("Checksum() with different %s is unchanged", test.name)
+		}
+	}
+
+	// The values of missing pairs are not covered.
+	a := Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "",
+		X: []float64{1, math.NaN()}, Y: []float64{2, 3}, Valid: []bool{true, false}}
+	b := Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "",
+		X: []float64{1, 7}, Y: []float64{2, 8}, Valid: []bool{true, false}}
+	if a.Checksum() != b.Checksum() {
+		t.Errorf("Checksum() depends on the values of missing pairs")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// The builtin values are shared, so the ones altered are copied first.
+	d := AnscombeII
+	d.Y = slices.Clone(d.Y)
+	sum := d.Checksum()
+	if err := d.Verify(sum); err != nil {
+		t.Errorf("Verify() unexpected error: %v", err)
+	}
+	if err := d.Verify(strings.ToUpper(sum)); err != nil {
+		t.Errorf("Verify() of an uppercase checksum unexpected error: %v", err)
+	}
+
+	d.Y[3] += 0.01
+	if err := d.Verify(sum); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("Verify() of altered data error = %v, want a mismatch", err)
+	}
+
+	ds := DatasaurusDozen
+	ds.Data = slices.Clone(ds.Data)
+	sum = ds.Checksum()
+	if err := ds.Verify(sum); err != nil {
+		t.Errorf("Datasets.Verify() unexpected error: %v", err)
+	}
+	ds.Data[0], ds.Data[1] = ds.Data[1], ds.Data[0]
+	if err := ds.Verify(sum); err == nil {
+		t.Errorf("Datasets.Verify() of reordered datasets expected error but got none")
+	}
+}
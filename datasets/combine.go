@@ -0,0 +1,157 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+)
+
+// Point is a single pair of values, as added to a Dataset by Append.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Append returns the dataset with points added to the end, for building
+// up a dataset as data arrives:
+//
+//	d = d.Append(datasets.Point{X: 1.5, Y: 2.25})
+//
+// If the dataset has a Valid mask, the new pairs are marked present. As
+// with the builtin append, the series grow in place when they have room
+// and are reallocated with extra room when they do not, so building a
+// dataset a point at a time takes amortized constant time per point. The
+// result may share memory with d, which should not be appended to again.
+func (d Dataset) Append(points ...Point) Dataset {
+	for _, p := range points {
+		d.X = append(d.X, p.X)
+		d.Y = append(d.Y, p.Y)
+		if d.Valid != nil {
+			d.Valid = append(d.Valid, true)
+		}
+	}
+
+	return d
+}
+
+// Concat returns a dataset of the pairs of a followed by those of b.
+//
+// The metadata is reconciled field by field: a value set in only one of
+// the datasets, or the same in both, is kept. Differing names are joined
+// with " + ", and differing descriptions and attributions with "; ", so
+// that no source is lost. The labels and units must not differ, since the
+// values would not then measure the same things. If either dataset has a
+// Valid mask, so does the result.
+//
+// An error is returned if the labels or units differ, or X and Y of either
+// dataset differ in length.
+func Concat(a, b Dataset) (Dataset, error) {
+	if len(a.X) != len(a.Y) || len(b.X) != len(b.Y) {
+		return Dataset{}, errors.New("X and Y must have the same length")
+	}
+	out := Dataset{
+		Name:        joinMetadata(a.Name, b.Name, " + "),
+		Description: joinMetadata(a.Description, b.Description, "; "),
+		Attribution: joinMetadata(a.Attribution, b.Attribution, "; "),
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           slices.Concat(a.X, b.X),
+		Y:           slices.Concat(a.Y, b.Y),
+		Valid:       nil,
+	}
+	for _, f := range []struct {
+		name string
+		dst  *string
+		a, b string
+	}{
+		{"X labels", &out.XLabel, a.XLabel, b.XLabel},
+		{"Y labels", &out.YLabel, a.YLabel, b.YLabel},
+		{"X units", &out.XUnits, a.XUnits, b.XUnits},
+		{"Y units", &out.YUnits, a.YUnits, b.YUnits},
+	} {
+		if f.a != "" && f.b != "" && f.a != f.b {
+			return Dataset{}, errors.New(f.name + " differ: " + strconv.Quote(f.a) + " and " + strconv.Quote(f.b))
+		}
+		*f.dst = joinMetadata(f.a, f.b, "")
+	}
+	if a.Valid != nil || b.Valid != nil {
+		out.Valid = slices.Concat(validMask(a), validMask(b))
+	}
+
+	return out, nil
+}
+
+// Merge returns a collection holding the datasets of ds and other, for
+// adding a new batch of observations to a collection of named series.
+// Datasets of other with the same Name as one in ds are concatenated to it
+// by Concat, and the rest are added at the end, in order. The metadata of
+// the collections is reconciled as by Concat.
+//
+// An error is returned if a pair of datasets with the same name cannot be
+// concatenated.
+func (ds Datasets) Merge(other Datasets) (Datasets, error) {
+	out := Datasets{
+		Name:        joinMetadata(ds.Name, other.Name, " + "),
+		Description: joinMetadata(ds.Description, other.Description, "; "),
+		Attribution: joinMetadata(ds.Attribution, other.Attribution, "; "),
+		Data:        slices.Clone(ds.Data),
+	}
+	for _, d := range other.Data {
+		i := slices.IndexFunc(out.Data, func(e Dataset) bool { return e.Name == d.Name })
+		if i < 0 {
+			out.Data = append(out.Data, d)
+
+			continue
+		}
+		merged, err := Concat(out.Data[i], d)
+		if err != nil {
+			return Datasets{}, errors.New("dataset " + strconv.Quote(d.Name) + ": " + err.Error())
+		}
+		out.Data[i] = merged
+	}
+
+	return out, nil
+}
+
+// joinMetadata returns a if b is empty or the same, b if a is empty, and
+// otherwise a and b joined by sep.
+func joinMetadata(a, b, sep string) string {
+	switch {
+	case b == "" || a == b:
+		return a
+	case a == "":
+		return b
+	default:
+		return a + sep + b
+	}
+}
+
+// validMask returns the Valid mask of d, or a mask marking every pair
+// present if it has none.
+func validMask(d Dataset) []bool {
+	if d.Valid != nil {
+		return d.Valid
+	}
+	mask := make([]bool, len(d.X))
+	for i := range mask {
+		mask[i] = true
+	}
+
+	return mask
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDatasetAppend(t *testing.T) {
+	d := Dataset{Name: "lab", Description: "", Attribution: "", XLabel: "t", YLabel: "", XUnits: "", YUnits: "", X: []float64{1}, Y: []float64{2}, Valid: []bool{false}}
+	got := d.Append(Point{X: 3, Y: 4}, Point{X: 5, Y: 6})
+	if !slices.Equal(got.X, []float64{1, 3, 5}) || !slices.Equal(got.Y, []float64{2, 4, 6}) {
+		t.Errorf("Append() = %v, %v, want [1 3 5], [2 4 6]", got.X, got.Y)
+	}
+	if !slices.Equal(got.Valid, []bool{false, true, true}) || got.Name != "lab" || got.XLabel != "t" {
+		t.Errorf("Append() Valid = %v named %q labelled %q, want [false true true] named lab labelled t", got.Valid, got.Name, got.XLabel)
+	}
+	if len(d.X) != 1 || d.X[0] != 1 || len(d.Valid) != 1 {
+		t.Errorf("Append() changed the original to %v with Valid %v", d.X, d.Valid)
+	}
+
+	empty := Dataset{}.Append(Point{X: 1, Y: 2})
+	if !slices.Equal(empty.X, []float64{1}) || empty.Valid != nil {
+		t.Errorf("Append() to an empty dataset = %v with Valid %v, want [1] with none", empty.X, empty.Valid)
+	}
+}
+
+func TestDatasetAppendGrowth(t *testing.T) {
+	// Adding points one at a time reallocates the series only as they
+	// fill up, not on every call.
+	allocs := testing.AllocsPerRun(10, func() {
+		d := Dataset{Valid: []bool{}}
+		for i := range 1000 {
+			d = d.Append(Point{X: float64(i), Y: float64(i)})
+		}
+	})
+	if allocs > 100 {
+		t.Errorf("Append() of 1000 points made %v allocations, want at most 100", allocs)
+	}
+}
+
+func TestConcat(t *testing.T) {
+	a := Dataset{Name: "week 1", Description: "trial", Attribution: "lab A", XLabel: "dose", YLabel: "", XUnits: "mg", YUnits: "", X: []float64{1, 2}, Y: []float64{3, 4}, Valid: nil}
+	b := Dataset{Name: "week 2", Description: "trial", Attribution: "lab B", XLabel: "", YLabel: "response", XUnits: "mg", YUnits: "", X: []float64{5}, Y: []float64{6}, Valid: []bool{false}}
+	got, err := Concat(a, b)
+	if err != nil {
+		t.Fatalf("Concat() unexpected error: %v", err)
+	}
+	if !slices.Equal(got.X, []float64{1, 2, 5}) || !slices.Equal(got.Y, []float64{3, 4, 6}) || !slices.Equal(got.Valid, []bool{true, true, false}) {
+		t.Errorf("Concat() = %v, %v, %v", got.X, got.Y, got.Valid)
+	}
+	if got.Name != "week 1 + week 2" || got.Description != "trial" || got.Attribution != "lab A; lab B" {
+		t.Errorf("Concat() metadata = %q, %q, %q", got.Name, got.Description, got.Attribution)
+	}
+	if got.XLabel != "dose" || got.YLabel != "response" || got.XUnits != "mg" || got.YUnits != "" {
+		t.Errorf("Concat() labels = %q, %q, %q, %q", got.XLabel, got.YLabel, got.XUnits, got.YUnits)
+	}
+}
+
+func TestConcatErrors(t *testing.T) {
+	labelled := func(xLabel, yLabel, xUnits string, x, y []float64) Dataset {
+		return Dataset{Name: "", Description: "", Attribution: "", XLabel: xLabel, YLabel: yLabel, XUnits: xUnits, YUnits: "", X: x, Y: y, Valid: nil}
+	}
+	one := []float64{1}
+	tests := []struct {
+		name    string
+		a, b    Dataset
+		wantErr string
+	}{
+		{
+			name:    "units differ",
+			a:       labelled("", "", "kg", one, one),
+			b:       labelled("", "", "lb", one, one),
+			wantErr: `X units differ: "kg" and "lb"`,
+		},
+		{
+			name:    "labels differ",
+			a:       labelled("", "height", "", one, one),
+			b:       labelled("", "age", "", one, one),
+			wantErr: `Y labels differ: "height" and "age"`,
+		},
+		{
+			name:    "length mismatch",
+			a:       labelled("", "", "", one, one),
+			b:       labelled("", "", "", one, nil),
+			wantErr: "same length",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Concat(test.a, test.b)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Concat() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestDatasetsMerge(t *testing.T) {
+	series := func(name string, vals ...float64) Dataset {
+		return Dataset{Name: name, Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: vals, Y: vals, Valid: nil}
+	}
+	ds := Datasets{Name: "sensors", Description: "", Attribution: "", Data: []Dataset{series("a", 1), series("b", 2)}}
+	batch := Datasets{Name: "sensors", Description: "batch 2", Attribution: "", Data: []Dataset{series("b", 3), series("c", 4)}}
+	got, err := ds.Merge(batch)
+	if err != nil {
+		t.Fatalf("Merge() unexpected error: %v", err)
+	}
+	if got.Name != "sensors" || got.Description != "batch 2" {
+		t.Errorf("Merge() metadata = %q, %q, want sensors, batch 2", got.Name, got.Description)
+	}
+	want := map[string][]float64{"a": {1}, "b": {2, 3}, "c": {4}}
+	var names []string
+	for _, d := range got.Data {
+		names = append(names, d.Name)
+		if !slices.Equal(d.X, want[d.Name]) {
+			t.Errorf("Merge() dataset %s = %v, want %v", d.Name, d.X, want[d.Name])
+		}
+	}
+	if !slices.Equal(names, []string{"a", "b", "c"}) {
+		t.Errorf("Merge() datasets = %v, want [a b c]", names)
+	}
+	if len(ds.Data[1].X) != 1 {
+		t.Errorf("Merge() changed the original collection")
+	}
+
+	bad := series("a", 5)
+	bad.XUnits = "s"
+	ds.Data[0].XUnits = "ms"
+	if _, err := ds.Merge(Datasets{Name: "", Description: "", Attribution: "", Data: []Dataset{bad}}); err == nil || !strings.Contains(err.Error(), `dataset "a"`) {
+		t.Errorf("Merge() error = %v, want one naming dataset a", err)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// gzipMagic starts gzip compressed data.
+const gzipMagic = "\x1f\x8b"
+
+// decompress returns a reader of the decompressed contents of r if r
+// starts with gzip or zstd compressed data, and of r itself otherwise.
+func decompress(r io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(4)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	switch {
+	case bytes.HasPrefix(magic, []byte(gzipMagic)):
+		return gzip.NewReader(br)
+	case isZstd(magic):
+		return newZstdReader(br), nil
+	default:
+		return br, nil
+	}
+}
+
+// decompressAt is decompress for random access readers. Compressed data
+// is decompressed in full into memory, as the formats that need random
+// access keep their index at the end.
+func decompressAt(r io.ReaderAt, size int64) (io.ReaderAt, int64, error) {
+	var magic [4]byte
+	n, err := r.ReadAt(magic[:], 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, 0, err
+	}
+	if !bytes.HasPrefix(magic[:n], []byte(gzipMagic)) && !isZstd(magic[:n]) {
+		return r, size, nil
+	}
+
+	dr, err := decompress(io.NewSectionReader(r, 0, size))
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := io.ReadAll(dr)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return bytes.NewReader(data), int64(len(data)), nil
+}
+
+// isZstd reports whether magic starts a zstd frame or a skippable frame.
+func isZstd(magic []byte) bool {
+	if len(magic) < 4 {
+		return false
+	}
+	m := binary.LittleEndian.Uint32(magic)
+
+	return m == zstdMagic || m&^0xf == zstdSkippableMagic
+}
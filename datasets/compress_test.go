@@ -0,0 +1,192 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// gzipBytes returns data compressed with gzip.
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		t.Fatalf("gzip.Writer.Write() unexpected error: %v", err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("gzip.Writer.Close() unexpected error: %v", err)
+	}
+
+	return buf.Bytes()
+}
+
+// readTestdata returns the contents of a file under testdata.
+func readTestdata(t *testing.T, elem ...string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(append([]string{"testdata"}, elem...)...))
+	if err != nil {
+		t.Fatalf("os.ReadFile() unexpected error: %v", err)
+	}
+
+	return data
+}
+
+func TestDecompress(t *testing.T) {
+	tests := []struct {
+		name  string
+		input []byte
+		want  string
+	}{
+		{name: "plain", input: []byte("x,y\n1,2\n"), want: "x,y\n1,2\n"},
+		{name: "shorter than a magic number", input: []byte("1"), want: "1"},
+		{name: "empty", input: nil, want: ""},
+		{name: "gzip", input: gzipBytes(t, []byte("x,y\n1,2\n")), want: "x,y\n1,2\n"},
+		{name: "zstd", input: []byte(zstdHello), want: "hello, world"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r, err := decompress(bytes.NewReader(test.input))
+			if err != nil {
+				t.Fatalf("decompress() unexpected error: %v", err)
+			}
+			got, err := io.ReadAll(r)
+			if err != nil {
+				t.Fatalf("io.ReadAll() unexpected error: %v", err)
+			}
+			if string(got) != test.want {
+				t.Errorf("decompress() read %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestFromCSVCompressed(t *testing.T) {
+	plain := readTestdata(t, "compressed", "points.csv")
+	want, err := FromCSV(bytes.NewReader(plain), ColumnName("x"), ColumnName("y"))
+	if err != nil {
+		t.Fatalf("FromCSV() unexpected error: %v", err)
+	}
+
+	inputs := map[string][]byte{
+		"gzip":          gzipBytes(t, plain),
+		"zstd":          readTestdata(t, "compressed", "points.csv.zst"),
+		"zstd stream":   readTestdata(t, "compressed", "points_stream.csv.zst"),
+		"zstd 2 frames": readTestdata(t, "compressed", "points_multi.csv.zst"),
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			got, err := FromCSV(bytes.NewReader(input), ColumnName("x"), ColumnName("y"))
+			if err != nil {
+				t.Fatalf("FromCSV() unexpected error: %v", err)
+			}
+			if !equalNaN(got.X, want.X) || !equalNaN(got.Y, want.Y) {
+				t.Errorf("FromCSV() read %d pairs that differ from points.csv", len(got.X))
+			}
+		})
+	}
+}
+
+func TestFromJSONCompressed(t *testing.T) {
+	input := gzipBytes(t, []byte(`{"name": "packed", "x": [1, 2, 3], "y": [4, null, 6]}`))
+	got, err := FromJSON(bytes.NewReader(input))
+	if err != nil {
+		t.Fatalf("FromJSON() unexpected error: %v", err)
+	}
+	if got.Name != "packed" || len(got.X) != 3 || got.X[2] != 3 || got.Y[2] != 6 {
+		t.Errorf("FromJSON() = %+v, want the packed dataset", got)
+	}
+}
+
+func TestFromParquetCompressed(t *testing.T) {
+	plain := readTestdata(t, "parquet", "plain.parquet")
+	inputs := map[string][]byte{
+		"gzip": gzipBytes(t, plain),
+		"zstd": readTestdata(t, "compressed", "plain.parquet.zst"),
+	}
+	for name, input := range inputs {
+		t.Run(name, func(t *testing.T) {
+			r := bytes.NewReader(input)
+			got, err := FromParquet(r, r.Size(), ColumnName("a"), ColumnName("b"))
+			if err != nil {
+				t.Fatalf("FromParquet() unexpected error: %v", err)
+			}
+			if len(got.X) != 300 {
+				t.Fatalf("FromParquet() read %d pairs, want 300", len(got.X))
+			}
+			for i := range 300 {
+				if got.X[i] != parquetA(i) || got.Y[i] != parquetB(i) {
+					t.Fatalf("FromParquet() pair %d = (%v, %v), want (%v, %v)", i, got.X[i], got.Y[i], parquetA(i), parquetB(i))
+				}
+			}
+		})
+	}
+}
+
+func TestFromXLSXCompressed(t *testing.T) {
+	plain := readTestdata(t, "xlsx", "workbook.xlsx")
+	r := bytes.NewReader(plain)
+	want, err := FromXLSX(r, r.Size(), ColumnName("dose"), ColumnName("response"), WithSheet("Data"))
+	if err != nil {
+		t.Fatalf("FromXLSX() unexpected error: %v", err)
+	}
+
+	r = bytes.NewReader(gzipBytes(t, plain))
+	got, err := FromXLSX(r, r.Size(), ColumnName("dose"), ColumnName("response"), WithSheet("Data"))
+	if err != nil {
+		t.Fatalf("FromXLSX() unexpected error: %v", err)
+	}
+	if got.Name != want.Name || !equalNaN(got.X, want.X) || !equalNaN(got.Y, want.Y) {
+		t.Errorf("FromXLSX() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFromCSVCompressedErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "corrupt gzip header",
+			input:   "\x1f\x8b\x07\x00\x00\x00\x00\x00\x00\x03",
+			wantErr: "gzip",
+		},
+		{
+			name:    "corrupt zstd checksum",
+			input:   zstdHello[:len(zstdHello)-1] + "\x00",
+			wantErr: "zstd checksum mismatch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := FromCSV(strings.NewReader(test.input), ColumnIndex(0), ColumnIndex(1))
+			if err == nil {
+				t.Fatalf("FromCSV() expected error containing %q but got none", test.wantErr)
+			}
+			if !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("FromCSV() error = %q, want it to contain %q", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// ContingencyTable holds the counts of observations of two categorical
+// variables, one row per category of the first and one column per category
+// of the second.
+type ContingencyTable struct {
+	// Name provides a descriptive name for the table
+	Name string
+	// Description provides additional context about the table
+	Description string
+	// Attribution provides reference to the authoritative source for this table
+	Attribution string
+	// RowLabel names the variable of the rows
+	RowLabel string
+	// ColumnLabel names the variable of the columns
+	ColumnLabel string
+	// Rows names the categories of the rows, in order
+	Rows []string
+	// Columns names the categories of the columns, in order
+	Columns []string
+	// Counts holds the number of observations in each row and column
+	Counts [][]float64
+	// Ordinal is whether the categories of both variables are ordered, so
+	// that measures of ordinal association such as gamma apply
+	Ordinal bool
+}
+
+// Total returns the number of observations in the table.
+func (t ContingencyTable) Total() float64 {
+	total := 0.0
+	for _, row := range t.Counts {
+		for _, c := range row {
+			total += c
+		}
+	}
+
+	return total
+}
+
+// Dataset returns the observations of the table as a Dataset of one pair
+// per observation, with the row and column categories coded as 1, 2, and
+// so on in order as X and Y. This is the form that rank correlations such
+// as Kendall's tau-b take; the codes mean nothing for nominal categories.
+// Its XLabel and YLabel are the row and column labels.
+//
+// An error is returned if Counts does not have one row per category of
+// Rows and one column per category of Columns, or a count is not a finite
+// whole number of at least zero.
+func (t ContingencyTable) Dataset() (Dataset, error) {
+	if len(t.Counts) != len(t.Rows) {
+		return Dataset{}, errors.New("counts must have one row per row category")
+	}
+	n := 0
+	for i, row := range t.Counts {
+		if len(row) != len(t.Columns) {
+			return Dataset{}, errors.New("row " + strconv.Itoa(i) + " of counts must have one column per column category")
+		}
+		for _, c := range row {
+			if !(c >= 0) || math.IsInf(c, 1) || c != math.Trunc(c) {
+				return Dataset{}, errors.New("counts must be finite whole numbers of at least zero")
+			}
+			n += int(c)
+		}
+	}
+
+	d := generated(t.Name, t.Description, n)
+	d.Attribution = t.Attribution
+	d.XLabel, d.YLabel = t.RowLabel, t.ColumnLabel
+	k := 0
+	for i, row := range t.Counts {
+		for j, c := range row {
+			for range int(c) {
+				d.X[k] = float64(i + 1)
+				d.Y[k] = float64(j + 1)
+				k++
+			}
+		}
+	}
+
+	return d, nil
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestContingencyTableDataset(t *testing.T) {
+	table := ContingencyTable{
+		Name:        "test",
+		Description: "small table",
+		Attribution: "tests",
+		RowLabel:    "rows",
+		ColumnLabel: "columns",
+		Rows:        []string{"a", "b"},
+		Columns:     []string{"x", "y", "z"},
+		Counts: [][]float64{
+			{2, 0, 1},
+			{0, 1, 0},
+		},
+		Ordinal: false,
+	}
+	if got := table.Total(); got != 4 {
+		t.Errorf("Total() = %v, want 4", got)
+	}
+
+	d, err := table.Dataset()
+	if err != nil {
+		t.Fatalf("Dataset() unexpected error: %v", err)
+	}
+	if d.Name != "test" || d.Description != "small table" || d.Attribution != "tests" || d.XLabel != "rows" || d.YLabel != "columns" {
+		t.Errorf("Dataset() metadata = %q, %q, %q, %q, %q", d.Name, d.Description, d.Attribution, d.XLabel, d.YLabel)
+	}
+	if want := []float64{1, 1, 1, 2}; !slices.Equal(d.X, want) {
+		t.Errorf("Dataset() X = %v, want %v", d.X, want)
+	}
+	if want := []float64{1, 1, 3, 2}; !slices.Equal(d.Y, want) {
+		t.Errorf("Dataset() Y = %v, want %v", d.Y, want)
+	}
+}
+
+func TestContingencyTableDatasetErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		counts  [][]float64
+		wantErr string
+	}{
+		{name: "missing row", counts: [][]float64{{1, 2}}, wantErr: "one row per row category"},
+		{name: "short row", counts: [][]float64{{1, 2}, {3}}, wantErr: "row 1"},
+		{name: "fraction", counts: [][]float64{{1, 2.5}, {3, 4}}, wantErr: "whole numbers"},
+		{name: "negative", counts: [][]float64{{1, -2}, {3, 4}}, wantErr: "whole numbers"},
+		{name: "infinite", counts: [][]float64{{1, 2}, {3, math.Inf(1)}}, wantErr: "whole numbers"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			table := ContingencyTable{Name: "", Description: "", Attribution: "", RowLabel: "", ColumnLabel: "",
+				Rows: []string{"a", "b"}, Columns: []string{"x", "y"}, Counts: test.counts, Ordinal: false}
+			_, err := table.Dataset()
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Dataset() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// Copula is a family of joint distributions of uniform values, used to
+// give generated pairs a chosen dependence independently of their
+// marginal distributions.
+type Copula int
+
+const (
+	// GaussianCopula is the dependence of the bivariate normal
+	// distribution, symmetric in both tails.
+	GaussianCopula Copula = iota
+	// ClaytonCopula has stronger dependence in the lower tail than in the
+	// upper one, and only models positive dependence.
+	ClaytonCopula
+)
+
+// String returns the name of the copula.
+func (c Copula) String() string {
+	switch c {
+	case GaussianCopula:
+		return "Gaussian"
+	case ClaytonCopula:
+		return "Clayton"
+	default:
+		return "Unknown"
+	}
+}
+
+// RankCorrelation selects the rank correlation coefficient targeted by
+// GenerateCopula.
+type RankCorrelation int
+
+const (
+	// KendallTau is Kendall's tau.
+	KendallTau RankCorrelation = iota
+	// SpearmanRho is Spearman's rank correlation coefficient.
+	SpearmanRho
+)
+
+// String returns the name of the coefficient.
+func (r RankCorrelation) String() string {
+	switch r {
+	case KendallTau:
+		return "Kendall's tau"
+	case SpearmanRho:
+		return "Spearman's rho"
+	default:
+		return "Unknown"
+	}
+}
+
+// Marginal is the quantile function of a marginal distribution, mapping a
+// probability in (0, 1) to a value. It must be increasing, so that it
+// keeps the ranks of the values it maps.
+type Marginal func(p float64) float64
+
+// UniformMarginal is the quantile function of the uniform distribution on
+// (0, 1).
+func UniformMarginal(p float64) float64 {
+	return p
+}
+
+// NormalMarginal is the quantile function of the standard normal
+// distribution.
+func NormalMarginal(p float64) float64 {
+	return -math.Sqrt2 * math.Erfcinv(2*p)
+}
+
+// LognormalMarginal is the quantile function of the standard lognormal
+// distribution, whose logarithm is standard normal; it is right skewed.
+func LognormalMarginal(p float64) float64 {
+	return math.Exp(NormalMarginal(p))
+}
+
+// CauchyMarginal is the quantile function of the standard Cauchy
+// distribution, which is so heavy tailed that it has no mean or variance.
+func CauchyMarginal(p float64) float64 {
+	return math.Tan(math.Pi * (p - 0.5))
+}
+
+// CopulaOption configures GenerateCopula.
+type CopulaOption func(*copulaOptions)
+
+// copulaOptions holds the settings accumulated from a list of CopulaOption
+// values.
+type copulaOptions struct {
+	x, y Marginal
+}
+
+// WithMarginals sets the marginal distributions of X and Y. The default is
+// UniformMarginal for both.
+func WithMarginals(x, y Marginal) CopulaOption {
+	return func(o *copulaOptions) {
+		o.x = x
+		o.y = y
+	}
+}
+
+// GenerateCopula returns n pairs drawn from copula with a population rank
+// correlation, measured by coef, of target, for testing rank correlation
+// methods against a known answer.
+//
+// The copula parameter is found from target: for the Gaussian copula the
+// correlation of the underlying normal values is sin(π/2·τ) for Kendall's
+// tau or 2·sin(π/6·ρ) for Spearman's rho, and for the Clayton copula θ is
+// 2τ/(1-τ) for Kendall's tau or is solved for numerically for Spearman's
+// rho. Since rank correlations do not depend on the marginal
+// distributions, the pairs can then be given any marginals, such as
+// LognormalMarginal for skewed data or CauchyMarginal for heavy tails,
+// without changing the population coefficient. The same seed always
+// produces the same ranks, whatever the marginals.
+//
+// An error is returned if n is negative, target is not in [-1, 1], or in
+// [0, 1) for the Clayton copula, or the copula or coefficient is unknown.
+func GenerateCopula(n int, copula Copula, coef RankCorrelation, target float64, seed int64, opts ...CopulaOption) (Dataset, error) {
+	o := copulaOptions{x: UniformMarginal, y: UniformMarginal}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if n < 0 {
+		return Dataset{}, errors.New("number of pairs cannot be negative")
+	}
+	if coef != KendallTau && coef != SpearmanRho {
+		return Dataset{}, errors.New("unknown rank correlation coefficient")
+	}
+	if target < -1 || target > 1 || math.IsNaN(target) {
+		return Dataset{}, errors.New("rank correlation must be in the interval [-1, 1]")
+	}
+
+	var sample func(rng *rand.Rand) (float64, float64)
+	switch copula {
+	case GaussianCopula:
+		rho := math.Sin(math.Pi / 2 * target)
+		if coef == SpearmanRho {
+			rho = 2 * math.Sin(math.Pi/6*target)
+		}
+		sample = gaussianCopula(rho)
+	case ClaytonCopula:
+		if target < 0 || target >= 1 {
+			return Dataset{}, errors.New("the Clayton copula needs a rank correlation in the interval [0, 1)")
+		}
+		tau := target
+		if coef == SpearmanRho {
+			tau = claytonTau(target)
+		}
+		sample = claytonCopula(2 * tau / (1 - tau))
+	default:
+		return Dataset{}, errors.New("unknown copula")
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	d := generated(copula.String()+" copula", "Sample from the "+copula.String()+" copula with "+
+		coef.String()+" "+strconv.FormatFloat(target, 'g', -1, 64)+".", n)
+	for i := range n {
+		u, v := sample(rng)
+		d.X[i] = o.x(u)
+		d.Y[i] = o.y(v)
+	}
+
+	return d, nil
+}
+
+// gaussianCopula returns a sampler of the Gaussian copula whose underlying
+// normal values have correlation rho.
+func gaussianCopula(rho float64) func(rng *rand.Rand) (float64, float64) {
+	s := math.Sqrt(1 - rho*rho)
+
+	return func(rng *rand.Rand) (float64, float64) {
+		z1, z2 := rng.NormFloat64(), rng.NormFloat64()
+
+		return normalCDF(z1), normalCDF(rho*z1 + s*z2)
+	}
+}
+
+// claytonCopula returns a sampler of the Clayton copula with parameter
+// theta >= 0, drawing v from its distribution conditional on u.
+func claytonCopula(theta float64) func(rng *rand.Rand) (float64, float64) {
+	return func(rng *rand.Rand) (float64, float64) {
+		u, w := openUniform(rng), openUniform(rng)
+		if theta == 0 {
+			return u, w
+		}
+		v := math.Pow(math.Pow(u, -theta)*(math.Pow(w, -theta/(1+theta))-1)+1, -1/theta)
+
+		return u, v
+	}
+}
+
+// claytonTau returns the Kendall's tau of the Clayton copula whose
+// Spearman's rho is rho, found by bisection since there is no closed form.
+func claytonTau(rho float64) float64 {
+	lo, hi := 0.0, 1.0
+	for range 30 {
+		mid := (lo + hi) / 2
+		if claytonSpearman(2*mid/(1-mid)) < rho {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return (lo + hi) / 2
+}
+
+// claytonSpearman returns the Spearman's rho of the Clayton copula with
+// parameter theta > 0, 12∬C(u, v) du dv - 3, by the midpoint rule. C is
+// written as a·(1 + (a/b)^θ - a^θ)^(-1/θ) with a = min(u, v) and
+// b = max(u, v), which does not overflow for large θ.
+func claytonSpearman(theta float64) float64 {
+	const steps = 200
+
+	sum := 0.0
+	for i := range steps {
+		u := (float64(i) + 0.5) / steps
+		for j := range steps {
+			v := (float64(j) + 0.5) / steps
+			a, b := min(u, v), max(u, v)
+			sum += a * math.Pow(1+math.Pow(a/b, theta)-math.Pow(a, theta), -1/theta)
+		}
+	}
+
+	return 12*sum/(steps*steps) - 3
+}
+
+// normalCDF returns the standard normal distribution function at x.
+func normalCDF(x float64) float64 {
+	return math.Erfc(-x/math.Sqrt2) / 2
+}
+
+// openUniform returns a uniform value in (0, 1), excluding the 0 that
+// rand.Float64 can return.
+func openUniform(rng *rand.Rand) float64 {
+	for {
+		if u := rng.Float64(); u > 0 {
+			return u
+		}
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/rsned/stats/descriptive"
+)
+
+// kendall returns Kendall's tau-a of data without ties.
+func kendall(x, y []float64) float64 {
+	s := 0.0
+	for i := range x {
+		for j := i + 1; j < len(x); j++ {
+			s += math.Copysign(1, (x[i]-x[j])*(y[i]-y[j]))
+		}
+	}
+	n := float64(len(x))
+
+	return s / (n * (n - 1) / 2)
+}
+
+func TestGenerateCopula(t *testing.T) {
+	tests := []struct {
+		copula Copula
+		coef   RankCorrelation
+		target float64
+	}{
+		{copula: GaussianCopula, coef: KendallTau, target: 0.5},
+		{copula: GaussianCopula, coef: KendallTau, target: -0.7},
+		{copula: GaussianCopula, coef: SpearmanRho, target: 0.3},
+		{copula: GaussianCopula, coef: SpearmanRho, target: -0.9},
+		{copula: ClaytonCopula, coef: KendallTau, target: 0},
+		{copula: ClaytonCopula, coef: KendallTau, target: 0.6},
+		{copula: ClaytonCopula, coef: SpearmanRho, target: 0.4},
+		{copula: ClaytonCopula, coef: SpearmanRho, target: 0.95},
+	}
+
+	for _, test := range tests {
+		d, err := GenerateCopula(2000, test.copula, test.coef, test.target, 5)
+		if err != nil {
+			t.Fatalf("GenerateCopula(%v, %v, %v) unexpected error: %v", test.copula, test.coef, test.target, err)
+		}
+		got := kendall(d.X, d.Y)
+		if test.coef == SpearmanRho {
+			got = pearson(rank(d.X), rank(d.Y))
+		}
+		if math.Abs(got-test.target) > 0.04 {
+			t.Errorf("GenerateCopula(%v, %v, %v) sample coefficient = %v", test.copula, test.coef, test.target, got)
+		}
+		for i := range d.X {
+			if d.X[i] <= 0 || d.X[i] >= 1 || d.Y[i] <= 0 || d.Y[i] >= 1 {
+				t.Fatalf("GenerateCopula(%v, %v, %v) pair %d = (%v, %v), want uniform values",
+					test.copula, test.coef, test.target, i, d.X[i], d.Y[i])
+			}
+		}
+	}
+}
+
+func TestGenerateCopulaMarginals(t *testing.T) {
+	u, _ := GenerateCopula(500, ClaytonCopula, KendallTau, 0.5, 9)
+	d, err := GenerateCopula(500, ClaytonCopula, KendallTau, 0.5, 9, WithMarginals(LognormalMarginal, CauchyMarginal))
+	if err != nil {
+		t.Fatalf("GenerateCopula() unexpected error: %v", err)
+	}
+
+	if !slices.Equal(rank(d.X), rank(u.X)) || !slices.Equal(rank(d.Y), rank(u.Y)) {
+		t.Errorf("GenerateCopula() with marginals changed the ranks")
+	}
+	for i := range d.X {
+		if want := math.Exp(-math.Sqrt2 * math.Erfcinv(2*u.X[i])); math.Abs(d.X[i]-want) > 1e-9*want {
+			t.Fatalf("GenerateCopula() X[%d] = %v, want %v", i, d.X[i], want)
+		}
+	}
+	if m, _ := descriptive.Median(d.Y); math.Abs(m) > 0.2 {
+		t.Errorf("GenerateCopula() Cauchy median = %v, want about 0", m)
+	}
+}
+
+func TestMarginals(t *testing.T) {
+	tests := []struct {
+		name     string
+		marginal Marginal
+		p        float64
+		want     float64
+	}{
+		{name: "uniform", marginal: UniformMarginal, p: 0.3, want: 0.3},
+		{name: "normal median", marginal: NormalMarginal, p: 0.5, want: 0},
+		{name: "normal 97.5%", marginal: NormalMarginal, p: 0.975, want: 1.959963984540054},
+		{name: "lognormal median", marginal: LognormalMarginal, p: 0.5, want: 1},
+		{name: "Cauchy quartile", marginal: CauchyMarginal, p: 0.75, want: 1},
+	}
+
+	for _, test := range tests {
+		if got := test.marginal(test.p); math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("%s(%v) = %v, want %v", test.name, test.p, got, test.want)
+		}
+	}
+}
+
+func TestGenerateCopulaErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		copula  Copula
+		coef    RankCorrelation
+		target  float64
+		wantErr string
+	}{
+		{name: "negative n", n: -1, copula: GaussianCopula, coef: KendallTau, target: 0, wantErr: "negative"},
+		{name: "target out of range", n: 10, copula: GaussianCopula, coef: KendallTau, target: -1.5, wantErr: "[-1, 1]"},
+		{name: "negative Clayton", n: 10, copula: ClaytonCopula, coef: SpearmanRho, target: -0.2, wantErr: "[0, 1)"},
+		{name: "perfect Clayton", n: 10, copula: ClaytonCopula, coef: KendallTau, target: 1, wantErr: "[0, 1)"},
+		{name: "unknown copula", n: 10, copula: Copula(9), coef: KendallTau, target: 0, wantErr: "unknown copula"},
+		{name: "unknown coefficient", n: 10, copula: GaussianCopula, coef: RankCorrelation(9), target: 0, wantErr: "coefficient"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := GenerateCopula(test.n, test.copula, test.coef, test.target, 1)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("GenerateCopula() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCopulaString(t *testing.T) {
+	if got := ClaytonCopula.String(); got != "Clayton" {
+		t.Errorf("ClaytonCopula.String() = %q, want %q", got, "Clayton")
+	}
+	if got := SpearmanRho.String(); got != "Spearman's rho" {
+		t.Errorf("SpearmanRho.String() = %q, want %q", got, "Spearman's rho")
+	}
+	if got := Copula(-1).String(); got != "Unknown" {
+		t.Errorf("Copula(-1).String() = %q, want %q", got, "Unknown")
+	}
+}
@@ -0,0 +1,427 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Column selects a column of a delimited file, by the name in its header
+// row or by position.
+type Column struct {
+	name  string
+	index int
+}
+
+// ColumnName selects the column whose header is name. The file must have a
+// header row.
+func ColumnName(name string) Column {
+	return Column{name: name, index: -1}
+}
+
+// ColumnIndex selects the column at the zero-based position i.
+func ColumnIndex(i int) Column {
+	return Column{name: "", index: i}
+}
+
+// String returns the column name, or its position as "#i".
+func (c Column) String() string {
+	if c.name != "" {
+		return strconv.Quote(c.name)
+	}
+
+	return "#" + strconv.Itoa(c.index)
+}
+
+// CSVOption configures FromCSV.
+type CSVOption func(*csvOptions)
+
+// csvOptions holds the settings accumulated from a list of CSVOption values.
+type csvOptions struct {
+	delimiter rune
+	comment   rune
+	// header is nil to detect a header row, or whether there is one.
+	header       *bool
+	name         string
+	decimalComma bool
+}
+
+// WithDelimiter sets the field delimiter, which may be any rune other than
+// a quote, carriage return or newline, such as ';' or '|'. The default is a
+// comma; FromTSV uses a tab.
+func WithDelimiter(delimiter rune) CSVOption {
+	return func(o *csvOptions) {
+		o.delimiter = delimiter
+	}
+}
+
+// WithComment sets the character that starts a comment line, which is
+// skipped. The default is '#'; 0 disables comments.
+func WithComment(comment rune) CSVOption {
+	return func(o *csvOptions) {
+		o.comment = comment
+	}
+}
+
+// WithHeader states whether the first row is a header, instead of
+// detecting it. By default the first row is taken as a header if either of
+// the selected fields in it is not a number.
+func WithHeader(present bool) CSVOption {
+	return func(o *csvOptions) {
+		o.header = &present
+	}
+}
+
+// WithName sets the Name of the loaded Dataset.
+func WithName(name string) CSVOption {
+	return func(o *csvOptions) {
+		o.name = name
+	}
+}
+
+// WithDecimalComma reads numbers written with a decimal comma, as in
+// "1,5" for one and a half, which is common in European exports. A field
+// containing a period is then not a number, so that "1.500" is rejected
+// rather than misread. Numbers with a decimal comma must be quoted unless
+// the delimiter is changed from a comma, usually to ';'.
+func WithDecimalComma() CSVOption {
+	return func(o *csvOptions) {
+		o.decimalComma = true
+	}
+}
+
+// csvBatch is the number of pairs FromCSV reads at a time.
+const csvBatch = 4096
+
+// FromCSV reads a Dataset from delimited text, taking X from column x and
+// Y from column y of every row:
+//
+//	d, err := datasets.FromCSV(f, datasets.ColumnName("height"), datasets.ColumnName("weight"))
+//	r, err := correlation.CorrelateDataset(d, correlation.Pearson)
+//
+// Blank lines and comment lines are skipped, surrounding spaces are
+// trimmed from the fields, and fields may be quoted as in RFC 4180, which
+// allows them to hold the delimiter, doubled quotes and line breaks. Rows
+// may have different numbers of fields as long as both selected columns
+// are present. Text compressed with gzip or zstd, as in a .csv.gz or
+// .csv.zst file, is decompressed as it is read. XLabel and YLabel are set
+// from the header row of the selected columns, if there is one.
+//
+// An empty field is a missing value. It is read as NaN, and Valid is set to
+// mark the pairs with missing values, telling them apart from fields that
+// hold "NaN". Valid is left nil if no field is empty.
+//
+// To compute over a large file without holding it in memory, read it
+// through CSVSource instead.
+//
+// An error is returned if the text is malformed, a selected column does not
+// exist, a column is selected by name without a header row, or a selected
+// field is neither empty nor a number. The error gives the line of the offending field.
+func FromCSV(r io.Reader, x, y Column, opts ...CSVOption) (Dataset, error) {
+	src := newCSVSource(r, x, y, opts)
+	d := Dataset{Name: src.o.name, Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{}, Y: []float64{}, Valid: nil}
+	err := Each(src, csvBatch, func(x, y []float64) error {
+		if d.Valid == nil && slices.Contains(src.valid, false) {
+			d.Valid = slices.Repeat([]bool{true}, len(d.X))
+		}
+		if d.Valid != nil {
+			d.Valid = append(d.Valid, src.valid...)
+		}
+		d.X = append(d.X, x...)
+		d.Y = append(d.Y, y...)
+
+		return nil
+	})
+	if err != nil {
+		return Dataset{}, err
+	}
+	d.XLabel, d.YLabel = src.xLabel, src.yLabel
+
+	return d, nil
+}
+
+// FromTSV reads a Dataset from tab separated text. It is FromCSV with a tab
+// as the delimiter, and takes the same options.
+func FromTSV(r io.Reader, x, y Column, opts ...CSVOption) (Dataset, error) {
+	return FromCSV(r, x, y, append([]CSVOption{WithDelimiter('\t')}, opts...)...)
+}
+
+// CSVSource returns a DataSource that yields the pairs of columns x and y
+// of delimited text in r as they are read, so that a streaming computation
+// can consume a file of any size in constant memory:
+//
+//	r, err := correlation.CorrelateSource(datasets.CSVSource(f, datasets.ColumnIndex(0), datasets.ColumnIndex(1)), correlation.Pearson)
+//
+// The rows are read as by FromCSV, and a missing value is yielded as NaN.
+// Next returns the pairs before a row that cannot be read together with
+// its error, which is then returned by every later call.
+func CSVSource(r io.Reader, x, y Column, opts ...CSVOption) DataSource {
+	return newCSVSource(r, x, y, opts)
+}
+
+// TSVSource returns a DataSource over tab separated text. It is CSVSource
+// with a tab as the delimiter, and takes the same options.
+func TSVSource(r io.Reader, x, y Column, opts ...CSVOption) DataSource {
+	return newCSVSource(r, x, y, append([]CSVOption{WithDelimiter('\t')}, opts...))
+}
+
+// csvSource is a DataSource over delimited text.
+type csvSource struct {
+	src  io.Reader
+	x, y Column
+	o    csvOptions
+	// cr reads the decompressed text, once the first batch is requested.
+	cr *csv.Reader
+	// xi and yi are the positions of the selected columns.
+	xi, yi int
+	// xLabel and yLabel are the headers of the selected columns, if there
+	// is a header row.
+	xLabel, yLabel string
+	// pending is the first row, when it holds data, until it is yielded.
+	pending []string
+	xs, ys  []float64
+	// valid holds whether each pair of the last batch has both values.
+	valid []bool
+	err   error
+}
+
+// newCSVSource returns a csvSource reading columns x and y of r.
+func newCSVSource(r io.Reader, x, y Column, opts []CSVOption) *csvSource {
+	o := csvOptions{delimiter: ',', comment: '#', header: nil, name: ""}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &csvSource{src: r, x: x, y: y, o: o, cr: nil, xi: 0, yi: 0, xLabel: "", yLabel: "", pending: nil, xs: nil, ys: nil, valid: nil, err: nil}
+}
+
+// Next implements DataSource.
+func (s *csvSource) Next(batch int) ([]float64, []float64, error) {
+	if batch <= 0 {
+		return nil, nil, errors.New("batch size must be positive")
+	}
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	if s.cr == nil {
+		if err := s.start(); err != nil {
+			s.err = err
+
+			return nil, nil, err
+		}
+	}
+
+	s.xs, s.ys, s.valid = s.xs[:0], s.ys[:0], s.valid[:0]
+	for len(s.xs) < batch {
+		record := s.pending
+		s.pending = nil
+		if record == nil {
+			var err error
+			if record, err = s.cr.Read(); err != nil {
+				s.err = err
+
+				return s.xs, s.ys, err
+			}
+		}
+		if err := s.appendRow(record); err != nil {
+			s.err = err
+
+			return s.xs, s.ys, err
+		}
+	}
+
+	return s.xs, s.ys, nil
+}
+
+// start opens the text and reads the first row, resolving the selected
+// columns against it if it is a header, or keeping it as data otherwise.
+func (s *csvSource) start() error {
+	r, err := decompress(s.src)
+	if err != nil {
+		return err
+	}
+	s.cr = csv.NewReader(r)
+	s.cr.Comma = s.o.delimiter
+	s.cr.Comment = s.o.comment
+	s.cr.FieldsPerRecord = -1
+	// Trimming leading space would swallow empty fields when the
+	// delimiter is itself a space, as in tab separated files.
+	s.cr.TrimLeadingSpace = !unicode.IsSpace(s.o.delimiter)
+
+	first, err := s.cr.Read()
+	if err != nil {
+		return err
+	}
+
+	header := s.x.name != "" || s.y.name != "" || !isDataField(first, s.x, s.o.decimalComma) ||
+		!isDataField(first, s.y, s.o.decimalComma)
+	if s.o.header != nil {
+		header = *s.o.header
+	}
+
+	s.xi, s.yi = s.x.index, s.y.index
+	if !header {
+		if s.x.name != "" || s.y.name != "" {
+			return errors.New("columns selected by name need a header row")
+		}
+		s.pending = first
+
+		return nil
+	}
+	if s.xi, err = resolveColumn(first, s.x); err != nil {
+		return err
+	}
+	if s.yi, err = resolveColumn(first, s.y); err != nil {
+		return err
+	}
+	s.xLabel = strings.TrimSpace(first[s.xi])
+	s.yLabel = strings.TrimSpace(first[s.yi])
+
+	return nil
+}
+
+// appendRow parses the selected fields of record and appends them to the
+// batch, noting whether both are present.
+func (s *csvSource) appendRow(record []string) error {
+	xv, err := parseField(s.cr, record, s.xi, s.o.decimalComma)
+	if err != nil {
+		return err
+	}
+	yv, err := parseField(s.cr, record, s.yi, s.o.decimalComma)
+	if err != nil {
+		return err
+	}
+	s.xs = append(s.xs, xv)
+	s.ys = append(s.ys, yv)
+	s.valid = append(s.valid, strings.TrimSpace(record[s.xi]) != "" && strings.TrimSpace(record[s.yi]) != "")
+
+	return nil
+}
+
+// isDataField reports whether the field of record selected by c can be
+// data, being a number or empty. A column selected by name never matches.
+func isDataField(record []string, c Column, decimalComma bool) bool {
+	if c.name != "" || c.index < 0 || c.index >= len(record) {
+		return false
+	}
+	if strings.TrimSpace(record[c.index]) == "" {
+		return true
+	}
+	_, err := parseNumber(record[c.index], decimalComma)
+
+	return err == nil
+}
+
+// resolveColumn returns the position of column c given the header row.
+func resolveColumn(header []string, c Column) (int, error) {
+	if c.name == "" {
+		if c.index < 0 || c.index >= len(header) {
+			return 0, errors.New("column " + c.String() + " is out of range")
+		}
+
+		return c.index, nil
+	}
+	for i, h := range header {
+		if strings.TrimSpace(h) == c.name {
+			return i, nil
+		}
+	}
+
+	return 0, errors.New("column " + c.String() + " not found in header")
+}
+
+// parseField parses field i of the record last read by cr as a number, or
+// as NaN if it is empty.
+func parseField(cr *csv.Reader, record []string, i int, decimalComma bool) (float64, error) {
+	if i < 0 || i >= len(record) {
+		line, _ := cr.FieldPos(0)
+
+		return 0, errors.New("line " + strconv.Itoa(line) + ": missing column #" + strconv.Itoa(i))
+	}
+	if strings.TrimSpace(record[i]) == "" {
+		return math.NaN(), nil
+	}
+	v, err := parseNumber(record[i], decimalComma)
+	if err != nil {
+		line, col := cr.FieldPos(i)
+
+		return 0, errors.New("line " + strconv.Itoa(line) + ", column " + strconv.Itoa(col) +
+			": " + strconv.Quote(record[i]) + " is not a number")
+	}
+
+	return v, nil
+}
+
+// parseNumber parses a field as a number, ignoring surrounding space.
+func parseNumber(field string, decimalComma bool) (float64, error) {
+	field = strings.TrimSpace(field)
+	if decimalComma {
+		if strings.ContainsRune(field, '.') {
+			return 0, errors.New("period in a number with a decimal comma")
+		}
+		field = strings.Replace(field, ",", ".", 1)
+	}
+
+	return strconv.ParseFloat(field, 64)
+}
+
+// WriteCSV writes the dataset to w as comma separated text with a header
+// row naming the columns "x" and "y", so that it can be read back with
+//
+//	d, err := datasets.FromCSV(f, datasets.ColumnName("x"), datasets.ColumnName("y"))
+//
+// Values are written in the shortest form that reads back exactly, with
+// NaN and the infinities as "NaN", "+Inf" and "-Inf". The NaN values of
+// pairs that Valid marks missing are written as empty fields, so that
+// they read back as missing. The name and other metadata are not written.
+//
+// An error is returned if X and Y have different lengths or writing fails.
+func (d Dataset) WriteCSV(w io.Writer) error {
+	if len(d.X) != len(d.Y) {
+		return &ValidationError{Dataset: d.Name, Series: "", Index: -1, Err: ErrLengthMismatch}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"x", "y"}); err != nil {
+		return err
+	}
+	for i := range d.X {
+		missing := i < len(d.Valid) && !d.Valid[i]
+		row := []string{formatCSVValue(d.X[i], missing), formatCSVValue(d.Y[i], missing)}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
+
+// formatCSVValue formats v for WriteCSV, as an empty field if it is NaN in
+// a missing pair.
+func formatCSVValue(v float64, missing bool) string {
+	if missing && math.IsNaN(v) {
+		return ""
+	}
+
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,519 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFromCSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		x     Column
+		y     Column
+		opts  []CSVOption
+		wantX []float64
+		wantY []float64
+	}{
+		{
+			name:  "header by name",
+			input: "id,height,weight\n1,170,65\n2,182.5,80\n",
+			x:     ColumnName("height"),
+			y:     ColumnName("weight"),
+			opts:  nil,
+			wantX: []float64{170, 182.5},
+			wantY: []float64{65, 80},
+		},
+		{
+			name:  "detected header by index",
+			input: "a,b\n1,2\n3,4\n",
+			x:     ColumnIndex(1),
+			y:     ColumnIndex(0),
+			opts:  nil,
+			wantX: []float64{2, 4},
+			wantY: []float64{1, 3},
+		},
+		{
+			name:  "no header",
+			input: "1,2\n3,4\n",
+			x:     ColumnIndex(0),
+			y:     ColumnIndex(1),
+			opts:  nil,
+			wantX: []float64{1, 3},
+			wantY: []float64{2, 4},
+		},
+		{
+			name:  "comments, blank lines and spaces",
+			input: "# exported 2024-01-02\nx, y\n\n 1, -2e3\n# a note\n3 ,4\n",
+			x:     ColumnName("x"),
+			y:     ColumnName("y"),
+			opts:  nil,
+			wantX: []float64{1, 3},
+			wantY: []float64{-2000, 4},
+		},
+		{
+			name:  "semicolon delimiter and quoted fields",
+			input: "\"x\";\"y\"\n\"1.5\";2\n",
+			x:     ColumnName("x"),
+			y:     ColumnName("y"),
+			opts:  []CSVOption{WithDelimiter(';')},
+			wantX: []float64{1.5},
+			wantY: []float64{2},
+		},
+		{
+			name:  "numeric header forced",
+			input: "2019,2020\n1,2\n",
+			x:     ColumnIndex(0),
+			y:     ColumnIndex(1),
+			opts:  []CSVOption{WithHeader(true)},
+			wantX: []float64{1},
+			wantY: []float64{2},
+		},
+		{
+			name:  "comments disabled",
+			input: "#1,2\n3,4\n",
+			x:     ColumnName("#1"),
+			y:     ColumnName("2"),
+			opts:  []CSVOption{WithComment(0)},
+			wantX: []float64{3},
+			wantY: []float64{4},
+		},
+		{
+			name:  "arbitrary rune delimiter",
+			input: "x|y\n1|2\n",
+			x:     ColumnName("x"),
+			y:     ColumnName("y"),
+			opts:  []CSVOption{WithDelimiter('|')},
+			wantX: []float64{1},
+			wantY: []float64{2},
+		},
+		{
+			name:  "quoted fields with delimiters, quotes and newlines",
+			input: "\"id, \"\"n\"\"\",\"mass\nkg\",v\n\"1\", \"2.5\",3\n",
+			x:     ColumnName("id, \"n\""),
+			y:     ColumnName("mass\nkg"),
+			opts:  nil,
+			wantX: []float64{1},
+			wantY: []float64{2.5},
+		},
+		{
+			name:  "decimal comma with semicolons",
+			input: "x;y\n1,5;-2,25e1\n3;4\n",
+			x:     ColumnName("x"),
+			y:     ColumnName("y"),
+			opts:  []CSVOption{WithDelimiter(';'), WithDecimalComma()},
+			wantX: []float64{1.5, 3},
+			wantY: []float64{-22.5, 4},
+		},
+		{
+			name:  "quoted decimal comma",
+			input: "\"0,5\",\"7,0\"\n",
+			x:     ColumnIndex(0),
+			y:     ColumnIndex(1),
+			opts:  []CSVOption{WithDecimalComma()},
+			wantX: []float64{0.5},
+			wantY: []float64{7},
+		},
+		{
+			name:  "empty input",
+			input: "",
+			x:     ColumnIndex(0),
+			y:     ColumnIndex(1),
+			opts:  nil,
+			wantX: []float64{},
+			wantY: []float64{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := FromCSV(strings.NewReader(test.input), test.x, test.y, test.opts...)
+			if err != nil {
+				t.Fatalf("FromCSV() unexpected error: %v", err)
+			}
+			if !slices.Equal(d.X, test.wantX) || !slices.Equal(d.Y, test.wantY) {
+				t.Errorf("FromCSV() = %v, %v, want %v, %v", d.X, d.Y, test.wantX, test.wantY)
+			}
+		})
+	}
+}
+
+func TestFromTSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		x     Column
+		y     Column
+		opts  []CSVOption
+		wantX []float64
+		wantY []float64
+	}{
+		{
+			name:  "header by name",
+			input: "time\tlabel\tvalue\n0.5\ta b\t 12 \n1\tc\t13\n",
+			x:     ColumnName("time"),
+			y:     ColumnName("value"),
+			opts:  nil,
+			wantX: []float64{0.5, 1},
+			wantY: []float64{12, 13},
+		},
+		{
+			name:  "empty fields keep their place",
+			input: "1\t\t2\n3\t\t4\n",
+			x:     ColumnIndex(0),
+			y:     ColumnIndex(2),
+			opts:  nil,
+			wantX: []float64{1, 3},
+			wantY: []float64{2, 4},
+		},
+		{
+			name:  "decimal comma",
+			input: "x\ty\n1,25\t2\n",
+			x:     ColumnName("x"),
+			y:     ColumnName("y"),
+			opts:  []CSVOption{WithDecimalComma()},
+			wantX: []float64{1.25},
+			wantY: []float64{2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := FromTSV(strings.NewReader(test.input), test.x, test.y, test.opts...)
+			if err != nil {
+				t.Fatalf("FromTSV() unexpected error: %v", err)
+			}
+			if !slices.Equal(d.X, test.wantX) || !slices.Equal(d.Y, test.wantY) {
+				t.Errorf("FromTSV() = %v, %v, want %v, %v", d.X, d.Y, test.wantX, test.wantY)
+			}
+		})
+	}
+}
+
+func TestFromCSVName(t *testing.T) {
+	d, err := FromCSV(strings.NewReader("1,2\n3,5\n"), ColumnIndex(0), ColumnIndex(1), WithName("lab"))
+	if err != nil {
+		t.Fatalf("FromCSV() unexpected error: %v", err)
+	}
+	if d.Name != "lab" {
+		t.Errorf("FromCSV() name = %q, want %q", d.Name, "lab")
+	}
+	if err := d.Validate(); err != nil {
+		t.Errorf("Validate() of loaded dataset = %v", err)
+	}
+}
+
+func TestFromCSVLabels(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		x, y       Column
+		wantXLabel string
+		wantYLabel string
+	}{
+		{
+			name:       "header by name",
+			input:      "id, height ,weight\n1,170,65\n",
+			x:          ColumnName("height"),
+			y:          ColumnName("weight"),
+			wantXLabel: "height",
+			wantYLabel: "weight",
+		},
+		{
+			name:       "header by index",
+			input:      "height,weight\n170,65\n",
+			x:          ColumnIndex(1),
+			y:          ColumnIndex(0),
+			wantXLabel: "weight",
+			wantYLabel: "height",
+		},
+		{
+			name:       "no header",
+			input:      "170,65\n",
+			x:          ColumnIndex(0),
+			y:          ColumnIndex(1),
+			wantXLabel: "",
+			wantYLabel: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := FromCSV(strings.NewReader(test.input), test.x, test.y)
+			if err != nil {
+				t.Fatalf("FromCSV() unexpected error: %v", err)
+			}
+			if d.XLabel != test.wantXLabel || d.YLabel != test.wantYLabel {
+				t.Errorf("FromCSV() labels = %q, %q, want %q, %q", d.XLabel, d.YLabel, test.wantXLabel, test.wantYLabel)
+			}
+		})
+	}
+}
+
+func TestFromCSVErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		x       Column
+		y       Column
+		opts    []CSVOption
+		wantErr string
+	}{
+		{
+			name:    "unknown column name",
+			input:   "a,b\n1,2\n",
+			x:       ColumnName("a"),
+			y:       ColumnName("c"),
+			opts:    nil,
+			wantErr: `column "c" not found in header`,
+		},
+		{
+			name:    "name without header",
+			input:   "1,2\n",
+			x:       ColumnName("a"),
+			y:       ColumnIndex(1),
+			opts:    []CSVOption{WithHeader(false)},
+			wantErr: "columns selected by name need a header row",
+		},
+		{
+			name:    "index out of range in header",
+			input:   "a,b\n1,2\n",
+			x:       ColumnIndex(0),
+			y:       ColumnIndex(5),
+			opts:    nil,
+			wantErr: "column #5 is out of range",
+		},
+		{
+			name:    "bad number",
+			input:   "x,y\n1,2\n3,oops\n",
+			x:       ColumnName("x"),
+			y:       ColumnName("y"),
+			opts:    nil,
+			wantErr: `line 3, column 3: "oops" is not a number`,
+		},
+		{
+			name:    "short row",
+			input:   "x,y\n1,2\n3\n",
+			x:       ColumnName("x"),
+			y:       ColumnName("y"),
+			opts:    nil,
+			wantErr: "line 3: missing column #1",
+		},
+		{
+			name:    "period with decimal comma",
+			input:   "x;y\n1,5;1.500\n",
+			x:       ColumnName("x"),
+			y:       ColumnName("y"),
+			opts:    []CSVOption{WithDelimiter(';'), WithDecimalComma()},
+			wantErr: `line 2, column 5: "1.500" is not a number`,
+		},
+		{
+			name:    "invalid delimiter",
+			input:   "1\n",
+			x:       ColumnIndex(0),
+			y:       ColumnIndex(0),
+			opts:    []CSVOption{WithDelimiter('"')},
+			wantErr: "invalid field or comment delimiter",
+		},
+		{
+			name:    "malformed quotes",
+			input:   "x,y\n1,\"2\n",
+			x:       ColumnName("x"),
+			y:       ColumnName("y"),
+			opts:    nil,
+			wantErr: "extraneous or missing",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := FromCSV(strings.NewReader(test.input), test.x, test.y, test.opts...)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("FromCSV() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCSVSource(t *testing.T) {
+	input := "x,y\n1,1\n2,4\n3,\n4,sixteen\n5,25\n"
+	src := CSVSource(strings.NewReader(input), ColumnName("x"), ColumnName("y"))
+
+	if _, _, err := src.Next(0); err == nil {
+		t.Errorf("Next(0) expected error but got none")
+	}
+	x, y, err := src.Next(2)
+	if err != nil || !slices.Equal(x, []float64{1, 2}) || !slices.Equal(y, []float64{1, 4}) {
+		t.Fatalf("Next(2) = (%v, %v, %v), want ([1 2], [1 4], nil)", x, y, err)
+	}
+	// A missing value is NaN, and the batch stops at the bad row,
+	// returning the pairs before it.
+	x, y, err = src.Next(2)
+	if err == nil || !strings.Contains(err.Error(), "line 5") || len(x) != 1 || x[0] != 3 || !math.IsNaN(y[0]) {
+		t.Fatalf("Next(2) = (%v, %v, %v), want ([3], [NaN], an error on line 5)", x, y, err)
+	}
+	if _, _, again := src.Next(2); !errors.Is(again, err) {
+		t.Errorf("Next(2) after an error = %v, want %v", again, err)
+	}
+}
+
+func TestCSVSourceEOF(t *testing.T) {
+	// Without a header the first row is data.
+	src := TSVSource(strings.NewReader("1\t2\n3\t4\n"), ColumnIndex(0), ColumnIndex(1))
+	x, y, err := src.Next(5)
+	if !errors.Is(err, io.EOF) || !slices.Equal(x, []float64{1, 3}) || !slices.Equal(y, []float64{2, 4}) {
+		t.Fatalf("Next(5) = (%v, %v, %v), want ([1 3], [2 4], io.EOF)", x, y, err)
+	}
+	if x, _, err = src.Next(5); !errors.Is(err, io.EOF) || len(x) != 0 {
+		t.Errorf("Next(5) at the end = (%v, %v), want no pairs and io.EOF", x, err)
+	}
+
+	empty := CSVSource(strings.NewReader(""), ColumnIndex(0), ColumnIndex(1))
+	if x, _, err := empty.Next(5); !errors.Is(err, io.EOF) || len(x) != 0 {
+		t.Errorf("Next(5) of empty text = (%v, %v), want no pairs and io.EOF", x, err)
+	}
+	missing := CSVSource(strings.NewReader("a,b\n1,2\n"), ColumnName("x"), ColumnName("y"))
+	if _, _, err := missing.Next(5); err == nil || errors.Is(err, io.EOF) {
+		t.Errorf("Next(5) selecting absent columns = %v, want an error", err)
+	}
+}
+
+func TestDatasetWriteCSV(t *testing.T) {
+	d := Dataset{
+		Name:        "lab",
+		Description: "",
+		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           []float64{1, 0.1, math.NaN(), -1e300},
+		Y:           []float64{2.5, math.Inf(1), math.Inf(-1), 1.0 / 3},
+		Valid:       nil,
+	}
+
+	var b strings.Builder
+	if err := d.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV() unexpected error: %v", err)
+	}
+	want := "x,y\n1,2.5\n0.1,+Inf\nNaN,-Inf\n-1e+300,0.3333333333333333\n"
+	if b.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", b.String(), want)
+	}
+
+	got, err := FromCSV(strings.NewReader(b.String()), ColumnName("x"), ColumnName("y"))
+	if err != nil {
+		t.Fatalf("FromCSV() of written dataset unexpected error: %v", err)
+	}
+	if !equalNaN(got.X, d.X) || !equalNaN(got.Y, d.Y) {
+		t.Errorf("FromCSV() of written dataset = %v, %v, want %v, %v", got.X, got.Y, d.X, d.Y)
+	}
+}
+
+func TestFromCSVMissing(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name      string
+		input     string
+		x, y      Column
+		wantX     []float64
+		wantY     []float64
+		wantValid []bool
+	}{
+		{
+			name:      "no empty fields",
+			input:     "x,y\n1,2\nNaN,3\n",
+			x:         ColumnName("x"),
+			y:         ColumnName("y"),
+			wantX:     []float64{1, nan},
+			wantY:     []float64{2, 3},
+			wantValid: nil,
+		},
+		{
+			name:      "empty fields",
+			input:     "x,y\n1,2\n, 3\n4,\nNaN,5\n",
+			x:         ColumnName("x"),
+			y:         ColumnName("y"),
+			wantX:     []float64{1, nan, 4, nan},
+			wantY:     []float64{2, 3, nan, 5},
+			wantValid: []bool{true, false, false, true},
+		},
+		{
+			name:      "empty field in the first row without a header",
+			input:     ",2\n3,4\n",
+			x:         ColumnIndex(0),
+			y:         ColumnIndex(1),
+			wantX:     []float64{nan, 3},
+			wantY:     []float64{2, 4},
+			wantValid: []bool{false, true},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := FromCSV(strings.NewReader(test.input), test.x, test.y)
+			if err != nil {
+				t.Fatalf("FromCSV() unexpected error: %v", err)
+			}
+			if !equalNaN(d.X, test.wantX) || !equalNaN(d.Y, test.wantY) || !slices.Equal(d.Valid, test.wantValid) {
+				t.Errorf("FromCSV() = %v, %v, %v, want %v, %v, %v", d.X, d.Y, d.Valid, test.wantX, test.wantY, test.wantValid)
+			}
+		})
+	}
+}
+
+func TestDatasetWriteCSVMissing(t *testing.T) {
+	d := Dataset{
+		Name:        "gaps",
+		Description: "",
+		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           []float64{1, math.NaN(), math.NaN()},
+		Y:           []float64{2, 3, 4},
+		Valid:       []bool{true, false, true},
+	}
+
+	var b strings.Builder
+	if err := d.WriteCSV(&b); err != nil {
+		t.Fatalf("WriteCSV() unexpected error: %v", err)
+	}
+	if want := "x,y\n1,2\n,3\nNaN,4\n"; b.String() != want {
+		t.Errorf("WriteCSV() = %q, want %q", b.String(), want)
+	}
+
+	got, err := FromCSV(strings.NewReader(b.String()), ColumnName("x"), ColumnName("y"))
+	if err != nil {
+		t.Fatalf("FromCSV() of written dataset unexpected error: %v", err)
+	}
+	if !equalNaN(got.X, d.X) || !equalNaN(got.Y, d.Y) || !slices.Equal(got.Valid, d.Valid) {
+		t.Errorf("FromCSV() of written dataset = %+v, want %+v", got, d)
+	}
+}
+
+func TestDatasetWriteCSVErrors(t *testing.T) {
+	d := Dataset{Name: "bad", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{1, 2}, Y: []float64{1}, Valid: nil}
+	if err := d.WriteCSV(io.Discard); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("WriteCSV() error = %v, want %v", err, ErrLengthMismatch)
+	}
+}
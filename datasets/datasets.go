@@ -24,10 +24,22 @@ type Dataset struct {
 	Description string
 	// Attribution provides reference to the authoritative source for this dataset
 	Attribution string
+	// XLabel names the quantity in X, such as a column name, for axis labels
+	XLabel string
+	// YLabel names the quantity in Y
+	YLabel string
+	// XUnits gives the units of the X values, such as "kg"
+	XUnits string
+	// YUnits gives the units of the Y values
+	YUnits string
 	// X contains the independent variable values
 	X []float64
 	// Y contains the dependent variable values
 	Y []float64
+	// Valid, if not nil, has one entry per pair and is false for the pairs
+	// that are missing. A nil Valid means that only pairs holding NaN are
+	// missing. See IsMissing.
+	Valid []bool
 }
 
 // Datasets represents a collection of related datasets with metadata.
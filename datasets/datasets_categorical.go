@@ -0,0 +1,102 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+// CategoricalDatasets returns the collection of example contingency
+// tables of categorical and ordinal data.
+func CategoricalDatasets() []ContingencyTable {
+	return []ContingencyTable{
+		HairEyeColor(),
+		JobSatisfaction(),
+		PathologistAgreement(),
+	}
+}
+
+// HairEyeColor returns the hair and eye colors of 592 statistics students,
+// a table of two nominal variables for measures such as Cramér's V.
+func HairEyeColor() ContingencyTable {
+	return ContingencyTable{
+		Name:        "Hair and Eye Color",
+		Description: "Hair and eye color of 592 statistics students at the University of Delaware, summed over sex. The variables are strongly associated, mostly through blond hair going with blue eyes: chi-squared ≈ 138.3 on 9 degrees of freedom, Cramér's V ≈ 0.279.",
+		Attribution: "Snee, R. D. (1974). Graphical Display of Two-Way Contingency Tables. The American Statistician, 28(1), 9-12. doi:10.1080/00031305.1974.10479053",
+		RowLabel:    "Hair color",
+		ColumnLabel: "Eye color",
+		Rows:        []string{"Black", "Brown", "Red", "Blond"},
+		Columns:     []string{"Brown", "Blue", "Hazel", "Green"},
+		Counts: [][]float64{
+			{68, 20, 15, 5},
+			{119, 84, 54, 29},
+			{26, 17, 14, 14},
+			{7, 94, 10, 16},
+		},
+		Ordinal: false,
+	}
+}
+
+// JobSatisfaction returns the job satisfaction of 96 workers by their
+// income, a table of two ordinal variables with a Likert scale of
+// satisfaction, for measures such as gamma and the polychoric correlation.
+func JobSatisfaction() ContingencyTable {
+	return ContingencyTable{
+		Name:        "Job Satisfaction",
+		Description: "Annual income in dollars and job satisfaction, on a four point scale, of 96 respondents to the US General Social Survey. Satisfaction tends to rise with income, but weakly: Goodman and Kruskal's gamma ≈ 0.221.",
+		Attribution: "Agresti, A. (2007). An Introduction to Categorical Data Analysis (2nd ed.). Wiley. doi:10.1002/0470114754",
+		RowLabel:    "Income",
+		ColumnLabel: "Job satisfaction",
+		Rows:        []string{"< 15,000", "15,000-25,000", "25,000-40,000", "> 40,000"},
+		Columns:     []string{"Very dissatisfied", "A little dissatisfied", "Moderately satisfied", "Very satisfied"},
+		Counts: [][]float64{
+			{1, 3, 10, 6},
+			{2, 3, 10, 7},
+			{1, 6, 14, 12},
+			{0, 1, 9, 11},
+		},
+		Ordinal: true,
+	}
+}
+
+// PathologistAgreement returns the ratings of 118 slides by two
+// pathologists on an ordered scale, for measures of agreement such as
+// Cohen's kappa and its weighted forms.
+func PathologistAgreement() ContingencyTable {
+	return ContingencyTable{
+		Name:        "Pathologist Agreement",
+		Description: "Classification of 118 slides of the uterine cervix by two pathologists, A in the rows and B in the columns, into five categories of increasing severity. Most slides are on or near the diagonal: Cohen's kappa ≈ 0.498, and kappa with linear weights ≈ 0.649 and with quadratic weights ≈ 0.779.",
+		Attribution: "Holmquist, N. D., McMahan, C. A., & Williams, O. D. (1967). Variability in Classification of Carcinoma in situ of the Uterine Cervix. Archives of Pathology, 84, 334-345; as tabulated in Agresti, A. (2013). Categorical Data Analysis (3rd ed.). Wiley.",
+		RowLabel:    "Pathologist A",
+		ColumnLabel: "Pathologist B",
+		Rows:        pathologyRatings(),
+		Columns:     pathologyRatings(),
+		Counts: [][]float64{
+			{22, 2, 2, 0, 0},
+			{5, 7, 14, 0, 0},
+			{0, 2, 36, 0, 0},
+			{0, 1, 14, 7, 0},
+			{0, 0, 3, 0, 3},
+		},
+		Ordinal: true,
+	}
+}
+
+// pathologyRatings returns the categories of PathologistAgreement.
+func pathologyRatings() []string {
+	return []string{
+		"Negative",
+		"Atypical squamous hyperplasia",
+		"Carcinoma in situ",
+		"Squamous carcinoma with early stromal invasion",
+		"Invasive carcinoma",
+	}
+}
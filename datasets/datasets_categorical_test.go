@@ -0,0 +1,76 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCategoricalDatasets(t *testing.T) {
+	tests := []struct {
+		table   ContingencyTable
+		total   float64
+		ordinal bool
+	}{
+		{table: HairEyeColor(), total: 592, ordinal: false},
+		{table: JobSatisfaction(), total: 96, ordinal: true},
+		{table: PathologistAgreement(), total: 118, ordinal: true},
+	}
+
+	if got := len(CategoricalDatasets()); got != len(tests) {
+		t.Errorf("CategoricalDatasets() has %d tables, want %d", got, len(tests))
+	}
+	for _, test := range tests {
+		table := test.table
+		if table.Description == "" || table.Attribution == "" || table.RowLabel == "" || table.ColumnLabel == "" {
+			t.Errorf("%s is missing its description, attribution or labels", table.Name)
+		}
+		if got := table.Total(); got != test.total {
+			t.Errorf("%s total = %v, want %v", table.Name, got, test.total)
+		}
+		if table.Ordinal != test.ordinal {
+			t.Errorf("%s ordinal = %v, want %v", table.Name, table.Ordinal, test.ordinal)
+		}
+		if _, err := table.Dataset(); err != nil {
+			t.Errorf("%s Dataset() unexpected error: %v", table.Name, err)
+		}
+	}
+}
+
+func TestPathologistAgreementKappa(t *testing.T) {
+	// The documented Cohen's kappa, from the observed and chance agreement.
+	table := PathologistAgreement()
+	n := table.Total()
+	rows := make([]float64, len(table.Rows))
+	cols := make([]float64, len(table.Columns))
+	observed := 0.0
+	for i, row := range table.Counts {
+		for j, c := range row {
+			rows[i] += c
+			cols[j] += c
+			if i == j {
+				observed += c / n
+			}
+		}
+	}
+	chance := 0.0
+	for i := range rows {
+		chance += rows[i] * cols[i] / (n * n)
+	}
+	if kappa := (observed - chance) / (1 - chance); math.Abs(kappa-0.498) > 5e-4 {
+		t.Errorf("PathologistAgreement() kappa = %v, want 0.498", kappa)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+// The attributions of the classic datasets.
+const (
+	galtonAttribution     = "Galton, F. (1886). Regression Towards Mediocrity in Hereditary Stature. The Journal of the Anthropological Institute of Great Britain and Ireland, 15, 246-263. doi:10.2307/2841583"
+	faithfulAttribution   = "Azzalini, A., & Bowman, A. W. (1990). A Look at Some Data on the Old Faithful Geyser. Journal of the Royal Statistical Society, Series C, 39(3), 357-365. doi:10.2307/2347385; as distributed with R in Härdle, W. (1991). Smoothing Techniques with Implementation in S. Springer."
+	hubbleAttribution     = "Hubble, E. (1929). A Relation Between Distance and Radial Velocity Among Extra-Galactic Nebulae. Proceedings of the National Academy of Sciences, 15(3), 168-173. doi:10.1073/pnas.15.3.168"
+	challengerAttribution = "Dalal, S. R., Fowlkes, E. B., & Hoadley, B. (1989). Risk Analysis of the Space Shuttle: Pre-Challenger Prediction of Failure. Journal of the American Statistical Association, 84(408), 945-957. doi:10.1080/01621459.1989.10478858"
+	longleyAttribution    = "Longley, J. W. (1967). An Appraisal of Least Squares Programs for the Electronic Computer from the Point of View of the User. Journal of the American Statistical Association, 62(319), 819-841. doi:10.1080/01621459.1967.10500896"
+	airlineAttribution    = "Box, G. E. P., & Jenkins, G. M. (1976). Time Series Analysis, Forecasting and Control. San Francisco: Holden-Day. Series G."
+	irisAttribution       = "Fisher, R. A. (1936). The Use of Multiple Measurements in Taxonomic Problems. Annals of Eugenics, 7(2), 179-188. doi:10.1111/j.1469-1809.1936.tb02137.x; measurements by Anderson, E. (1935)."
+)
+
+// ClassicDatasets is the collection of classic real world datasets:
+// Galton's heights, Old Faithful, Hubble's galaxies, the Challenger
+// O-rings, Longley, the iris sepal and petal measurements and the airline
+// passengers time series.
+var ClassicDatasets = Datasets{
+	Name:        "Classic Datasets",
+	Description: "Real world datasets that appear throughout the history and teaching of correlation and regression, from Galton's discovery of regression to the mean to the Challenger disaster.",
+	Attribution: "Collection of published datasets; see the attribution of each dataset",
+	Data: []Dataset{
+		Galton,
+		OldFaithful,
+		Hubble,
+		Challenger,
+		Longley,
+		IrisSepal,
+		IrisPetal,
+		AirPassengers,
+	},
+}
+
+// Galton is Galton's heights of parents and their adult children, the
+// data in which regression to the mean was first described.
+var Galton = builtinLabeled("galton.csv", "Galton",
+	"Heights of 928 adult children and of their 205 sets of parents, from Galton's 1886 table. The mid-parent height is the mean of the father's height and 1.08 times the mother's, and female heights were also multiplied by 1.08. Values are the centres of 1 inch bins, with 64.0 and 73.0 for parents and 61.7 and 73.7 for children standing for the open ends. Children of tall or short parents are on average closer to the mean than their parents: mean mid-parent height ≈ 68.31, mean child height ≈ 68.09, correlation ≈ 0.459.",
+	galtonAttribution, "in", "in")
+
+// OldFaithful is the eruption durations and waiting times of the Old
+// Faithful geyser, a standard example of clustered, bimodal data.
+var OldFaithful = builtinLabeled("faithful.csv", "Old Faithful",
+	"Duration of 272 eruptions of the Old Faithful geyser in Yellowstone National Park and the waiting time until the next eruption, both in minutes. Both variables are bimodal, forming two clusters of short and long eruptions: mean duration ≈ 3.49, mean waiting time ≈ 70.9, correlation ≈ 0.901.",
+	faithfulAttribution, "min", "min")
+
+// Hubble is the distances and recession velocities of the galaxies in
+// Hubble's 1929 paper.
+var Hubble = builtinLabeled("hubble.csv", "Hubble",
+	"Distances and recession velocities of 24 galaxies from Hubble's 1929 paper, whose linear relationship was the first evidence of the expansion of the universe. Hubble's distances were too small by a factor of about 7, so the slope ≈ 454 km/s/Mpc is far above the modern Hubble constant: correlation ≈ 0.790.",
+	hubbleAttribution, "Mpc", "km/s")
+
+// Challenger is the launch temperatures and O-ring thermal distress
+// counts of the space shuttle flights before the Challenger disaster.
+var Challenger = builtinLabeled("challenger.csv", "Challenger",
+	"Launch temperature and number of the 6 field joint O-rings showing thermal distress for the 23 space shuttle flights before the Challenger disaster, which launched in about 31 °F. Distress was more frequent in cold launches: mean temperature ≈ 69.6 °F, correlation ≈ -0.511.",
+	challengerAttribution, "°F", "")
+
+// Longley is the gross national product and employment series of
+// Longley's economic data, a test of numerical accuracy.
+var Longley = builtinLabeled("longley.csv", "Longley",
+	"US gross national product in billions of dollars and number of people employed in millions for the 16 years 1947 to 1962, from Longley's highly collinear test data for the numerical accuracy of least squares programs: correlation ≈ 0.984.",
+	longleyAttribution, "billion dollars", "million people")
+
+// IrisSepal is the sepal lengths and widths of Fisher's iris data.
+var IrisSepal = builtinLabeled("iris_sepal.csv", "Iris Sepal",
+	"Sepal length and width of 150 iris flowers, 50 of each of the species Iris setosa, versicolor and virginica. The correlation over all flowers ≈ -0.118 is negative, although it is positive within every species, an example of Simpson's paradox.",
+	irisAttribution, "cm", "cm")
+
+// IrisPetal is the petal lengths and widths of Fisher's iris data.
+var IrisPetal = builtinLabeled("iris_petal.csv", "Iris Petal",
+	"Petal length and width of 150 iris flowers, 50 of each of the species Iris setosa, versicolor and virginica, in the same order as Iris Sepal. The species separate clearly: correlation ≈ 0.963.",
+	irisAttribution, "cm", "cm")
+
+// AirPassengers is the Box and Jenkins airline passengers series, with
+// the time as X and the monthly total as Y, a classic example of trend and
+// seasonality.
+//
+// Other classic time series can be read from the Rdatasets archive with
+// Fetch, such as the yearly sunspot numbers and the Mauna Loa CO2
+// concentrations:
+//
+//	sunspots, err := datasets.Fetch(ctx, "rdatasets/datasets/sunspot.year",
+//		datasets.ColumnName("time"), datasets.ColumnName("value"))
+//	co2, err := datasets.Fetch(ctx, "rdatasets/datasets/co2",
+//		datasets.ColumnName("time"), datasets.ColumnName("value"))
+var AirPassengers = builtinLabeled("airpassengers.csv", "Air Passengers",
+	"Monthly totals of international airline passengers in thousands from January 1949 to December 1960, with X the time in years at the start of each month. The series has a rising trend and a yearly seasonal pattern whose amplitude grows with the level, so it is often log transformed before modelling: mean ≈ 280.3, range 104 to 622.",
+	airlineAttribution, "year", "thousand passengers")
@@ -0,0 +1,75 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"testing"
+)
+
+func TestClassicDatasets(t *testing.T) {
+	tests := []struct {
+		d                     Dataset
+		n                     int
+		meanX, meanY, r       float64
+		xLabel, yLabel, units string
+	}{
+		{d: Galton, n: 928, meanX: 68.308, meanY: 68.088, r: 0.4588, xLabel: "Mid-parent height", yLabel: "Child height", units: "in"},
+		{d: OldFaithful, n: 272, meanX: 3.4878, meanY: 70.897, r: 0.9008, xLabel: "Eruption duration", yLabel: "Waiting time", units: "min"},
+		{d: Hubble, n: 24, meanX: 0.9120, meanY: 373.13, r: 0.7896, xLabel: "Distance", yLabel: "Recession velocity", units: "Mpc"},
+		{d: Challenger, n: 23, meanX: 69.565, meanY: 0.3913, r: -0.5111, xLabel: "Launch temperature", yLabel: "O-rings with thermal distress", units: "°F"},
+		{d: Longley, n: 16, meanX: 387.70, meanY: 65.317, r: 0.9836, xLabel: "GNP", yLabel: "Employed", units: "billion dollars"},
+		{d: IrisSepal, n: 150, meanX: 5.8433, meanY: 3.0573, r: -0.1176, xLabel: "Sepal length", yLabel: "Sepal width", units: "cm"},
+		{d: IrisPetal, n: 150, meanX: 3.7580, meanY: 1.1993, r: 0.9629, xLabel: "Petal length", yLabel: "Petal width", units: "cm"},
+		{d: AirPassengers, n: 144, meanX: 1954.9583, meanY: 280.30, r: 0.9239, xLabel: "Time", yLabel: "Passengers", units: "year"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.d.Name, func(t *testing.T) {
+			d := test.d
+			if len(d.X) != test.n || len(d.Y) != test.n {
+				t.Fatalf("%s has %d x and %d y values, want %d", d.Name, len(d.X), len(d.Y), test.n)
+			}
+			if d.Description == "" || d.Attribution == "" {
+				t.Errorf("%s is missing its description or attribution", d.Name)
+			}
+			if d.XLabel != test.xLabel || d.YLabel != test.yLabel || d.XUnits != test.units {
+				t.Errorf("%s labels = %q, %q in %q, want %q, %q in %q",
+					d.Name, d.XLabel, d.YLabel, d.XUnits, test.xLabel, test.yLabel, test.units)
+			}
+			if got := mean(d.X); math.Abs(got-test.meanX) > 1e-3*math.Abs(test.meanX) {
+				t.Errorf("%s mean of X = %v, want %v", d.Name, got, test.meanX)
+			}
+			if got := mean(d.Y); math.Abs(got-test.meanY) > 1e-3*math.Abs(test.meanY) {
+				t.Errorf("%s mean of Y = %v, want %v", d.Name, got, test.meanY)
+			}
+			if got := pearson(d.X, d.Y); math.Abs(got-test.r) > 1e-4 {
+				t.Errorf("%s correlation = %v, want %v", d.Name, got, test.r)
+			}
+		})
+	}
+}
+
+func TestAirPassengersTime(t *testing.T) {
+	d := AirPassengers
+	for i, x := range d.X {
+		if want := 1949 + float64(i)/12; x != want {
+			t.Fatalf("AirPassengers X[%d] = %v, want %v", i, x, want)
+		}
+	}
+	if d.Y[0] != 112 || d.Y[143] != 432 {
+		t.Errorf("AirPassengers first and last values = %v, %v, want 112, 432", d.Y[0], d.Y[143])
+	}
+}
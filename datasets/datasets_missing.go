@@ -0,0 +1,47 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+// MissingDatasets is the collection of builtin datasets with missing
+// values, copies of classic datasets with values removed in known places,
+// for trying out ways of handling missing data against known answers.
+var MissingDatasets = Datasets{
+	Name:        "Datasets with Missing Values",
+	Description: "Classic datasets with values deliberately removed, completely at random, in a run of a time series, and more often for large values, each documenting the results of the common ways of handling them.",
+	Attribution: "Collection of published datasets; see the attribution of each dataset",
+	Data: []Dataset{
+		AirPassengersGaps,
+		OldFaithfulMissing,
+		IrisPetalMissing,
+	},
+}
+
+// AirPassengersGaps is AirPassengers with missing monthly totals,
+// singly, in a run of three and at the end, for testing interpolation.
+var AirPassengersGaps = builtinLabeled("airpassengers_gaps.csv", "Air Passengers with Gaps",
+	"The airline passengers series with 6 of its 144 monthly totals missing: June 1951, March to May 1953, November 1957 and the last month, December 1960. Interpolation suits such gaps in a smooth series. The correlation of the totals with time is 0.9239 for the full series, and 0.9241 after dropping the missing pairs, 0.9065 after filling the gaps with the mean, and 0.9233 after filling them by linear interpolation, with the last month taking the value before it.",
+	airlineAttribution, "year", "thousand passengers")
+
+// OldFaithfulMissing is OldFaithful with values missing completely at
+// random, for which dropping incomplete pairs is unbiased.
+var OldFaithfulMissing = builtinLabeled("faithful_missing.csv", "Old Faithful with Missing Values",
+	"The Old Faithful eruptions with values missing completely at random: the waiting times of every 17th eruption from the 6th, 16 in all, and the durations of the 12th, 101st and 201st, leaving 253 of the 272 pairs complete. Dropping the incomplete pairs gives a correlation of 0.9004, close to the 0.9008 of the full data, while filling each series with its mean gives 0.8730, shrinking the correlation towards zero.",
+	faithfulAttribution, "min", "min")
+
+// IrisPetalMissing is IrisPetal with the largest petal widths missing,
+// an example of data missing not at random.
+var IrisPetalMissing = builtinLabeled("iris_petal_missing.csv", "Iris Petal with Missing Values",
+	"The iris petal measurements with the widths of the 17 Iris virginica flowers whose petals are at least 2.2 cm wide missing, so that whether a value is missing depends on the value itself. The mean width of the 133 remaining flowers is 1.0541 cm against 1.1993 cm for all 150, a bias that neither dropping nor imputing can remove. The correlation is 0.9727 after dropping the incomplete pairs and 0.8845 after filling with the mean, against 0.9629 for the full data.",
+	irisAttribution, "cm", "cm")
@@ -0,0 +1,83 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"testing"
+)
+
+// fillMean returns a copy of data with the NaN values replaced by the mean
+// of the others.
+func fillMean(data []float64) []float64 {
+	present := make([]float64, 0, len(data))
+	for _, v := range data {
+		if !math.IsNaN(v) {
+			present = append(present, v)
+		}
+	}
+	m := mean(present)
+	out := make([]float64, len(data))
+	for i, v := range data {
+		out[i] = v
+		if math.IsNaN(v) {
+			out[i] = m
+		}
+	}
+
+	return out
+}
+
+func TestMissingDatasets(t *testing.T) {
+	tests := []struct {
+		d        Dataset
+		full     Dataset
+		missing  int
+		complete float64
+		filled   float64
+	}{
+		{d: AirPassengersGaps, full: AirPassengers, missing: 6, complete: 0.9241, filled: 0.9065},
+		{d: OldFaithfulMissing, full: OldFaithful, missing: 19, complete: 0.9004, filled: 0.8730},
+		{d: IrisPetalMissing, full: IrisPetal, missing: 17, complete: 0.9727, filled: 0.8845},
+	}
+
+	for _, test := range tests {
+		t.Run(test.d.Name, func(t *testing.T) {
+			d := test.d
+			if len(d.X) != len(test.full.X) || d.Attribution != test.full.Attribution || d.XLabel != test.full.XLabel {
+				t.Fatalf("%s does not match %s", d.Name, test.full.Name)
+			}
+			if got := d.CountMissing(); got != test.missing {
+				t.Errorf("%s CountMissing() = %d, want %d", d.Name, got, test.missing)
+			}
+			for i := range d.X {
+				if d.IsMissing(i) {
+					continue
+				}
+				if d.X[i] != test.full.X[i] || d.Y[i] != test.full.Y[i] {
+					t.Fatalf("%s pair %d = (%v, %v), want (%v, %v)", d.Name, i, d.X[i], d.Y[i], test.full.X[i], test.full.Y[i])
+				}
+			}
+
+			dropped := d.DropMissing()
+			if got := pearson(dropped.X, dropped.Y); math.Abs(got-test.complete) > 5e-5 {
+				t.Errorf("%s complete case correlation = %v, want %v", d.Name, got, test.complete)
+			}
+			if got := pearson(fillMean(d.X), fillMean(d.Y)); math.Abs(got-test.filled) > 5e-5 {
+				t.Errorf("%s mean filled correlation = %v, want %v", d.Name, got, test.filled)
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ package datasets
 
 import (
 	"math"
+	"slices"
 	"testing"
 )
 
@@ -112,6 +113,11 @@ func TestDatasetType(t *testing.T) {
 		Name:        "Linear Test",
 		Description: "A simple linear relationship for testing",
 		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		Valid:       nil,
 	}
 
 	if len(custom.X) != 5 {
@@ -186,6 +192,11 @@ func TestDatasetsType(t *testing.T) {
 			Name:        "Linear",
 			Description: "Perfect linear relationship",
 			Attribution: "",
+			XLabel:      "",
+			YLabel:      "",
+			XUnits:      "",
+			YUnits:      "",
+			Valid:       nil,
 		}
 
 		dataset2 := Dataset{
@@ -194,6 +205,11 @@ func TestDatasetsType(t *testing.T) {
 			Name:        "Inverse",
 			Description: "Perfect inverse relationship",
 			Attribution: "",
+			XLabel:      "",
+			YLabel:      "",
+			XUnits:      "",
+			YUnits:      "",
+			Valid:       nil,
 		}
 
 		customCollection := Datasets{
@@ -239,3 +255,26 @@ func TestDatasetsType(t *testing.T) {
 		}
 	})
 }
+
+func TestBuiltinDataFiles(t *testing.T) {
+	// Every embedded file belongs to a dataset and parses to equal length
+	// series.
+	files, err := builtinData.ReadDir("data")
+	if err != nil {
+		t.Fatalf("ReadDir() unexpected error: %v", err)
+	}
+	all := slices.Concat(AnscombeQuartet.Data, DatasaurusDozen.Data, ClassicDatasets.Data, MissingDatasets.Data)
+	if len(files) != len(all) {
+		t.Errorf("data holds %d files, want one for each of the %d builtin datasets", len(files), len(all))
+	}
+	for _, f := range files {
+		if _, err := parseBuiltin(f.Name(), ColumnIndex(0), ColumnIndex(1)); err != nil {
+			t.Errorf("parseBuiltin(%q) unexpected error: %v", f.Name(), err)
+		}
+	}
+	for _, d := range all {
+		if len(d.X) == 0 || len(d.X) != len(d.Y) {
+			t.Errorf("%s has %d x and %d y values", d.Name, len(d.X), len(d.Y))
+		}
+	}
+}
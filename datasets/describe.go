@@ -0,0 +1,85 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// describeHeader is the header row of the table written by Describe.
+var describeHeader = []string{"Dataset", "N", "Mean X", "Mean Y", "SD X", "SD Y", "Correlation"}
+
+// Describe writes a table of the Summary of each dataset of the collection
+// to w, one row per dataset giving its number of pairs, the means and
+// sample standard deviations of X and Y, and their correlation, the table
+// made famous by Anscombe's Quartet and the Datasaurus Dozen:
+//
+//	datasets.AnscombeQuartet.Describe(os.Stdout)
+//
+// prints
+//
+//	Dataset        N  Mean X  Mean Y  SD X  SD Y  Correlation
+//	Anscombe I    11    9.00    7.50  3.32  2.03        0.816
+//	Anscombe II   11    9.00    7.50  3.32  2.03        0.816
+//	Anscombe III  11    9.00    7.50  3.32  2.03        0.816
+//	Anscombe IV   11    9.00    7.50  3.32  2.03        0.817
+//
+// The means and standard deviations are rounded to 2 decimal places and
+// the correlation to 3, and undefined statistics are shown as NaN. An
+// error is returned if the table cannot be written.
+func (ds Datasets) Describe(w io.Writer) error {
+	rows := [][]string{describeHeader}
+	for _, d := range ds.Data {
+		s := d.Summary()
+		rows = append(rows, []string{
+			d.Name,
+			strconv.Itoa(s.N),
+			strconv.FormatFloat(s.MeanX, 'f', 2, 64),
+			strconv.FormatFloat(s.MeanY, 'f', 2, 64),
+			strconv.FormatFloat(s.StdDevX, 'f', 2, 64),
+			strconv.FormatFloat(s.StdDevY, 'f', 2, 64),
+			strconv.FormatFloat(s.Correlation, 'f', 3, 64),
+		})
+	}
+
+	widths := make([]int, len(describeHeader))
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+
+	// The names are aligned left and the numbers right, with two spaces
+	// between the columns.
+	var b strings.Builder
+	for _, row := range rows {
+		for i, cell := range row {
+			pad := strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell))
+			if i == 0 {
+				b.WriteString(cell + pad)
+
+				continue
+			}
+			b.WriteString("  " + pad + cell)
+		}
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
@@ -0,0 +1,58 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	var b strings.Builder
+	if err := AnscombeQuartet.Describe(&b); err != nil {
+		t.Fatalf("Describe() unexpected error: %v", err)
+	}
+	want := `Dataset        N  Mean X  Mean Y  SD X  SD Y  Correlation
+Anscombe I    11    9.00    7.50  3.32  2.03        0.816
+Anscombe II   11    9.00    7.50  3.32  2.03        0.816
+Anscombe III  11    9.00    7.50  3.32  2.03        0.816
+Anscombe IV   11    9.00    7.50  3.32  2.03        0.817
+`
+	if got := b.String(); got != want {
+		t.Errorf("Describe() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDescribeUndefined(t *testing.T) {
+	ds := Datasets{Name: "", Description: "", Attribution: "", Data: []Dataset{
+		{Name: "één", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "",
+			X: []float64{1}, Y: []float64{math.Inf(1)}, Valid: nil},
+	}}
+	var b strings.Builder
+	if err := ds.Describe(&b); err != nil {
+		t.Fatalf("Describe() unexpected error: %v", err)
+	}
+	want := `Dataset  N  Mean X  Mean Y  SD X  SD Y  Correlation
+één      1    1.00    +Inf   NaN   NaN          NaN
+`
+	if got := b.String(); got != want {
+		t.Errorf("Describe() =\n%s\nwant\n%s", got, want)
+	}
+
+	if err := ds.Describe(failWriter{}); err == nil {
+		t.Errorf("Describe() to a failing writer expected error but got none")
+	}
+}
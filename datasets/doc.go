@@ -21,8 +21,35 @@
 // The Dataset type represents a pair of related data series, typically used
 // for correlation analysis, regression, or other bivariate statistical
 // operations. The Datasets type allows grouping multiple related datasets
-// with shared metadata.
+// with shared metadata, and a Frame holds any number of named columns of
+// the same observations for multivariate work.
 //
 // Example datasets are provided to facilitate testing and learning, covering
-// various statistical scenarios and data patterns.
+// various statistical scenarios and data patterns. ClassicDatasets bundles
+// well known real data such as Galton's heights, Old Faithful and the
+// airline passengers series, CategoricalDatasets holds contingency tables of
+// nominal and ordinal data, MissingDatasets has copies with values removed
+// for trying out ways of handling missing data, and the Generate functions
+// draw samples with known population properties: correlated normal, copula,
+// heavy-tailed and contaminated pairs, nonlinear patterns and autocorrelated
+// series. The builtin datasets are package variables whose values are
+// shared by every user of the package, so copy them with slices.Clone
+// before changing them. Other data can be loaded from delimited text with FromCSV and
+// FromTSV, from JSON with FromJSON and FromJSONLines, from Apache Parquet
+// files with FromParquet, or from Excel workbooks with FromXLSX. Apache
+// Arrow arrays and record batches are used in place with FromArrow,
+// FromArrowRecord and FrameFromArrow, and written with ToArrow; gonum
+// matrices are read with FromMatrix and made with Dense, and CSVSource,
+// TSVSource and JSONLinesSource stream rows and records to computations
+// that read a DataSource. Fetch downloads files of public
+// archives such as Rdatasets and keeps them in a local cache.
+//
+// Loaded data can be prepared for analysis with chainable transforms such
+// as Standardize, Log, BoxCox and Rank, which return new datasets, and time
+// series can be detrended or differenced before they are correlated. Plot
+// draws a dataset in the terminal, to look at the data before summarizing
+// it, and Checksum and Verify detect data that has changed between runs.
+// Cached keeps the Summary of a dataset until its values change, for loops
+// that ask for it repeatedly, and Describe tabulates the summaries of a
+// collection.
 package datasets
@@ -0,0 +1,272 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// fetchSource is a public archive that Fetch downloads from.
+type fetchSource struct {
+	// name names the archive in the Attribution of fetched datasets.
+	name string
+	// base is the URL that the rest of an identifier is appended to.
+	base string
+	// suffix is appended to the rest of an identifier after base.
+	suffix string
+}
+
+// fetchSources maps the first element of a Fetch identifier to its
+// archive.
+var fetchSources = map[string]fetchSource{
+	"rdatasets": {
+		name:   "Rdatasets",
+		base:   "https://vincentarelbundock.github.io/Rdatasets/csv/",
+		suffix: ".csv",
+	},
+	"uci": {
+		name:   "UCI Machine Learning Repository",
+		base:   "https://archive.ics.uci.edu/ml/machine-learning-databases/",
+		suffix: "",
+	},
+}
+
+// FetchOption configures Fetch.
+type FetchOption func(*fetchOptions)
+
+// fetchOptions holds the settings accumulated from a list of FetchOption
+// values.
+type fetchOptions struct {
+	sha256   string
+	client   *http.Client
+	cacheDir string
+	csv      []CSVOption
+}
+
+// WithSHA256 sets the SHA-256 checksum, in hexadecimal, that the file
+// must have. Without one, any download is accepted and a cached file is
+// trusted as it is.
+func WithSHA256(sum string) FetchOption {
+	return func(o *fetchOptions) {
+		o.sha256 = strings.ToLower(sum)
+	}
+}
+
+// WithHTTPClient sets the client that downloads files. The default is
+// http.DefaultClient.
+func WithHTTPClient(c *http.Client) FetchOption {
+	return func(o *fetchOptions) {
+		o.client = c
+	}
+}
+
+// WithCacheDir sets the directory that downloaded files are kept in. The
+// default is rsned-stats/datasets under os.UserCacheDir.
+func WithCacheDir(dir string) FetchOption {
+	return func(o *fetchOptions) {
+		o.cacheDir = dir
+	}
+}
+
+// WithCSVOptions sets the options that the downloaded file is read with,
+// as by FromCSV.
+func WithCSVOptions(opts ...CSVOption) FetchOption {
+	return func(o *fetchOptions) {
+		o.csv = opts
+	}
+}
+
+// Fetch returns a Dataset read from a file of a public archive, taking X
+// from column x and Y from column y as FromCSV does. The file is
+// downloaded over HTTPS the first time and read from a local cache after
+// that, so later calls work offline.
+//
+// The identifier names the archive and the file within it:
+//
+//   - "rdatasets/<package>/<item>" is an item of Vincent Arel-Bundock's
+//     Rdatasets collection, such as "rdatasets/datasets/mtcars" or
+//     "rdatasets/palmerpenguins/penguins". These files have a header row.
+//   - "uci/<path>" is a file of the UCI Machine Learning Repository, such
+//     as "uci/iris/iris.data". Most have no header row, so columns are
+//     selected by position, and some need WithCSVOptions to be read.
+//
+// For example:
+//
+//	d, err := datasets.Fetch(ctx, "rdatasets/datasets/mtcars",
+//		datasets.ColumnName("wt"), datasets.ColumnName("mpg"),
+//		datasets.WithSHA256(mtcarsSHA256))
+//
+// Archives can change their files, so pass the checksum of the file that
+// an analysis was written against with WithSHA256 to be sure of getting
+// it. A download that does not match is rejected, and a cached file that
+// does not match is downloaded again.
+//
+// The Name of the Dataset is the last element of the identifier, and its
+// Attribution gives the archive and the URL of the file.
+//
+// An error is returned if the identifier is not valid, the file cannot be
+// downloaded or cached, it does not match the checksum, or it cannot be
+// read as by FromCSV.
+func Fetch(ctx context.Context, id string, x, y Column, opts ...FetchOption) (Dataset, error) {
+	o := fetchOptions{sha256: "", client: http.DefaultClient, cacheDir: "", csv: nil}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	src, rest, err := parseFetchID(id)
+	if err != nil {
+		return Dataset{}, err
+	}
+	if o.cacheDir == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return Dataset{}, err
+		}
+		o.cacheDir = filepath.Join(dir, "rsned-stats", "datasets")
+	}
+	url := src.base + rest + src.suffix
+	path := filepath.Join(o.cacheDir, filepath.FromSlash(id)+src.suffix)
+
+	if !cached(path, o.sha256) {
+		if err := download(ctx, o.client, url, path, o.sha256); err != nil {
+			return Dataset{}, errors.New("fetching " + id + ": " + err.Error())
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return Dataset{}, err
+	}
+	defer f.Close()
+
+	d, err := FromCSV(f, x, y, o.csv...)
+	if err != nil {
+		return Dataset{}, errors.New("reading " + id + ": " + err.Error())
+	}
+	d.Name = rest[strings.LastIndexByte(rest, '/')+1:]
+	d.Attribution = src.name + ", " + url
+
+	return d, nil
+}
+
+// parseFetchID splits a Fetch identifier into its archive and the path of
+// the file within it.
+func parseFetchID(id string) (fetchSource, string, error) {
+	name, rest, _ := strings.Cut(id, "/")
+	src, ok := fetchSources[name]
+	if !ok {
+		return fetchSource{}, "", errors.New("unknown dataset archive in identifier " + strconv.Quote(id))
+	}
+	elems := strings.Split(rest, "/")
+	if name == "rdatasets" && len(elems) != 2 {
+		return fetchSource{}, "", errors.New("rdatasets identifier must be rdatasets/<package>/<item>, not " + strconv.Quote(id))
+	}
+	for _, e := range elems {
+		if !validFetchElem(e) {
+			return fetchSource{}, "", errors.New("invalid dataset identifier " + strconv.Quote(id))
+		}
+	}
+
+	return src, rest, nil
+}
+
+// validFetchElem reports whether e can be an element of the path of a
+// fetched file: it must be non-empty, use only letters, digits, '.', '_'
+// and '-', and not be "." or "..".
+func validFetchElem(e string) bool {
+	if e == "" || e == "." || e == ".." {
+		return false
+	}
+	for _, c := range e {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case c == '.', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// cached reports whether the file at path exists and, if sum is set, has
+// that SHA-256 checksum.
+func cached(path, sum string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	if sum == "" {
+		return true
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return false
+	}
+
+	return hex.EncodeToString(h.Sum(nil)) == sum
+}
+
+// download fetches url into the file at path, checking that it has the
+// SHA-256 checksum sum if set. The file is written under a temporary name
+// and renamed into place, so a failed download never leaves a partial file
+// at path.
+func download(ctx context.Context, client *http.Client, url, path, sum string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("GET " + url + ": " + resp.Status)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	h := sha256.New()
+	_, err = io.Copy(io.MultiWriter(tmp, h), resp.Body)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if got := hex.EncodeToString(h.Sum(nil)); sum != "" && got != sum {
+		return errors.New("checksum mismatch: got sha256 " + got + ", want " + sum)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// mtcarsCSV is the start of mtcars as Rdatasets serves it.
+const mtcarsCSV = `"rownames","mpg","cyl","disp","hp","drat","wt"
+"Mazda RX4",21,6,160,110,3.9,2.62
+"Mazda RX4 Wag",21,6,160,110,3.9,2.875
+"Datsun 710",22.8,4,108,93,3.85,2.32
+`
+
+// irisData is the start of iris as the UCI repository serves it.
+const irisData = `5.1,3.5,1.4,0.2,Iris-setosa
+4.9,3.0,1.4,0.2,Iris-setosa
+7.0,3.2,4.7,1.4,Iris-versicolor
+`
+
+// sha256Hex returns the SHA-256 checksum of s in hexadecimal.
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// serveArchives points the archives of Fetch at a local server of files,
+// keyed by path, for the rest of the test. It returns the number of
+// requests the server has received.
+func serveArchives(t *testing.T, files map[string]string) *atomic.Int32 {
+	t.Helper()
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	saved := fetchSources
+	fetchSources = map[string]fetchSource{
+		"rdatasets": {name: "Rdatasets", base: srv.URL + "/csv/", suffix: ".csv"},
+		"uci":       {name: "UCI", base: srv.URL + "/ml/", suffix: ""},
+	}
+	t.Cleanup(func() { fetchSources = saved })
+
+	return &requests
+}
+
+func TestFetch(t *testing.T) {
+	requests := serveArchives(t, map[string]string{"/csv/datasets/mtcars.csv": mtcarsCSV})
+	dir := t.TempDir()
+
+	for i := range 2 {
+		d, err := Fetch(context.Background(), "rdatasets/datasets/mtcars", ColumnName("wt"), ColumnName("mpg"),
+			WithCacheDir(dir), WithSHA256(sha256Hex(mtcarsCSV)))
+		if err != nil {
+			t.Fatalf("Fetch() call %d unexpected error: %v", i, err)
+		}
+		if !equalNaN(d.X, []float64{2.62, 2.875, 2.32}) || !equalNaN(d.Y, []float64{21, 21, 22.8}) {
+			t.Errorf("Fetch() = (%v, %v), want the wt and mpg columns", d.X, d.Y)
+		}
+		if d.Name != "mtcars" || !strings.HasPrefix(d.Attribution, "Rdatasets, http") {
+			t.Errorf("Fetch() Name, Attribution = %q, %q, want mtcars and the source", d.Name, d.Attribution)
+		}
+	}
+	// The second call is served from the cache.
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Fetch() twice made %d requests, want 1", n)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "rdatasets", "datasets", "mtcars.csv")); err != nil {
+		t.Errorf("Fetch() did not cache the file: %v", err)
+	}
+}
+
+func TestFetchCSVOptions(t *testing.T) {
+	serveArchives(t, map[string]string{"/ml/iris/iris.data": irisData})
+
+	d, err := Fetch(context.Background(), "uci/iris/iris.data", ColumnIndex(2), ColumnIndex(3),
+		WithCacheDir(t.TempDir()), WithCSVOptions(WithHeader(false)))
+	if err != nil {
+		t.Fatalf("Fetch() unexpected error: %v", err)
+	}
+	if !equalNaN(d.X, []float64{1.4, 1.4, 4.7}) || !equalNaN(d.Y, []float64{0.2, 0.2, 1.4}) || d.Name != "iris.data" {
+		t.Errorf("Fetch() = %+v, want the petal columns of iris.data", d)
+	}
+}
+
+func TestFetchStaleCache(t *testing.T) {
+	requests := serveArchives(t, map[string]string{"/csv/datasets/mtcars.csv": mtcarsCSV})
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rdatasets", "datasets", "mtcars.csv")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("rownames,mpg,wt\nold,1,2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a checksum the cached file is used as it is.
+	d, err := Fetch(context.Background(), "rdatasets/datasets/mtcars", ColumnName("wt"), ColumnName("mpg"), WithCacheDir(dir))
+	if err != nil || len(d.X) != 1 || requests.Load() != 0 {
+		t.Fatalf("Fetch() without a checksum = (%v, %v) after %d requests, want the cached file", d, err, requests.Load())
+	}
+	// With one, a cached file that does not match is downloaded again.
+	d, err = Fetch(context.Background(), "rdatasets/datasets/mtcars", ColumnName("wt"), ColumnName("mpg"),
+		WithCacheDir(dir), WithSHA256(strings.ToUpper(sha256Hex(mtcarsCSV))))
+	if err != nil || len(d.X) != 3 || requests.Load() != 1 {
+		t.Fatalf("Fetch() with a checksum = (%v, %v) after %d requests, want a fresh download", d, err, requests.Load())
+	}
+}
+
+func TestFetchErrors(t *testing.T) {
+	serveArchives(t, map[string]string{"/csv/datasets/mtcars.csv": mtcarsCSV})
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		id      string
+		opts    []FetchOption
+		wantErr string
+	}{
+		{
+			name:    "unknown archive",
+			ctx:     context.Background(),
+			id:      "kaggle/titanic",
+			wantErr: "unknown dataset archive",
+		},
+		{
+			name:    "rdatasets without a package",
+			ctx:     context.Background(),
+			id:      "rdatasets/mtcars",
+			wantErr: "rdatasets/<package>/<item>",
+		},
+		{
+			name:    "parent directory",
+			ctx:     context.Background(),
+			id:      "uci/../secret",
+			wantErr: "invalid dataset identifier",
+		},
+		{
+			name:    "empty element",
+			ctx:     context.Background(),
+			id:      "uci//iris.data",
+			wantErr: "invalid dataset identifier",
+		},
+		{
+			name:    "not found",
+			ctx:     context.Background(),
+			id:      "rdatasets/datasets/nosuch",
+			wantErr: "404 Not Found",
+		},
+		{
+			name:    "checksum mismatch",
+			ctx:     context.Background(),
+			id:      "rdatasets/datasets/mtcars",
+			opts:    []FetchOption{WithSHA256(sha256Hex("something else"))},
+			wantErr: "checksum mismatch",
+		},
+		{
+			name:    "cancelled",
+			ctx:     cancelled,
+			id:      "rdatasets/datasets/mtcars",
+			wantErr: "context canceled",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			opts := append([]FetchOption{WithCacheDir(dir)}, test.opts...)
+			_, err := Fetch(test.ctx, test.id, ColumnName("wt"), ColumnName("mpg"), opts...)
+			if err == nil {
+				t.Fatalf("Fetch() expected error containing %q but got none", test.wantErr)
+			}
+			if !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Fetch() error = %q, want it to contain %q", err, test.wantErr)
+			}
+			// A failed download leaves nothing in the cache.
+			entries, _ := filepath.Glob(filepath.Join(dir, "*", "*", "*"))
+			if len(entries) != 0 {
+				t.Errorf("Fetch() left %v in the cache", entries)
+			}
+		})
+	}
+}
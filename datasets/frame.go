@@ -0,0 +1,242 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+)
+
+// Frame is a table of named columns of float64 values, all of the same
+// length, in which row i of every column belongs to the same observation.
+// It holds multivariate data, such as the inputs of a correlation matrix,
+// in the way Dataset holds pairs:
+//
+//	f, err := datasets.NewFrame([]string{"height", "weight", "age"}, [][]float64{heights, weights, ages})
+//	m, err := correlation.CorrelationMatrix(f.Columns(), correlation.Spearman)
+//
+// The columns share memory with the slices a Frame is made from, as the
+// series of a Dataset do. A Frame is not safe for concurrent modification.
+type Frame struct {
+	// Name provides a descriptive name for the frame
+	Name string
+	// Description provides additional context about the frame
+	Description string
+	// Attribution provides reference to the authoritative source for this frame
+	Attribution string
+
+	names []string
+	cols  [][]float64
+	index map[string]int
+}
+
+// NewFrame returns a Frame of the columns cols named by names.
+//
+// An error is returned if there are different numbers of names and
+// columns, a name is empty or repeated, or the columns have different
+// lengths.
+func NewFrame(names []string, cols [][]float64) (*Frame, error) {
+	if len(names) != len(cols) {
+		return nil, errors.New("frame needs one name per column")
+	}
+	f := &Frame{
+		Name:        "",
+		Description: "",
+		Attribution: "",
+		names:       nil,
+		cols:        nil,
+		index:       make(map[string]int, len(names)),
+	}
+	for i, name := range names {
+		if err := f.AddColumn(name, cols[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return f, nil
+}
+
+// NumRows returns the number of rows.
+func (f *Frame) NumRows() int {
+	if len(f.cols) == 0 {
+		return 0
+	}
+
+	return len(f.cols[0])
+}
+
+// NumColumns returns the number of columns.
+func (f *Frame) NumColumns() int {
+	return len(f.cols)
+}
+
+// Names returns the names of the columns in order.
+func (f *Frame) Names() []string {
+	return slices.Clone(f.names)
+}
+
+// Column returns the values of the named column, or false if there is no
+// such column.
+func (f *Frame) Column(name string) ([]float64, bool) {
+	i, ok := f.index[name]
+	if !ok {
+		return nil, false
+	}
+
+	return f.cols[i], true
+}
+
+// Columns returns the columns in order, as the correlation matrix
+// functions take them.
+func (f *Frame) Columns() [][]float64 {
+	return slices.Clone(f.cols)
+}
+
+// Series returns the columns keyed by name, as correlation.CorrelateNamed
+// takes them.
+func (f *Frame) Series() map[string][]float64 {
+	m := make(map[string][]float64, len(f.cols))
+	for i, name := range f.names {
+		m[name] = f.cols[i]
+	}
+
+	return m
+}
+
+// AddColumn appends a column named name holding values.
+//
+// An error is returned if the name is empty or already used, or values
+// does not have one value per row.
+func (f *Frame) AddColumn(name string, values []float64) error {
+	if name == "" {
+		return errors.New("frame column name cannot be empty")
+	}
+	if _, ok := f.index[name]; ok {
+		return errors.New("frame already has a column " + strconv.Quote(name))
+	}
+	if len(f.cols) > 0 && len(values) != f.NumRows() {
+		return errors.New("column " + strconv.Quote(name) + " has " + strconv.Itoa(len(values)) +
+			" values, want " + strconv.Itoa(f.NumRows()))
+	}
+	if f.index == nil {
+		f.index = make(map[string]int)
+	}
+	f.index[name] = len(f.cols)
+	f.names = append(f.names, name)
+	f.cols = append(f.cols, values)
+
+	return nil
+}
+
+// Select returns a Frame of the named columns, in the order given, sharing
+// their values with f.
+//
+// An error is returned if a column does not exist or is named twice.
+func (f *Frame) Select(names ...string) (*Frame, error) {
+	cols := make([][]float64, len(names))
+	for i, name := range names {
+		col, ok := f.Column(name)
+		if !ok {
+			return nil, errors.New("frame has no column " + strconv.Quote(name))
+		}
+		cols[i] = col
+	}
+	sel, err := NewFrame(names, cols)
+	if err != nil {
+		return nil, err
+	}
+	sel.Name, sel.Description, sel.Attribution = f.Name, f.Description, f.Attribution
+
+	return sel, nil
+}
+
+// Filter returns a Frame of the rows for which keep returns true, with new
+// copies of their values. keep is called with the values of each row in
+// column order, in a slice that is reused from row to row.
+func (f *Frame) Filter(keep func(row []float64) bool) *Frame {
+	out := &Frame{
+		Name:        f.Name,
+		Description: f.Description,
+		Attribution: f.Attribution,
+		names:       slices.Clone(f.names),
+		cols:        make([][]float64, len(f.cols)),
+		index:       make(map[string]int, len(f.cols)),
+	}
+	for i, name := range f.names {
+		out.index[name] = i
+		out.cols[i] = []float64{}
+	}
+
+	row := make([]float64, len(f.cols))
+	for r := range f.NumRows() {
+		for c, col := range f.cols {
+			row[c] = col[r]
+		}
+		if !keep(row) {
+			continue
+		}
+		for c, col := range f.cols {
+			out.cols[c] = append(out.cols[c], col[r])
+		}
+	}
+
+	return out
+}
+
+// Dataset returns a view of the columns x and y as a Dataset, sharing
+// their values with f. Its Name is "x vs y" for column names x and y, its
+// XLabel and YLabel are x and y, and its Attribution is that of f.
+//
+// An error is returned if either column does not exist.
+func (f *Frame) Dataset(x, y string) (Dataset, error) {
+	xs, ok := f.Column(x)
+	if !ok {
+		return Dataset{}, errors.New("frame has no column " + strconv.Quote(x))
+	}
+	ys, ok := f.Column(y)
+	if !ok {
+		return Dataset{}, errors.New("frame has no column " + strconv.Quote(y))
+	}
+
+	return Dataset{Name: x + " vs " + y, Description: f.Description, Attribution: f.Attribution, XLabel: x, YLabel: y, XUnits: "", YUnits: "", X: xs, Y: ys, Valid: nil}, nil
+}
+
+// Pairs returns a view of every pair of columns as a Dataset, taking the
+// earlier column of each pair as X, in the order of the upper triangle of
+// a correlation matrix: (0, 1), (0, 2), ..., (1, 2), and so on. Each is
+// named and labelled as by Dataset.
+func (f *Frame) Pairs() Datasets {
+	n := len(f.cols)
+	data := make([]Dataset, 0, n*(n-1)/2)
+	for i := range n {
+		for j := i + 1; j < n; j++ {
+			data = append(data, Dataset{
+				Name:        f.names[i] + " vs " + f.names[j],
+				Description: f.Description,
+				Attribution: f.Attribution,
+				XLabel:      f.names[i],
+				YLabel:      f.names[j],
+				XUnits:      "",
+				YUnits:      "",
+				X:           f.cols[i],
+				Y:           f.cols[j],
+				Valid:       nil,
+			})
+		}
+	}
+
+	return Datasets{Name: f.Name, Description: f.Description, Attribution: f.Attribution, Data: data}
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testFrame returns a frame of three columns of four rows.
+func testFrame(t *testing.T) *Frame {
+	t.Helper()
+	f, err := NewFrame([]string{"a", "b", "c"}, [][]float64{
+		{1, 2, 3, 4},
+		{10, 20, 30, 40},
+		{4, 3, 2, 1},
+	})
+	if err != nil {
+		t.Fatalf("NewFrame() unexpected error: %v", err)
+	}
+	f.Name = "test"
+
+	return f
+}
+
+func TestFrame(t *testing.T) {
+	f := testFrame(t)
+	if f.NumRows() != 4 || f.NumColumns() != 3 {
+		t.Errorf("frame is %d by %d, want 4 by 3", f.NumRows(), f.NumColumns())
+	}
+	if got := f.Names(); !slices.Equal(got, []string{"a", "b", "c"}) {
+		t.Errorf("Names() = %v, want [a b c]", got)
+	}
+	if b, ok := f.Column("b"); !ok || !slices.Equal(b, []float64{10, 20, 30, 40}) {
+		t.Errorf("Column(b) = %v, %v, want [10 20 30 40], true", b, ok)
+	}
+	if _, ok := f.Column("z"); ok {
+		t.Errorf("Column(z) found a column that does not exist")
+	}
+	if cols := f.Columns(); len(cols) != 3 || cols[2][0] != 4 {
+		t.Errorf("Columns() = %v, want the three columns in order", cols)
+	}
+	if s := f.Series(); len(s) != 3 || s["c"][3] != 1 {
+		t.Errorf("Series() = %v, want the three columns by name", s)
+	}
+
+	if err := f.AddColumn("d", []float64{0, 0, 0, 1}); err != nil {
+		t.Fatalf("AddColumn() unexpected error: %v", err)
+	}
+	if f.NumColumns() != 4 {
+		t.Errorf("NumColumns() after AddColumn = %d, want 4", f.NumColumns())
+	}
+
+	var empty Frame
+	if empty.NumRows() != 0 || empty.NumColumns() != 0 {
+		t.Errorf("zero Frame is %d by %d, want 0 by 0", empty.NumRows(), empty.NumColumns())
+	}
+	if err := empty.AddColumn("x", []float64{1, 2}); err != nil || empty.NumRows() != 2 {
+		t.Errorf("AddColumn() to the zero Frame = %v with %d rows, want 2 rows", err, empty.NumRows())
+	}
+}
+
+func TestFrameErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		cols    [][]float64
+		wantErr string
+	}{
+		{
+			name:    "missing name",
+			names:   []string{"a"},
+			cols:    [][]float64{{1}, {2}},
+			wantErr: "one name per column",
+		},
+		{
+			name:    "empty name",
+			names:   []string{"a", ""},
+			cols:    [][]float64{{1}, {2}},
+			wantErr: "name cannot be empty",
+		},
+		{
+			name:    "repeated name",
+			names:   []string{"a", "a"},
+			cols:    [][]float64{{1}, {2}},
+			wantErr: `already has a column "a"`,
+		},
+		{
+			name:    "ragged",
+			names:   []string{"a", "b"},
+			cols:    [][]float64{{1, 2}, {3}},
+			wantErr: `column "b" has 1 values, want 2`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewFrame(test.names, test.cols)
+			if err == nil {
+				t.Fatalf("NewFrame() expected error containing %q but got none", test.wantErr)
+			}
+			if !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("NewFrame() error = %q, want it to contain %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestFrameSelect(t *testing.T) {
+	f := testFrame(t)
+	sel, err := f.Select("c", "a")
+	if err != nil {
+		t.Fatalf("Select() unexpected error: %v", err)
+	}
+	if !slices.Equal(sel.Names(), []string{"c", "a"}) || sel.Name != "test" {
+		t.Errorf("Select(c, a) = %v named %q, want [c a] named test", sel.Names(), sel.Name)
+	}
+	if _, err := f.Select("a", "z"); err == nil {
+		t.Errorf("Select() of a missing column expected error but got none")
+	}
+	if _, err := f.Select("a", "a"); err == nil {
+		t.Errorf("Select() of a repeated column expected error but got none")
+	}
+}
+
+func TestFrameFilter(t *testing.T) {
+	f := testFrame(t)
+	got := f.Filter(func(row []float64) bool { return row[0] > 1 && row[2] > 1 })
+	if got.NumRows() != 2 || got.Name != "test" {
+		t.Fatalf("Filter() kept %d rows named %q, want 2 named test", got.NumRows(), got.Name)
+	}
+	if b, _ := got.Column("b"); !slices.Equal(b, []float64{20, 30}) {
+		t.Errorf("Filter() column b = %v, want [20 30]", b)
+	}
+	// The filtered frame has its own values.
+	b, _ := got.Column("b")
+	b[0] = -1
+	if orig, _ := f.Column("b"); orig[1] != 20 {
+		t.Errorf("modifying a filtered frame changed the original")
+	}
+
+	none := f.Filter(func([]float64) bool { return false })
+	if a, ok := none.Column("a"); none.NumRows() != 0 || !ok || a == nil {
+		t.Errorf("Filter() of no rows = %v, %v, want an empty column a", a, ok)
+	}
+}
+
+func TestFrameDataset(t *testing.T) {
+	f := testFrame(t)
+	f.Attribution = "source"
+	d, err := f.Dataset("a", "c")
+	if err != nil {
+		t.Fatalf("Dataset() unexpected error: %v", err)
+	}
+	if d.Name != "a vs c" || d.Attribution != "source" || d.XLabel != "a" || d.YLabel != "c" || d.X[3] != 4 || d.Y[3] != 1 {
+		t.Errorf("Dataset(a, c) = %+v, want the a and c columns", d)
+	}
+	if _, err := f.Dataset("a", "z"); err == nil {
+		t.Errorf("Dataset() of a missing column expected error but got none")
+	}
+
+	pairs := f.Pairs()
+	var names []string
+	for _, p := range pairs.Data {
+		names = append(names, p.Name)
+	}
+	if want := []string{"a vs b", "a vs c", "b vs c"}; !slices.Equal(names, want) || pairs.Name != "test" {
+		t.Errorf("Pairs() = %v named %q, want %v named test", names, pairs.Name, want)
+	}
+}
@@ -0,0 +1,342 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"strconv"
+)
+
+// GenerateBivariateNormal returns n pairs drawn from the standard
+// bivariate normal distribution with population correlation r, for
+// testing, teaching and benchmarking.
+//
+// The pairs are made from independent standard normal values z1 and z2 by
+// the Cholesky factor of the correlation matrix, x = z1 and
+// y = r*z1 + sqrt(1-r²)*z2, so the population correlation is exactly r,
+// while the sample correlation varies around it by about (1-r²)/sqrt(n).
+// The same seed always produces the same pairs.
+//
+// An error is returned if n is negative or r is not in [-1, 1].
+func GenerateBivariateNormal(n int, r float64, seed int64) (Dataset, error) {
+	if n < 0 {
+		return Dataset{}, errors.New("number of pairs cannot be negative")
+	}
+	if r < -1 || r > 1 || math.IsNaN(r) {
+		return Dataset{}, errors.New("correlation must be in the interval [-1, 1]")
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	s := math.Sqrt(1 - r*r)
+	d := generated("bivariate normal", "Standard bivariate normal sample with population correlation "+
+		strconv.FormatFloat(r, 'g', -1, 64)+".", n)
+	for i := range n {
+		z1, z2 := rng.NormFloat64(), rng.NormFloat64()
+		d.X[i] = z1
+		d.Y[i] = r*z1 + s*z2
+	}
+
+	return d, nil
+}
+
+// GenerateBivariateT returns n pairs drawn from the bivariate Student's t
+// distribution with df degrees of freedom and correlation parameter r,
+// which is the bivariate normal with both values divided by the same
+// sqrt(W/df), W being chi-squared with df degrees of freedom. Its tails are
+// heavier the smaller df is: df = 1 gives Cauchy marginals, which have no
+// mean or variance, and large df approaches GenerateBivariateNormal.
+//
+// The population Pearson correlation is r when df > 2, and undefined
+// otherwise, while Kendall's tau is (2/π)·asin(r) for every df. The
+// occasional huge pairs make the sample Pearson correlation unstable, which
+// robust and rank correlations are not. The same seed always produces the
+// same pairs.
+//
+// An error is returned if n is negative, r is not in [-1, 1], or df is not
+// positive and finite.
+func GenerateBivariateT(n int, r, df float64, seed int64) (Dataset, error) {
+	if n < 0 {
+		return Dataset{}, errors.New("number of pairs cannot be negative")
+	}
+	if r < -1 || r > 1 || math.IsNaN(r) {
+		return Dataset{}, errors.New("correlation must be in the interval [-1, 1]")
+	}
+	if !(df > 0) || math.IsInf(df, 1) {
+		return Dataset{}, errors.New("degrees of freedom must be positive and finite")
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	s := math.Sqrt(1 - r*r)
+	d := generated("bivariate t", "Bivariate Student's t sample with "+strconv.FormatFloat(df, 'g', -1, 64)+
+		" degrees of freedom and correlation "+strconv.FormatFloat(r, 'g', -1, 64)+".", n)
+	for i := range n {
+		z1, z2 := rng.NormFloat64(), rng.NormFloat64()
+		w := math.Sqrt(2 * gammaRand(rng, df/2) / df)
+		d.X[i] = z1 / w
+		d.Y[i] = (r*z1 + s*z2) / w
+	}
+
+	return d, nil
+}
+
+// GenerateContaminated returns n pairs from an epsilon-contaminated
+// bivariate normal distribution, to show how a few bad points break
+// Pearson's r. The fraction eps of the pairs, rounded to a whole number and
+// placed at random, come from a contaminating normal distribution with
+// standard deviation 10 and correlation -r; the rest are standard normal
+// with correlation r, as from GenerateBivariateNormal.
+//
+// With these scales Pearson's r is already near 0 at 1% contamination and
+// heads towards -r beyond it, while a robust correlation estimate stays
+// near r until eps approaches its breakdown point. The same seed always
+// produces the same pairs.
+//
+// An error is returned if n is negative, r is not in [-1, 1], or eps is not
+// in [0, 1].
+func GenerateContaminated(n int, r, eps float64, seed int64) (Dataset, error) {
+	if n < 0 {
+		return Dataset{}, errors.New("number of pairs cannot be negative")
+	}
+	if r < -1 || r > 1 || math.IsNaN(r) {
+		return Dataset{}, errors.New("correlation must be in the interval [-1, 1]")
+	}
+	if eps < 0 || eps > 1 || math.IsNaN(eps) {
+		return Dataset{}, errors.New("contamination fraction must be in the interval [0, 1]")
+	}
+
+	const scale = 10
+
+	rng := rand.New(rand.NewSource(seed))
+	s := math.Sqrt(1 - r*r)
+	d := generated("contaminated normal", "Bivariate normal sample with correlation "+
+		strconv.FormatFloat(r, 'g', -1, 64)+" and a fraction "+strconv.FormatFloat(eps, 'g', -1, 64)+
+		" of contaminating pairs.", n)
+	bad := make([]bool, n)
+	for _, i := range rng.Perm(n)[:int(math.Round(eps*float64(n)))] {
+		bad[i] = true
+	}
+	for i := range n {
+		z1, z2 := rng.NormFloat64(), rng.NormFloat64()
+		if bad[i] {
+			d.X[i] = scale * z1
+			d.Y[i] = scale * (-r*z1 + s*z2)
+
+			continue
+		}
+		d.X[i] = z1
+		d.Y[i] = r*z1 + s*z2
+	}
+
+	return d, nil
+}
+
+// gammaRand returns a value from the gamma distribution with the given
+// shape and unit scale, by the method of Marsaglia and Tsang.
+func gammaRand(rng *rand.Rand, shape float64) float64 {
+	if shape < 1 {
+		// A gamma(shape+1) value times U^(1/shape) is gamma(shape).
+		return gammaRand(rng, shape+1) * math.Pow(openUniform(rng), 1/shape)
+	}
+
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		z := rng.NormFloat64()
+		v := 1 + c*z
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		if math.Log(openUniform(rng)) < z*z/2+d-d*v+d*math.Log(v) {
+			return d * v
+		}
+	}
+}
+
+// GenerateAR1 returns two AR(1) time series of length n as the X and Y of
+// a dataset, each following v[t] = phi*v[t-1] + e[t], where the standard
+// normal innovations of the two series at the same time have correlation
+// r.
+//
+// For |phi| < 1 the series start from their stationary distribution and
+// the population correlation of X and Y is exactly r, but the closer phi
+// is to 1, the further the sample correlation strays from it: two
+// independent series with phi near 1 often show a large correlation by
+// chance. With phi = 1 the series are random walks, starting at 0, whose
+// sample correlation does not settle down at all as n grows. Differencing
+// the series with Difference(1) when phi = 1, or taking v[t] - phi*v[t-1]
+// in general, leaves the innovations and so recovers r. The same seed
+// always produces the same series.
+//
+// An error is returned if n is negative or phi or r is not in [-1, 1].
+func GenerateAR1(n int, phi, r float64, seed int64) (Dataset, error) {
+	if n < 0 {
+		return Dataset{}, errors.New("number of pairs cannot be negative")
+	}
+	if phi < -1 || phi > 1 || math.IsNaN(phi) {
+		return Dataset{}, errors.New("autoregressive coefficient must be in the interval [-1, 1]")
+	}
+	if r < -1 || r > 1 || math.IsNaN(r) {
+		return Dataset{}, errors.New("correlation must be in the interval [-1, 1]")
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	s := math.Sqrt(1 - r*r)
+	d := generated("AR(1)", "Pair of AR(1) series with coefficient "+strconv.FormatFloat(phi, 'g', -1, 64)+
+		" and innovation correlation "+strconv.FormatFloat(r, 'g', -1, 64)+".", n)
+	var x, y float64
+	for i := range n {
+		z1, z2 := rng.NormFloat64(), rng.NormFloat64()
+		ex, ey := z1, r*z1+s*z2
+		if i == 0 && math.Abs(phi) < 1 {
+			// The stationary distribution has variance 1/(1-phi²).
+			ex /= math.Sqrt(1 - phi*phi)
+			ey /= math.Sqrt(1 - phi*phi)
+		}
+		x = phi*x + ex
+		y = phi*y + ey
+		d.X[i] = x
+		d.Y[i] = y
+	}
+
+	return d, nil
+}
+
+// Pattern is a shape of point cloud made by GeneratePattern.
+type Pattern int
+
+const (
+	// PatternQuadratic is the parabola y = x² for x in [-1, 1], a
+	// dependence with no linear correlation.
+	PatternQuadratic Pattern = iota
+	// PatternExponential is the curve y = exp(3x-3) for x in [-1, 1], a
+	// monotonic but nonlinear dependence.
+	PatternExponential
+	// PatternSinusoidal is two periods of the sine wave y = sin(2πx) for x
+	// in [-1, 1].
+	PatternSinusoidal
+	// PatternRing is the unit circle, with no linear or monotonic
+	// correlation.
+	PatternRing
+	// PatternClusters is three clusters centred on (-0.6, -0.3), (0, 0.6)
+	// and (0.6, -0.3), with no linear correlation between them.
+	PatternClusters
+)
+
+// String returns the name of the pattern.
+func (p Pattern) String() string {
+	switch p {
+	case PatternQuadratic:
+		return "quadratic"
+	case PatternExponential:
+		return "exponential"
+	case PatternSinusoidal:
+		return "sinusoidal"
+	case PatternRing:
+		return "ring"
+	case PatternClusters:
+		return "clusters"
+	default:
+		return "Unknown"
+	}
+}
+
+// clusterCenters are the centres of the clusters of PatternClusters.
+var clusterCenters = [...]Point{{X: -0.6, Y: -0.3}, {X: 0, Y: 0.6}, {X: 0.6, Y: -0.3}}
+
+// GeneratePattern returns n pairs lying on the shape of pattern, for
+// building Anscombe style demonstrations and testing measures of nonlinear
+// dependence, such as the distance correlation, that Pearson's r misses.
+//
+// Normal noise with standard deviation noise is added to Y, or to both
+// coordinates for PatternRing and PatternClusters, whose points are spread
+// around the shape rather than above and below it. The shapes span about
+// [-1, 1] on each axis, so a noise of 0.1 is mild and 1 mostly hides the
+// pattern. The same seed always produces the same pairs.
+//
+// An error is returned if n is negative, noise is negative or not finite,
+// or the pattern is unknown.
+func GeneratePattern(n int, pattern Pattern, noise float64, seed int64) (Dataset, error) {
+	if n < 0 {
+		return Dataset{}, errors.New("number of pairs cannot be negative")
+	}
+	if !(noise >= 0) || math.IsInf(noise, 1) {
+		return Dataset{}, errors.New("noise must be finite and non-negative")
+	}
+
+	var shape func(rng *rand.Rand) (float64, float64)
+	switch pattern {
+	case PatternQuadratic:
+		shape = curve(func(x float64) float64 { return x * x })
+	case PatternExponential:
+		shape = curve(func(x float64) float64 { return math.Exp(3*x - 3) })
+	case PatternSinusoidal:
+		shape = curve(func(x float64) float64 { return math.Sin(2 * math.Pi * x) })
+	case PatternRing:
+		shape = func(rng *rand.Rand) (float64, float64) {
+			return math.Sincos(2 * math.Pi * rng.Float64())
+		}
+	case PatternClusters:
+		shape = func(rng *rand.Rand) (float64, float64) {
+			c := clusterCenters[rng.Intn(len(clusterCenters))]
+
+			return c.X, c.Y
+		}
+	default:
+		return Dataset{}, errors.New("unknown pattern")
+	}
+
+	rng := rand.New(rand.NewSource(seed))
+	both := pattern == PatternRing || pattern == PatternClusters
+	d := generated(pattern.String()+" pattern", "Points on a "+pattern.String()+
+		" pattern with noise of standard deviation "+strconv.FormatFloat(noise, 'g', -1, 64)+".", n)
+	for i := range n {
+		x, y := shape(rng)
+		if both {
+			x += noise * rng.NormFloat64()
+		}
+		d.X[i] = x
+		d.Y[i] = y + noise*rng.NormFloat64()
+	}
+
+	return d, nil
+}
+
+// curve returns a shape of points (x, fn(x)) for x uniform in [-1, 1].
+func curve(fn func(float64) float64) func(rng *rand.Rand) (float64, float64) {
+	return func(rng *rand.Rand) (float64, float64) {
+		x := 2*rng.Float64() - 1
+
+		return x, fn(x)
+	}
+}
+
+// generated returns a Dataset of n pairs, to be filled in by a generator.
+func generated(name, description string, n int) Dataset {
+	return Dataset{
+		Name:        name,
+		Description: description,
+		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           make([]float64, n),
+		Y:           make([]float64, n),
+		Valid:       nil,
+	}
+}
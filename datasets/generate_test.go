@@ -0,0 +1,344 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGenerateBivariateNormal(t *testing.T) {
+	for _, r := range []float64{-1, -0.6, 0, 0.3, 0.9, 1} {
+		d, err := GenerateBivariateNormal(20000, r, 11)
+		if err != nil {
+			t.Fatalf("GenerateBivariateNormal(%v) unexpected error: %v", r, err)
+		}
+		if len(d.X) != 20000 || len(d.Y) != 20000 {
+			t.Fatalf("GenerateBivariateNormal(%v) returned %d, %d values, want 20000", r, len(d.X), len(d.Y))
+		}
+		// The standard error of the sample correlation is (1-r²)/sqrt(n),
+		// under 0.01 here.
+		if got := pearson(d.X, d.Y); math.Abs(got-r) > 0.03 {
+			t.Errorf("GenerateBivariateNormal(%v) sample correlation = %v", r, got)
+		}
+		if m, v := mean(d.Y), variance(d.Y); math.Abs(m) > 0.05 || math.Abs(v-1) > 0.05 {
+			t.Errorf("GenerateBivariateNormal(%v) Y mean, variance = %v, %v, want 0, 1", r, m, v)
+		}
+	}
+
+	a, _ := GenerateBivariateNormal(10, 0.5, 3)
+	b, _ := GenerateBivariateNormal(10, 0.5, 3)
+	if !slices.Equal(a.X, b.X) || !slices.Equal(a.Y, b.Y) {
+		t.Errorf("GenerateBivariateNormal() with the same seed differs")
+	}
+	if !strings.Contains(a.Description, "0.5") {
+		t.Errorf("GenerateBivariateNormal() description = %q, want the correlation", a.Description)
+	}
+}
+
+func TestGenerateBivariateNormalErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		r       float64
+		wantErr string
+	}{
+		{name: "negative n", n: -1, r: 0, wantErr: "negative"},
+		{name: "r above 1", n: 10, r: 1.01, wantErr: "[-1, 1]"},
+		{name: "NaN r", n: 10, r: math.NaN(), wantErr: "[-1, 1]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := GenerateBivariateNormal(test.n, test.r, 1)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("GenerateBivariateNormal() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestGeneratePattern(t *testing.T) {
+	tests := []struct {
+		pattern Pattern
+		// on reports whether a noiseless point lies on the pattern.
+		on func(x, y float64) bool
+		// uncorrelated is whether the pattern has no linear correlation.
+		uncorrelated bool
+	}{
+		{pattern: PatternQuadratic, on: func(x, y float64) bool { return math.Abs(y-x*x) < 1e-12 }, uncorrelated: true},
+		{pattern: PatternExponential, on: func(x, y float64) bool { return math.Abs(y-math.Exp(3*x-3)) < 1e-12 }, uncorrelated: false},
+		{pattern: PatternSinusoidal, on: func(x, y float64) bool { return math.Abs(y-math.Sin(2*math.Pi*x)) < 1e-12 }, uncorrelated: false},
+		{pattern: PatternRing, on: func(x, y float64) bool { return math.Abs(math.Hypot(x, y)-1) < 1e-12 }, uncorrelated: true},
+		{pattern: PatternClusters, on: func(x, y float64) bool {
+			return slices.Contains(clusterCenters[:], Point{X: x, Y: y})
+		}, uncorrelated: true},
+	}
+
+	for _, test := range tests {
+		d, err := GeneratePattern(500, test.pattern, 0, 4)
+		if err != nil {
+			t.Fatalf("GeneratePattern(%v) unexpected error: %v", test.pattern, err)
+		}
+		for i := range d.X {
+			if d.X[i] < -1 || d.X[i] > 1 || !test.on(d.X[i], d.Y[i]) {
+				t.Fatalf("GeneratePattern(%v) pair %d = (%v, %v) is not on the pattern", test.pattern, i, d.X[i], d.Y[i])
+			}
+		}
+		if test.uncorrelated {
+			if r := pearson(d.X, d.Y); math.Abs(r) > 0.15 {
+				t.Errorf("GeneratePattern(%v) correlation = %v, want about 0", test.pattern, r)
+			}
+		}
+
+		noisy, _ := GeneratePattern(5000, test.pattern, 0.5, 4)
+		clean, _ := GeneratePattern(5000, test.pattern, 0, 4)
+		if got, want := variance(noisy.Y), variance(clean.Y)+0.25; math.Abs(got-want) > 0.05 {
+			t.Errorf("GeneratePattern(%v, 0.5) Y variance = %v, want about %v", test.pattern, got, want)
+		}
+		moved := !slices.Equal(noisy.X, clean.X)
+		if want := test.pattern == PatternRing || test.pattern == PatternClusters; moved != want {
+			t.Errorf("GeneratePattern(%v, 0.5) added noise to X = %v, want %v", test.pattern, moved, want)
+		}
+	}
+}
+
+func TestGeneratePatternErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		pattern Pattern
+		noise   float64
+		wantErr string
+	}{
+		{name: "negative n", n: -2, pattern: PatternRing, noise: 0, wantErr: "negative"},
+		{name: "negative noise", n: 10, pattern: PatternRing, noise: -0.1, wantErr: "noise"},
+		{name: "infinite noise", n: 10, pattern: PatternRing, noise: math.Inf(1), wantErr: "noise"},
+		{name: "NaN noise", n: 10, pattern: PatternRing, noise: math.NaN(), wantErr: "noise"},
+		{name: "unknown pattern", n: 10, pattern: Pattern(7), noise: 0, wantErr: "unknown pattern"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := GeneratePattern(test.n, test.pattern, test.noise, 1)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("GeneratePattern() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestPatternString(t *testing.T) {
+	if got := PatternSinusoidal.String(); got != "sinusoidal" {
+		t.Errorf("PatternSinusoidal.String() = %q, want %q", got, "sinusoidal")
+	}
+	if got := Pattern(-1).String(); got != "Unknown" {
+		t.Errorf("Pattern(-1).String() = %q, want %q", got, "Unknown")
+	}
+}
+
+func TestGenerateAR1(t *testing.T) {
+	tests := []struct {
+		phi, r float64
+	}{
+		{phi: 0, r: 0.5},
+		{phi: 0.5, r: 0.8},
+		{phi: 0.9, r: -0.4},
+		{phi: -0.7, r: 0},
+		{phi: 1, r: 0.6},
+	}
+
+	for _, test := range tests {
+		d, err := GenerateAR1(20000, test.phi, test.r, 8)
+		if err != nil {
+			t.Fatalf("GenerateAR1(%v, %v) unexpected error: %v", test.phi, test.r, err)
+		}
+		if len(d.X) != 20000 || len(d.Y) != 20000 {
+			t.Fatalf("GenerateAR1(%v, %v) returned %d, %d values, want 20000", test.phi, test.r, len(d.X), len(d.Y))
+		}
+
+		// Removing the autoregression leaves the innovations.
+		ex := make([]float64, len(d.X)-1)
+		ey := make([]float64, len(d.Y)-1)
+		for i := range ex {
+			ex[i] = d.X[i+1] - test.phi*d.X[i]
+			ey[i] = d.Y[i+1] - test.phi*d.Y[i]
+		}
+		if v := variance(ex); math.Abs(v-1) > 0.05 {
+			t.Errorf("GenerateAR1(%v, %v) innovation variance = %v, want 1", test.phi, test.r, v)
+		}
+		if got := pearson(ex, ey); math.Abs(got-test.r) > 0.03 {
+			t.Errorf("GenerateAR1(%v, %v) innovation correlation = %v", test.phi, test.r, got)
+		}
+
+		if test.phi < 1 {
+			want := 1 / (1 - test.phi*test.phi)
+			if v := variance(d.X); math.Abs(v-want) > 0.1*want {
+				t.Errorf("GenerateAR1(%v, %v) variance = %v, want %v", test.phi, test.r, v, want)
+			}
+		} else {
+			diff := d.Difference(1)
+			if got := pearson(diff.X, diff.Y); math.Abs(got-test.r) > 0.03 {
+				t.Errorf("GenerateAR1(%v, %v) differenced correlation = %v", test.phi, test.r, got)
+			}
+		}
+	}
+}
+
+func TestGenerateAR1Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		phi, r  float64
+		wantErr string
+	}{
+		{name: "negative n", n: -1, phi: 0.5, r: 0, wantErr: "negative"},
+		{name: "explosive phi", n: 10, phi: 1.1, r: 0, wantErr: "autoregressive"},
+		{name: "NaN phi", n: 10, phi: math.NaN(), r: 0, wantErr: "autoregressive"},
+		{name: "r below -1", n: 10, phi: 0.5, r: -2, wantErr: "correlation"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := GenerateAR1(test.n, test.phi, test.r, 1)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("GenerateAR1() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestGenerateBivariateT(t *testing.T) {
+	tests := []struct {
+		r, df float64
+	}{
+		{r: 0.5, df: 1},
+		{r: -0.7, df: 1.5},
+		{r: 0.3, df: 3.5},
+		{r: 0.9, df: 30},
+	}
+
+	for _, test := range tests {
+		d, err := GenerateBivariateT(2000, test.r, test.df, 6)
+		if err != nil {
+			t.Fatalf("GenerateBivariateT(%v, %v) unexpected error: %v", test.r, test.df, err)
+		}
+		want := 2 / math.Pi * math.Asin(test.r)
+		if got := kendall(d.X, d.Y); math.Abs(got-want) > 0.04 {
+			t.Errorf("GenerateBivariateT(%v, %v) Kendall's tau = %v, want %v", test.r, test.df, got, want)
+		}
+	}
+
+	// Half of the standard Cauchy distribution lies within 1 of 0.
+	d, _ := GenerateBivariateT(20000, 0, 1, 2)
+	inside := 0
+	for _, v := range d.X {
+		if math.Abs(v) < 1 {
+			inside++
+		}
+	}
+	if frac := float64(inside) / 20000; math.Abs(frac-0.5) > 0.02 {
+		t.Errorf("GenerateBivariateT(0, 1) fraction within 1 = %v, want 0.5", frac)
+	}
+
+	// For df > 4 the sample Pearson correlation settles down around r.
+	d, _ = GenerateBivariateT(20000, 0.6, 10, 2)
+	if got := pearson(d.X, d.Y); math.Abs(got-0.6) > 0.03 {
+		t.Errorf("GenerateBivariateT(0.6, 10) correlation = %v, want 0.6", got)
+	}
+	if v, want := variance(d.X), 10.0/8; math.Abs(v-want) > 0.1 {
+		t.Errorf("GenerateBivariateT(0.6, 10) variance = %v, want %v", v, want)
+	}
+}
+
+func TestGenerateContaminated(t *testing.T) {
+	tests := []struct {
+		eps      float64
+		wantR    float64
+		wantVarX float64
+	}{
+		{eps: 0, wantR: 0.8, wantVarX: 1},
+		{eps: 0.01, wantR: 0, wantVarX: 1.99},
+		{eps: 0.1, wantR: -7.28 / 10.9, wantVarX: 10.9},
+	}
+
+	for _, test := range tests {
+		d, err := GenerateContaminated(50000, 0.8, test.eps, 3)
+		if err != nil {
+			t.Fatalf("GenerateContaminated(0.8, %v) unexpected error: %v", test.eps, err)
+		}
+		if got := pearson(d.X, d.Y); math.Abs(got-test.wantR) > 0.1 {
+			t.Errorf("GenerateContaminated(0.8, %v) correlation = %v, want %v", test.eps, got, test.wantR)
+		}
+		if v := variance(d.X); math.Abs(v-test.wantVarX) > 0.1*test.wantVarX {
+			t.Errorf("GenerateContaminated(0.8, %v) variance = %v, want %v", test.eps, v, test.wantVarX)
+		}
+	}
+}
+
+func TestGenerateHeavyTailedErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		gen     func() (Dataset, error)
+		wantErr string
+	}{
+		{
+			name:    "t negative n",
+			gen:     func() (Dataset, error) { return GenerateBivariateT(-1, 0, 1, 1) },
+			wantErr: "negative",
+		},
+		{
+			name:    "t bad r",
+			gen:     func() (Dataset, error) { return GenerateBivariateT(10, 1.5, 1, 1) },
+			wantErr: "correlation",
+		},
+		{
+			name:    "t zero df",
+			gen:     func() (Dataset, error) { return GenerateBivariateT(10, 0, 0, 1) },
+			wantErr: "degrees of freedom",
+		},
+		{
+			name:    "t infinite df",
+			gen:     func() (Dataset, error) { return GenerateBivariateT(10, 0, math.Inf(1), 1) },
+			wantErr: "degrees of freedom",
+		},
+		{
+			name:    "contaminated negative n",
+			gen:     func() (Dataset, error) { return GenerateContaminated(-1, 0, 0, 1) },
+			wantErr: "negative",
+		},
+		{
+			name:    "contaminated bad r",
+			gen:     func() (Dataset, error) { return GenerateContaminated(10, math.NaN(), 0, 1) },
+			wantErr: "correlation",
+		},
+		{
+			name:    "contaminated bad eps",
+			gen:     func() (Dataset, error) { return GenerateContaminated(10, 0, 1.2, 1) },
+			wantErr: "contamination",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.gen()
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
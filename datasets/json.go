@@ -0,0 +1,300 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+)
+
+// JSONOption configures FromJSON, FromJSONLines and JSONLinesSource.
+type JSONOption func(*jsonOptions)
+
+// jsonOptions holds the settings accumulated from a list of JSONOption
+// values.
+type jsonOptions struct {
+	xField string
+	yField string
+}
+
+// WithFields sets the names of the fields that hold the X and Y values. The
+// defaults are "x" and "y".
+func WithFields(x, y string) JSONOption {
+	return func(o *jsonOptions) {
+		o.xField = x
+		o.yField = y
+	}
+}
+
+// jsonFloat is a float64 in JSON, where NaN is written as null and the
+// infinities as the strings "+Inf" and "-Inf", which JSON numbers cannot
+// express.
+type jsonFloat float64
+
+// MarshalJSON implements json.Marshaler.
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	switch {
+	case math.IsNaN(v):
+		return []byte("null"), nil
+	case math.IsInf(v, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(v, -1):
+		return []byte(`"-Inf"`), nil
+	}
+
+	return strconv.AppendFloat(nil, v, 'g', -1, 64), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (f *jsonFloat) UnmarshalJSON(data []byte) error {
+	switch string(data) {
+	case "null", `"NaN"`:
+		*f = jsonFloat(math.NaN())
+	case `"+Inf"`, `"Inf"`:
+		*f = jsonFloat(math.Inf(1))
+	case `"-Inf"`:
+		*f = jsonFloat(math.Inf(-1))
+	default:
+		v, err := strconv.ParseFloat(string(data), 64)
+		if err != nil {
+			return errors.New(string(data) + " is not a number")
+		}
+		*f = jsonFloat(v)
+	}
+
+	return nil
+}
+
+// datasetJSON is the columnar JSON form of a Dataset.
+type datasetJSON struct {
+	Name        string      `json:"name,omitempty"`
+	Description string      `json:"description,omitempty"`
+	Attribution string      `json:"attribution,omitempty"`
+	XLabel      string      `json:"xLabel,omitempty"`
+	YLabel      string      `json:"yLabel,omitempty"`
+	XUnits      string      `json:"xUnits,omitempty"`
+	YUnits      string      `json:"yUnits,omitempty"`
+	X           []jsonFloat `json:"x"`
+	Y           []jsonFloat `json:"y"`
+	Valid       []bool      `json:"valid,omitempty"`
+}
+
+// MarshalJSON encodes the dataset in the columnar layout read by FromJSON,
+// omitting empty metadata:
+//
+//	{
+//	  "name": "lab",
+//	  "x": [1, 2, 3],
+//	  "y": [2.5, 3.1, null]
+//	}
+//
+// NaN values are null and the infinities are the strings "+Inf" and "-Inf".
+// Nil series are written as empty arrays. Valid, if set, is written as an
+// array of booleans named "valid".
+//
+// An error is returned if X and Y, or Valid, have different lengths.
+func (d Dataset) MarshalJSON() ([]byte, error) {
+	if len(d.X) != len(d.Y) || (d.Valid != nil && len(d.Valid) != len(d.X)) {
+		return nil, &ValidationError{Dataset: d.Name, Series: "", Index: -1, Err: ErrLengthMismatch}
+	}
+
+	return json.Marshal(datasetJSON{
+		Name:        d.Name,
+		Description: d.Description,
+		Attribution: d.Attribution,
+		XLabel:      d.XLabel,
+		YLabel:      d.YLabel,
+		XUnits:      d.XUnits,
+		YUnits:      d.YUnits,
+		X:           toJSONFloats(d.X),
+		Y:           toJSONFloats(d.Y),
+		Valid:       d.Valid,
+	})
+}
+
+// toJSONFloats converts vals for encoding, mapping nil to an empty slice so
+// that it is written as an array rather than null.
+func toJSONFloats(vals []float64) []jsonFloat {
+	out := make([]jsonFloat, len(vals))
+	for i, v := range vals {
+		out[i] = jsonFloat(v)
+	}
+
+	return out
+}
+
+// FromJSON reads a Dataset from JSON in either of two layouts. An array of
+// records holds one object per pair:
+//
+//	[{"x": 1, "y": 2.5}, {"x": 2, "y": 3.1}]
+//
+// while a columnar object holds the series as arrays, optionally with the
+// name, description and attribution of the dataset and the Valid mask as
+// an array of booleans named "valid":
+//
+//	{"name": "lab", "x": [1, 2], "y": [2.5, 3.1]}
+//
+// The columnar object may also hold the axis labels and units as the
+// strings "xLabel", "yLabel", "xUnits" and "yUnits". In the array of
+// records, XLabel and YLabel are set to the names of the value fields.
+//
+// The value fields are "x" and "y" unless set with WithFields; other fields
+// are ignored. A null value, or the string "NaN", is read as NaN, and the
+// strings "+Inf" and "-Inf" as the infinities. JSON compressed with gzip
+// or zstd is decompressed as it is read.
+//
+// An error is returned if the JSON is malformed or is neither layout, a
+// value field is missing or not a number, or the columns have different
+// lengths.
+func FromJSON(r io.Reader, opts ...JSONOption) (Dataset, error) {
+	o := jsonOptions{xField: "x", yField: "y"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	r, err := decompress(r)
+	if err != nil {
+		return Dataset{}, err
+	}
+	var raw json.RawMessage
+	if err := json.NewDecoder(r).Decode(&raw); err != nil {
+		return Dataset{}, err
+	}
+
+	switch trimmed := bytes.TrimLeft(raw, " \t\r\n"); {
+	case len(trimmed) > 0 && trimmed[0] == '[':
+		return recordsFromJSON(raw, o)
+	case len(trimmed) > 0 && trimmed[0] == '{':
+		return columnsFromJSON(raw, o)
+	default:
+		return Dataset{}, errors.New("JSON dataset must be an array of records or an object of columns")
+	}
+}
+
+// recordsFromJSON decodes an array of records.
+func recordsFromJSON(raw json.RawMessage, o jsonOptions) (Dataset, error) {
+	var records []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &records); err != nil {
+		return Dataset{}, err
+	}
+
+	d := Dataset{
+		Name:        "",
+		Description: "",
+		Attribution: "",
+		XLabel:      o.xField,
+		YLabel:      o.yField,
+		XUnits:      "",
+		YUnits:      "",
+		X:           make([]float64, len(records)),
+		Y:           make([]float64, len(records)),
+		Valid:       nil,
+	}
+	for i, rec := range records {
+		var err error
+		where := "record " + strconv.Itoa(i)
+		if d.X[i], err = recordField(rec, o.xField, where); err != nil {
+			return Dataset{}, err
+		}
+		if d.Y[i], err = recordField(rec, o.yField, where); err != nil {
+			return Dataset{}, err
+		}
+	}
+
+	return d, nil
+}
+
+// recordField decodes the named field of a record, which where locates
+// for errors.
+func recordField(rec map[string]json.RawMessage, field, where string) (float64, error) {
+	v, ok := rec[field]
+	if !ok {
+		return 0, errors.New(where + ": missing field " + strconv.Quote(field))
+	}
+	var f jsonFloat
+	if err := json.Unmarshal(v, &f); err != nil {
+		return 0, errors.New(where + ", field " + strconv.Quote(field) + ": " + err.Error())
+	}
+
+	return float64(f), nil
+}
+
+// columnsFromJSON decodes an object of columns.
+func columnsFromJSON(raw json.RawMessage, o jsonOptions) (Dataset, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return Dataset{}, err
+	}
+
+	var d Dataset
+	for field, dst := range map[string]*string{
+		"name":        &d.Name,
+		"description": &d.Description,
+		"attribution": &d.Attribution,
+		"xLabel":      &d.XLabel,
+		"yLabel":      &d.YLabel,
+		"xUnits":      &d.XUnits,
+		"yUnits":      &d.YUnits,
+	} {
+		if v, ok := obj[field]; ok && field != o.xField && field != o.yField {
+			if err := json.Unmarshal(v, dst); err != nil {
+				return Dataset{}, errors.New("field " + strconv.Quote(field) + " must be a string")
+			}
+		}
+	}
+
+	var err error
+	if d.X, err = columnField(obj, o.xField); err != nil {
+		return Dataset{}, err
+	}
+	if d.Y, err = columnField(obj, o.yField); err != nil {
+		return Dataset{}, err
+	}
+	if len(d.X) != len(d.Y) {
+		return Dataset{}, errors.New("columns " + strconv.Quote(o.xField) + " and " + strconv.Quote(o.yField) +
+			" have different lengths")
+	}
+	if v, ok := obj["valid"]; ok && o.xField != "valid" && o.yField != "valid" {
+		if err := json.Unmarshal(v, &d.Valid); err != nil || len(d.Valid) != len(d.X) {
+			return Dataset{}, errors.New(`field "valid" must be an array of one boolean per pair`)
+		}
+	}
+
+	return d, nil
+}
+
+// columnField decodes the named column of obj.
+func columnField(obj map[string]json.RawMessage, field string) ([]float64, error) {
+	v, ok := obj[field]
+	if !ok {
+		return nil, errors.New("missing column " + strconv.Quote(field))
+	}
+	var col []jsonFloat
+	if err := json.Unmarshal(v, &col); err != nil {
+		return nil, errors.New("column " + strconv.Quote(field) + ": " + err.Error())
+	}
+
+	out := make([]float64, len(col))
+	for i, f := range col {
+		out[i] = float64(f)
+	}
+
+	return out, nil
+}
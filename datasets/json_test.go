@@ -0,0 +1,301 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestFromJSON(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name  string
+		input string
+		opts  []JSONOption
+		wantX []float64
+		wantY []float64
+	}{
+		{
+			name:  "records",
+			input: `[{"x": 1, "y": 2.5}, {"y": -3e2, "x": 2, "id": "b"}]`,
+			opts:  nil,
+			wantX: []float64{1, 2},
+			wantY: []float64{2.5, -300},
+		},
+		{
+			name:  "columns",
+			input: `{"x": [1, 2, 3], "y": [4, 5, 6], "units": "cm"}`,
+			opts:  nil,
+			wantX: []float64{1, 2, 3},
+			wantY: []float64{4, 5, 6},
+		},
+		{
+			name:  "records with mapped fields",
+			input: ` [{"height": 170, "weight": 65}]`,
+			opts:  []JSONOption{WithFields("height", "weight")},
+			wantX: []float64{170},
+			wantY: []float64{65},
+		},
+		{
+			name:  "columns with mapped fields",
+			input: `{"id": [9], "t": [1, 2], "v": [3, 4]}`,
+			opts:  []JSONOption{WithFields("t", "v")},
+			wantX: []float64{1, 2},
+			wantY: []float64{3, 4},
+		},
+		{
+			name:  "non-finite values",
+			input: `{"x": [null, "+Inf"], "y": ["-Inf", "NaN"]}`,
+			opts:  nil,
+			wantX: []float64{nan, math.Inf(1)},
+			wantY: []float64{math.Inf(-1), nan},
+		},
+		{
+			name:  "empty records",
+			input: `[]`,
+			opts:  nil,
+			wantX: []float64{},
+			wantY: []float64{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := FromJSON(strings.NewReader(test.input), test.opts...)
+			if err != nil {
+				t.Fatalf("FromJSON() unexpected error: %v", err)
+			}
+			if !equalNaN(d.X, test.wantX) || !equalNaN(d.Y, test.wantY) {
+				t.Errorf("FromJSON() = %v, %v, want %v, %v", d.X, d.Y, test.wantX, test.wantY)
+			}
+		})
+	}
+}
+
+// equalNaN reports whether a and b are equal, treating NaNs as equal.
+func equalNaN(a, b []float64) bool {
+	return slices.EqualFunc(a, b, func(u, v float64) bool {
+		return u == v || (math.IsNaN(u) && math.IsNaN(v))
+	})
+}
+
+func TestFromJSONMetadata(t *testing.T) {
+	input := `{"name": "lab", "description": "bench", "attribution": "me", "x": [1, 2], "y": [3, 5]}`
+	d, err := FromJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("FromJSON() unexpected error: %v", err)
+	}
+	if d.Name != "lab" || d.Description != "bench" || d.Attribution != "me" {
+		t.Errorf("FromJSON() metadata = %q, %q, %q, want %q, %q, %q",
+			d.Name, d.Description, d.Attribution, "lab", "bench", "me")
+	}
+	if err := d.Validate(); err != nil {
+		t.Errorf("Validate() of loaded dataset = %v", err)
+	}
+}
+
+func TestFromJSONLabels(t *testing.T) {
+	input := `{"xLabel": "dose", "yLabel": "response", "xUnits": "mg", "x": [1, 2], "y": [3, 5]}`
+	d, err := FromJSON(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("FromJSON() unexpected error: %v", err)
+	}
+	if d.XLabel != "dose" || d.YLabel != "response" || d.XUnits != "mg" || d.YUnits != "" {
+		t.Errorf("FromJSON() labels = %q, %q, %q, %q, want dose, response, mg and none",
+			d.XLabel, d.YLabel, d.XUnits, d.YUnits)
+	}
+
+	d, err = FromJSON(strings.NewReader(`[{"a": 1, "b": 2}]`), WithFields("a", "b"))
+	if err != nil {
+		t.Fatalf("FromJSON() unexpected error: %v", err)
+	}
+	if d.XLabel != "a" || d.YLabel != "b" {
+		t.Errorf("FromJSON() of records labels = %q, %q, want a, b", d.XLabel, d.YLabel)
+	}
+}
+
+func TestFromJSONErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		opts    []JSONOption
+		wantErr string
+	}{
+		{
+			name:    "empty input",
+			input:   "",
+			opts:    nil,
+			wantErr: "EOF",
+		},
+		{
+			name:    "malformed",
+			input:   `[{"x": 1,`,
+			opts:    nil,
+			wantErr: "unexpected EOF",
+		},
+		{
+			name:    "short valid mask",
+			input:   `{"x": [1, 2], "y": [3, 4], "valid": [true]}`,
+			opts:    nil,
+			wantErr: `field "valid" must be an array of one boolean per pair`,
+		},
+		{
+			name:    "neither layout",
+			input:   `42`,
+			opts:    nil,
+			wantErr: "array of records or an object of columns",
+		},
+		{
+			name:    "record missing field",
+			input:   `[{"x": 1, "y": 2}, {"x": 3}]`,
+			opts:    nil,
+			wantErr: `record 1: missing field "y"`,
+		},
+		{
+			name:    "record bad value",
+			input:   `[{"x": "one", "y": 2}]`,
+			opts:    nil,
+			wantErr: `record 0, field "x": "one" is not a number`,
+		},
+		{
+			name:    "missing column",
+			input:   `{"x": [1]}`,
+			opts:    nil,
+			wantErr: `missing column "y"`,
+		},
+		{
+			name:    "column not an array",
+			input:   `{"x": [1], "y": 2}`,
+			opts:    nil,
+			wantErr: `column "y"`,
+		},
+		{
+			name:    "column lengths differ",
+			input:   `{"x": [1, 2], "y": [3]}`,
+			opts:    nil,
+			wantErr: "different lengths",
+		},
+		{
+			name:    "name not a string",
+			input:   `{"name": 3, "x": [1], "y": [2]}`,
+			opts:    nil,
+			wantErr: `field "name" must be a string`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := FromJSON(strings.NewReader(test.input), test.opts...)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("FromJSON() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestDatasetMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		d    Dataset
+		want string
+	}{
+		{
+			name: "metadata and non-finite values",
+			d: Dataset{
+				Name:        "lab",
+				Description: "bench",
+				Attribution: "",
+				XLabel:      "",
+				YLabel:      "",
+				XUnits:      "",
+				YUnits:      "",
+				X:           []float64{1, 0.1, math.NaN()},
+				Y:           []float64{-2e-9, math.Inf(1), math.Inf(-1)},
+				Valid:       nil,
+			},
+			want: `{"name":"lab","description":"bench","x":[1,0.1,null],"y":[-2e-09,"+Inf","-Inf"]}`,
+		},
+		{
+			name: "valid mask",
+			d: Dataset{
+				Name:        "",
+				Description: "",
+				Attribution: "",
+				XLabel:      "",
+				YLabel:      "",
+				XUnits:      "",
+				YUnits:      "",
+				X:           []float64{1, math.NaN()},
+				Y:           []float64{2, 3},
+				Valid:       []bool{true, false},
+			},
+			want: `{"x":[1,null],"y":[2,3],"valid":[true,false]}`,
+		},
+		{
+			name: "labels and units",
+			d: Dataset{
+				Name:        "",
+				Description: "",
+				Attribution: "",
+				XLabel:      "dose",
+				YLabel:      "response",
+				XUnits:      "mg",
+				YUnits:      "",
+				X:           []float64{1},
+				Y:           []float64{2},
+				Valid:       nil,
+			},
+			want: `{"xLabel":"dose","yLabel":"response","xUnits":"mg","x":[1],"y":[2]}`,
+		},
+		{
+			name: "nil series",
+			d:    Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: nil, Y: nil, Valid: nil},
+			want: `{"x":[],"y":[]}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(test.d)
+			if err != nil {
+				t.Fatalf("json.Marshal() unexpected error: %v", err)
+			}
+			if string(data) != test.want {
+				t.Errorf("json.Marshal() = %s, want %s", data, test.want)
+			}
+
+			got, err := FromJSON(strings.NewReader(string(data)))
+			if err != nil {
+				t.Fatalf("FromJSON() of marshaled dataset unexpected error: %v", err)
+			}
+			if got.Name != test.d.Name || got.Description != test.d.Description ||
+				!equalNaN(got.X, test.d.X) || !equalNaN(got.Y, test.d.Y) || !slices.Equal(got.Valid, test.d.Valid) {
+				t.Errorf("FromJSON() of marshaled dataset = %+v, want %+v", got, test.d)
+			}
+		})
+	}
+}
+
+func TestDatasetMarshalJSONErrors(t *testing.T) {
+	d := Dataset{Name: "bad", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{1}, Y: nil, Valid: nil}
+	if _, err := json.Marshal(d); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("json.Marshal() error = %v, want %v", err, ErrLengthMismatch)
+	}
+}
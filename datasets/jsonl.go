@@ -0,0 +1,172 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"strconv"
+)
+
+// jsonLinesBatch is the number of pairs FromJSONLines reads at a time.
+const jsonLinesBatch = 4096
+
+// FromJSONLines reads a Dataset from JSON Lines, also known as
+// newline-delimited JSON, in which each line holds one record:
+//
+//	{"ts": "2025-01-01T00:00:00Z", "x": 1, "y": 2.5}
+//	{"ts": "2025-01-01T00:01:00Z", "x": 2, "y": 3.1}
+//
+// Records are read as by FromJSON, from the fields "x" and "y" unless set
+// with WithFields, and other fields are ignored. XLabel and YLabel are set
+// to the names of the value fields. Blank lines are skipped.
+// Input compressed with gzip or zstd is decompressed as it is read.
+//
+// To compute over a large file without holding it in memory, read it
+// through JSONLinesSource instead.
+//
+// An error is returned if a line is not a JSON object, or a value field is
+// missing or not a number. The error gives the line of the offending
+// record.
+func FromJSONLines(r io.Reader, opts ...JSONOption) (Dataset, error) {
+	o := jsonOptions{xField: "x", yField: "y"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	d := Dataset{Name: "", Description: "", Attribution: "", XLabel: o.xField, YLabel: o.yField, XUnits: "", YUnits: "", X: []float64{}, Y: []float64{}, Valid: nil}
+	err := Each(JSONLinesSource(r, opts...), jsonLinesBatch, func(x, y []float64) error {
+		d.X = append(d.X, x...)
+		d.Y = append(d.Y, y...)
+
+		return nil
+	})
+	if err != nil {
+		return Dataset{}, err
+	}
+
+	return d, nil
+}
+
+// JSONLinesSource returns a DataSource that yields the records of JSON
+// Lines in r as they are read, so that a streaming computation can consume
+// a file of any size in constant memory:
+//
+//	r, err := correlation.CorrelateSource(datasets.JSONLinesSource(f), correlation.Pearson)
+//
+// or, to keep a running coefficient:
+//
+//	var o correlation.OnlineCorrelator
+//	err := datasets.Each(datasets.JSONLinesSource(f), 1024, func(x, y []float64) error {
+//		for i := range x {
+//			if err := o.Add(x[i], y[i]); err != nil {
+//				return err
+//			}
+//		}
+//		return nil
+//	})
+//
+// The lines are read as by FromJSONLines. Next returns the pairs before a
+// line that cannot be read together with its error, which is then
+// returned by every later call.
+func JSONLinesSource(r io.Reader, opts ...JSONOption) DataSource {
+	o := jsonOptions{xField: "x", yField: "y"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &jsonLinesSource{src: r, br: nil, o: o, line: 0, x: nil, y: nil, err: nil}
+}
+
+// jsonLinesSource is a DataSource over JSON Lines.
+type jsonLinesSource struct {
+	src io.Reader
+	// br reads the decompressed lines, once the first batch is requested.
+	br   *bufio.Reader
+	o    jsonOptions
+	line int
+	x    []float64
+	y    []float64
+	err  error
+}
+
+// Next implements DataSource.
+func (s *jsonLinesSource) Next(batch int) ([]float64, []float64, error) {
+	if batch <= 0 {
+		return nil, nil, errors.New("batch size must be positive")
+	}
+	if s.err != nil {
+		return nil, nil, s.err
+	}
+	if s.br == nil {
+		r, err := decompress(s.src)
+		if err != nil {
+			s.err = err
+
+			return nil, nil, err
+		}
+		s.br = bufio.NewReader(r)
+	}
+
+	s.x, s.y = s.x[:0], s.y[:0]
+	for len(s.x) < batch {
+		line, err := s.br.ReadBytes('\n')
+		if len(line) > 0 {
+			s.line++
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			x, y, perr := s.record(line)
+			if perr != nil {
+				s.err = perr
+
+				return s.x, s.y, perr
+			}
+			s.x = append(s.x, x)
+			s.y = append(s.y, y)
+		}
+		if err != nil {
+			s.err = err
+
+			return s.x, s.y, err
+		}
+	}
+
+	return s.x, s.y, nil
+}
+
+// record decodes the value fields of the record on the current line.
+func (s *jsonLinesSource) record(line []byte) (float64, float64, error) {
+	where := "line " + strconv.Itoa(s.line)
+	var rec map[string]json.RawMessage
+	if err := json.Unmarshal(line, &rec); err != nil {
+		return 0, 0, errors.New(where + ": " + err.Error())
+	}
+	if rec == nil {
+		return 0, 0, errors.New(where + ": record must be a JSON object")
+	}
+	x, err := recordField(rec, s.o.xField, where)
+	if err != nil {
+		return 0, 0, err
+	}
+	y, err := recordField(rec, s.o.yField, where)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return x, y, nil
+}
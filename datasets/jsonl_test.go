@@ -0,0 +1,182 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestFromJSONLines(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name  string
+		input string
+		opts  []JSONOption
+		wantX []float64
+		wantY []float64
+	}{
+		{
+			name:  "records",
+			input: "{\"x\": 1, \"y\": 2.5}\n{\"x\": 2, \"y\": 3.1}\n",
+			wantX: []float64{1, 2},
+			wantY: []float64{2.5, 3.1},
+		},
+		{
+			name:  "blank lines, CRLF and no final newline",
+			input: "\n{\"x\": 1, \"y\": 2}\r\n\r\n  \n{\"y\": 4, \"x\": 3}",
+			wantX: []float64{1, 3},
+			wantY: []float64{2, 4},
+		},
+		{
+			name:  "other fields ignored",
+			input: `{"ts": "2025-01-01", "x": 1, "y": 2, "tags": {"a": [1, 2]}}`,
+			wantX: []float64{1},
+			wantY: []float64{2},
+		},
+		{
+			name:  "missing values and infinities",
+			input: "{\"x\": null, \"y\": \"NaN\"}\n{\"x\": \"+Inf\", \"y\": \"-Inf\"}\n",
+			wantX: []float64{nan, math.Inf(1)},
+			wantY: []float64{nan, math.Inf(-1)},
+		},
+		{
+			name:  "named fields",
+			input: "{\"height\": 170, \"weight\": 65}\n{\"height\": 182, \"weight\": 80}\n",
+			opts:  []JSONOption{WithFields("height", "weight")},
+			wantX: []float64{170, 182},
+			wantY: []float64{65, 80},
+		},
+		{
+			name:  "empty",
+			input: "",
+			wantX: []float64{},
+			wantY: []float64{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := FromJSONLines(strings.NewReader(test.input), test.opts...)
+			if err != nil {
+				t.Fatalf("FromJSONLines() unexpected error: %v", err)
+			}
+			if !equalNaN(got.X, test.wantX) || !equalNaN(got.Y, test.wantY) {
+				t.Errorf("FromJSONLines() = (%v, %v), want (%v, %v)", got.X, got.Y, test.wantX, test.wantY)
+			}
+			if got.X == nil || got.Y == nil {
+				t.Errorf("FromJSONLines() returned nil series")
+			}
+		})
+	}
+}
+
+func TestFromJSONLinesCompressed(t *testing.T) {
+	var input bytes.Buffer
+	for i := range 10000 {
+		input.WriteString(`{"x": ` + strconv.Itoa(i) + `, "y": ` + strconv.Itoa(2*i) + "}\n")
+	}
+	got, err := FromJSONLines(bytes.NewReader(gzipBytes(t, input.Bytes())))
+	if err != nil {
+		t.Fatalf("FromJSONLines() unexpected error: %v", err)
+	}
+	if len(got.X) != 10000 || got.X[9999] != 9999 || got.Y[9999] != 19998 {
+		t.Errorf("FromJSONLines() read %d records ending (%v, %v), want 10000 ending (9999, 19998)",
+			len(got.X), got.X[len(got.X)-1], got.Y[len(got.Y)-1])
+	}
+}
+
+func TestFromJSONLinesErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "malformed line",
+			input:   "{\"x\": 1, \"y\": 2}\n{\"x\": 1,\n",
+			wantErr: "line 2: ",
+		},
+		{
+			name:    "array line",
+			input:   "[1, 2]\n",
+			wantErr: "line 1: ",
+		},
+		{
+			name:    "null line",
+			input:   "\n\nnull\n",
+			wantErr: "line 3: record must be a JSON object",
+		},
+		{
+			name:    "missing field",
+			input:   "{\"x\": 1, \"y\": 2}\n\n{\"x\": 3}\n",
+			wantErr: `line 3: missing field "y"`,
+		},
+		{
+			name:    "not a number",
+			input:   `{"x": 1, "y": "two"}`,
+			wantErr: `line 1, field "y": `,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := FromJSONLines(strings.NewReader(test.input))
+			if err == nil {
+				t.Fatalf("FromJSONLines() expected error containing %q but got none", test.wantErr)
+			}
+			if !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("FromJSONLines() error = %q, want it to contain %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestJSONLinesSource(t *testing.T) {
+	input := "{\"x\": 1, \"y\": 1}\n{\"x\": 2, \"y\": 4}\n{\"x\": 3, \"y\": 9}\n{\"x\": 4}\n{\"x\": 5, \"y\": 25}\n"
+	src := JSONLinesSource(strings.NewReader(input))
+
+	if _, _, err := src.Next(0); err == nil {
+		t.Errorf("Next(0) expected error but got none")
+	}
+	x, y, err := src.Next(2)
+	if err != nil || len(x) != 2 || x[1] != 2 || y[1] != 4 {
+		t.Fatalf("Next(2) = (%v, %v, %v), want ([1 2], [1 4], nil)", x, y, err)
+	}
+	// The batch stops at the bad line, returning the pairs before it.
+	x, y, err = src.Next(2)
+	if err == nil || len(x) != 1 || x[0] != 3 || y[0] != 9 {
+		t.Fatalf("Next(2) = (%v, %v, %v), want ([3], [9], error)", x, y, err)
+	}
+	if _, _, again := src.Next(2); !errors.Is(again, err) {
+		t.Errorf("Next(2) after an error = %v, want %v", again, err)
+	}
+}
+
+func TestJSONLinesSourceEOF(t *testing.T) {
+	src := JSONLinesSource(strings.NewReader("{\"x\": 1, \"y\": 2}\n{\"x\": 3, \"y\": 4}\n"))
+	x, _, err := src.Next(5)
+	if !errors.Is(err, io.EOF) || len(x) != 2 {
+		t.Fatalf("Next(5) = (%v, %v), want 2 pairs and io.EOF", x, err)
+	}
+	if x, _, err = src.Next(5); !errors.Is(err, io.EOF) || len(x) != 0 {
+		t.Errorf("Next(5) at the end = (%v, %v), want no pairs and io.EOF", x, err)
+	}
+}
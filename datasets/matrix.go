@@ -0,0 +1,125 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"strconv"
+)
+
+// Matrix is the read access to a matrix that FromMatrix and
+// FrameFromMatrix need. It is satisfied by every mat.Matrix from
+// gonum.org/v1/gonum/mat, such as *mat.Dense, without this package
+// depending on gonum.
+type Matrix interface {
+	// Dims returns the numbers of rows and columns.
+	Dims() (int, int)
+	// At returns the element at row i and column j.
+	At(i, j int) float64
+}
+
+// FromMatrix returns a Dataset whose X and Y hold the columns x and y of
+// m, with one pair per row, as when the observations of a gonum matrix are
+// in its rows:
+//
+//	d, err := datasets.FromMatrix(dense, 0, 1)
+//
+// The values are copied.
+//
+// An error is returned if either column is out of range.
+func FromMatrix(m Matrix, x, y int) (Dataset, error) {
+	rows, cols := m.Dims()
+	for _, c := range []int{x, y} {
+		if c < 0 || c >= cols {
+			return Dataset{}, errors.New("matrix column " + strconv.Itoa(c) + " is out of range")
+		}
+	}
+
+	d := Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: make([]float64, rows), Y: make([]float64, rows), Valid: nil}
+	for i := range rows {
+		d.X[i] = m.At(i, x)
+		d.Y[i] = m.At(i, y)
+	}
+
+	return d, nil
+}
+
+// FrameFromMatrix returns a Frame holding the columns of m, named by names.
+// The values are copied.
+//
+// An error is returned if there is not one name per column, or a name is
+// empty or repeated.
+func FrameFromMatrix(m Matrix, names []string) (*Frame, error) {
+	rows, cols := m.Dims()
+	if len(names) != cols {
+		return nil, errors.New("frame needs one name per column")
+	}
+	data := make([][]float64, cols)
+	for j := range cols {
+		data[j] = make([]float64, rows)
+		for i := range rows {
+			data[j][i] = m.At(i, j)
+		}
+	}
+
+	return NewFrame(names, data)
+}
+
+// Dense returns the dataset as a matrix of two columns, X and Y, with one
+// row per pair: its numbers of rows and columns and its elements in row
+// major order. These are the arguments of gonum's mat.NewDense, so that
+//
+//	rows, cols, data, err := d.Dense()
+//	if err != nil {
+//		return err
+//	}
+//	m := mat.NewDense(rows, cols, data)
+//
+// makes a gonum matrix of the dataset, provided it is not empty, which
+// mat.NewDense does not allow. The elements are copied.
+//
+// An error wrapping ErrLengthMismatch is returned if X and Y differ in
+// length.
+func (d Dataset) Dense() (int, int, []float64, error) {
+	if len(d.X) != len(d.Y) {
+		return 0, 0, nil, &ValidationError{Dataset: d.Name, Series: "", Index: -1, Err: ErrLengthMismatch}
+	}
+	rows := len(d.X)
+	data := make([]float64, 2*rows)
+	for i := range rows {
+		data[2*i] = d.X[i]
+		data[2*i+1] = d.Y[i]
+	}
+
+	return rows, 2, data, nil
+}
+
+// Dense returns the frame as a matrix with a column per column of the
+// frame, in the form taken by gonum's mat.NewDense, as Dataset.Dense does.
+// The columns of a frame always have the same length, so there is no error
+// to report:
+//
+//	m := mat.NewDense(f.Dense())
+func (f *Frame) Dense() (int, int, []float64) {
+	rows, cols := f.NumRows(), f.NumColumns()
+	data := make([]float64, rows*cols)
+	for j, col := range f.cols {
+		for i, v := range col {
+			data[i*cols+j] = v
+		}
+	}
+
+	return rows, cols, data
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeDense is a row major Matrix standing in for a gonum *mat.Dense.
+type fakeDense struct {
+	rows, cols int
+	data       []float64
+}
+
+func (m fakeDense) Dims() (int, int)    { return m.rows, m.cols }
+func (m fakeDense) At(i, j int) float64 { return m.data[i*m.cols+j] }
+
+func TestFromMatrix(t *testing.T) {
+	m := fakeDense{rows: 3, cols: 3, data: []float64{
+		1, 10, 100,
+		2, 20, 200,
+		3, 30, 300,
+	}}
+	d, err := FromMatrix(m, 2, 0)
+	if err != nil {
+		t.Fatalf("FromMatrix() unexpected error: %v", err)
+	}
+	if !slices.Equal(d.X, []float64{100, 200, 300}) || !slices.Equal(d.Y, []float64{1, 2, 3}) {
+		t.Errorf("FromMatrix(m, 2, 0) = %v, %v, want columns 2 and 0", d.X, d.Y)
+	}
+	for _, c := range []int{-1, 3} {
+		if _, err := FromMatrix(m, c, 0); err == nil || !strings.Contains(err.Error(), "out of range") {
+			t.Errorf("FromMatrix() of column %d error = %v, want one containing %q", c, err, "out of range")
+		}
+	}
+
+	f, err := FrameFromMatrix(m, []string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("FrameFromMatrix() unexpected error: %v", err)
+	}
+	if col, _ := f.Column("b"); !slices.Equal(col, []float64{10, 20, 30}) {
+		t.Errorf("FrameFromMatrix() column b = %v, want [10 20 30]", col)
+	}
+	if _, err := FrameFromMatrix(m, []string{"a", "b"}); err == nil {
+		t.Errorf("FrameFromMatrix() with too few names expected error but got none")
+	}
+}
+
+func TestDense(t *testing.T) {
+	d := Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{1, 2}, Y: []float64{4, 5}, Valid: nil}
+	rows, cols, data, err := d.Dense()
+	if err != nil || rows != 2 || cols != 2 || !slices.Equal(data, []float64{1, 4, 2, 5}) {
+		t.Errorf("Dense() = %d, %d, %v, %v, want 2, 2, [1 4 2 5]", rows, cols, data, err)
+	}
+	d.X = append(d.X, 3)
+	if _, _, _, err := d.Dense(); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("Dense() of ragged series error = %v, want %v", err, ErrLengthMismatch)
+	}
+
+	f := testFrame(t)
+	rows, cols, data = f.Dense()
+	back, err := FrameFromMatrix(fakeDense{rows: rows, cols: cols, data: data}, f.Names())
+	if err != nil {
+		t.Fatalf("FrameFromMatrix() unexpected error: %v", err)
+	}
+	for i, col := range f.Columns() {
+		if !slices.Equal(back.Columns()[i], col) {
+			t.Errorf("round trip of column %d = %v, want %v", i, back.Columns()[i], col)
+		}
+	}
+}
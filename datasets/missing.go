@@ -0,0 +1,66 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import "math"
+
+// IsMissing reports whether pair i is missing, either because Valid marks
+// it so or because its X or Y value is NaN.
+func (d Dataset) IsMissing(i int) bool {
+	if i < len(d.Valid) && !d.Valid[i] {
+		return true
+	}
+
+	return math.IsNaN(d.X[i]) || math.IsNaN(d.Y[i])
+}
+
+// CountMissing returns the number of missing pairs, as reported by
+// IsMissing.
+func (d Dataset) CountMissing() int {
+	n := 0
+	for i := range min(len(d.X), len(d.Y)) {
+		if d.IsMissing(i) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// DropMissing returns a copy of the dataset without its missing pairs, as
+// reported by IsMissing, and with a nil Valid.
+func (d Dataset) DropMissing() Dataset {
+	n := min(len(d.X), len(d.Y))
+	out := Dataset{
+		Name:        d.Name,
+		Description: d.Description,
+		Attribution: d.Attribution,
+		XLabel:      d.XLabel,
+		YLabel:      d.YLabel,
+		XUnits:      d.XUnits,
+		YUnits:      d.YUnits,
+		X:           make([]float64, 0, n),
+		Y:           make([]float64, 0, n),
+		Valid:       nil,
+	}
+	for i := range n {
+		if !d.IsMissing(i) {
+			out.X = append(out.X, d.X[i])
+			out.Y = append(out.Y, d.Y[i])
+		}
+	}
+
+	return out
+}
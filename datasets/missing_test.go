@@ -0,0 +1,98 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDatasetMissing(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name      string
+		d         Dataset
+		wantCount int
+		wantX     []float64
+		wantY     []float64
+	}{
+		{
+			name:      "none missing",
+			d:         Dataset{Name: "a", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{1, 2}, Y: []float64{3, 4}, Valid: nil},
+			wantCount: 0,
+			wantX:     []float64{1, 2},
+			wantY:     []float64{3, 4},
+		},
+		{
+			name:      "NaN values",
+			d:         Dataset{Name: "b", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{1, nan, 3}, Y: []float64{4, 5, nan}, Valid: nil},
+			wantCount: 2,
+			wantX:     []float64{1},
+			wantY:     []float64{4},
+		},
+		{
+			name: "masked pairs",
+			d: Dataset{
+				Name:        "c",
+				Description: "",
+				Attribution: "",
+				XLabel:      "",
+				YLabel:      "",
+				XUnits:      "",
+				YUnits:      "",
+				X:           []float64{1, 2, 3, nan},
+				Y:           []float64{4, 5, 6, 7},
+				Valid:       []bool{true, false, true, true},
+			},
+			wantCount: 2,
+			wantX:     []float64{1, 3},
+			wantY:     []float64{4, 6},
+		},
+		{
+			name:      "empty",
+			d:         Dataset{Name: "d", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: nil, Y: nil, Valid: nil},
+			wantCount: 0,
+			wantX:     []float64{},
+			wantY:     []float64{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.d.CountMissing(); got != test.wantCount {
+				t.Errorf("CountMissing() = %d, want %d", got, test.wantCount)
+			}
+			got := test.d.DropMissing()
+			if !equalNaN(got.X, test.wantX) || !equalNaN(got.Y, test.wantY) || got.Valid != nil || got.Name != test.d.Name {
+				t.Errorf("DropMissing() = %+v, want X %v, Y %v and a nil Valid", got, test.wantX, test.wantY)
+			}
+			if err := got.Validate(); len(got.X) >= MinPairs && err != nil {
+				t.Errorf("Validate() after DropMissing() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestDatasetDropMissingCopies(t *testing.T) {
+	d := Dataset{Name: "", Description: "", Attribution: "", XLabel: "dose", YLabel: "", XUnits: "mg", YUnits: "", X: []float64{1, 2}, Y: []float64{3, 4}, Valid: nil}
+	got := d.DropMissing()
+	got.X[0] = -1
+	if d.X[0] != 1 {
+		t.Errorf("modifying the result of DropMissing() changed the original")
+	}
+	if got.XLabel != "dose" || got.XUnits != "mg" {
+		t.Errorf("DropMissing() labels = %q, %q, want dose, mg", got.XLabel, got.XUnits)
+	}
+}
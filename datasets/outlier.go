@@ -0,0 +1,143 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"math"
+
+	"github.com/rsned/stats/descriptive"
+)
+
+// OutlierMethod determines how RemoveOutliers decides that a value is an
+// outlier of its series.
+type OutlierMethod int
+
+const (
+	// OutlierIQR flags values outside Tukey's fences, more than 1.5 times
+	// the interquartile range below the first quartile or above the third.
+	OutlierIQR OutlierMethod = iota
+	// OutlierZScore flags values more than 3 sample standard deviations
+	// from the mean. The outliers themselves inflate the standard
+	// deviation, and in samples of fewer than 11 no value can be flagged,
+	// so this suits large, roughly normal samples.
+	OutlierZScore
+	// OutlierMAD flags values whose modified z-score, their distance from
+	// the median in units of the median absolute deviation scaled by
+	// descriptive.NormalConsistency, about 1.4826, is more than 3.5
+	// (Iglewicz and Hoaglin, 1993). It is robust to the outliers it looks
+	// for. If more than half the values are equal, every other value is
+	// flagged.
+	OutlierMAD
+)
+
+// String returns the string representation of the OutlierMethod.
+func (m OutlierMethod) String() string {
+	switch m {
+	case OutlierIQR:
+		return "IQR"
+	case OutlierZScore:
+		return "ZScore"
+	case OutlierMAD:
+		return "MAD"
+	default:
+		return "Unknown"
+	}
+}
+
+// RemoveOutliers returns a copy of the dataset without the pairs in which
+// X or Y is an outlier of its series by method, and a dataset of the pairs
+// that were removed, both in their original order:
+//
+//	clean, removed, err := datasets.AnscombeIII.RemoveOutliers(datasets.OutlierMAD)
+//
+// Each series is judged on its own, ignoring missing pairs as reported by
+// IsMissing, which are never removed. Both results have the metadata of the dataset, and
+// the parts of Valid for their pairs.
+//
+// An error is returned if the method is unknown or X and Y differ in
+// length.
+func (d Dataset) RemoveOutliers(method OutlierMethod) (Dataset, Dataset, error) {
+	if len(d.X) != len(d.Y) {
+		return Dataset{}, Dataset{}, errors.New("X and Y must have the same length")
+	}
+	var xs, ys []float64
+	for i := range d.X {
+		if !d.IsMissing(i) {
+			xs = append(xs, d.X[i])
+			ys = append(ys, d.Y[i])
+		}
+	}
+	loX, hiX, err := outlierBounds(xs, method)
+	if err != nil {
+		return Dataset{}, Dataset{}, err
+	}
+	loY, hiY, err := outlierBounds(ys, method)
+	if err != nil {
+		return Dataset{}, Dataset{}, err
+	}
+
+	var keep, drop []int
+	for i, x := range d.X {
+		y := d.Y[i]
+		if !d.IsMissing(i) && (x < loX || x > hiX || y < loY || y > hiY) {
+			drop = append(drop, i)
+		} else {
+			keep = append(keep, i)
+		}
+	}
+
+	return d.subset(keep), d.subset(drop), nil
+}
+
+// outlierBounds returns the smallest and largest values of data that are
+// not outliers by method. The bounds are NaN, so that nothing is an
+// outlier, if data has too few values to judge.
+func outlierBounds(data []float64, method OutlierMethod) (float64, float64, error) {
+	switch method {
+	case OutlierIQR:
+		if len(data) == 0 {
+			return math.NaN(), math.NaN(), nil
+		}
+		q, err := descriptive.Percentiles(data, []float64{25, 75})
+		if err != nil {
+			return 0, 0, err
+		}
+		q1, q3 := q[0], q[1]
+
+		return q1 - 1.5*(q3-q1), q3 + 1.5*(q3-q1), nil
+	case OutlierZScore:
+		m, sd := mean(data), math.Sqrt(variance(data))
+
+		return m - 3*sd, m + 3*sd, nil
+	case OutlierMAD:
+		if len(data) == 0 {
+			return math.NaN(), math.NaN(), nil
+		}
+		med, err := descriptive.Median(data)
+		if err != nil {
+			return 0, 0, err
+		}
+		mad, err := descriptive.MAD(data, descriptive.WithConsistency(descriptive.NormalConsistency))
+		if err != nil {
+			return 0, 0, err
+		}
+		spread := 3.5 * mad
+
+		return med - spread, med + spread, nil
+	default:
+		return 0, 0, errors.New("unsupported outlier method")
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDatasetRemoveOutliers(t *testing.T) {
+	// One wild value in 20, with a missing pair that is never removed.
+	x := make([]float64, 20)
+	y := make([]float64, 20)
+	for i := range 20 {
+		x[i] = float64(i)
+		y[i] = float64(i%5) + 10
+	}
+	y[7] = 100
+	x[12] = math.NaN()
+	wide := Dataset{Name: "wide", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: x, Y: y, Valid: nil}
+
+	tests := []struct {
+		name        string
+		d           Dataset
+		method      OutlierMethod
+		wantRemoved []float64
+	}{
+		{name: "Anscombe III IQR", d: AnscombeIII, method: OutlierIQR, wantRemoved: []float64{13}},
+		{name: "Anscombe III MAD", d: AnscombeIII, method: OutlierMAD, wantRemoved: []float64{13}},
+		{name: "Anscombe III z-score", d: AnscombeIII, method: OutlierZScore, wantRemoved: nil},
+		{name: "Anscombe IV IQR", d: AnscombeIV, method: OutlierIQR, wantRemoved: []float64{19}},
+		{name: "Anscombe IV MAD", d: AnscombeIV, method: OutlierMAD, wantRemoved: []float64{19}},
+		{name: "z-score", d: wide, method: OutlierZScore, wantRemoved: []float64{7}},
+		{name: "IQR with missing", d: wide, method: OutlierIQR, wantRemoved: []float64{7}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			clean, removed, err := test.d.RemoveOutliers(test.method)
+			if err != nil {
+				t.Fatalf("RemoveOutliers(%v) unexpected error: %v", test.method, err)
+			}
+			if !slices.Equal(removed.X, test.wantRemoved) {
+				t.Errorf("RemoveOutliers(%v) removed X = %v, want %v", test.method, removed.X, test.wantRemoved)
+			}
+			if len(clean.X)+len(removed.X) != len(test.d.X) || len(clean.Y) != len(clean.X) {
+				t.Errorf("RemoveOutliers(%v) kept %d and removed %d of %d pairs",
+					test.method, len(clean.X), len(removed.X), len(test.d.X))
+			}
+			if clean.Name != test.d.Name || removed.Name != test.d.Name {
+				t.Errorf("RemoveOutliers(%v) names = %q, %q, want %q", test.method, clean.Name, removed.Name, test.d.Name)
+			}
+		})
+	}
+}
+
+func TestDatasetRemoveOutliersValid(t *testing.T) {
+	d := Dataset{
+		Name:        "",
+		Description: "",
+		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           []float64{1, 2, 3, 4, 5, 6, 7, 8, 1000},
+		Y:           []float64{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		Valid:       []bool{true, false, true, true, true, true, true, true, true},
+	}
+	clean, removed, err := d.RemoveOutliers(OutlierMAD)
+	if err != nil {
+		t.Fatalf("RemoveOutliers() unexpected error: %v", err)
+	}
+	if !slices.Equal(removed.X, []float64{1000}) || !slices.Equal(removed.Valid, []bool{true}) {
+		t.Errorf("RemoveOutliers() removed %v with Valid %v, want [1000] with [true]", removed.X, removed.Valid)
+	}
+	if len(clean.Valid) != 8 || clean.Valid[1] {
+		t.Errorf("RemoveOutliers() clean Valid = %v, want the mask of the kept pairs", clean.Valid)
+	}
+}
+
+func TestDatasetRemoveOutliersErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       Dataset
+		method  OutlierMethod
+		wantErr string
+	}{
+		{name: "unknown method", d: AnscombeI, method: OutlierMethod(99), wantErr: "unsupported outlier method"},
+		{
+			name:    "length mismatch",
+			d:       Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{1, 2}, Y: []float64{1}, Valid: nil},
+			method:  OutlierIQR,
+			wantErr: "same length",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := test.d.RemoveOutliers(test.method)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("RemoveOutliers() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutlierMethodString(t *testing.T) {
+	for m, want := range map[OutlierMethod]string{OutlierIQR: "IQR", OutlierZScore: "ZScore", OutlierMAD: "MAD", OutlierMethod(99): "Unknown"} {
+		if got := m.String(); got != want {
+			t.Errorf("OutlierMethod(%d).String() = %q, want %q", int(m), got, want)
+		}
+	}
+}
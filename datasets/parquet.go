@@ -0,0 +1,811 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+	"math/bits"
+	"strconv"
+)
+
+// parquetMagic starts and ends every Parquet file.
+const parquetMagic = "PAR1"
+
+// The Parquet physical types that FromParquet reads.
+const (
+	parquetInt32  = 1
+	parquetInt64  = 2
+	parquetFloat  = 4
+	parquetDouble = 5
+)
+
+// parquetRepeated is the repetition type of a repeated field.
+const parquetRepeated = 2
+
+// parquetDecimal is the converted type of a decimal stored as an integer.
+const parquetDecimal = 5
+
+// The Parquet compression codecs that FromParquet reads.
+const (
+	parquetUncompressed = 0
+	parquetSnappy       = 1
+	parquetGzip         = 2
+	parquetZstd         = 6
+)
+
+// The Parquet page types.
+const (
+	parquetDataPage       = 0
+	parquetDictionaryPage = 2
+	parquetDataPageV2     = 3
+)
+
+// The Parquet value encodings that FromParquet reads.
+const (
+	parquetPlain           = 0
+	parquetPlainDictionary = 2
+	parquetRLEDictionary   = 8
+	parquetByteStreamSplit = 9
+)
+
+// parquetSchemaElement is a node of the schema of a Parquet file, which is
+// stored as a depth first list of nodes.
+type parquetSchemaElement struct {
+	// typ is the physical type, or -1 for a group.
+	typ           int32
+	repetition    int32
+	name          string
+	numChildren   int32
+	convertedType int32
+	scale         int32
+}
+
+// parquetColumn is a leaf column of the schema.
+type parquetColumn struct {
+	path          string
+	typ           int32
+	maxDefinition int
+	repeated      bool
+	// scale is the number of decimal places of a decimal column.
+	scale int32
+}
+
+// parquetChunk is the metadata of the part of a column in one row group.
+type parquetChunk struct {
+	external       bool
+	codec          int32
+	dataOffset     int64
+	dictOffset     int64
+	compressedSize int64
+}
+
+// parquetRowGroup is the metadata of a row group.
+type parquetRowGroup struct {
+	chunks  []parquetChunk
+	numRows int64
+}
+
+// parquetMetadata is the part of the footer of a Parquet file that
+// FromParquet uses.
+type parquetMetadata struct {
+	schema    []parquetSchemaElement
+	rowGroups []parquetRowGroup
+}
+
+// FromParquet reads a Dataset from an Apache Parquet file of the given
+// size, taking X from column x and Y from column y:
+//
+//	f, err := os.Open("measurements.parquet")
+//	...
+//	fi, err := f.Stat()
+//	...
+//	d, err := datasets.FromParquet(f, fi.Size(), datasets.ColumnName("height"), datasets.ColumnName("weight"))
+//
+// Columns are selected by name, with the names of nested columns joined by
+// dots as in "point.x", or by their position among the leaf columns of the
+// schema. XLabel and YLabel are set to the names of the columns. Only
+// non-repeated columns of the INT32, INT64, FLOAT and DOUBLE
+// physical types can be read; decimals stored as integers are scaled, and
+// null values are read as NaN.
+//
+// The reader supports pages that are uncompressed or compressed with
+// snappy, gzip or zstd, in versions 1 and 2 of the data page format, with
+// the PLAIN, dictionary and BYTE_STREAM_SPLIT encodings. These are what
+// Spark, pandas and pyarrow write by default. A whole file compressed with
+// gzip or zstd is decompressed into memory first.
+//
+// An error is returned if r is not a Parquet file, a selected column does
+// not exist or cannot be read, or the file uses a compression codec or
+// encoding that is not supported.
+func FromParquet(r io.ReaderAt, size int64, x, y Column) (Dataset, error) {
+	r, size, err := decompressAt(r, size)
+	if err != nil {
+		return Dataset{}, err
+	}
+	meta, err := readParquetMetadata(r, size)
+	if err != nil {
+		return Dataset{}, err
+	}
+	columns, err := parquetColumns(meta.schema)
+	if err != nil {
+		return Dataset{}, err
+	}
+	xi, err := resolveParquetColumn(columns, x)
+	if err != nil {
+		return Dataset{}, err
+	}
+	yi, err := resolveParquetColumn(columns, y)
+	if err != nil {
+		return Dataset{}, err
+	}
+
+	d := Dataset{Name: "", Description: "", Attribution: "", XLabel: columns[xi].path, YLabel: columns[yi].path, XUnits: "", YUnits: "", X: []float64{}, Y: []float64{}, Valid: nil}
+	for _, rg := range meta.rowGroups {
+		if len(rg.chunks) != len(columns) {
+			return Dataset{}, errors.New("parquet row group does not match the schema")
+		}
+		xs, err := readParquetChunk(r, size, rg.chunks[xi], columns[xi], rg.numRows)
+		if err != nil {
+			return Dataset{}, err
+		}
+		ys, err := readParquetChunk(r, size, rg.chunks[yi], columns[yi], rg.numRows)
+		if err != nil {
+			return Dataset{}, err
+		}
+		d.X = append(d.X, xs...)
+		d.Y = append(d.Y, ys...)
+	}
+
+	return d, nil
+}
+
+// readParquetMetadata reads and decodes the footer of a Parquet file.
+func readParquetMetadata(r io.ReaderAt, size int64) (parquetMetadata, error) {
+	errNotParquet := errors.New("not a parquet file")
+	if size < 2*int64(len(parquetMagic))+4 {
+		return parquetMetadata{}, errNotParquet
+	}
+	var head, tail [8]byte
+	if _, err := r.ReadAt(head[:4], 0); err != nil {
+		return parquetMetadata{}, err
+	}
+	if _, err := r.ReadAt(tail[:], size-8); err != nil {
+		return parquetMetadata{}, err
+	}
+	if string(head[:4]) != parquetMagic || string(tail[4:]) != parquetMagic {
+		return parquetMetadata{}, errNotParquet
+	}
+	n := int64(binary.LittleEndian.Uint32(tail[:4]))
+	if n > size-12 {
+		return parquetMetadata{}, errors.New("corrupt parquet footer")
+	}
+	footer := make([]byte, n)
+	if _, err := r.ReadAt(footer, size-8-n); err != nil {
+		return parquetMetadata{}, err
+	}
+
+	t := &thriftReader{buf: footer, pos: 0, err: nil}
+	var meta parquetMetadata
+	t.readStruct(func(id int16, typ byte) bool {
+		switch {
+		case id == 2 && typ == thriftList:
+			t.readList(func(byte) {
+				meta.schema = append(meta.schema, decodeParquetSchemaElement(t))
+			})
+		case id == 4 && typ == thriftList:
+			t.readList(func(byte) {
+				meta.rowGroups = append(meta.rowGroups, decodeParquetRowGroup(t))
+			})
+		default:
+			return false
+		}
+
+		return true
+	})
+	if t.err != nil {
+		return parquetMetadata{}, errors.New("corrupt parquet footer: " + t.err.Error())
+	}
+
+	return meta, nil
+}
+
+// decodeParquetSchemaElement decodes a SchemaElement.
+func decodeParquetSchemaElement(t *thriftReader) parquetSchemaElement {
+	e := parquetSchemaElement{typ: -1, repetition: 0, name: "", numChildren: 0, convertedType: -1, scale: 0}
+	t.readStruct(func(id int16, typ byte) bool {
+		switch {
+		case id == 1 && typ == thriftI32:
+			e.typ = t.i32()
+		case id == 3 && typ == thriftI32:
+			e.repetition = t.i32()
+		case id == 4 && typ == thriftBinary:
+			e.name = string(t.readBinary())
+		case id == 5 && typ == thriftI32:
+			e.numChildren = t.i32()
+		case id == 6 && typ == thriftI32:
+			e.convertedType = t.i32()
+		case id == 7 && typ == thriftI32:
+			e.scale = t.i32()
+		default:
+			return false
+		}
+
+		return true
+	})
+
+	return e
+}
+
+// decodeParquetRowGroup decodes a RowGroup.
+func decodeParquetRowGroup(t *thriftReader) parquetRowGroup {
+	var rg parquetRowGroup
+	t.readStruct(func(id int16, typ byte) bool {
+		switch {
+		case id == 1 && typ == thriftList:
+			t.readList(func(byte) {
+				rg.chunks = append(rg.chunks, decodeParquetChunk(t))
+			})
+		case id == 3 && typ == thriftI64:
+			rg.numRows = t.varint()
+		default:
+			return false
+		}
+
+		return true
+	})
+
+	return rg
+}
+
+// decodeParquetChunk decodes a ColumnChunk and its ColumnMetaData.
+func decodeParquetChunk(t *thriftReader) parquetChunk {
+	var c parquetChunk
+	t.readStruct(func(id int16, typ byte) bool {
+		switch {
+		case id == 1 && typ == thriftBinary:
+			c.external = len(t.readBinary()) > 0
+		case id == 3 && typ == thriftStruct:
+			t.readStruct(func(id int16, typ byte) bool {
+				switch {
+				case id == 4 && typ == thriftI32:
+					c.codec = t.i32()
+				case id == 7 && typ == thriftI64:
+					c.compressedSize = t.varint()
+				case id == 9 && typ == thriftI64:
+					c.dataOffset = t.varint()
+				case id == 11 && typ == thriftI64:
+					c.dictOffset = t.varint()
+				default:
+					return false
+				}
+
+				return true
+			})
+		default:
+			return false
+		}
+
+		return true
+	})
+
+	return c
+}
+
+// errParquetCorrupt is the error for column data that cannot be decoded.
+var errParquetCorrupt = errors.New("corrupt parquet column data")
+
+// parquetCodecNames names the Parquet compression codecs by number, for
+// error messages.
+var parquetCodecNames = []string{"UNCOMPRESSED", "SNAPPY", "GZIP", "LZO", "BROTLI", "LZ4", "ZSTD", "LZ4_RAW"}
+
+// parquetEncodingNames names the Parquet encodings by number, for error
+// messages.
+var parquetEncodingNames = []string{
+	"PLAIN", "GROUP_VAR_INT", "PLAIN_DICTIONARY", "RLE", "BIT_PACKED", "DELTA_BINARY_PACKED",
+	"DELTA_LENGTH_BYTE_ARRAY", "DELTA_BYTE_ARRAY", "RLE_DICTIONARY", "BYTE_STREAM_SPLIT",
+}
+
+// parquetName returns names[i], or i as a number if it is out of range.
+func parquetName(names []string, i int32) string {
+	if i >= 0 && int(i) < len(names) {
+		return names[i]
+	}
+
+	return strconv.Itoa(int(i))
+}
+
+// parquetColumns returns the leaf columns of schema in order.
+func parquetColumns(schema []parquetSchemaElement) ([]parquetColumn, error) {
+	if len(schema) == 0 {
+		return nil, errors.New("parquet file has no schema")
+	}
+
+	var columns []parquetColumn
+	next := 1
+	var walk func(n int32, prefix string, maxDefinition int, repeated bool) error
+	walk = func(n int32, prefix string, maxDefinition int, repeated bool) error {
+		for range n {
+			if next >= len(schema) {
+				return errors.New("corrupt parquet schema")
+			}
+			e := schema[next]
+			next++
+
+			// Optional and repeated fields have a definition level, which
+			// is less than the maximum for a value that is null.
+			def := maxDefinition
+			if e.repetition != 0 {
+				def++
+			}
+			rep := repeated || e.repetition == parquetRepeated
+			if e.numChildren > 0 {
+				if err := walk(e.numChildren, prefix+e.name+".", def, rep); err != nil {
+					return err
+				}
+
+				continue
+			}
+			c := parquetColumn{path: prefix + e.name, typ: e.typ, maxDefinition: def, repeated: rep, scale: 0}
+			if e.convertedType == parquetDecimal {
+				c.scale = e.scale
+			}
+			columns = append(columns, c)
+		}
+
+		return nil
+	}
+	if err := walk(schema[0].numChildren, "", 0, false); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// resolveParquetColumn returns the position of column c among the leaf
+// columns, checking that it can be read.
+func resolveParquetColumn(columns []parquetColumn, c Column) (int, error) {
+	i := -1
+	if c.name == "" {
+		if c.index >= 0 && c.index < len(columns) {
+			i = c.index
+		}
+	} else {
+		for j, col := range columns {
+			if col.path == c.name {
+				i = j
+
+				break
+			}
+		}
+	}
+	if i < 0 {
+		if c.name == "" {
+			return 0, errors.New("column " + c.String() + " is out of range")
+		}
+
+		return 0, errors.New("column " + c.String() + " not found in parquet schema")
+	}
+
+	col := columns[i]
+	switch {
+	case col.repeated:
+		return 0, errors.New("column " + strconv.Quote(col.path) + " is repeated")
+	case col.typ != parquetInt32 && col.typ != parquetInt64 && col.typ != parquetFloat && col.typ != parquetDouble:
+		return 0, errors.New("column " + strconv.Quote(col.path) + " is not numeric")
+	case col.scale < 0 || col.scale > 38:
+		return 0, errors.New("column " + strconv.Quote(col.path) + " has an invalid decimal scale")
+	}
+
+	return i, nil
+}
+
+// parquetPageHeader is the part of a PageHeader that FromParquet uses.
+type parquetPageHeader struct {
+	typ              int32
+	uncompressedSize int32
+	compressedSize   int32
+	numValues        int32
+	encoding         int32
+	// definitionEncoding is the encoding of the definition levels of a
+	// version 1 data page.
+	definitionEncoding int32
+	// definitionLength and repetitionLength are the sizes of the levels
+	// at the start of a version 2 data page.
+	definitionLength int32
+	repetitionLength int32
+	// compressed is false for a version 2 data page whose values are not
+	// compressed.
+	compressed bool
+}
+
+// decodeParquetPageHeader decodes a PageHeader and whichever of its data
+// and dictionary page headers is present.
+func decodeParquetPageHeader(t *thriftReader) parquetPageHeader {
+	h := parquetPageHeader{
+		typ:                -1,
+		uncompressedSize:   0,
+		compressedSize:     0,
+		numValues:          0,
+		encoding:           parquetPlain,
+		definitionEncoding: 0,
+		definitionLength:   0,
+		repetitionLength:   0,
+		compressed:         true,
+	}
+	t.readStruct(func(id int16, typ byte) bool {
+		switch {
+		case id == 1 && typ == thriftI32:
+			h.typ = t.i32()
+		case id == 2 && typ == thriftI32:
+			h.uncompressedSize = t.i32()
+		case id == 3 && typ == thriftI32:
+			h.compressedSize = t.i32()
+		case (id == 5 || id == 7) && typ == thriftStruct:
+			// DataPageHeader and DictionaryPageHeader.
+			t.readStruct(func(id int16, typ byte) bool {
+				switch {
+				case id == 1 && typ == thriftI32:
+					h.numValues = t.i32()
+				case id == 2 && typ == thriftI32:
+					h.encoding = t.i32()
+				case id == 3 && typ == thriftI32:
+					h.definitionEncoding = t.i32()
+				default:
+					return false
+				}
+
+				return true
+			})
+		case id == 8 && typ == thriftStruct:
+			// DataPageHeaderV2.
+			t.readStruct(func(id int16, typ byte) bool {
+				switch {
+				case id == 1 && typ == thriftI32:
+					h.numValues = t.i32()
+				case id == 4 && typ == thriftI32:
+					h.encoding = t.i32()
+				case id == 5 && typ == thriftI32:
+					h.definitionLength = t.i32()
+				case id == 6 && typ == thriftI32:
+					h.repetitionLength = t.i32()
+				case id == 7 && (typ == thriftBoolTrue || typ == thriftBoolFalse):
+					h.compressed = typ == thriftBoolTrue
+				default:
+					return false
+				}
+
+				return true
+			})
+		default:
+			return false
+		}
+
+		return true
+	})
+
+	return h
+}
+
+// readParquetChunk reads the values of a column in a row group, which has
+// numRows rows.
+func readParquetChunk(r io.ReaderAt, size int64, chunk parquetChunk, col parquetColumn, numRows int64) ([]float64, error) {
+	if chunk.external {
+		return nil, errors.New("column " + strconv.Quote(col.path) + " is stored in another file")
+	}
+	start := chunk.dataOffset
+	if chunk.dictOffset > 0 && chunk.dictOffset < start {
+		start = chunk.dictOffset
+	}
+	if start < int64(len(parquetMagic)) || chunk.compressedSize < 0 || chunk.compressedSize > size-start || numRows < 0 {
+		return nil, errParquetCorrupt
+	}
+	buf := make([]byte, chunk.compressedSize)
+	if _, err := r.ReadAt(buf, start); err != nil {
+		return nil, err
+	}
+
+	out := make([]float64, 0, min(numRows, chunk.compressedSize))
+	var dict []float64
+	t := &thriftReader{buf: buf, pos: 0, err: nil}
+	for int64(len(out)) < numRows {
+		if t.pos >= len(buf) {
+			return nil, errors.New("column " + strconv.Quote(col.path) + " has fewer values than rows")
+		}
+		h := decodeParquetPageHeader(t)
+		page := t.next(int(h.compressedSize))
+		if t.err != nil || h.uncompressedSize < 0 || h.numValues < 0 || int64(h.numValues) > numRows-int64(len(out)) {
+			return nil, errParquetCorrupt
+		}
+
+		var err error
+		switch h.typ {
+		case parquetDictionaryPage:
+			dict, err = decodeParquetDictionary(page, h, chunk.codec, col)
+		case parquetDataPage, parquetDataPageV2:
+			out, err = decodeParquetDataPage(out, page, h, chunk.codec, col, dict)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// decompressParquet decompresses page data compressed with codec, which
+// decompresses to size bytes.
+func decompressParquet(data []byte, codec int32, size int) ([]byte, error) {
+	var out []byte
+	switch codec {
+	case parquetUncompressed:
+		out = data
+	case parquetSnappy:
+		var err error
+		if out, err = snappyDecode(data); err != nil {
+			return nil, err
+		}
+	case parquetGzip:
+		zr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		if out, err = io.ReadAll(io.LimitReader(zr, int64(size)+1)); err != nil {
+			return nil, err
+		}
+	case parquetZstd:
+		var err error
+		if out, err = io.ReadAll(io.LimitReader(newZstdReader(bytes.NewReader(data)), int64(size)+1)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, errors.New("unsupported parquet compression codec " + parquetName(parquetCodecNames, codec))
+	}
+	if len(out) != size {
+		return nil, errParquetCorrupt
+	}
+
+	return out, nil
+}
+
+// decodeParquetDictionary decodes a dictionary page.
+func decodeParquetDictionary(page []byte, h parquetPageHeader, codec int32, col parquetColumn) ([]float64, error) {
+	data, err := decompressParquet(page, codec, int(h.uncompressedSize))
+	if err != nil {
+		return nil, err
+	}
+	if h.encoding != parquetPlain && h.encoding != parquetPlainDictionary {
+		return nil, errors.New("unsupported parquet dictionary encoding " + parquetName(parquetEncodingNames, h.encoding))
+	}
+
+	return decodeParquetValues(data, parquetPlain, col, int(h.numValues), nil)
+}
+
+// decodeParquetDataPage decodes a data page of either version, appending
+// its values to out with NaN for each null.
+func decodeParquetDataPage(out []float64, page []byte, h parquetPageHeader, codec int32, col parquetColumn, dict []float64) ([]float64, error) {
+	n := int(h.numValues)
+	var levels, data []byte
+	if h.typ == parquetDataPageV2 {
+		// The levels come first and are never compressed.
+		rl, dl := int(h.repetitionLength), int(h.definitionLength)
+		if rl != 0 || dl < 0 || dl > len(page) {
+			return nil, errParquetCorrupt
+		}
+		levels, data = page[:dl], page[dl:]
+		if h.compressed {
+			var err error
+			if data, err = decompressParquet(data, codec, int(h.uncompressedSize)-dl); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		var err error
+		if data, err = decompressParquet(page, codec, int(h.uncompressedSize)); err != nil {
+			return nil, err
+		}
+		if col.maxDefinition > 0 {
+			// RLE, the only encoding of levels in current files, with
+			// the length of the levels first.
+			if h.definitionEncoding != 3 {
+				return nil, errors.New("unsupported parquet level encoding " +
+					parquetName(parquetEncodingNames, h.definitionEncoding))
+			}
+			if len(data) < 4 {
+				return nil, errParquetCorrupt
+			}
+			m := binary.LittleEndian.Uint32(data)
+			if uint64(m) > uint64(len(data)-4) {
+				return nil, errParquetCorrupt
+			}
+			levels, data = data[4:4+m], data[4+m:]
+		}
+	}
+
+	if col.maxDefinition == 0 {
+		values, err := decodeParquetValues(data, h.encoding, col, n, dict)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(out, values...), nil
+	}
+
+	defs, err := decodeHybrid(levels, bits.Len(uint(col.maxDefinition)), n)
+	if err != nil {
+		return nil, err
+	}
+	present := 0
+	for _, d := range defs {
+		if int(d) == col.maxDefinition {
+			present++
+		}
+	}
+	values, err := decodeParquetValues(data, h.encoding, col, present, dict)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range defs {
+		if int(d) == col.maxDefinition {
+			out = append(out, values[0])
+			values = values[1:]
+		} else {
+			out = append(out, math.NaN())
+		}
+	}
+
+	return out, nil
+}
+
+// decodeParquetValues decodes n values in the given encoding.
+func decodeParquetValues(data []byte, encoding int32, col parquetColumn, n int, dict []float64) ([]float64, error) {
+	width := 4
+	if col.typ == parquetInt64 || col.typ == parquetDouble {
+		width = 8
+	}
+
+	// Values in the fixed width encodings must all be present before
+	// space is allocated for them.
+	if n < 0 || (encoding == parquetPlain || encoding == parquetByteStreamSplit) && len(data)/width < n {
+		return nil, errParquetCorrupt
+	}
+
+	out := make([]float64, n)
+	switch encoding {
+	case parquetPlain:
+		for i := range out {
+			out[i] = parquetValue(data[i*width:], col)
+		}
+	case parquetPlainDictionary, parquetRLEDictionary:
+		if dict == nil {
+			return nil, errors.New("column " + strconv.Quote(col.path) + " has no dictionary page")
+		}
+		if n == 0 {
+			return out, nil
+		}
+		if len(data) == 0 {
+			return nil, errParquetCorrupt
+		}
+		indexes, err := decodeHybrid(data[1:], int(data[0]), n)
+		if err != nil {
+			return nil, err
+		}
+		for i, k := range indexes {
+			if int(k) >= len(dict) {
+				return nil, errParquetCorrupt
+			}
+			out[i] = dict[k]
+		}
+	case parquetByteStreamSplit:
+		// Byte k of value i is at position i of stream k, where the
+		// streams are the width equal parts of the data.
+		stream := len(data) / width
+		if len(data)%width != 0 {
+			return nil, errParquetCorrupt
+		}
+		var b [8]byte
+		for i := range out {
+			for k := range width {
+				b[k] = data[k*stream+i]
+			}
+			out[i] = parquetValue(b[:], col)
+		}
+	default:
+		return nil, errors.New("unsupported parquet encoding " + parquetName(parquetEncodingNames, encoding))
+	}
+
+	return out, nil
+}
+
+// parquetValue decodes a little endian value of the physical type of col
+// from the start of b, scaling decimals.
+func parquetValue(b []byte, col parquetColumn) float64 {
+	var v float64
+	switch col.typ {
+	case parquetInt32:
+		v = float64(int32(binary.LittleEndian.Uint32(b)))
+	case parquetInt64:
+		v = float64(int64(binary.LittleEndian.Uint64(b)))
+	case parquetFloat:
+		v = float64(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+	default:
+		v = math.Float64frombits(binary.LittleEndian.Uint64(b))
+	}
+	if col.scale > 0 {
+		v /= math.Pow10(int(col.scale))
+	}
+
+	return v
+}
+
+// decodeHybrid decodes n values of the given bit width in the Parquet
+// hybrid of run length encoding and bit packing, which is used for levels
+// and dictionary indexes.
+func decodeHybrid(data []byte, bitWidth, n int) ([]uint32, error) {
+	if bitWidth > 32 {
+		return nil, errParquetCorrupt
+	}
+
+	out := make([]uint32, 0, n)
+	for len(out) < n {
+		header, k := binary.Uvarint(data)
+		if k <= 0 {
+			return nil, errParquetCorrupt
+		}
+		data = data[k:]
+
+		if header&1 == 0 {
+			// A run of one value, stored in whole bytes.
+			size := (bitWidth + 7) / 8
+			if len(data) < size {
+				return nil, errParquetCorrupt
+			}
+			var v uint32
+			for i := size - 1; i >= 0; i-- {
+				v = v<<8 | uint32(data[i])
+			}
+			data = data[size:]
+			for count := header >> 1; count > 0 && len(out) < n; count-- {
+				out = append(out, v)
+			}
+
+			continue
+		}
+
+		// Groups of 8 values packed from the least significant bit. The
+		// last group may be cut short once all n values are present.
+		values := int(min(header>>1, uint64(n))) * 8
+		mask := uint64(1)<<bitWidth - 1
+		for i := 0; i < values && len(out) < n; i++ {
+			bit := i * bitWidth
+			if (bit+bitWidth+7)/8 > len(data) {
+				return nil, errParquetCorrupt
+			}
+			var w uint64
+			for j := min(bit/8+4, len(data)-1); j >= bit/8; j-- {
+				w = w<<8 | uint64(data[j])
+			}
+			out = append(out, uint32(w>>(bit%8)&mask))
+		}
+		used := min(int(min(header>>1, uint64(len(data))))*bitWidth, len(data))
+		data = data[used:]
+	}
+
+	return out, nil
+}
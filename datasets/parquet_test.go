@@ -0,0 +1,243 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The files in testdata/parquet were written with
+// github.com/parquet-go/parquet-go. Their columns hold these functions of
+// the row number i, with a null in the optional columns where i%7 == 3.
+func parquetA(i int) float64 { return float64(i)*0.5 - 3 }
+func parquetB(i int) float64 { return float64((i*37)%101) - 50 }
+func parquetC(i int) float64 { return float64(float32(i) / 8) }
+func parquetD(i int) float64 { return float64(i * i % 1000) }
+
+// parquetNull returns f with NaN where the optional columns are null.
+func parquetNull(f func(int) float64) func(int) float64 {
+	return func(i int) float64 {
+		if i%7 == 3 {
+			return math.NaN()
+		}
+
+		return f(i)
+	}
+}
+
+// openParquet returns the contents of a file in testdata/parquet.
+func openParquet(t *testing.T, name string) *bytes.Reader {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "parquet", name))
+	if err != nil {
+		t.Fatalf("os.ReadFile() unexpected error: %v", err)
+	}
+
+	return bytes.NewReader(data)
+}
+
+func TestFromParquet(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		x     Column
+		y     Column
+		n     int
+		wantX func(int) float64
+		wantY func(int) float64
+	}{
+		{
+			name:  "plain double and int64 over row groups and pages",
+			file:  "plain.parquet",
+			x:     ColumnName("a"),
+			y:     ColumnName("b"),
+			n:     300,
+			wantX: parquetA,
+			wantY: parquetB,
+		},
+		{
+			name:  "plain float and int32 by index",
+			file:  "plain.parquet",
+			x:     ColumnIndex(3),
+			y:     ColumnIndex(2),
+			n:     300,
+			wantX: parquetD,
+			wantY: parquetC,
+		},
+		{
+			name:  "snappy dictionary version 2 pages with nulls",
+			file:  "snappy_dict.parquet",
+			x:     ColumnName("a"),
+			y:     ColumnName("b"),
+			n:     300,
+			wantX: parquetNull(parquetA),
+			wantY: parquetB,
+		},
+		{
+			name:  "gzip byte stream split with nulls",
+			file:  "gzip_split.parquet",
+			x:     ColumnName("a"),
+			y:     ColumnName("c"),
+			n:     300,
+			wantX: parquetNull(parquetA),
+			wantY: parquetC,
+		},
+		{
+			name:  "gzip byte stream split int32",
+			file:  "gzip_split.parquet",
+			x:     ColumnName("d"),
+			y:     ColumnName("d"),
+			n:     300,
+			wantX: parquetD,
+			wantY: parquetD,
+		},
+		{
+			name:  "zstd pages",
+			file:  "zstd.parquet",
+			x:     ColumnName("a"),
+			y:     ColumnName("b"),
+			n:     10,
+			wantX: parquetA,
+			wantY: parquetB,
+		},
+		{
+			name:  "nested columns",
+			file:  "schema.parquet",
+			x:     ColumnName("point.x"),
+			y:     ColumnName("point.y"),
+			n:     10,
+			wantX: parquetA,
+			wantY: parquetNull(parquetA),
+		},
+		{
+			name:  "decimal",
+			file:  "schema.parquet",
+			x:     ColumnName("price"),
+			y:     ColumnName("point.x"),
+			n:     10,
+			wantX: func(i int) float64 { return float64(i) * 1.25 },
+			wantY: parquetA,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := openParquet(t, test.file)
+			d, err := FromParquet(r, r.Size(), test.x, test.y)
+			if err != nil {
+				t.Fatalf("FromParquet() unexpected error: %v", err)
+			}
+			wantX := make([]float64, test.n)
+			wantY := make([]float64, test.n)
+			for i := range test.n {
+				wantX[i] = test.wantX(i)
+				wantY[i] = test.wantY(i)
+			}
+			if !equalNaN(d.X, wantX) || !equalNaN(d.Y, wantY) {
+				t.Errorf("FromParquet() = %v, %v, want %v, %v", d.X, d.Y, wantX, wantY)
+			}
+		})
+	}
+}
+
+func TestFromParquetErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		x       Column
+		y       Column
+		wantErr string
+	}{
+		{
+			name:    "unknown column",
+			file:    "plain.parquet",
+			x:       ColumnName("a"),
+			y:       ColumnName("z"),
+			wantErr: `column "z" not found in parquet schema`,
+		},
+		{
+			name:    "index out of range",
+			file:    "plain.parquet",
+			x:       ColumnIndex(4),
+			y:       ColumnIndex(0),
+			wantErr: "column #4 is out of range",
+		},
+		{
+			name:    "string column",
+			file:    "schema.parquet",
+			x:       ColumnName("label"),
+			y:       ColumnName("price"),
+			wantErr: `column "label" is not numeric`,
+		},
+		{
+			name:    "repeated column",
+			file:    "schema.parquet",
+			x:       ColumnName("price"),
+			y:       ColumnName("tags.list.element"),
+			wantErr: `column "tags.list.element" is repeated`,
+		},
+		{
+			name:    "unsupported encoding",
+			file:    "schema.parquet",
+			x:       ColumnName("price"),
+			y:       ColumnName("delta"),
+			wantErr: "unsupported parquet encoding DELTA_BINARY_PACKED",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := openParquet(t, test.file)
+			_, err := FromParquet(r, r.Size(), test.x, test.y)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("FromParquet() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestFromParquetNotParquet(t *testing.T) {
+	for _, input := range []string{"", "PAR1", "x,y\n1,2\n3,4\n", "PAR1\x00\x00\x00\x00\xff\xff\x00\x00PAR1"} {
+		r := strings.NewReader(input)
+		if _, err := FromParquet(r, r.Size(), ColumnIndex(0), ColumnIndex(1)); err == nil {
+			t.Errorf("FromParquet(%q) expected error but got none", input)
+		}
+	}
+}
+
+func TestFromParquetCorrupt(t *testing.T) {
+	// Damaging any byte must give an error or wrong values, never a panic.
+	for _, name := range []string{"snappy_dict.parquet", "gzip_split.parquet", "zstd.parquet", "schema.parquet"} {
+		data, err := os.ReadFile(filepath.Join("testdata", "parquet", name))
+		if err != nil {
+			t.Fatalf("os.ReadFile() unexpected error: %v", err)
+		}
+		for i := range data {
+			damaged := bytes.Clone(data)
+			damaged[i] ^= 0xa5
+			r := bytes.NewReader(damaged)
+			_, _ = FromParquet(r, r.Size(), ColumnIndex(0), ColumnIndex(1))
+		}
+		for n := range len(data) {
+			r := bytes.NewReader(data[:n])
+			_, _ = FromParquet(r, r.Size(), ColumnIndex(0), ColumnIndex(1))
+		}
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// brailleDots holds the bit of each dot of a braille cell, which is 2 dots
+// wide and 4 high, indexed by row and then column.
+var brailleDots = [4][2]rune{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// Plot writes a scatterplot of the dataset to w as text, width characters
+// wide and height lines high, for looking at data without leaving the
+// terminal:
+//
+//	for _, d := range datasets.AnscombeQuartet.Data {
+//		d.Plot(os.Stdout, 40, 12)
+//	}
+//
+// The points are drawn in braille characters, each holding a grid of 2 by
+// 4 dots, so the plot resolves 2*width by 4*height positions. The axes are
+// marked with the range of the values and, if set, YLabel above the plot
+// and XLabel below it, with their units. Missing pairs, as reported by
+// IsMissing, and infinite values are not drawn.
+//
+// An error is returned if width or height is less than 1, X and Y differ
+// in length, or the plot cannot be written.
+func (d Dataset) Plot(w io.Writer, width, height int) error {
+	if width < 1 || height < 1 {
+		return errors.New("plot width and height must be at least 1")
+	}
+	if len(d.X) != len(d.Y) {
+		return errors.New("X and Y must have the same length")
+	}
+
+	var xs, ys []float64
+	for i, x := range d.X {
+		y := d.Y[i]
+		if !d.IsMissing(i) && !math.IsInf(x, 0) && !math.IsInf(y, 0) {
+			xs = append(xs, x)
+			ys = append(ys, y)
+		}
+	}
+	xMin, xMax := plotRange(xs)
+	yMin, yMax := plotRange(ys)
+
+	cells := make([][]rune, height)
+	for r := range cells {
+		cells[r] = make([]rune, width)
+	}
+	for i, x := range xs {
+		col := int(math.Round((x - xMin) / (xMax - xMin) * float64(2*width-1)))
+		row := int(math.Round((yMax - ys[i]) / (yMax - yMin) * float64(4*height-1)))
+		cells[row/4][col/2] |= brailleDots[row%4][col%2]
+	}
+
+	top := strconv.FormatFloat(yMax, 'g', 4, 64)
+	bottom := strconv.FormatFloat(yMin, 'g', 4, 64)
+	margin := max(len(top), len(bottom))
+
+	var b strings.Builder
+	if label := axisLabel(d.YLabel, d.YUnits); label != "" {
+		b.WriteString(label + "\n")
+	}
+	for r, row := range cells {
+		tick := "│"
+		switch r {
+		case 0:
+			b.WriteString(strings.Repeat(" ", margin-len(top)) + top)
+			tick = "┤"
+		case height - 1:
+			b.WriteString(strings.Repeat(" ", margin-len(bottom)) + bottom)
+			tick = "┤"
+		default:
+			b.WriteString(strings.Repeat(" ", margin))
+		}
+		b.WriteString(" " + tick)
+		for _, c := range row {
+			b.WriteRune(0x2800 + c)
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(strings.Repeat(" ", margin+1) + "└" + strings.Repeat("─", width) + "\n")
+
+	left := strconv.FormatFloat(xMin, 'g', 4, 64)
+	right := strconv.FormatFloat(xMax, 'g', 4, 64)
+	gap := max(1, width-len(left)-len(right))
+	b.WriteString(strings.Repeat(" ", margin+2) + left + strings.Repeat(" ", gap) + right + "\n")
+	if label := axisLabel(d.XLabel, d.XUnits); label != "" {
+		pad := max(0, margin+2+width-utf8.RuneCountInString(label))
+		b.WriteString(strings.Repeat(" ", pad) + label + "\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// plotRange returns the range of an axis showing vals, widened around a
+// single value so that it is never empty.
+func plotRange(vals []float64) (float64, float64) {
+	if len(vals) == 0 {
+		return 0, 1
+	}
+	lo, hi := vals[0], vals[0]
+	for _, v := range vals[1:] {
+		lo = min(lo, v)
+		hi = max(hi, v)
+	}
+	if lo == hi {
+		return lo - 1, hi + 1
+	}
+
+	return lo, hi
+}
+
+// axisLabel returns the label of an axis with its units, if any.
+func axisLabel(label, units string) string {
+	switch {
+	case units == "":
+		return label
+	case label == "":
+		return "(" + units + ")"
+	default:
+		return label + " (" + units + ")"
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+// failWriter is an io.Writer that always fails.
+type failWriter struct{}
+
+func (failWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestDatasetPlot(t *testing.T) {
+	tests := []struct {
+		name          string
+		d             Dataset
+		width, height int
+		want          string
+	}{
+		{
+			name: "corners with labels",
+			d: Dataset{
+				Name:        "",
+				Description: "",
+				Attribution: "",
+				XLabel:      "x",
+				YLabel:      "y",
+				XUnits:      "",
+				YUnits:      "m",
+				X:           []float64{0, 1, math.NaN(), 0.5},
+				Y:           []float64{0, 1, 5, math.Inf(1)},
+				Valid:       nil,
+			},
+			width:  2,
+			height: 1,
+			want: "y (m)\n" +
+				"1 ┤⡀⠈\n" +
+				"  └──\n" +
+				"   0 1\n" +
+				"    x\n",
+		},
+		{
+			name:   "single point",
+			d:      Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{2}, Y: []float64{3}, Valid: nil},
+			width:  3,
+			height: 2,
+			want: "4 ┤⠀⠀⠀\n" +
+				"2 ┤⠀⠈⠀\n" +
+				"  └───\n" +
+				"   1 3\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := test.d.Plot(&b, test.width, test.height); err != nil {
+				t.Fatalf("Plot() unexpected error: %v", err)
+			}
+			if got := b.String(); got != test.want {
+				t.Errorf("Plot() =\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDatasetPlotErrors(t *testing.T) {
+	d := AnscombeI
+	var b strings.Builder
+	if err := d.Plot(&b, 0, 10); err == nil || !strings.Contains(err.Error(), "at least 1") {
+		t.Errorf("Plot() of zero width error = %v, want one containing %q", err, "at least 1")
+	}
+	d.Y = d.Y[1:]
+	if err := d.Plot(&b, 10, 10); err == nil || !strings.Contains(err.Error(), "same length") {
+		t.Errorf("Plot() of mismatched series error = %v, want one containing %q", err, "same length")
+	}
+	if err := AnscombeI.Plot(failWriter{}, 10, 10); err == nil {
+		t.Errorf("Plot() to a failing writer expected error but got none")
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+)
+
+// Split returns two datasets that divide the pairs of the dataset at
+// random, the first holding the fraction frac of them, rounded to the
+// nearest pair, and the second the rest, as for a train and test split:
+//
+//	train, test, err := d.Split(0.8, 1)
+//
+// The pairs of each part are in random order. Both parts have the
+// metadata of the dataset, and the parts of Valid for their pairs. The
+// same seed always produces the same split.
+//
+// An error is returned if frac is not in [0, 1] or if X and Y differ in
+// length.
+func (d Dataset) Split(frac float64, seed int64) (Dataset, Dataset, error) {
+	if frac < 0 || frac > 1 || math.IsNaN(frac) {
+		return Dataset{}, Dataset{}, errors.New("split fraction must be in the interval [0, 1]")
+	}
+	if len(d.X) != len(d.Y) {
+		return Dataset{}, Dataset{}, errors.New("X and Y must have the same length")
+	}
+
+	perm := rand.New(rand.NewSource(seed)).Perm(len(d.X))
+	n := int(math.Round(frac * float64(len(perm))))
+
+	return d.subset(perm[:n]), d.subset(perm[n:]), nil
+}
+
+// Shuffle returns a copy of the dataset with its pairs in random order,
+// moving X and Y together so that every pair stays intact. The same seed
+// always produces the same order.
+//
+// An error is returned if X and Y differ in length.
+func (d Dataset) Shuffle(seed int64) (Dataset, error) {
+	if len(d.X) != len(d.Y) {
+		return Dataset{}, errors.New("X and Y must have the same length")
+	}
+
+	return d.subset(rand.New(rand.NewSource(seed)).Perm(len(d.X))), nil
+}
+
+// ShuffleY returns a copy of the dataset with X in place and the values of
+// Y in random order, deliberately breaking the pairing. Correlations of
+// such copies show the spread expected when there is no relationship, as
+// in a permutation test:
+//
+//	r, _ := correlation.CorrelateDataset(d, correlation.Pearson)
+//	for seed := range int64(1000) {
+//		s, _ := d.ShuffleY(seed)
+//		rs, _ := correlation.CorrelateDataset(s, correlation.Pearson)
+//		...
+//	}
+//
+// A pair of the copy is marked missing in Valid if either pair its values
+// came from was. The same seed always produces the same order.
+//
+// An error is returned if X and Y differ in length.
+func (d Dataset) ShuffleY(seed int64) (Dataset, error) {
+	if len(d.X) != len(d.Y) {
+		return Dataset{}, errors.New("X and Y must have the same length")
+	}
+
+	perm := rand.New(rand.NewSource(seed)).Perm(len(d.Y))
+	out := d.perturbedCopy("Y shuffled")
+	for i, k := range perm {
+		out.Y[i] = d.Y[k]
+		if d.Valid != nil {
+			out.Valid[i] = d.Valid[i] && d.Valid[k]
+		}
+	}
+
+	return out, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDatasetSplit(t *testing.T) {
+	d := AnscombeI
+	d.Valid = make([]bool, len(d.X))
+	for i := range d.Valid {
+		d.Valid[i] = i != 3
+	}
+
+	for _, frac := range []float64{0, 0.3, 0.5, 0.8, 1} {
+		train, test, err := d.Split(frac, 7)
+		if err != nil {
+			t.Fatalf("Split(%v) unexpected error: %v", frac, err)
+		}
+		if want := int(frac*11 + 0.5); len(train.X) != want || len(test.X) != 11-want {
+			t.Errorf("Split(%v) sizes = %d, %d, want %d, %d", frac, len(train.X), len(test.X), want, 11-want)
+		}
+
+		// Every pair lands in exactly one part, still paired with its Y
+		// value and validity.
+		var xs []float64
+		for _, part := range []Dataset{train, test} {
+			for i, x := range part.X {
+				k := slices.Index(d.X, x)
+				if part.Y[i] != d.Y[k] || part.Valid[i] != d.Valid[k] {
+					t.Errorf("Split(%v) paired x = %v with %v, %v, want %v, %v", frac, x, part.Y[i], part.Valid[i], d.Y[k], d.Valid[k])
+				}
+				xs = append(xs, x)
+			}
+		}
+		slices.Sort(xs)
+		want := slices.Sorted(slices.Values(d.X))
+		if !slices.Equal(xs, want) {
+			t.Errorf("Split(%v) parts hold %v, want %v", frac, xs, want)
+		}
+	}
+
+	a, _, _ := d.Split(0.5, 3)
+	b, _, _ := d.Split(0.5, 3)
+	if !slices.Equal(a.X, b.X) {
+		t.Errorf("Split() with the same seed = %v and %v, want the same", a.X, b.X)
+	}
+}
+
+func TestDatasetSplitErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		d       Dataset
+		frac    float64
+		wantErr string
+	}{
+		{name: "negative fraction", d: AnscombeI, frac: -0.1, wantErr: "interval [0, 1]"},
+		{name: "fraction above 1", d: AnscombeI, frac: 1.5, wantErr: "interval [0, 1]"},
+		{
+			name:    "length mismatch",
+			d:       Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{1, 2}, Y: []float64{1}, Valid: nil},
+			frac:    0.5,
+			wantErr: "same length",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := test.d.Split(test.frac, 1)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Split() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestDatasetShuffle(t *testing.T) {
+	d := AnscombeII
+	got, err := d.Shuffle(5)
+	if err != nil {
+		t.Fatalf("Shuffle() unexpected error: %v", err)
+	}
+	if slices.Equal(got.X, d.X) {
+		t.Errorf("Shuffle() left the pairs in order")
+	}
+	for i, x := range got.X {
+		k := slices.Index(d.X, x)
+		if got.Y[i] != d.Y[k] {
+			t.Errorf("Shuffle() paired x = %v with y = %v, want %v", x, got.Y[i], d.Y[k])
+		}
+	}
+	again, _ := d.Shuffle(5)
+	if !slices.Equal(got.X, again.X) || got.Name != d.Name {
+		t.Errorf("Shuffle() with the same seed = %v named %q, want %v named %q", again.X, again.Name, got.X, d.Name)
+	}
+}
+
+func TestDatasetShuffleY(t *testing.T) {
+	d := AnscombeI
+	d.Valid = make([]bool, len(d.X))
+	for i := range d.Valid {
+		d.Valid[i] = i != 0
+	}
+	got, err := d.ShuffleY(5)
+	if err != nil {
+		t.Fatalf("ShuffleY() unexpected error: %v", err)
+	}
+	if !slices.Equal(got.X, d.X) {
+		t.Errorf("ShuffleY() X = %v, want it unchanged at %v", got.X, d.X)
+	}
+	if slices.Equal(got.Y, d.Y) {
+		t.Errorf("ShuffleY() left Y in order")
+	}
+	if !slices.Equal(slices.Sorted(slices.Values(got.Y)), slices.Sorted(slices.Values(d.Y))) {
+		t.Errorf("ShuffleY() Y = %v, want a permutation of %v", got.Y, d.Y)
+	}
+	for i := range got.Valid {
+		want := i != 0 && got.Y[i] != d.Y[0]
+		if got.Valid[i] != want {
+			t.Errorf("ShuffleY() Valid[%d] = %v, want %v", i, got.Valid[i], want)
+		}
+	}
+	if !strings.Contains(got.Name, "Y shuffled") {
+		t.Errorf("ShuffleY() name = %q, want it marked as shuffled", got.Name)
+	}
+}
+
+func TestDatasetShuffleErrors(t *testing.T) {
+	d := Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{1, 2}, Y: []float64{1}, Valid: nil}
+	if _, err := d.Shuffle(1); err == nil || !strings.Contains(err.Error(), "same length") {
+		t.Errorf("Shuffle() error = %v, want one containing %q", err, "same length")
+	}
+	if _, err := d.ShuffleY(1); err == nil || !strings.Contains(err.Error(), "same length") {
+		t.Errorf("ShuffleY() error = %v, want one containing %q", err, "same length")
+	}
+}
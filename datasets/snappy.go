@@ -0,0 +1,106 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// snappyMaxExpansion bounds the ratio of decoded to encoded size. A copy
+// element of 2 bytes decodes to at most 11 bytes and one of 3 bytes to at
+// most 64, so a larger claimed length is corrupt and is rejected before
+// it is allocated.
+const snappyMaxExpansion = 32
+
+// errSnappyCorrupt is the error for input that is not valid snappy data.
+var errSnappyCorrupt = errors.New("corrupt snappy data")
+
+// snappyDecode decodes a block in the raw snappy format, as used for
+// Parquet pages: the decoded length as a varint followed by a sequence of
+// literals and back references.
+func snappyDecode(src []byte) ([]byte, error) {
+	n, k := binary.Uvarint(src)
+	if k <= 0 || n > uint64(len(src))*snappyMaxExpansion {
+		return nil, errSnappyCorrupt
+	}
+	src = src[k:]
+	dst := make([]byte, 0, n)
+
+	for len(src) > 0 {
+		tag := src[0]
+		var length, offset int
+		switch tag & 0x03 {
+		case 0:
+			// A literal, whose length minus 1 is in the tag or, for long
+			// literals, in the 1 to 4 bytes after it.
+			length = int(tag >> 2)
+			src = src[1:]
+			if length >= 60 {
+				size := length - 59
+				if len(src) < size {
+					return nil, errSnappyCorrupt
+				}
+				length = 0
+				for i := size - 1; i >= 0; i-- {
+					length = length<<8 | int(src[i])
+				}
+				src = src[size:]
+			}
+			length++
+			if length <= 0 || length > len(src) || uint64(len(dst)+length) > n {
+				return nil, errSnappyCorrupt
+			}
+			dst = append(dst, src[:length]...)
+			src = src[length:]
+
+			continue
+		case 1:
+			if len(src) < 2 {
+				return nil, errSnappyCorrupt
+			}
+			length = 4 + int(tag>>2&0x07)
+			offset = int(tag&0xe0)<<3 | int(src[1])
+			src = src[2:]
+		case 2:
+			if len(src) < 3 {
+				return nil, errSnappyCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint16(src[1:]))
+			src = src[3:]
+		default:
+			if len(src) < 5 {
+				return nil, errSnappyCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint32(src[1:]))
+			src = src[5:]
+		}
+		if offset <= 0 || offset > len(dst) || uint64(len(dst)+length) > n {
+			return nil, errSnappyCorrupt
+		}
+		// The source and destination of a copy may overlap, repeating the
+		// last offset bytes, so it is done a byte at a time.
+		for i := len(dst) - offset; length > 0; i, length = i+1, length-1 {
+			dst = append(dst, dst[i])
+		}
+	}
+	if uint64(len(dst)) != n {
+		return nil, errSnappyCorrupt
+	}
+
+	return dst, nil
+}
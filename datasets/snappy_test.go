@@ -0,0 +1,97 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSnappyDecode(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "empty",
+			input: "\x00",
+			want:  "",
+		},
+		{
+			name:  "literal",
+			input: "\x05\x10hello",
+			want:  "hello",
+		},
+		{
+			name:  "long literal",
+			input: "\x3d\xf0\x3c" + strings.Repeat("x", 61),
+			want:  strings.Repeat("x", 61),
+		},
+		{
+			name:  "overlapping copy with 1 byte offset",
+			input: "\x08\x04ab\x09\x02",
+			want:  "abababab",
+		},
+		{
+			name:  "copy with 2 byte offset",
+			input: "\x09\x08abc\x16\x03\x00",
+			want:  "abcabcabc",
+		},
+		{
+			name:  "copy with 4 byte offset",
+			input: "\x04\x00a\x0b\x01\x00\x00\x00",
+			want:  "aaaa",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := snappyDecode([]byte(test.input))
+			if err != nil {
+				t.Fatalf("snappyDecode() unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, []byte(test.want)) {
+				t.Errorf("snappyDecode() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSnappyDecodeCorrupt(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "no length", input: ""},
+		{name: "truncated literal", input: "\x05\x10hel"},
+		{name: "truncated long literal length", input: "\x3d\xf0"},
+		{name: "short output", input: "\x06\x10hello"},
+		{name: "long output", input: "\x04\x10hello"},
+		{name: "zero offset", input: "\x08\x04ab\x09\x00"},
+		{name: "offset before start", input: "\x08\x04ab\x09\x03"},
+		{name: "truncated copy", input: "\x09\x08abc\x16\x03"},
+		{name: "implausible length", input: "\xff\xff\xff\xff\x0f\x00a"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := snappyDecode([]byte(test.input)); err == nil {
+				t.Errorf("snappyDecode(%q) expected error but got none", test.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"cmp"
+	"slices"
+)
+
+// SortByX returns a copy of the dataset with its pairs in increasing order
+// of X, moving each Y value, and its entry of Valid, with its X value so
+// that no pair is broken. Pairs with equal X stay in their original order,
+// and NaN values of X come first.
+func (d Dataset) SortByX() Dataset {
+	return d.sortBy(d.X)
+}
+
+// SortByY returns a copy of the dataset with its pairs in increasing order
+// of Y, as SortByX does for X.
+func (d Dataset) SortByY() Dataset {
+	return d.sortBy(d.Y)
+}
+
+// sortBy returns a copy of the dataset with its pairs in increasing order
+// of keys.
+func (d Dataset) sortBy(keys []float64) Dataset {
+	order := make([]int, min(len(d.X), len(d.Y)))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(keys[a], keys[b])
+	})
+
+	return d.subset(order)
+}
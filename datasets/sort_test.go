@@ -0,0 +1,53 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestDatasetSort(t *testing.T) {
+	nan := math.NaN()
+	d := Dataset{
+		Name:        "lab",
+		Description: "",
+		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           []float64{3, 1, 2, 1, nan},
+		Y:           []float64{10, 40, 20, 30, 50},
+		Valid:       []bool{true, true, false, true, true},
+	}
+
+	got := d.SortByX()
+	if !equalNaN(got.X, []float64{nan, 1, 1, 2, 3}) || !slices.Equal(got.Y, []float64{50, 40, 30, 20, 10}) {
+		t.Errorf("SortByX() = %v, %v, want [NaN 1 1 2 3], [50 40 30 20 10]", got.X, got.Y)
+	}
+	if !slices.Equal(got.Valid, []bool{true, true, true, false, true}) || got.Name != "lab" {
+		t.Errorf("SortByX() Valid = %v named %q, want [true true true false true] named lab", got.Valid, got.Name)
+	}
+
+	got = d.SortByY()
+	if !equalNaN(got.X, []float64{3, 2, 1, 1, nan}) || !slices.Equal(got.Y, []float64{10, 20, 30, 40, 50}) {
+		t.Errorf("SortByY() = %v, %v, want [3 2 1 1 NaN], [10 20 30 40 50]", got.X, got.Y)
+	}
+	if d.X[0] != 3 || d.Y[0] != 10 {
+		t.Errorf("sorting changed the original to %v, %v", d.X, d.Y)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"io"
+)
+
+// DataSource supplies paired data in batches, so that computations can
+// consume data without knowing how it is stored or fetched, and without
+// holding all of it in memory at once.
+type DataSource interface {
+	// Next returns up to batch further pairs as parallel x and y slices of
+	// equal length. When the data is exhausted it returns io.EOF. Like
+	// io.Reader, it may return a final batch of pairs together with io.EOF,
+	// so callers should process any pairs before looking at the error.
+	//
+	// The returned slices are only valid until the next call to Next.
+	Next(batch int) ([]float64, []float64, error)
+}
+
+// sliceSource is a DataSource over in-memory slices.
+type sliceSource struct {
+	x   []float64
+	y   []float64
+	pos int
+}
+
+// Source returns a DataSource that yields the pairs of the dataset in order.
+func (d Dataset) Source() DataSource {
+	return &sliceSource{x: d.X, y: d.Y, pos: 0}
+}
+
+// Next implements DataSource.
+func (s *sliceSource) Next(batch int) ([]float64, []float64, error) {
+	if batch <= 0 {
+		return nil, nil, errors.New("batch size must be positive")
+	}
+	n := min(len(s.x), len(s.y))
+	if s.pos >= n {
+		return nil, nil, io.EOF
+	}
+
+	end := min(s.pos+batch, n)
+	x, y := s.x[s.pos:end], s.y[s.pos:end]
+	s.pos = end
+
+	return x, y, nil
+}
+
+// ReadAll reads every remaining pair from src, requesting batch pairs at a
+// time, and returns them as new slices.
+//
+// An error is returned if src returns an error other than io.EOF, or a batch
+// whose x and y slices differ in length.
+func ReadAll(src DataSource, batch int) ([]float64, []float64, error) {
+	var xs, ys []float64
+	err := Each(src, batch, func(x, y []float64) error {
+		xs = append(xs, x...)
+		ys = append(ys, y...)
+
+		return nil
+	})
+
+	return xs, ys, err
+}
+
+// Each calls fn with every batch of pairs from src until the data is
+// exhausted, requesting batch pairs at a time. It stops early and returns
+// the error if fn returns one.
+//
+// An error is returned if src returns an error other than io.EOF, or a batch
+// whose x and y slices differ in length.
+func Each(src DataSource, batch int, fn func(x, y []float64) error) error {
+	for {
+		x, y, err := src.Next(batch)
+		if len(x) != len(y) {
+			return errors.New("data source returned x and y batches of different lengths")
+		}
+		if len(x) > 0 {
+			if ferr := fn(x, y); ferr != nil {
+				return ferr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
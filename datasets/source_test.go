@@ -0,0 +1,87 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"testing"
+)
+
+func TestDatasetSource(t *testing.T) {
+	d := AnscombeI
+	for _, batch := range []int{1, 3, 11, 100} {
+		x, y, err := ReadAll(d.Source(), batch)
+		if err != nil {
+			t.Fatalf("ReadAll(batch %d) unexpected error: %v", batch, err)
+		}
+		if !slices.Equal(x, d.X) || !slices.Equal(y, d.Y) {
+			t.Errorf("ReadAll(batch %d) did not return the dataset", batch)
+		}
+	}
+
+	src := d.Source()
+	x, _, err := src.Next(4)
+	if err != nil || len(x) != 4 {
+		t.Errorf("Next(4) = %d pairs, %v, want 4 pairs", len(x), err)
+	}
+	if _, _, err := src.Next(0); err == nil {
+		t.Errorf("Next(0) expected error but got none")
+	}
+	for range 2 {
+		if _, _, err := src.Next(4); err != nil {
+			t.Fatalf("Next(4) unexpected error: %v", err)
+		}
+	}
+	if _, _, err := src.Next(4); !errors.Is(err, io.EOF) {
+		t.Errorf("Next() after the end = %v, want io.EOF", err)
+	}
+}
+
+// eofSource returns its only batch together with io.EOF, then a mismatched
+// batch if asked again.
+type eofSource struct {
+	calls int
+}
+
+func (s *eofSource) Next(int) ([]float64, []float64, error) {
+	s.calls++
+	if s.calls == 1 {
+		return []float64{1, 2}, []float64{3, 4}, io.EOF
+	}
+
+	return []float64{1}, nil, nil
+}
+
+func TestEach(t *testing.T) {
+	x, y, err := ReadAll(&eofSource{calls: 0}, 10)
+	if err != nil {
+		t.Fatalf("ReadAll() unexpected error: %v", err)
+	}
+	if !slices.Equal(x, []float64{1, 2}) || !slices.Equal(y, []float64{3, 4}) {
+		t.Errorf("ReadAll() = %v, %v, want the batch returned with io.EOF", x, y)
+	}
+
+	src := &eofSource{calls: 1}
+	if _, _, err := ReadAll(src, 10); err == nil {
+		t.Errorf("ReadAll() with mismatched batch expected error but got none")
+	}
+
+	stop := errors.New("stop")
+	if err := Each(AnscombeI.Source(), 2, func(_, _ []float64) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("Each() = %v, want the callback error", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+// Filter returns a copy of the dataset holding the pairs for which keep
+// returns true, in their original order, with the parts of Valid for them:
+//
+//	adults := d.Filter(func(x, y float64) bool { return x >= 18 })
+//
+// keep is called with the values of every pair, including missing ones.
+func (d Dataset) Filter(keep func(x, y float64) bool) Dataset {
+	var indices []int
+	for i := range min(len(d.X), len(d.Y)) {
+		if keep(d.X[i], d.Y[i]) {
+			indices = append(indices, i)
+		}
+	}
+
+	return d.subset(indices)
+}
+
+// Slice returns a view of the pairs i through j-1 of the dataset, sharing
+// their values, and Valid if set, with d as slicing does. Appending to the
+// view does not overwrite the pairs of d that follow it.
+//
+// It panics if the bounds are out of range, as slicing does.
+func (d Dataset) Slice(i, j int) Dataset {
+	out := d
+	out.X = d.X[i:j:j]
+	out.Y = d.Y[i:j:j]
+	if d.Valid != nil {
+		out.Valid = d.Valid[i:j:j]
+	}
+
+	return out
+}
+
+// subset returns a copy of the dataset holding the pairs at the given
+// indices, in that order.
+func (d Dataset) subset(indices []int) Dataset {
+	out := Dataset{
+		Name:        d.Name,
+		Description: d.Description,
+		Attribution: d.Attribution,
+		XLabel:      d.XLabel,
+		YLabel:      d.YLabel,
+		XUnits:      d.XUnits,
+		YUnits:      d.YUnits,
+		X:           make([]float64, len(indices)),
+		Y:           make([]float64, len(indices)),
+		Valid:       nil,
+	}
+	if d.Valid != nil {
+		out.Valid = make([]bool, len(indices))
+	}
+	for i, k := range indices {
+		out.X[i] = d.X[k]
+		out.Y[i] = d.Y[k]
+		if d.Valid != nil {
+			out.Valid[i] = d.Valid[k]
+		}
+	}
+
+	return out
+}
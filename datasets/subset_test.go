@@ -0,0 +1,75 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestDatasetFilter(t *testing.T) {
+	d := Dataset{
+		Name:        "lab",
+		Description: "",
+		Attribution: "",
+		XLabel:      "age",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           []float64{12, 30, math.NaN(), 45, 17},
+		Y:           []float64{1, 2, 3, 4, 5},
+		Valid:       []bool{true, true, false, false, true},
+	}
+	got := d.Filter(func(x, y float64) bool { return x >= 18 || y == 3 })
+	if !slices.Equal(got.Y, []float64{2, 3, 4}) || !slices.Equal(got.Valid, []bool{true, false, false}) {
+		t.Errorf("Filter() = %v, %v with Valid %v, want Y [2 3 4] with [true false false]", got.X, got.Y, got.Valid)
+	}
+	if got.Name != "lab" || got.XLabel != "age" {
+		t.Errorf("Filter() metadata = %q, %q, want lab, age", got.Name, got.XLabel)
+	}
+	got.Y[0] = -1
+	if d.Y[1] != 2 {
+		t.Errorf("modifying the result of Filter() changed the original")
+	}
+
+	if none := d.Filter(func(x, y float64) bool { return false }); len(none.X) != 0 || len(none.Y) != 0 {
+		t.Errorf("Filter() of nothing = %v, %v, want no pairs", none.X, none.Y)
+	}
+}
+
+func TestDatasetSlice(t *testing.T) {
+	d := AnscombeI
+	d.Valid = make([]bool, len(d.X))
+	got := d.Slice(2, 5)
+	if !slices.Equal(got.X, d.X[2:5]) || !slices.Equal(got.Y, d.Y[2:5]) || len(got.Valid) != 3 {
+		t.Errorf("Slice(2, 5) = %v, %v, %v, want pairs 2 to 4", got.X, got.Y, got.Valid)
+	}
+	if got.Name != d.Name {
+		t.Errorf("Slice() name = %q, want %q", got.Name, d.Name)
+	}
+
+	_ = append(got.X, -1)
+	if d.X[5] == -1 {
+		t.Errorf("appending to a Slice() overwrote the original")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Slice() out of range did not panic")
+		}
+	}()
+	d.Slice(5, 20)
+}
@@ -0,0 +1,179 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+
+	"github.com/rsned/stats/descriptive"
+)
+
+// Summary holds the descriptive statistics of a Dataset, the figures quoted
+// in the descriptions of datasets such as Anscombe's Quartet.
+//
+// Statistics that are undefined for the data, such as the variance of
+// fewer than two values, are NaN.
+type Summary struct {
+	// N is the number of pairs that are not missing.
+	N int
+	// MeanX and MeanY are the arithmetic means of the series.
+	MeanX float64
+	MeanY float64
+	// VarianceX and VarianceY are the sample variances of the series, with
+	// n-1 in the denominator.
+	VarianceX float64
+	VarianceY float64
+	// StdDevX and StdDevY are the sample standard deviations of the series.
+	StdDevX float64
+	StdDevY float64
+	// MinX, MaxX, MinY and MaxY are the smallest and largest values of the
+	// series.
+	MinX float64
+	MaxX float64
+	MinY float64
+	MaxY float64
+	// Correlation is Pearson's correlation coefficient between X and Y.
+	Correlation float64
+}
+
+// Summary returns the descriptive statistics of the dataset. Missing pairs,
+// as reported by IsMissing, are skipped, as they are by the methods giving
+// the statistics one at a time.
+func (d Dataset) Summary() Summary {
+	p := d.DropMissing()
+	minX, maxX := minMax(p.X)
+	minY, maxY := minMax(p.Y)
+	varX, varY := variance(p.X), variance(p.Y)
+
+	return Summary{
+		N:           len(p.X),
+		MeanX:       mean(p.X),
+		MeanY:       mean(p.Y),
+		VarianceX:   varX,
+		VarianceY:   varY,
+		StdDevX:     math.Sqrt(varX),
+		StdDevY:     math.Sqrt(varY),
+		MinX:        minX,
+		MaxX:        maxX,
+		MinY:        minY,
+		MaxY:        maxY,
+		Correlation: pearson(p.X, p.Y),
+	}
+}
+
+// MeanX returns the arithmetic mean of X over the pairs that are not
+// missing, or NaN if there are none.
+func (d Dataset) MeanX() float64 {
+	return mean(d.DropMissing().X)
+}
+
+// MeanY returns the arithmetic mean of Y over the pairs that are not
+// missing, or NaN if there are none.
+func (d Dataset) MeanY() float64 {
+	return mean(d.DropMissing().Y)
+}
+
+// VarianceX returns the sample variance of X over the pairs that are not
+// missing, or NaN if there are fewer than two.
+func (d Dataset) VarianceX() float64 {
+	return variance(d.DropMissing().X)
+}
+
+// VarianceY returns the sample variance of Y over the pairs that are not
+// missing, or NaN if there are fewer than two.
+func (d Dataset) VarianceY() float64 {
+	return variance(d.DropMissing().Y)
+}
+
+// StdDevX returns the sample standard deviation of X over the pairs that
+// are not missing, or NaN if there are fewer than two.
+func (d Dataset) StdDevX() float64 {
+	return math.Sqrt(d.VarianceX())
+}
+
+// StdDevY returns the sample standard deviation of Y over the pairs that
+// are not missing, or NaN if there are fewer than two.
+func (d Dataset) StdDevY() float64 {
+	return math.Sqrt(d.VarianceY())
+}
+
+// MinMaxX returns the smallest and largest values of X over the pairs that
+// are not missing, or NaN for both if there are none.
+func (d Dataset) MinMaxX() (float64, float64) {
+	return minMax(d.DropMissing().X)
+}
+
+// MinMaxY returns the smallest and largest values of Y over the pairs that
+// are not missing, or NaN for both if there are none.
+func (d Dataset) MinMaxY() (float64, float64) {
+	return minMax(d.DropMissing().Y)
+}
+
+// mean returns the arithmetic mean of v, or NaN if v is empty.
+func mean(v []float64) float64 {
+	m, err := descriptive.Mean(v)
+	if err != nil {
+		return math.NaN()
+	}
+
+	return m
+}
+
+// variance returns the sample variance of v, or NaN if v has fewer than
+// two values.
+func variance(v []float64) float64 {
+	s, err := descriptive.Variance(v)
+	if err != nil {
+		return math.NaN()
+	}
+
+	return s
+}
+
+// minMax returns the smallest and largest values of v, or NaN for both if v
+// is empty. A NaN in v makes both results NaN.
+func minMax(v []float64) (float64, float64) {
+	lo, err := descriptive.Min(v)
+	if err != nil {
+		return math.NaN(), math.NaN()
+	}
+	hi, _ := descriptive.Max(v)
+
+	return lo, hi
+}
+
+// pearson returns Pearson's correlation between x and y, or NaN if the
+// series differ in length, have fewer than two values or either is
+// constant. The correlation package imports this one, so its Pearson
+// cannot be used here.
+func pearson(x, y []float64) float64 {
+	if len(x) != len(y) || len(x) < 2 {
+		return math.NaN()
+	}
+	mx, my := mean(x), mean(y)
+	var sxx, syy, sxy float64
+	for i := range x {
+		dx := x[i] - mx
+		dy := y[i] - my
+		sxx += dx * dx
+		syy += dy * dy
+		sxy += dx * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return math.NaN()
+	}
+
+	return sxy / math.Sqrt(sxx*syy)
+}
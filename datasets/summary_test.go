@@ -0,0 +1,150 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSummaryAnscombe(t *testing.T) {
+	// The figures quoted in the descriptions of every Anscombe dataset.
+	for _, d := range AnscombeQuartet.Data {
+		t.Run(d.Name, func(t *testing.T) {
+			s := d.Summary()
+			checks := []struct {
+				name string
+				got  float64
+				want float64
+				tol  float64
+			}{
+				{name: "MeanX", got: s.MeanX, want: 9, tol: 1e-12},
+				{name: "MeanY", got: s.MeanY, want: 7.50, tol: 0.01},
+				{name: "VarianceX", got: s.VarianceX, want: 11, tol: 1e-12},
+				{name: "VarianceY", got: s.VarianceY, want: 4.125, tol: 0.005},
+				{name: "StdDevX", got: s.StdDevX, want: math.Sqrt(11), tol: 1e-12},
+				{name: "Correlation", got: s.Correlation, want: 0.816, tol: 0.001},
+			}
+			for _, c := range checks {
+				if math.Abs(c.got-c.want) > c.tol {
+					t.Errorf("%s = %v, want %v ± %v", c.name, c.got, c.want, c.tol)
+				}
+			}
+			if s.N != 11 {
+				t.Errorf("N = %d, want 11", s.N)
+			}
+		})
+	}
+}
+
+func TestSummaryMethods(t *testing.T) {
+	d := Dataset{
+		Name:        "small",
+		Description: "",
+		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           []float64{2, -1, 5, 3},
+		Y:           []float64{4, 4, 4, 4},
+		Valid:       nil,
+	}
+
+	if got := d.MeanX(); got != 2.25 {
+		t.Errorf("MeanX() = %v, want 2.25", got)
+	}
+	if got := d.MeanY(); got != 4 {
+		t.Errorf("MeanY() = %v, want 4", got)
+	}
+	// Deviations from 2.25 are -0.25, -3.25, 2.75, 0.75.
+	if got, want := d.VarianceX(), 18.75/3; math.Abs(got-want) > 1e-12 {
+		t.Errorf("VarianceX() = %v, want %v", got, want)
+	}
+	if got := d.VarianceY(); got != 0 {
+		t.Errorf("VarianceY() = %v, want 0", got)
+	}
+	if got := d.StdDevY(); got != 0 {
+		t.Errorf("StdDevY() = %v, want 0", got)
+	}
+	if lo, hi := d.MinMaxX(); lo != -1 || hi != 5 {
+		t.Errorf("MinMaxX() = %v, %v, want -1, 5", lo, hi)
+	}
+	if lo, hi := d.MinMaxY(); lo != 4 || hi != 4 {
+		t.Errorf("MinMaxY() = %v, %v, want 4, 4", lo, hi)
+	}
+	// Y is constant, so the correlation is undefined.
+	if got := d.Summary().Correlation; !math.IsNaN(got) {
+		t.Errorf("Summary().Correlation = %v, want NaN", got)
+	}
+}
+
+func TestSummaryUndefined(t *testing.T) {
+	empty := Dataset{Name: "empty", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: nil, Y: nil, Valid: nil}
+	s := empty.Summary()
+	for name, v := range map[string]float64{
+		"MeanX":       s.MeanX,
+		"VarianceY":   s.VarianceY,
+		"StdDevX":     s.StdDevX,
+		"MinX":        s.MinX,
+		"MaxY":        s.MaxY,
+		"Correlation": s.Correlation,
+	} {
+		if !math.IsNaN(v) {
+			t.Errorf("empty %s = %v, want NaN", name, v)
+		}
+	}
+	if s.N != 0 {
+		t.Errorf("empty N = %d, want 0", s.N)
+	}
+
+	one := Dataset{Name: "one", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{3}, Y: []float64{4}, Valid: nil}
+	if got := one.MeanX(); got != 3 {
+		t.Errorf("MeanX() of one value = %v, want 3", got)
+	}
+	if got := one.VarianceX(); !math.IsNaN(got) {
+		t.Errorf("VarianceX() of one value = %v, want NaN", got)
+	}
+}
+
+func TestSummaryMissing(t *testing.T) {
+	// The pair marked missing and the one with a NaN are skipped, leaving
+	// the pairs of TestSummaryMethods.
+	d := Dataset{
+		Name:        "gaps",
+		Description: "",
+		Attribution: "",
+		XLabel:      "",
+		YLabel:      "",
+		XUnits:      "",
+		YUnits:      "",
+		X:           []float64{2, 100, -1, 5, math.NaN(), 3},
+		Y:           []float64{4, -100, 4, 4, 4, 4},
+		Valid:       []bool{true, false, true, true, true, true},
+	}
+	s := d.Summary()
+	if s.N != 4 || s.MeanX != 2.25 || s.MinY != 4 || s.MaxX != 5 {
+		t.Errorf("Summary() = %+v, want N 4, MeanX 2.25, MinY 4 and MaxX 5", s)
+	}
+	if got := d.MeanY(); got != 4 {
+		t.Errorf("MeanY() = %v, want 4", got)
+	}
+	if lo, hi := d.MinMaxX(); lo != -1 || hi != 5 {
+		t.Errorf("MinMaxX() = %v, %v, want -1, 5", lo, hi)
+	}
+	if got, want := d.VarianceX(), 18.75/3; math.Abs(got-want) > 1e-12 {
+		t.Errorf("VarianceX() = %v, want %v", got, want)
+	}
+}
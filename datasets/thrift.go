@@ -0,0 +1,244 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The type codes of the Thrift compact protocol.
+const (
+	thriftBoolTrue  = 1
+	thriftBoolFalse = 2
+	thriftByte      = 3
+	thriftI16       = 4
+	thriftI32       = 5
+	thriftI64       = 6
+	thriftDouble    = 7
+	thriftBinary    = 8
+	thriftList      = 9
+	thriftSet       = 10
+	thriftMap       = 11
+	thriftStruct    = 12
+)
+
+// thriftMaxDepth bounds the nesting of skipped values, so that corrupt
+// input cannot exhaust the stack.
+const thriftMaxDepth = 64
+
+// errThriftCorrupt is the error for input that is not valid Thrift.
+var errThriftCorrupt = errors.New("corrupt thrift data")
+
+// thriftReader decodes values in the Thrift compact protocol, which Parquet
+// uses for its file and page metadata. The first error is kept in err, and
+// after it every read returns a zero value, so callers check err once at
+// the end.
+type thriftReader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+// fail records err unless an error has already been recorded.
+func (t *thriftReader) fail(err error) {
+	if t.err == nil {
+		t.err = err
+	}
+}
+
+// next returns the next n bytes of input.
+func (t *thriftReader) next(n int) []byte {
+	if t.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(t.buf)-t.pos {
+		t.fail(errThriftCorrupt)
+
+		return nil
+	}
+	b := t.buf[t.pos : t.pos+n]
+	t.pos += n
+
+	return b
+}
+
+// readByte reads a single byte.
+func (t *thriftReader) readByte() byte {
+	b := t.next(1)
+	if b == nil {
+		return 0
+	}
+
+	return b[0]
+}
+
+// uvarint reads an unsigned varint.
+func (t *thriftReader) uvarint() uint64 {
+	if t.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(t.buf[t.pos:])
+	if n <= 0 {
+		t.fail(errThriftCorrupt)
+
+		return 0
+	}
+	t.pos += n
+
+	return v
+}
+
+// varint reads a zigzag encoded signed varint, the form of i16, i32 and
+// i64 values.
+func (t *thriftReader) varint() int64 {
+	v := t.uvarint()
+
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+// i32 reads an i32 value.
+func (t *thriftReader) i32() int32 {
+	v := t.varint()
+	if int64(int32(v)) != v {
+		t.fail(errThriftCorrupt)
+
+		return 0
+	}
+
+	return int32(v)
+}
+
+// readBinary reads a binary or string value. The result aliases the input.
+func (t *thriftReader) readBinary() []byte {
+	n := t.uvarint()
+	if n > uint64(len(t.buf)) {
+		t.fail(errThriftCorrupt)
+
+		return nil
+	}
+
+	return t.next(int(n))
+}
+
+// listHeader reads the header of a list or set, returning the element type
+// and the number of elements.
+func (t *thriftReader) listHeader() (byte, int) {
+	b := t.readByte()
+	n := uint64(b >> 4)
+	if n == 15 {
+		n = t.uvarint()
+	}
+	// Every element takes at least one byte, so a longer list is corrupt.
+	if n > uint64(len(t.buf)-t.pos) {
+		t.fail(errThriftCorrupt)
+
+		return 0, 0
+	}
+
+	return b & 0x0f, int(n)
+}
+
+// readStruct reads the fields of a struct up to its stop field. It calls
+// field with the id and type of each, which must either read the value and
+// return true or return false to have it skipped. A boolean field has no
+// value beyond its type, which is thriftBoolTrue or thriftBoolFalse.
+func (t *thriftReader) readStruct(field func(id int16, typ byte) bool) {
+	var last int16
+	for t.err == nil {
+		b := t.readByte()
+		if b == 0 {
+			return
+		}
+		typ := b & 0x0f
+		id := last + int16(b>>4)
+		if b>>4 == 0 {
+			id = int16(t.varint())
+		}
+		last = id
+		if !field(id, typ) {
+			t.skip(typ, 0)
+		}
+	}
+}
+
+// readList reads a list, calling elem with the type of each element, which
+// must read it.
+func (t *thriftReader) readList(elem func(typ byte)) {
+	typ, n := t.listHeader()
+	for range n {
+		if t.err != nil {
+			return
+		}
+		elem(typ)
+	}
+}
+
+// skip skips a field value of type typ nested depth values deep.
+func (t *thriftReader) skip(typ byte, depth int) {
+	if depth > thriftMaxDepth {
+		t.fail(errThriftCorrupt)
+
+		return
+	}
+	switch typ {
+	case thriftBoolTrue, thriftBoolFalse:
+	case thriftByte:
+		t.next(1)
+	case thriftI16, thriftI32, thriftI64:
+		t.uvarint()
+	case thriftDouble:
+		t.next(8)
+	case thriftBinary:
+		t.readBinary()
+	case thriftList, thriftSet:
+		elem, n := t.listHeader()
+		for range n {
+			if t.err != nil {
+				return
+			}
+			t.skipElem(elem, depth+1)
+		}
+	case thriftMap:
+		n := t.uvarint()
+		if n == 0 {
+			return
+		}
+		kv := t.readByte()
+		for i := uint64(0); i < n && t.err == nil; i++ {
+			t.skipElem(kv>>4, depth+1)
+			t.skipElem(kv&0x0f, depth+1)
+		}
+	case thriftStruct:
+		t.readStruct(func(_ int16, typ byte) bool {
+			t.skip(typ, depth+1)
+
+			return true
+		})
+	default:
+		t.fail(errThriftCorrupt)
+	}
+}
+
+// skipElem skips an element of a list, set or map, where, unlike in a
+// field, a boolean takes a byte.
+func (t *thriftReader) skipElem(typ byte, depth int) {
+	if typ == thriftBoolTrue || typ == thriftBoolFalse {
+		t.next(1)
+
+		return
+	}
+	t.skip(typ, depth)
+}
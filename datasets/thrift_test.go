@@ -0,0 +1,98 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestThriftReadStruct(t *testing.T) {
+	// A struct holding, in order: field 1 i32 -3, field 2 binary "hi",
+	// field 3 true, field 20 a list of 2 bools, field 21 a map of 1 i32
+	// to a string, field 22 a struct holding field 1 double, field 23 i64
+	// 300, and a stop.
+	input := "\x15\x05" +
+		"\x18\x02hi" +
+		"\x11" +
+		"\x09\x28\x21\x01\x02" +
+		"\x1b\x01\x58\x02\x01z" +
+		"\x1c\x17\x00\x00\x00\x00\x00\x00\xf0\x3f\x00" +
+		"\x16\xd8\x04" +
+		"\x00"
+	tr := &thriftReader{buf: []byte(input), pos: 0, err: nil}
+
+	var (
+		i32  int32
+		str  string
+		flag bool
+		i64  int64
+		ids  []int16
+	)
+	tr.readStruct(func(id int16, typ byte) bool {
+		ids = append(ids, id)
+		switch {
+		case id == 1 && typ == thriftI32:
+			i32 = tr.i32()
+		case id == 2 && typ == thriftBinary:
+			str = string(tr.readBinary())
+		case id == 3:
+			flag = typ == thriftBoolTrue
+		case id == 23 && typ == thriftI64:
+			i64 = tr.varint()
+		default:
+			return false
+		}
+
+		return true
+	})
+	if tr.err != nil {
+		t.Fatalf("readStruct() unexpected error: %v", tr.err)
+	}
+	if tr.pos != len(input) {
+		t.Errorf("readStruct() read %d bytes, want %d", tr.pos, len(input))
+	}
+	if i32 != -3 || str != "hi" || !flag || i64 != 300 {
+		t.Errorf("readStruct() = %d, %q, %v, %d, want -3, \"hi\", true, 300", i32, str, flag, i64)
+	}
+	if want := []int16{1, 2, 3, 20, 21, 22, 23}; !slices.Equal(ids, want) {
+		t.Errorf("readStruct() field ids = %v, want %v", ids, want)
+	}
+}
+
+func TestThriftReadStructCorrupt(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "empty", input: ""},
+		{name: "no stop", input: "\x15\x05"},
+		{name: "truncated binary", input: "\x18\x05hi"},
+		{name: "long list", input: "\x19\xf5\xff\xff\xff\x0f"},
+		{name: "bad type", input: "\x1d\x00"},
+		{name: "deep nesting", input: strings.Repeat("\x1c", thriftMaxDepth+2)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tr := &thriftReader{buf: []byte(test.input), pos: 0, err: nil}
+			tr.readStruct(func(int16, byte) bool { return false })
+			if tr.err == nil {
+				t.Errorf("readStruct(%q) expected error but got none", test.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// The transforms below return a new Dataset, or Frame, with every series
+// transformed, so that preprocessing before a correlation can be chained:
+//
+//	d = d.Log().Standardize()
+//
+// A value outside the domain of a transform becomes NaN, as in math.Log,
+// and so is missing in the result. NaN values stay NaN and are ignored by
+// the transforms that depend on the whole series. The metadata and Valid
+// mask are copied; the labels of a Dataset name the transform, as in
+// "log(height)", and its units are cleared unless the values are still in
+// them.
+
+// Standardize returns a copy of the dataset with each series converted to
+// z-scores, by subtracting its mean and dividing by its sample standard
+// deviation. A series with fewer than 2 values, or no spread, becomes NaN.
+func (d Dataset) Standardize() Dataset {
+	return d.transform("z", standardize)
+}
+
+// Log returns a copy of the dataset with the natural logarithm of every
+// value. Zero becomes -Inf and negative values NaN.
+func (d Dataset) Log() Dataset {
+	return d.transform("log", mapValues(math.Log))
+}
+
+// Log1p returns a copy of the dataset with the natural logarithm of 1 plus
+// every value, which is accurate near zero and maps zero counts to zero.
+// Values less than -1 become NaN.
+func (d Dataset) Log1p() Dataset {
+	return d.transform("log1p", mapValues(math.Log1p))
+}
+
+// BoxCox returns a copy of the dataset with the Box-Cox power transform
+// with parameter lambda applied to every value:
+//
+//	(v^lambda - 1) / lambda  if lambda != 0
+//	log(v)                   if lambda == 0
+//
+// Values that are not positive become NaN.
+func (d Dataset) BoxCox(lambda float64) Dataset {
+	return d.transform("boxcox", mapValues(boxCox(lambda)))
+}
+
+// Rank returns a copy of the dataset with every value replaced by its rank
+// within its series, counting from 1, with tied values given the mean of
+// the ranks they span. Pearson correlation of the ranks is Spearman
+// correlation of the values.
+func (d Dataset) Rank() Dataset {
+	return d.transform("rank", rank)
+}
+
+// Detrend returns a copy of the dataset with the least squares line
+// through each series, taken in order as a time series, subtracted from
+// it. Correlating two series that both trend over time finds the shared
+// trend rather than any relationship between them; the residuals left by
+// Detrend do not have that problem. A series with fewer than 2 values
+// becomes NaN.
+func (d Dataset) Detrend() Dataset {
+	out := d.transform("detrend", detrend)
+	out.XUnits, out.YUnits = d.XUnits, d.YUnits
+
+	return out
+}
+
+// Difference returns a dataset of the lag k differences of each series,
+// v[i+k] - v[i], which removes a trend of any shape that changes slowly
+// compared with k, and with k equal to the period, a seasonal pattern. The
+// result has k fewer pairs, none if the series have k or fewer, and pair i
+// of it is marked missing in Valid if pair i or i+k is.
+//
+// It panics if k is less than 1.
+func (d Dataset) Difference(k int) Dataset {
+	if k < 1 {
+		panic("datasets: difference lag must be at least 1")
+	}
+
+	out := d.transform("diff", difference(k))
+	out.XUnits, out.YUnits = d.XUnits, d.YUnits
+	if d.Valid != nil {
+		out.Valid = make([]bool, max(0, len(d.Valid)-k))
+		for i := range out.Valid {
+			out.Valid[i] = d.Valid[i] && d.Valid[i+k]
+		}
+	}
+
+	return out
+}
+
+// Standardize returns a Frame with every column converted to z-scores, as
+// by Dataset.Standardize.
+func (f *Frame) Standardize() *Frame {
+	return f.transform(standardize)
+}
+
+// Log returns a Frame with the natural logarithm of every value, as by
+// Dataset.Log.
+func (f *Frame) Log() *Frame {
+	return f.transform(mapValues(math.Log))
+}
+
+// Log1p returns a Frame with the natural logarithm of 1 plus every value,
+// as by Dataset.Log1p.
+func (f *Frame) Log1p() *Frame {
+	return f.transform(mapValues(math.Log1p))
+}
+
+// BoxCox returns a Frame with the Box-Cox power transform applied to every
+// value, as by Dataset.BoxCox.
+func (f *Frame) BoxCox(lambda float64) *Frame {
+	return f.transform(mapValues(boxCox(lambda)))
+}
+
+// Rank returns a Frame with every value replaced by its rank within its
+// column, as by Dataset.Rank.
+func (f *Frame) Rank() *Frame {
+	return f.transform(rank)
+}
+
+// Detrend returns a Frame with the linear trend removed from every column,
+// as by Dataset.Detrend.
+func (f *Frame) Detrend() *Frame {
+	return f.transform(detrend)
+}
+
+// Difference returns a Frame of the lag k differences of every column, as
+// by Dataset.Difference.
+//
+// It panics if k is less than 1.
+func (f *Frame) Difference(k int) *Frame {
+	if k < 1 {
+		panic("datasets: difference lag must be at least 1")
+	}
+
+	return f.transform(difference(k))
+}
+
+// transform returns a copy of the dataset with fn applied to each series
+// and the labels marked with the name of the transform.
+func (d Dataset) transform(name string, fn func([]float64) []float64) Dataset {
+	return Dataset{
+		Name:        d.Name,
+		Description: d.Description,
+		Attribution: d.Attribution,
+		XLabel:      transformLabel(name, d.XLabel),
+		YLabel:      transformLabel(name, d.YLabel),
+		XUnits:      "",
+		YUnits:      "",
+		X:           fn(d.X),
+		Y:           fn(d.Y),
+		Valid:       slices.Clone(d.Valid),
+	}
+}
+
+// transformLabel returns label marked with the name of a transform, or the
+// empty string if there is no label.
+func transformLabel(name, label string) string {
+	if label == "" {
+		return ""
+	}
+
+	return name + "(" + label + ")"
+}
+
+// transform returns a Frame of the same columns with fn applied to each.
+func (f *Frame) transform(fn func([]float64) []float64) *Frame {
+	out := &Frame{
+		Name:        f.Name,
+		Description: f.Description,
+		Attribution: f.Attribution,
+		names:       slices.Clone(f.names),
+		cols:        make([][]float64, len(f.cols)),
+		index:       make(map[string]int, len(f.cols)),
+	}
+	for i, name := range f.names {
+		out.index[name] = i
+		out.cols[i] = fn(f.cols[i])
+	}
+
+	return out
+}
+
+// mapValues returns a transform that applies fn to each value.
+func mapValues(fn func(float64) float64) func([]float64) []float64 {
+	return func(vals []float64) []float64 {
+		out := make([]float64, len(vals))
+		for i, v := range vals {
+			out[i] = fn(v)
+		}
+
+		return out
+	}
+}
+
+// boxCox returns the Box-Cox transform with parameter lambda.
+func boxCox(lambda float64) func(float64) float64 {
+	return func(v float64) float64 {
+		switch {
+		case !(v > 0):
+			return math.NaN()
+		case lambda == 0:
+			return math.Log(v)
+		default:
+			return math.Expm1(lambda*math.Log(v)) / lambda
+		}
+	}
+}
+
+// standardize returns the z-scores of vals, ignoring NaN values.
+func standardize(vals []float64) []float64 {
+	var n, mean, m2 float64
+	for _, v := range vals {
+		if math.IsNaN(v) {
+			continue
+		}
+		n++
+		delta := v - mean
+		mean += delta / n
+		m2 += delta * (v - mean)
+	}
+	sd := math.NaN()
+	if n >= 2 && m2 > 0 {
+		sd = math.Sqrt(m2 / (n - 1))
+	}
+
+	out := make([]float64, len(vals))
+	for i, v := range vals {
+		out[i] = (v - mean) / sd
+	}
+
+	return out
+}
+
+// rank returns the mid-ranks of vals, leaving NaN values as NaN.
+func rank(vals []float64) []float64 {
+	order := make([]int, 0, len(vals))
+	for i, v := range vals {
+		if !math.IsNaN(v) {
+			order = append(order, i)
+		}
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return cmp.Compare(vals[a], vals[b])
+	})
+
+	out := make([]float64, len(vals))
+	for i, v := range vals {
+		if math.IsNaN(v) {
+			out[i] = v
+		}
+	}
+	for i := 0; i < len(order); {
+		j := i + 1
+		for j < len(order) && vals[order[j]] == vals[order[i]] {
+			j++
+		}
+		// Positions i to j-1 hold ranks i+1 to j.
+		mid := float64(i+j+1) / 2
+		for _, k := range order[i:j] {
+			out[k] = mid
+		}
+		i = j
+	}
+
+	return out
+}
+
+// detrend returns the residuals of vals from the least squares line against
+// position, ignoring NaN values.
+func detrend(vals []float64) []float64 {
+	var n, meanT, meanV float64
+	for i, v := range vals {
+		if math.IsNaN(v) {
+			continue
+		}
+		n++
+		meanT += (float64(i) - meanT) / n
+		meanV += (v - meanV) / n
+	}
+	var stt, stv float64
+	for i, v := range vals {
+		if math.IsNaN(v) {
+			continue
+		}
+		dt := float64(i) - meanT
+		stt += dt * dt
+		stv += dt * (v - meanV)
+	}
+	slope := math.NaN()
+	if stt > 0 {
+		slope = stv / stt
+	}
+
+	out := make([]float64, len(vals))
+	for i, v := range vals {
+		out[i] = v - meanV - slope*(float64(i)-meanT)
+	}
+
+	return out
+}
+
+// difference returns a transform to the lag k differences of the values.
+func difference(k int) func([]float64) []float64 {
+	return func(vals []float64) []float64 {
+		out := make([]float64, max(0, len(vals)-k))
+		for i := range out {
+			out[i] = vals[i+k] - vals[i]
+		}
+
+		return out
+	}
+}
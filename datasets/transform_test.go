@@ -0,0 +1,267 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+// closeNaN reports whether a and b are equal to within 1e-12, treating NaN
+// values as equal.
+func closeNaN(a, b []float64) bool {
+	return slices.EqualFunc(a, b, func(u, v float64) bool {
+		return math.Abs(u-v) <= 1e-12 || (math.IsNaN(u) && math.IsNaN(v)) || u == v
+	})
+}
+
+func TestDatasetTransforms(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name  string
+		fn    func(Dataset) Dataset
+		x     []float64
+		wantX []float64
+	}{
+		{
+			name:  "standardize",
+			fn:    Dataset.Standardize,
+			x:     []float64{1, 2, 3, nan},
+			wantX: []float64{-1, 0, 1, nan},
+		},
+		{
+			name:  "standardize constant",
+			fn:    Dataset.Standardize,
+			x:     []float64{4, 4, 4},
+			wantX: []float64{nan, nan, nan},
+		},
+		{
+			name:  "log",
+			fn:    Dataset.Log,
+			x:     []float64{1, math.E, 0, -1},
+			wantX: []float64{0, 1, math.Inf(-1), nan},
+		},
+		{
+			name:  "log1p",
+			fn:    Dataset.Log1p,
+			x:     []float64{0, math.E - 1, -1, -2},
+			wantX: []float64{0, 1, math.Inf(-1), nan},
+		},
+		{
+			name:  "box-cox square root",
+			fn:    func(d Dataset) Dataset { return d.BoxCox(0.5) },
+			x:     []float64{1, 4, 9, 0},
+			wantX: []float64{0, 2, 4, nan},
+		},
+		{
+			name:  "box-cox log",
+			fn:    func(d Dataset) Dataset { return d.BoxCox(0) },
+			x:     []float64{1, math.E, -3},
+			wantX: []float64{0, 1, nan},
+		},
+		{
+			name:  "rank with ties",
+			fn:    Dataset.Rank,
+			x:     []float64{30, 10, nan, 20, 10, 40},
+			wantX: []float64{4, 1.5, nan, 3, 1.5, 5},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			y := slices.Clone(test.x)
+			d := Dataset{Name: "lab", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: test.x, Y: y, Valid: nil}
+			got := test.fn(d)
+			if !closeNaN(got.X, test.wantX) || !closeNaN(got.Y, test.wantX) {
+				t.Errorf("transform = %v, %v, want %v for both", got.X, got.Y, test.wantX)
+			}
+			if !closeNaN(d.X, y) {
+				t.Errorf("transform changed the original to %v", d.X)
+			}
+			if got.Name != "lab" {
+				t.Errorf("transform name = %q, want lab", got.Name)
+			}
+		})
+	}
+}
+
+func TestDatasetTransformChain(t *testing.T) {
+	d := Dataset{
+		Name:        "",
+		Description: "",
+		Attribution: "",
+		XLabel:      "dose",
+		YLabel:      "",
+		XUnits:      "mg",
+		YUnits:      "ml",
+		X:           []float64{1, 10, 100},
+		Y:           []float64{1, 2, 3},
+		Valid:       []bool{true, false, true},
+	}
+	got := d.Log().Standardize()
+	if !closeNaN(got.X, []float64{-1, 0, 1}) {
+		t.Errorf("Log().Standardize() X = %v, want [-1 0 1]", got.X)
+	}
+	if got.XLabel != "z(log(dose))" || got.YLabel != "" || got.XUnits != "" || got.YUnits != "" {
+		t.Errorf("Log().Standardize() labels = %q, %q, %q, %q, want z(log(dose)) and no units",
+			got.XLabel, got.YLabel, got.XUnits, got.YUnits)
+	}
+	got.Valid[0] = false
+	if !d.Valid[0] {
+		t.Errorf("modifying the Valid of a transform changed the original")
+	}
+}
+
+func TestFrameTransforms(t *testing.T) {
+	f := testFrame(t)
+	got := f.Rank()
+	if got.Name != "test" || !slices.Equal(got.Names(), f.Names()) {
+		t.Errorf("Rank() = %q with columns %v, want %q with %v", got.Name, got.Names(), "test", f.Names())
+	}
+	for _, name := range f.Names() {
+		col, _ := got.Column(name)
+		orig, _ := f.Column(name)
+		if want := rank(orig); !closeNaN(col, want) {
+			t.Errorf("Rank() column %s = %v, want %v", name, col, want)
+		}
+	}
+
+	z := f.Standardize()
+	for _, col := range z.Columns() {
+		var sum float64
+		for _, v := range col {
+			sum += v
+		}
+		if math.Abs(sum) > 1e-12 {
+			t.Errorf("Standardize() column %v has mean %v, want 0", col, sum/float64(len(col)))
+		}
+	}
+
+	for name, got := range map[string]*Frame{"Log": f.Log(), "Log1p": f.Log1p(), "BoxCox": f.BoxCox(1)} {
+		if got.NumColumns() != f.NumColumns() || got.NumRows() != f.NumRows() {
+			t.Errorf("%s() has %d columns of %d rows, want %d of %d", name, got.NumColumns(), got.NumRows(), f.NumColumns(), f.NumRows())
+		}
+	}
+}
+
+func TestDatasetDetrend(t *testing.T) {
+	nan := math.NaN()
+	d := Dataset{
+		Name:        "",
+		Description: "",
+		Attribution: "",
+		XLabel:      "sales",
+		YLabel:      "",
+		XUnits:      "USD",
+		YUnits:      "",
+		X:           []float64{1, 3, 5, 7, 9},
+		Y:           []float64{2, 1, nan, 1, 2},
+		Valid:       nil,
+	}
+	got := d.Detrend()
+	if !closeNaN(got.X, []float64{0, 0, 0, 0, 0}) {
+		t.Errorf("Detrend() X = %v, want zeros", got.X)
+	}
+	if want := []float64{0.5, -0.5, nan, -0.5, 0.5}; !closeNaN(got.Y, want) {
+		t.Errorf("Detrend() Y = %v, want %v", got.Y, want)
+	}
+	if got.XLabel != "detrend(sales)" || got.XUnits != "USD" {
+		t.Errorf("Detrend() X label = %q in %q, want detrend(sales) in USD", got.XLabel, got.XUnits)
+	}
+
+	one := Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{3}, Y: []float64{4}, Valid: nil}
+	if got := one.Detrend(); !math.IsNaN(got.X[0]) {
+		t.Errorf("Detrend() of one value = %v, want NaN", got.X)
+	}
+}
+
+func TestDatasetDifference(t *testing.T) {
+	tests := []struct {
+		name      string
+		k         int
+		x, y      []float64
+		valid     []bool
+		wantX     []float64
+		wantY     []float64
+		wantValid []bool
+	}{
+		{
+			name:      "lag 1",
+			k:         1,
+			x:         []float64{1, 4, 9, 16},
+			y:         []float64{5, 4, 3, 2},
+			valid:     nil,
+			wantX:     []float64{3, 5, 7},
+			wantY:     []float64{-1, -1, -1},
+			wantValid: nil,
+		},
+		{
+			name:      "seasonal lag",
+			k:         2,
+			x:         []float64{1, 5, 2, 6, 3},
+			y:         []float64{0, 0, 1, 1, 2},
+			valid:     []bool{true, false, true, true, true},
+			wantX:     []float64{1, 1, 1},
+			wantY:     []float64{1, 1, 1},
+			wantValid: []bool{true, false, true},
+		},
+		{
+			name:      "lag too long",
+			k:         3,
+			x:         []float64{1, 2},
+			y:         []float64{3, 4},
+			valid:     []bool{true, true},
+			wantX:     []float64{},
+			wantY:     []float64{},
+			wantValid: []bool{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := Dataset{Name: "", Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: test.x, Y: test.y, Valid: test.valid}
+			got := d.Difference(test.k)
+			if !slices.Equal(got.X, test.wantX) || !slices.Equal(got.Y, test.wantY) {
+				t.Errorf("Difference(%d) = %v, %v, want %v, %v", test.k, got.X, got.Y, test.wantX, test.wantY)
+			}
+			if !slices.Equal(got.Valid, test.wantValid) || (got.Valid == nil) != (test.wantValid == nil) {
+				t.Errorf("Difference(%d) Valid = %v, want %v", test.k, got.Valid, test.wantValid)
+			}
+		})
+	}
+}
+
+func TestDatasetDifferencePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Difference(0) did not panic")
+		}
+	}()
+	Dataset{}.Difference(0)
+}
+
+func TestFrameDetrendDifference(t *testing.T) {
+	f := testFrame(t)
+	for _, col := range f.Detrend().Columns() {
+		if !closeNaN(col, []float64{0, 0, 0, 0}) {
+			t.Errorf("Detrend() of a linear column = %v, want zeros", col)
+		}
+	}
+	got := f.Difference(2)
+	if col, _ := got.Column("b"); got.NumRows() != 2 || !slices.Equal(col, []float64{20, 20}) {
+		t.Errorf("Difference(2) column b = %v, want [20 20]", col)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+// MinPairs is the fewest pairs a Dataset needs to pass Validate, the
+// minimum for any correlation coefficient to be defined.
+const MinPairs = 2
+
+// The reasons a Dataset can fail validation. The errors returned by
+// Validate wrap one of these, so they can be told apart with errors.Is.
+var (
+	// ErrLengthMismatch means X and Y, or Valid, have different lengths.
+	ErrLengthMismatch = errors.New("x and y have different lengths")
+	// ErrTooShort means the dataset has fewer than MinPairs pairs.
+	ErrTooShort = errors.New("too few pairs")
+	// ErrNonFinite means a value is NaN or ±Inf.
+	ErrNonFinite = errors.New("value is not finite")
+	// ErrMissing means Valid marks a pair as missing.
+	ErrMissing = errors.New("pair is missing")
+)
+
+// ValidationError describes why a Dataset failed Validate.
+type ValidationError struct {
+	// Dataset is the name of the dataset.
+	Dataset string
+	// Series is "X" or "Y" for a problem with a single value, and empty
+	// otherwise.
+	Series string
+	// Index is the index of the offending value or pair, or -1 when the
+	// problem is not with a single pair.
+	Index int
+	// Err is the reason, one of ErrLengthMismatch, ErrTooShort,
+	// ErrNonFinite and ErrMissing.
+	Err error
+}
+
+// Error implements error.
+func (e *ValidationError) Error() string {
+	msg := "dataset"
+	if e.Dataset != "" {
+		msg += " " + strconv.Quote(e.Dataset)
+	}
+	msg += ": " + e.Err.Error()
+	switch {
+	case e.Series != "":
+		msg += " in " + e.Series + " at index " + strconv.Itoa(e.Index)
+	case e.Index >= 0:
+		msg += " at index " + strconv.Itoa(e.Index)
+	}
+
+	return msg
+}
+
+// Unwrap returns the reason for the failure.
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Validate checks that the dataset can be analysed: X and Y, and Valid if
+// set, have the same length, there are at least MinPairs pairs, no pair is
+// marked missing, and every value is finite. Use DropMissing first to
+// analyse the pairs that are present.
+//
+// It returns nil if the dataset is valid, and otherwise a *ValidationError
+// for the first problem found.
+func (d Dataset) Validate() error {
+	if len(d.X) != len(d.Y) || (d.Valid != nil && len(d.Valid) != len(d.X)) {
+		return &ValidationError{Dataset: d.Name, Series: "", Index: -1, Err: ErrLengthMismatch}
+	}
+	if len(d.X) < MinPairs {
+		return &ValidationError{Dataset: d.Name, Series: "", Index: -1, Err: ErrTooShort}
+	}
+	for i := range d.X {
+		if d.Valid != nil && !d.Valid[i] {
+			return &ValidationError{Dataset: d.Name, Series: "", Index: i, Err: ErrMissing}
+		}
+		if math.IsNaN(d.X[i]) || math.IsInf(d.X[i], 0) {
+			return &ValidationError{Dataset: d.Name, Series: "X", Index: i, Err: ErrNonFinite}
+		}
+		if math.IsNaN(d.Y[i]) || math.IsInf(d.Y[i], 0) {
+			return &ValidationError{Dataset: d.Name, Series: "Y", Index: i, Err: ErrNonFinite}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		x       []float64
+		y       []float64
+		valid   []bool
+		wantErr error
+		series  string
+		index   int
+	}{
+		{name: "valid", x: []float64{1, 2}, y: []float64{3, 4}, valid: nil, wantErr: nil, series: "", index: 0},
+		{name: "length mismatch", x: []float64{1, 2, 3}, y: []float64{3, 4}, valid: nil, wantErr: ErrLengthMismatch, series: "", index: -1},
+		{name: "empty", x: nil, y: nil, valid: nil, wantErr: ErrTooShort, series: "", index: -1},
+		{name: "one pair", x: []float64{1}, y: []float64{1}, valid: nil, wantErr: ErrTooShort, series: "", index: -1},
+		{name: "NaN in X", x: []float64{1, math.NaN(), 3}, y: []float64{1, 2, 3}, valid: nil, wantErr: ErrNonFinite, series: "X", index: 1},
+		{name: "mask length mismatch", x: []float64{1, 2}, y: []float64{3, 4}, valid: []bool{true}, wantErr: ErrLengthMismatch, series: "", index: -1},
+		{name: "masked pair", x: []float64{1, 2, 3}, y: []float64{3, 4, 5}, valid: []bool{true, false, true}, wantErr: ErrMissing, series: "", index: 1},
+		{name: "all pairs valid", x: []float64{1, 2}, y: []float64{3, 4}, valid: []bool{true, true}, wantErr: nil, series: "", index: 0},
+		{name: "Inf in Y", x: []float64{1, 2, 3}, y: []float64{1, 2, math.Inf(-1)}, valid: nil, wantErr: ErrNonFinite, series: "Y", index: 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := Dataset{Name: test.name, Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: test.x, Y: test.y, Valid: test.valid}
+			err := d.Validate()
+			if test.wantErr == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+
+				return
+			}
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("Validate() = %v, want %v", err, test.wantErr)
+			}
+			var ve *ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("Validate() = %T, want *ValidationError", err)
+			}
+			if ve.Series != test.series || ve.Index != test.index || ve.Dataset != test.name {
+				t.Errorf("Validate() = %+v, want series %q, index %d, dataset %q", ve, test.series, test.index, test.name)
+			}
+		})
+	}
+}
+
+func TestValidationErrorMessage(t *testing.T) {
+	err := &ValidationError{Dataset: "lab", Series: "Y", Index: 7, Err: ErrNonFinite}
+	if got, want := err.Error(), `dataset "lab": value is not finite in Y at index 7`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	err = &ValidationError{Dataset: "lab", Series: "", Index: 3, Err: ErrMissing}
+	if got, want := err.Error(), `dataset "lab": pair is missing at index 3`; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	err = &ValidationError{Dataset: "", Series: "", Index: -1, Err: ErrTooShort}
+	if got, want := err.Error(), "dataset: too few pairs"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestExampleDatasetsValid(t *testing.T) {
+	for _, c := range []Datasets{AnscombeQuartet, DatasaurusDozen} {
+		for _, d := range c.Data {
+			if err := d.Validate(); err != nil {
+				t.Errorf("%s: Validate() = %v", d.Name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,527 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// The largest row and column numbers of a worksheet.
+const (
+	xlsxMaxRows    = 1 << 20
+	xlsxMaxColumns = 1 << 14
+)
+
+// XLSXOption configures FromXLSX.
+type XLSXOption func(*xlsxOptions)
+
+// xlsxOptions holds the settings accumulated from a list of XLSXOption
+// values.
+type xlsxOptions struct {
+	sheet     string
+	cellRange string
+}
+
+// WithSheet selects the worksheet by name. The default is the first sheet
+// of the workbook.
+func WithSheet(name string) XLSXOption {
+	return func(o *xlsxOptions) {
+		o.sheet = name
+	}
+}
+
+// WithRange limits reading to a rectangle of cells given in A1 notation,
+// such as "B2:D50". Columns selected by position are then counted from the
+// first column of the range, and a header must be in its first row.
+func WithRange(ref string) XLSXOption {
+	return func(o *xlsxOptions) {
+		o.cellRange = ref
+	}
+}
+
+// xlsxRange is a rectangle of cells, with zero-based inclusive bounds.
+type xlsxRange struct {
+	row1, col1, row2, col2 int
+}
+
+// xlsxCell is the content of a worksheet cell.
+type xlsxCell struct {
+	// typ is the t attribute of the cell: "s" for a shared string, "str"
+	// and "inlineStr" for other strings, "b" for a boolean, "e" for an
+	// error and "n" or empty for a number.
+	typ string
+	// value is the text of the cell, with shared strings looked up. It is
+	// empty for a blank cell.
+	value string
+	blank bool
+}
+
+// xlsxWorkbook is the part of xl/workbook.xml that FromXLSX uses.
+type xlsxWorkbook struct {
+	Sheets []xlsxSheet `xml:"sheets>sheet"`
+}
+
+// xlsxSheet is an entry in the list of sheets of a workbook.
+type xlsxSheet struct {
+	Name string `xml:"name,attr"`
+	// ID is the relationship that gives the location of the worksheet.
+	ID string `xml:"id,attr"`
+}
+
+// xlsxRelationships is a relationships part, which gives the locations of
+// the parts that another part refers to.
+type xlsxRelationships struct {
+	Relationships []xlsxRelationship `xml:"Relationship"`
+}
+
+// xlsxRelationship is an entry of a relationships part.
+type xlsxRelationship struct {
+	ID     string `xml:"Id,attr"`
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+}
+
+// FromXLSX reads a Dataset from a worksheet of an Excel workbook in the
+// .xlsx format of the given size, taking X from column x and Y from column
+// y of every row:
+//
+//	f, err := os.Open("results.xlsx")
+//	...
+//	fi, err := f.Stat()
+//	...
+//	d, err := datasets.FromXLSX(f, fi.Size(), datasets.ColumnName("dose"), datasets.ColumnName("response"),
+//		datasets.WithSheet("Trial 2"), datasets.WithRange("B3:F40"))
+//
+// The first row is a header, as in FromCSV, if a column is selected by
+// name or either of the selected cells in it is not a number. Columns
+// selected by position count from column A, or from the first column of
+// the range. The Name of the Dataset is the name of the sheet, and XLabel
+// and YLabel are set from the header row, if there is one.
+//
+// Numbers are read as stored, so dates are Excel serial day numbers, and
+// text that holds a number is read as that number. Empty cells and error
+// values such as #N/A are read as NaN, but a row in which both selected
+// cells are empty is skipped. A workbook compressed with gzip or zstd is
+// decompressed into memory first.
+//
+// An error is returned if the workbook cannot be read, the sheet or a
+// selected column does not exist, the range is not valid, or a selected
+// cell holds text or a boolean. The error gives the reference of the
+// offending cell.
+func FromXLSX(r io.ReaderAt, size int64, x, y Column, opts ...XLSXOption) (Dataset, error) {
+	o := xlsxOptions{sheet: "", cellRange: ""}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	bounds := xlsxRange{row1: 0, col1: 0, row2: xlsxMaxRows - 1, col2: xlsxMaxColumns - 1}
+	if o.cellRange != "" {
+		var err error
+		if bounds, err = parseXLSXRange(o.cellRange); err != nil {
+			return Dataset{}, err
+		}
+	}
+
+	r, size, err := decompressAt(r, size)
+	if err != nil {
+		return Dataset{}, err
+	}
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return Dataset{}, err
+	}
+	sheet, sheetPath, stringsPath, err := findXLSXSheet(zr, o.sheet)
+	if err != nil {
+		return Dataset{}, err
+	}
+	var shared []string
+	if stringsPath != "" {
+		if shared, err = readXLSXSharedStrings(zr, stringsPath); err != nil {
+			return Dataset{}, err
+		}
+	}
+	f, err := zr.Open(sheetPath)
+	if err != nil {
+		return Dataset{}, errors.New("sheet " + strconv.Quote(sheet) + " is missing from the workbook")
+	}
+	defer f.Close()
+
+	d := Dataset{Name: sheet, Description: "", Attribution: "", XLabel: "", YLabel: "", XUnits: "", YUnits: "", X: []float64{}, Y: []float64{}, Valid: nil}
+	first := true
+	xi, yi := x.index, y.index
+	err = readXLSXRows(f, shared, bounds, func(row int, cells []xlsxCell) error {
+		if first {
+			first = false
+			header := x.name != "" || y.name != "" || !isXLSXNumber(cells, x) || !isXLSXNumber(cells, y)
+			if header {
+				names := make([]string, len(cells))
+				for i, c := range cells {
+					names[i] = c.value
+				}
+				var err error
+				if xi, err = resolveColumn(names, x); err != nil {
+					return err
+				}
+				if yi, err = resolveColumn(names, y); err != nil {
+					return err
+				}
+				d.XLabel = strings.TrimSpace(names[xi])
+				d.YLabel = strings.TrimSpace(names[yi])
+
+				return nil
+			}
+		}
+
+		xv, xBlank, err := xlsxNumber(cells, xi)
+		if err != nil {
+			return errors.New(xlsxCellRef(sheet, row, bounds.col1+xi) + ": " + err.Error())
+		}
+		yv, yBlank, err := xlsxNumber(cells, yi)
+		if err != nil {
+			return errors.New(xlsxCellRef(sheet, row, bounds.col1+yi) + ": " + err.Error())
+		}
+		if !xBlank || !yBlank {
+			d.X = append(d.X, xv)
+			d.Y = append(d.Y, yv)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Dataset{}, err
+	}
+
+	return d, nil
+}
+
+// findXLSXSheet returns the name and path of the named sheet, or of the
+// first sheet if name is empty, and the path of the shared strings, which
+// is empty if the workbook has none.
+func findXLSXSheet(zr *zip.Reader, name string) (string, string, string, error) {
+	// The package relationships give the location of the workbook, which
+	// is almost always xl/workbook.xml.
+	book := "xl/workbook.xml"
+	var pkg xlsxRelationships
+	if err := readXLSXPart(zr, "_rels/.rels", &pkg); err == nil {
+		for _, rel := range pkg.Relationships {
+			if strings.HasSuffix(rel.Type, "/officeDocument") {
+				book = strings.TrimPrefix(path.Clean("/"+rel.Target), "/")
+			}
+		}
+	}
+
+	var wb xlsxWorkbook
+	if err := readXLSXPart(zr, book, &wb); err != nil {
+		return "", "", "", errors.New("not an xlsx workbook: " + err.Error())
+	}
+	dir, file := path.Split(book)
+	var rels xlsxRelationships
+	if err := readXLSXPart(zr, dir+"_rels/"+file+".rels", &rels); err != nil {
+		return "", "", "", errors.New("not an xlsx workbook: " + err.Error())
+	}
+	target := func(rel xlsxRelationship) string {
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(path.Clean(rel.Target), "/")
+		}
+
+		return path.Join(dir, rel.Target)
+	}
+
+	var stringsPath string
+	for _, rel := range rels.Relationships {
+		if strings.HasSuffix(rel.Type, "/sharedStrings") {
+			stringsPath = target(rel)
+		}
+	}
+	for _, s := range wb.Sheets {
+		if name != "" && s.Name != name {
+			continue
+		}
+		for _, rel := range rels.Relationships {
+			if rel.ID == s.ID {
+				return s.Name, target(rel), stringsPath, nil
+			}
+		}
+
+		return "", "", "", errors.New("sheet " + strconv.Quote(s.Name) + " is missing from the workbook")
+	}
+	if name == "" {
+		return "", "", "", errors.New("workbook has no sheets")
+	}
+
+	return "", "", "", errors.New("sheet " + strconv.Quote(name) + " not found in workbook")
+}
+
+// readXLSXPart decodes the XML part at name into v.
+func readXLSXPart(zr *zip.Reader, name string, v any) error {
+	f, err := zr.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return xml.NewDecoder(f).Decode(v)
+}
+
+// readXLSXSharedStrings reads the shared strings part, which holds the
+// text of cells of type "s".
+func readXLSXSharedStrings(zr *zip.Reader, name string) ([]string, error) {
+	f, err := zr.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var shared []string
+	var text strings.Builder
+	// A string is the text of its t elements, except those in rPh, which
+	// hold phonetic readings of East Asian text.
+	inText, phonetic := false, 0
+	dec := xml.NewDecoder(f)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return shared, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "si":
+				text.Reset()
+			case "t":
+				inText = phonetic == 0
+			case "rPh":
+				phonetic++
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "si":
+				shared = append(shared, text.String())
+			case "t":
+				inText = false
+			case "rPh":
+				phonetic--
+			}
+		case xml.CharData:
+			if inText {
+				text.Write(t)
+			}
+		}
+	}
+}
+
+// readXLSXRows reads the rows of a worksheet within bounds, calling fn
+// with the zero-based number of each row that has cells and its cells,
+// indexed from the first column of bounds. The cells are only valid until
+// fn returns.
+func readXLSXRows(r io.Reader, shared []string, bounds xlsxRange, fn func(row int, cells []xlsxCell) error) error {
+	var (
+		cells    []xlsxCell
+		cell     xlsxCell
+		text     strings.Builder
+		row, col = -1, -1
+		inCell   bool
+		inValue  bool
+	)
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "row":
+				row++
+				if v := xmlAttr(t, "r"); v != "" {
+					n, err := strconv.Atoi(v)
+					if err != nil || n < 1 || n > xlsxMaxRows {
+						return errors.New("invalid row number " + strconv.Quote(v))
+					}
+					row = n - 1
+				}
+				if row > bounds.row2 {
+					return nil
+				}
+				cells = cells[:0]
+				col = -1
+			case "c":
+				col++
+				if v := xmlAttr(t, "r"); v != "" {
+					c, _, ok := parseXLSXCellRef(v)
+					if !ok {
+						return errors.New("invalid cell reference " + strconv.Quote(v))
+					}
+					col = c
+				}
+				cell = xlsxCell{typ: xmlAttr(t, "t"), value: "", blank: true}
+				inCell = true
+			case "v", "t":
+				// The value of a cell, or the text of an inline string.
+				inValue = inCell
+				text.Reset()
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "row":
+				if row >= bounds.row1 && len(cells) > 0 {
+					if err := fn(row, cells); err != nil {
+						return err
+					}
+				}
+			case "c":
+				inCell = false
+				if col < bounds.col1 || col > bounds.col2 || cell.blank {
+					continue
+				}
+				if cell.typ == "s" {
+					i, err := strconv.Atoi(cell.value)
+					if err != nil || i < 0 || i >= len(shared) {
+						return errors.New("invalid shared string " + strconv.Quote(cell.value))
+					}
+					cell.value = shared[i]
+				}
+				for len(cells) <= col-bounds.col1 {
+					cells = append(cells, xlsxCell{typ: "", value: "", blank: true})
+				}
+				cells[col-bounds.col1] = cell
+			case "v", "t":
+				if inValue {
+					cell.value += text.String()
+					cell.blank = false
+				}
+				inValue = false
+			}
+		case xml.CharData:
+			if inValue {
+				text.Write(t)
+			}
+		}
+	}
+}
+
+// xmlAttr returns the value of the attribute of e with the given local
+// name, or the empty string.
+func xmlAttr(e xml.StartElement, name string) string {
+	for _, a := range e.Attr {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+
+	return ""
+}
+
+// isXLSXNumber reports whether the cell selected by c holds a number. A
+// column selected by name never matches a number.
+func isXLSXNumber(cells []xlsxCell, c Column) bool {
+	if c.name != "" {
+		return false
+	}
+	_, blank, err := xlsxNumber(cells, c.index)
+
+	return err == nil && !blank
+}
+
+// xlsxNumber returns the number in cells[i], which is NaN for an empty
+// cell or an error value, and whether the cell is empty.
+func xlsxNumber(cells []xlsxCell, i int) (float64, bool, error) {
+	if i < 0 || i >= len(cells) || cells[i].blank {
+		return math.NaN(), true, nil
+	}
+
+	c := cells[i]
+	switch c.typ {
+	case "e":
+		return math.NaN(), false, nil
+	case "b":
+		return 0, false, errors.New("boolean is not a number")
+	}
+	v, err := strconv.ParseFloat(strings.TrimSpace(c.value), 64)
+	if err != nil {
+		return 0, false, errors.New(strconv.Quote(c.value) + " is not a number")
+	}
+
+	return v, false, nil
+}
+
+// parseXLSXRange parses a range in A1 notation.
+func parseXLSXRange(ref string) (xlsxRange, error) {
+	from, to, ok := strings.Cut(ref, ":")
+	if !ok {
+		to = from
+	}
+	c1, r1, ok1 := parseXLSXCellRef(from)
+	c2, r2, ok2 := parseXLSXCellRef(to)
+	if !ok1 || !ok2 || c2 < c1 || r2 < r1 {
+		return xlsxRange{}, errors.New("invalid cell range " + strconv.Quote(ref))
+	}
+
+	return xlsxRange{row1: r1, col1: c1, row2: r2, col2: c2}, nil
+}
+
+// parseXLSXCellRef parses a cell reference such as "B7" or "$B$7" into a
+// zero-based column and row.
+func parseXLSXCellRef(ref string) (int, int, bool) {
+	ref = strings.ToUpper(ref)
+	i, col := 0, 0
+	if i < len(ref) && ref[i] == '$' {
+		i++
+	}
+	start := i
+	for ; i < len(ref) && ref[i] >= 'A' && ref[i] <= 'Z'; i++ {
+		col = col*26 + int(ref[i]-'A') + 1
+		if col > xlsxMaxColumns {
+			return 0, 0, false
+		}
+	}
+	if i == start {
+		return 0, 0, false
+	}
+	if i < len(ref) && ref[i] == '$' {
+		i++
+	}
+	row, err := strconv.Atoi(ref[i:])
+	if err != nil || row < 1 || row > xlsxMaxRows || ref[i] == '+' {
+		return 0, 0, false
+	}
+
+	return col - 1, row - 1, true
+}
+
+// xlsxCellRef returns the reference of a cell, given by zero-based row and
+// column, in a sheet, such as "Sheet1!B7".
+func xlsxCellRef(sheet string, row, col int) string {
+	var letters []byte
+	for n := col + 1; n > 0; n = (n - 1) / 26 {
+		letters = append([]byte{byte('A' + (n-1)%26)}, letters...)
+	}
+
+	return sheet + "!" + string(letters) + strconv.Itoa(row+1)
+}
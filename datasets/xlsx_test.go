@@ -0,0 +1,303 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"archive/zip"
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// testdata/xlsx/workbook.xlsx was written with github.com/xuri/excelize/v2.
+// Its sheets are Notes, holding a title, Data, holding id, dose and
+// response columns with a header in row 1 and a blank response in row 5,
+// and Offset, holding a title in A1, x and y columns in C4:D8 and a note
+// in C12.
+
+// makeXLSX returns a minimal workbook with one sheet, Sheet1, holding the
+// given rows of sheetData and shared strings.
+func makeXLSX(t *testing.T, rows string, shared ...string) *bytes.Reader {
+	t.Helper()
+
+	var sst strings.Builder
+	for _, s := range shared {
+		sst.WriteString("<si><t>" + s + "</t></si>")
+	}
+	parts := map[string]string{
+		"_rels/.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="/xl/workbook.xml"/>` +
+			`</Relationships>`,
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>` +
+			`</Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` + sst.String() + `</sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<sheetData>` + rows + `</sheetData></worksheet>`,
+	}
+
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatalf("zip.Create() unexpected error: %v", err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatalf("zip.Write() unexpected error: %v", err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("zip.Close() unexpected error: %v", err)
+	}
+
+	return bytes.NewReader(b.Bytes())
+}
+
+// openXLSX returns the contents of testdata/xlsx/workbook.xlsx.
+func openXLSX(t *testing.T) *bytes.Reader {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "xlsx", "workbook.xlsx"))
+	if err != nil {
+		t.Fatalf("os.ReadFile() unexpected error: %v", err)
+	}
+
+	return bytes.NewReader(data)
+}
+
+func TestFromXLSX(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name     string
+		x        Column
+		y        Column
+		opts     []XLSXOption
+		wantName string
+		wantX    []float64
+		wantY    []float64
+	}{
+		{
+			name:     "header by name",
+			x:        ColumnName("dose"),
+			y:        ColumnName("response"),
+			opts:     []XLSXOption{WithSheet("Data")},
+			wantName: "Data",
+			wantX:    []float64{0.5, 1, 1.5, 2, 2.5, 3, 3.5, 4, 4.5, 5},
+			wantY:    []float64{0.25, 1, 2.25, nan, 6.25, 9, 12.25, 16, 20.25, 25},
+		},
+		{
+			name:     "detected header by index",
+			x:        ColumnIndex(0),
+			y:        ColumnIndex(1),
+			opts:     []XLSXOption{WithSheet("Data")},
+			wantName: "Data",
+			wantX:    []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			wantY:    []float64{0.5, 1, 1.5, 2, 2.5, 3, 3.5, 4, 4.5, 5},
+		},
+		{
+			name:     "range without header",
+			x:        ColumnIndex(0),
+			y:        ColumnIndex(2),
+			opts:     []XLSXOption{WithSheet("Data"), WithRange("A3:C4")},
+			wantName: "Data",
+			wantX:    []float64{2, 3},
+			wantY:    []float64{1, 2.25},
+		},
+		{
+			name:     "range with header",
+			x:        ColumnName("y"),
+			y:        ColumnIndex(0),
+			opts:     []XLSXOption{WithSheet("Offset"), WithRange("$C$4:$D$8")},
+			wantName: "Offset",
+			wantX:    []float64{-1, -2, -3, -4},
+			wantY:    []float64{10, 20, 30, 40},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := openXLSX(t)
+			d, err := FromXLSX(r, r.Size(), test.x, test.y, test.opts...)
+			if err != nil {
+				t.Fatalf("FromXLSX() unexpected error: %v", err)
+			}
+			if d.Name != test.wantName {
+				t.Errorf("FromXLSX() name = %q, want %q", d.Name, test.wantName)
+			}
+			if !equalNaN(d.X, test.wantX) || !equalNaN(d.Y, test.wantY) {
+				t.Errorf("FromXLSX() = %v, %v, want %v, %v", d.X, d.Y, test.wantX, test.wantY)
+			}
+		})
+	}
+}
+
+func TestFromXLSXCells(t *testing.T) {
+	nan := math.NaN()
+	tests := []struct {
+		name   string
+		rows   string
+		shared []string
+		wantX  []float64
+		wantY  []float64
+	}{
+		{
+			name: "shared, inline and rich text",
+			rows: `<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="inlineStr"><is><r><t>fi</t></r><r><t>rst</t></r></is></c></row>` +
+				`<row r="2"><c r="A2"><v>1</v></c><c r="B2" t="inlineStr"><is><t> 2.5 </t></is></c></row>`,
+			shared: []string{"x"},
+			wantX:  []float64{1},
+			wantY:  []float64{2.5},
+		},
+		{
+			name: "cells without references",
+			rows: `<row><c><v>1</v></c><c><v>2</v></c></row>` +
+				`<row><c><v>3</v></c><c t="str"><f>A2+1</f><v>4</v></c></row>`,
+			shared: nil,
+			wantX:  []float64{1, 3},
+			wantY:  []float64{2, 4},
+		},
+		{
+			name: "errors and blanks",
+			rows: `<row r="1"><c r="A1"><v>1</v></c><c r="B1" t="e"><v>#N/A</v></c></row>` +
+				`<row r="2"><c r="A2" s="1"/><c r="B2"><v>2</v></c></row>` +
+				`<row r="3"><c r="A3" s="1"/><c r="C3"><v>9</v></c></row>` +
+				`<row r="4"/>`,
+			shared: nil,
+			wantX:  []float64{1, nan},
+			wantY:  []float64{nan, 2},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := makeXLSX(t, test.rows, test.shared...)
+			d, err := FromXLSX(r, r.Size(), ColumnIndex(0), ColumnIndex(1))
+			if err != nil {
+				t.Fatalf("FromXLSX() unexpected error: %v", err)
+			}
+			if !equalNaN(d.X, test.wantX) || !equalNaN(d.Y, test.wantY) {
+				t.Errorf("FromXLSX() = %v, %v, want %v, %v", d.X, d.Y, test.wantX, test.wantY)
+			}
+		})
+	}
+}
+
+func TestFromXLSXErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		x       Column
+		y       Column
+		opts    []XLSXOption
+		wantErr string
+	}{
+		{
+			name:    "unknown sheet",
+			x:       ColumnIndex(0),
+			y:       ColumnIndex(1),
+			opts:    []XLSXOption{WithSheet("Missing")},
+			wantErr: `sheet "Missing" not found in workbook`,
+		},
+		{
+			name:    "unknown column",
+			x:       ColumnName("dose"),
+			y:       ColumnName("weight"),
+			opts:    []XLSXOption{WithSheet("Data")},
+			wantErr: `column "weight" not found in header`,
+		},
+		{
+			name:    "text in data",
+			x:       ColumnIndex(0),
+			y:       ColumnIndex(1),
+			opts:    []XLSXOption{WithSheet("Offset"), WithRange("C5:D20")},
+			wantErr: `Offset!C12: "total" is not a number`,
+		},
+		{
+			name:    "invalid range",
+			x:       ColumnIndex(0),
+			y:       ColumnIndex(1),
+			opts:    []XLSXOption{WithRange("D8:C4")},
+			wantErr: `invalid cell range "D8:C4"`,
+		},
+		{
+			name:    "title row taken as header",
+			x:       ColumnName("x"),
+			y:       ColumnName("y"),
+			opts:    []XLSXOption{WithSheet("Offset")},
+			wantErr: `column "x" not found in header`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := openXLSX(t)
+			_, err := FromXLSX(r, r.Size(), test.x, test.y, test.opts...)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("FromXLSX() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestFromXLSXNotWorkbook(t *testing.T) {
+	r := strings.NewReader("x,y\n1,2\n")
+	if _, err := FromXLSX(r, r.Size(), ColumnIndex(0), ColumnIndex(1)); err == nil {
+		t.Errorf("FromXLSX() of CSV expected error but got none")
+	}
+
+	r2 := makeXLSX(t, `<row r="1"><c r="A1" t="b"><v>1</v></c><c r="B1"><v>2</v></c></row><row r="2"><c r="A2" t="b"><v>1</v></c><c r="B2"><v>2</v></c></row>`)
+	if _, err := FromXLSX(r2, r2.Size(), ColumnIndex(0), ColumnIndex(1)); err == nil ||
+		!strings.Contains(err.Error(), "Sheet1!A2: boolean is not a number") {
+		t.Errorf("FromXLSX() of booleans error = %v, want one about Sheet1!A2", err)
+	}
+}
+
+func TestParseXLSXCellRef(t *testing.T) {
+	tests := []struct {
+		ref     string
+		wantCol int
+		wantRow int
+		wantOK  bool
+	}{
+		{ref: "A1", wantCol: 0, wantRow: 0, wantOK: true},
+		{ref: "$B$7", wantCol: 1, wantRow: 6, wantOK: true},
+		{ref: "az10", wantCol: 51, wantRow: 9, wantOK: true},
+		{ref: "XFD1048576", wantCol: 16383, wantRow: 1048575, wantOK: true},
+		{ref: "XFE1", wantCol: 0, wantRow: 0, wantOK: false},
+		{ref: "A0", wantCol: 0, wantRow: 0, wantOK: false},
+		{ref: "A+1", wantCol: 0, wantRow: 0, wantOK: false},
+		{ref: "7", wantCol: 0, wantRow: 0, wantOK: false},
+		{ref: "B", wantCol: 0, wantRow: 0, wantOK: false},
+	}
+
+	for _, test := range tests {
+		col, row, ok := parseXLSXCellRef(test.ref)
+		if col != test.wantCol || row != test.wantRow || ok != test.wantOK {
+			t.Errorf("parseXLSXCellRef(%q) = %d, %d, %v, want %d, %d, %v",
+				test.ref, col, row, ok, test.wantCol, test.wantRow, test.wantOK)
+		}
+		if ok {
+			if got := xlsxCellRef("S", row, col); got != "S!"+strings.ToUpper(strings.ReplaceAll(test.ref, "$", "")) {
+				t.Errorf("xlsxCellRef(%d, %d) = %q, want the reference of %q", row, col, got, test.ref)
+			}
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// The primes of the xxHash64 algorithm.
+const (
+	xxhPrime1 uint64 = 11400714785074694791
+	xxhPrime2 uint64 = 14029467366897019727
+	xxhPrime3 uint64 = 1609587929392839161
+	xxhPrime4 uint64 = 9650029242287828579
+	xxhPrime5 uint64 = 2870177450012600261
+)
+
+// xxhash64 computes the 64 bit xxHash of a stream with seed 0, which zstd
+// uses for its frame checksums.
+type xxhash64 struct {
+	v     [4]uint64
+	total uint64
+	buf   [32]byte
+	n     int
+}
+
+// newXXHash64 returns a hash of the empty input.
+func newXXHash64() *xxhash64 {
+	h := &xxhash64{v: [4]uint64{}, total: 0, buf: [32]byte{}, n: 0}
+	h.reset()
+
+	return h
+}
+
+// reset restores the hash to that of the empty input.
+func (h *xxhash64) reset() {
+	// The sums wrap, which constant arithmetic does not allow.
+	p1 := xxhPrime1
+	h.v = [4]uint64{p1 + xxhPrime2, xxhPrime2, 0, -p1}
+	h.total = 0
+	h.n = 0
+}
+
+// xxhRound mixes an 8 byte lane into an accumulator.
+func xxhRound(acc, lane uint64) uint64 {
+	return bits.RotateLeft64(acc+lane*xxhPrime2, 31) * xxhPrime1
+}
+
+// write adds p to the hashed input.
+func (h *xxhash64) write(p []byte) {
+	h.total += uint64(len(p))
+	if h.n > 0 {
+		k := copy(h.buf[h.n:], p)
+		h.n += k
+		p = p[k:]
+		if h.n < len(h.buf) {
+			return
+		}
+		h.stripe(h.buf[:])
+		h.n = 0
+	}
+	for ; len(p) >= len(h.buf); p = p[len(h.buf):] {
+		h.stripe(p)
+	}
+	h.n = copy(h.buf[:], p)
+}
+
+// stripe mixes a 32 byte stripe into the accumulators.
+func (h *xxhash64) stripe(p []byte) {
+	for i := range h.v {
+		h.v[i] = xxhRound(h.v[i], binary.LittleEndian.Uint64(p[8*i:]))
+	}
+}
+
+// sum returns the hash of the input so far.
+func (h *xxhash64) sum() uint64 {
+	var acc uint64
+	if h.total >= uint64(len(h.buf)) {
+		acc = bits.RotateLeft64(h.v[0], 1) + bits.RotateLeft64(h.v[1], 7) +
+			bits.RotateLeft64(h.v[2], 12) + bits.RotateLeft64(h.v[3], 18)
+		for _, v := range h.v {
+			acc = (acc^xxhRound(0, v))*xxhPrime1 + xxhPrime4
+		}
+	} else {
+		acc = xxhPrime5
+	}
+	acc += h.total
+
+	p := h.buf[:h.n]
+	for ; len(p) >= 8; p = p[8:] {
+		acc ^= xxhRound(0, binary.LittleEndian.Uint64(p))
+		acc = bits.RotateLeft64(acc, 27)*xxhPrime1 + xxhPrime4
+	}
+	if len(p) >= 4 {
+		acc ^= uint64(binary.LittleEndian.Uint32(p)) * xxhPrime1
+		acc = bits.RotateLeft64(acc, 23)*xxhPrime2 + xxhPrime3
+		p = p[4:]
+	}
+	for _, b := range p {
+		acc ^= uint64(b) * xxhPrime5
+		acc = bits.RotateLeft64(acc, 11) * xxhPrime1
+	}
+
+	acc ^= acc >> 33
+	acc *= xxhPrime2
+	acc ^= acc >> 29
+	acc *= xxhPrime3
+	acc ^= acc >> 32
+
+	return acc
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestXXHash64(t *testing.T) {
+	tests := []struct {
+		input string
+		want  uint64
+	}{
+		{input: "", want: 0xef46db3751d8e999},
+		{input: "a", want: 0xd24ec4f1a98c6e5b},
+		{input: "abc", want: 0x44bc2cf5ad770999},
+		{input: "Nobody inspects the spammish repetition", want: 0xfbcea83c8a378bf1},
+	}
+
+	for _, test := range tests {
+		h := newXXHash64()
+		h.write([]byte(test.input))
+		if got := h.sum(); got != test.want {
+			t.Errorf("xxhash64(%q) = %#x, want %#x", test.input, got, test.want)
+		}
+	}
+}
+
+func TestXXHash64Pieces(t *testing.T) {
+	// Writing the input in pieces of any size must give the same sum.
+	input := []byte(strings.Repeat("0123456789abcdefghij", 10))
+	h := newXXHash64()
+	h.write(input)
+	want := h.sum()
+	for size := 1; size <= 40; size++ {
+		h.reset()
+		for i := 0; i < len(input); i += size {
+			h.write(input[i:min(i+size, len(input))])
+		}
+		if got := h.sum(); got != want {
+			t.Errorf("xxhash64 in pieces of %d bytes = %#x, want %#x", size, got, want)
+		}
+	}
+}
@@ -0,0 +1,1071 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math/bits"
+)
+
+// The magic numbers of zstd frames. Skippable frames have any of the 16
+// magic numbers that match zstdSkippableMagic in all but the low 4 bits.
+const (
+	zstdMagic          = 0xfd2fb528
+	zstdSkippableMagic = 0x184d2a50
+)
+
+// zstdMaxWindow is the largest window that is decoded, the default limit
+// of the reference implementation. Larger windows need the --long option
+// to compress and are rejected rather than allocated.
+const zstdMaxWindow = 1 << 27
+
+// zstdMaxBlock is the largest size of a block, compressed or not.
+const zstdMaxBlock = 1 << 17
+
+// The largest symbols and accuracy logs of the FSE tables for literal
+// lengths, match lengths, offsets and Huffman weights.
+const (
+	zstdMaxLLSymbol  = 35
+	zstdMaxMLSymbol  = 52
+	zstdMaxOFSymbol  = 31
+	zstdMaxLLLog     = 9
+	zstdMaxMLLog     = 9
+	zstdMaxOFLog     = 8
+	zstdMaxWeightLog = 6
+	// zstdMaxHuffmanBits is the longest Huffman code.
+	zstdMaxHuffmanBits = 11
+)
+
+// errZstdCorrupt is the error for input that is not valid zstd data.
+var errZstdCorrupt = errors.New("corrupt zstd data")
+
+// The baselines and numbers of extra bits of the literal length codes.
+var (
+	zstdLLBase = [zstdMaxLLSymbol + 1]uint32{
+		0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15,
+		16, 18, 20, 22, 24, 28, 32, 40, 48, 64, 128, 256, 512, 1024, 2048, 4096,
+		8192, 16384, 32768, 65536,
+	}
+	zstdLLBits = [zstdMaxLLSymbol + 1]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 6, 7, 8, 9, 10, 11, 12,
+		13, 14, 15, 16,
+	}
+)
+
+// The baselines and numbers of extra bits of the match length codes.
+var (
+	zstdMLBase = [zstdMaxMLSymbol + 1]uint32{
+		3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18,
+		19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34,
+		35, 37, 39, 41, 43, 47, 51, 59, 67, 83, 99, 131, 259, 515, 1027, 2051,
+		4099, 8195, 16387, 32771, 65539,
+	}
+	zstdMLBits = [zstdMaxMLSymbol + 1]uint8{
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
+		1, 1, 1, 1, 2, 2, 3, 3, 4, 4, 5, 7, 8, 9, 10, 11,
+		12, 13, 14, 15, 16,
+	}
+)
+
+// The predefined FSE tables of the sequence codes.
+var (
+	zstdPredefinedLL = buildZstdFSE([]int16{
+		4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+		-1, -1, -1, -1,
+	}, 6)
+	zstdPredefinedML = buildZstdFSE([]int16{
+		1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+		-1, -1, -1, -1, -1,
+	}, 6)
+	zstdPredefinedOF = buildZstdFSE([]int16{
+		1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+	}, 5)
+)
+
+// zstdReader decompresses a stream of zstd frames, as written by the zstd
+// command, a block at a time.
+type zstdReader struct {
+	r   io.Reader
+	err error
+
+	// hist holds the output of the current frame that later blocks may
+	// refer back to, followed by the output not yet read.
+	hist   []byte
+	unread int
+
+	inFrame     bool
+	window      int
+	contentSize int64
+	produced    int64
+	checksum    bool
+	hash        *xxhash64
+
+	block  []byte
+	blocks zstdBlockDecoder
+}
+
+// newZstdReader returns a reader of the decompressed contents of r.
+func newZstdReader(r io.Reader) *zstdReader {
+	return &zstdReader{
+		r:           r,
+		err:         nil,
+		hist:        nil,
+		unread:      0,
+		inFrame:     false,
+		window:      0,
+		contentSize: -1,
+		produced:    0,
+		checksum:    false,
+		hash:        newXXHash64(),
+		block:       nil,
+		blocks:      zstdBlockDecoder{},
+	}
+}
+
+// Read implements io.Reader.
+func (z *zstdReader) Read(p []byte) (int, error) {
+	for z.unread == len(z.hist) {
+		if z.err != nil {
+			return 0, z.err
+		}
+		// Output of a block that fails to decode is dropped.
+		if z.err = z.next(); z.err != nil {
+			z.hist = z.hist[:z.unread]
+		}
+	}
+	n := copy(p, z.hist[z.unread:])
+	z.unread += n
+
+	return n, nil
+}
+
+// readFull reads exactly len(p) bytes, treating a short read as corrupt
+// data.
+func (z *zstdReader) readFull(p []byte) error {
+	if _, err := io.ReadFull(z.r, p); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return io.ErrUnexpectedEOF
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// next decodes the next block, starting a new frame if needed. It returns
+// io.EOF at the end of the input after a complete frame.
+func (z *zstdReader) next() error {
+	if !z.inFrame {
+		return z.startFrame()
+	}
+
+	// Keep only the window of history that later blocks may refer to,
+	// compacting rarely so that the cost of the copy is spread out.
+	if len(z.hist) > 2*z.window && len(z.hist) > zstdMaxBlock {
+		keep := copy(z.hist, z.hist[len(z.hist)-z.window:])
+		z.hist = z.hist[:keep]
+		z.unread = keep
+	}
+
+	var header [3]byte
+	if err := z.readFull(header[:]); err != nil {
+		return err
+	}
+	h := uint32(header[0]) | uint32(header[1])<<8 | uint32(header[2])<<16
+	last := h&1 == 1
+	size := int(h >> 3)
+	start := len(z.hist)
+
+	switch h >> 1 & 3 {
+	case 0:
+		// A raw block.
+		if size > zstdMaxBlock {
+			return errZstdCorrupt
+		}
+		z.hist = append(z.hist, make([]byte, size)...)
+		if err := z.readFull(z.hist[start:]); err != nil {
+			return err
+		}
+	case 1:
+		// A run of one byte repeated size times.
+		if size > zstdMaxBlock {
+			return errZstdCorrupt
+		}
+		var b [1]byte
+		if err := z.readFull(b[:]); err != nil {
+			return err
+		}
+		for range size {
+			z.hist = append(z.hist, b[0])
+		}
+	case 2:
+		if size > zstdMaxBlock {
+			return errZstdCorrupt
+		}
+		if cap(z.block) < size {
+			z.block = make([]byte, size)
+		}
+		z.block = z.block[:size]
+		if err := z.readFull(z.block); err != nil {
+			return err
+		}
+		hist, err := z.blocks.decode(z.hist, z.block)
+		if err != nil {
+			return err
+		}
+		z.hist = hist
+	default:
+		return errZstdCorrupt
+	}
+
+	z.produced += int64(len(z.hist) - start)
+	if z.checksum {
+		z.hash.write(z.hist[start:])
+	}
+	if z.contentSize >= 0 && z.produced > z.contentSize {
+		return errZstdCorrupt
+	}
+	if last {
+		return z.endFrame()
+	}
+
+	return nil
+}
+
+// startFrame reads a frame header, skipping any skippable frames. It
+// returns io.EOF if the input ends before a frame.
+func (z *zstdReader) startFrame() error {
+	var magic [4]byte
+	if _, err := io.ReadFull(z.r, magic[:]); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return errZstdCorrupt
+		}
+
+		return err
+	}
+	m := binary.LittleEndian.Uint32(magic[:])
+	if m&^0xf == zstdSkippableMagic {
+		if err := z.readFull(magic[:]); err != nil {
+			return err
+		}
+		n := int64(binary.LittleEndian.Uint32(magic[:]))
+		if skipped, err := io.CopyN(io.Discard, z.r, n); skipped != n {
+			if err == nil || errors.Is(err, io.EOF) {
+				return io.ErrUnexpectedEOF
+			}
+
+			return err
+		}
+
+		return nil
+	}
+	if m != zstdMagic {
+		return errors.New("not zstd data")
+	}
+
+	var descriptor [1]byte
+	if err := z.readFull(descriptor[:]); err != nil {
+		return err
+	}
+	d := descriptor[0]
+	fcsFlag := d >> 6
+	single := d>>5&1 == 1
+	if d>>3&1 == 1 {
+		return errZstdCorrupt
+	}
+	dictBytes := [4]int{0, 1, 2, 4}[d&3]
+	fcsBytes := [4]int{0, 2, 4, 8}[fcsFlag]
+	if fcsFlag == 0 && single {
+		fcsBytes = 1
+	}
+	windowBytes := 1
+	if single {
+		windowBytes = 0
+	}
+
+	var header [13]byte
+	rest := header[:windowBytes+dictBytes+fcsBytes]
+	if err := z.readFull(rest); err != nil {
+		return err
+	}
+	window := int64(0)
+	if !single {
+		exp, mantissa := rest[0]>>3, rest[0]&7
+		base := int64(1) << (10 + exp)
+		window = base + base/8*int64(mantissa)
+		rest = rest[1:]
+	}
+	var dict uint64
+	for i := dictBytes - 1; i >= 0; i-- {
+		dict = dict<<8 | uint64(rest[i])
+	}
+	if dict != 0 {
+		return errors.New("zstd data compressed with a dictionary is not supported")
+	}
+	rest = rest[dictBytes:]
+
+	z.contentSize = -1
+	if fcsBytes > 0 {
+		var size uint64
+		for i := fcsBytes - 1; i >= 0; i-- {
+			size = size<<8 | uint64(rest[i])
+		}
+		if fcsBytes == 2 {
+			size += 256
+		}
+		if size > 1<<62 {
+			return errZstdCorrupt
+		}
+		z.contentSize = int64(size)
+	}
+	if single {
+		window = z.contentSize
+	}
+	if window > zstdMaxWindow {
+		return errors.New("zstd window is too large")
+	}
+
+	z.window = max(int(window), 1)
+	z.checksum = d>>2&1 == 1
+	z.hash.reset()
+	z.produced = 0
+	z.hist = z.hist[:0]
+	z.unread = 0
+	z.blocks.reset()
+	z.inFrame = true
+
+	return nil
+}
+
+// endFrame checks the content size and checksum at the end of a frame.
+func (z *zstdReader) endFrame() error {
+	z.inFrame = false
+	if z.contentSize >= 0 && z.produced != z.contentSize {
+		return errZstdCorrupt
+	}
+	if z.checksum {
+		var sum [4]byte
+		if err := z.readFull(sum[:]); err != nil {
+			return err
+		}
+		if binary.LittleEndian.Uint32(sum[:]) != uint32(z.hash.sum()) {
+			return errors.New("zstd checksum mismatch")
+		}
+	}
+
+	return nil
+}
+
+// zstdBlockDecoder decodes compressed blocks, keeping the state that
+// carries from one block to the next within a frame.
+type zstdBlockDecoder struct {
+	huffman  zstdHuffman
+	ll       *zstdFSE
+	ml       *zstdFSE
+	of       *zstdFSE
+	repeats  [3]int
+	literals []byte
+}
+
+// reset prepares the decoder for a new frame.
+func (d *zstdBlockDecoder) reset() {
+	d.huffman = zstdHuffman{table: nil, log: 0}
+	d.ll, d.ml, d.of = nil, nil, nil
+	d.repeats = [3]int{1, 4, 8}
+}
+
+// decode decodes a compressed block, appending its output to hist.
+func (d *zstdBlockDecoder) decode(hist, block []byte) ([]byte, error) {
+	literals, n, err := d.decodeLiterals(block)
+	if err != nil {
+		return nil, err
+	}
+	block = block[n:]
+
+	// The number of sequences.
+	if len(block) == 0 {
+		return nil, errZstdCorrupt
+	}
+	count := int(block[0])
+	switch {
+	case count == 0:
+		if len(block) != 1 {
+			return nil, errZstdCorrupt
+		}
+
+		return append(hist, literals...), nil
+	case count < 128:
+		block = block[1:]
+	case count < 255:
+		if len(block) < 2 {
+			return nil, errZstdCorrupt
+		}
+		count = (count-128)<<8 + int(block[1])
+		block = block[2:]
+	default:
+		if len(block) < 3 {
+			return nil, errZstdCorrupt
+		}
+		count = int(block[1]) + int(block[2])<<8 + 0x7f00
+		block = block[3:]
+	}
+
+	// The tables of the literal length, offset and match length codes.
+	if len(block) == 0 {
+		return nil, errZstdCorrupt
+	}
+	modes := block[0]
+	block = block[1:]
+	if modes&3 != 0 {
+		return nil, errZstdCorrupt
+	}
+	if d.ll, block, err = readZstdTable(block, modes>>6, d.ll, zstdPredefinedLL, zstdMaxLLSymbol, zstdMaxLLLog); err != nil {
+		return nil, err
+	}
+	if d.of, block, err = readZstdTable(block, modes>>4&3, d.of, zstdPredefinedOF, zstdMaxOFSymbol, zstdMaxOFLog); err != nil {
+		return nil, err
+	}
+	if d.ml, block, err = readZstdTable(block, modes>>2&3, d.ml, zstdPredefinedML, zstdMaxMLSymbol, zstdMaxMLLog); err != nil {
+		return nil, err
+	}
+
+	return d.execute(hist, literals, block, count)
+}
+
+// execute decodes count sequences from the bitstream src and carries them
+// out, appending the output to hist.
+func (d *zstdBlockDecoder) execute(hist, literals, src []byte, count int) ([]byte, error) {
+	br, err := newZstdBackReader(src)
+	if err != nil {
+		return nil, err
+	}
+	llState := int(br.read(d.ll.log))
+	ofState := int(br.read(d.of.log))
+	mlState := int(br.read(d.ml.log))
+
+	limit := len(hist) + zstdMaxBlock
+	for i := range count {
+		ll, of, ml := d.ll.table[llState], d.of.table[ofState], d.ml.table[mlState]
+
+		value := 1<<of.symbol + int(br.read(of.symbol))
+		matchLen := int(zstdMLBase[ml.symbol]) + int(br.read(zstdMLBits[ml.symbol]))
+		litLen := int(zstdLLBase[ll.symbol]) + int(br.read(zstdLLBits[ll.symbol]))
+
+		offset := d.offset(value, litLen)
+		if i < count-1 {
+			llState = int(ll.base) + int(br.read(ll.bits))
+			mlState = int(ml.base) + int(br.read(ml.bits))
+			ofState = int(of.base) + int(br.read(of.bits))
+		}
+
+		if litLen > len(literals) || len(hist)+litLen+matchLen > limit {
+			return nil, errZstdCorrupt
+		}
+		hist = append(hist, literals[:litLen]...)
+		literals = literals[litLen:]
+		if offset <= 0 || offset > len(hist) {
+			return nil, errZstdCorrupt
+		}
+		// A match may overlap the bytes it produces, repeating the last
+		// offset bytes.
+		from := len(hist) - offset
+		for matchLen > 0 {
+			n := min(matchLen, offset)
+			hist = append(hist, hist[from:from+n]...)
+			from += n
+			matchLen -= n
+		}
+	}
+	if br.pos != 0 || len(hist)+len(literals) > limit {
+		return nil, errZstdCorrupt
+	}
+
+	return append(hist, literals...), nil
+}
+
+// offset returns the match offset given by an offset value, updating the
+// repeated offsets. Values 1 to 3 select a repeated offset, shifted by one
+// when there are no literals, and larger values give the offset plus 3.
+func (d *zstdBlockDecoder) offset(value, litLen int) int {
+	r := &d.repeats
+	if value > 3 {
+		r[0], r[1], r[2] = value-3, r[0], r[1]
+
+		return r[0]
+	}
+	i := value - 1
+	if litLen == 0 {
+		i++
+	}
+	switch i {
+	case 0:
+	case 1:
+		r[0], r[1] = r[1], r[0]
+	case 2:
+		r[0], r[1], r[2] = r[2], r[0], r[1]
+	default:
+		r[0], r[1], r[2] = r[0]-1, r[0], r[1]
+	}
+
+	return r[0]
+}
+
+// decodeLiterals decodes the literals section at the start of a block,
+// returning the literals and the size of the section.
+func (d *zstdBlockDecoder) decodeLiterals(block []byte) ([]byte, int, error) {
+	if len(block) == 0 {
+		return nil, 0, errZstdCorrupt
+	}
+	kind := block[0] & 3
+	format := block[0] >> 2 & 3
+
+	if kind < 2 {
+		// Raw or run length literals, with a header of 1 to 3 bytes.
+		var size, n int
+		switch format {
+		case 0, 2:
+			size, n = int(block[0]>>3), 1
+		case 1:
+			if len(block) < 2 {
+				return nil, 0, errZstdCorrupt
+			}
+			size, n = int(block[0]>>4)+int(block[1])<<4, 2
+		default:
+			if len(block) < 3 {
+				return nil, 0, errZstdCorrupt
+			}
+			size, n = int(block[0]>>4)+int(block[1])<<4+int(block[2])<<12, 3
+		}
+		if size > zstdMaxBlock {
+			return nil, 0, errZstdCorrupt
+		}
+		if kind == 0 {
+			if len(block) < n+size {
+				return nil, 0, errZstdCorrupt
+			}
+
+			return block[n : n+size], n + size, nil
+		}
+		if len(block) < n+1 {
+			return nil, 0, errZstdCorrupt
+		}
+		d.literals = d.literals[:0]
+		for range size {
+			d.literals = append(d.literals, block[n])
+		}
+
+		return d.literals, n + 1, nil
+	}
+
+	// Huffman coded literals, in 1 or 4 streams.
+	var size, compressed, n int
+	streams := 4
+	switch format {
+	case 0, 1:
+		if len(block) < 3 {
+			return nil, 0, errZstdCorrupt
+		}
+		h := uint32(block[0]) | uint32(block[1])<<8 | uint32(block[2])<<16
+		size, compressed, n = int(h>>4&0x3ff), int(h>>14&0x3ff), 3
+		if format == 0 {
+			streams = 1
+		}
+	case 2:
+		if len(block) < 4 {
+			return nil, 0, errZstdCorrupt
+		}
+		h := binary.LittleEndian.Uint32(block)
+		size, compressed, n = int(h>>4&0x3fff), int(h>>18&0x3fff), 4
+	default:
+		if len(block) < 5 {
+			return nil, 0, errZstdCorrupt
+		}
+		h := uint64(binary.LittleEndian.Uint32(block)) | uint64(block[4])<<32
+		size, compressed, n = int(h>>4&0x3ffff), int(h>>22&0x3ffff), 5
+	}
+	if size > zstdMaxBlock || len(block) < n+compressed {
+		return nil, 0, errZstdCorrupt
+	}
+	src := block[n : n+compressed]
+
+	if kind == 2 {
+		used, err := d.huffman.read(src)
+		if err != nil {
+			return nil, 0, err
+		}
+		src = src[used:]
+	} else if d.huffman.table == nil {
+		return nil, 0, errZstdCorrupt
+	}
+
+	d.literals = d.literals[:0]
+	var err error
+	if streams == 1 {
+		d.literals, err = d.huffman.decode(d.literals, src, size)
+	} else {
+		d.literals, err = d.huffman.decode4(d.literals, src, size)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return d.literals, n + compressed, nil
+}
+
+// readZstdTable returns the FSE table of a sequence code given its mode,
+// and the rest of src after any table description: the predefined table,
+// a single symbol, a table described in src, or the previous table.
+func readZstdTable(src []byte, mode byte, previous, predefined *zstdFSE, maxSymbol, maxLog int) (*zstdFSE, []byte, error) {
+	switch mode {
+	case 0:
+		return predefined, src, nil
+	case 1:
+		if len(src) == 0 || int(src[0]) > maxSymbol {
+			return nil, nil, errZstdCorrupt
+		}
+		t := &zstdFSE{table: []zstdFSEState{{symbol: src[0], bits: 0, base: 0}}, log: 0}
+
+		return t, src[1:], nil
+	case 2:
+		t, n, err := readZstdFSE(src, maxSymbol, maxLog)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return t, src[n:], nil
+	default:
+		if previous == nil {
+			return nil, nil, errZstdCorrupt
+		}
+
+		return previous, src, nil
+	}
+}
+
+// zstdFSEState is a state of an FSE decoding table: the symbol it decodes
+// and how to find the next state, which is base plus the next bits bits of
+// the stream.
+type zstdFSEState struct {
+	symbol uint8
+	bits   uint8
+	base   uint16
+}
+
+// zstdFSE is an FSE decoding table with 1<<log states.
+type zstdFSE struct {
+	table []zstdFSEState
+	log   uint8
+}
+
+// readZstdFSE reads an FSE table description from the start of src,
+// returning the table and the number of bytes read.
+func readZstdFSE(src []byte, maxSymbol, maxLog int) (*zstdFSE, int, error) {
+	br := zstdForwardReader{buf: src, pos: 0}
+	log := int(br.read(4)) + 5
+	if log > maxLog {
+		return nil, 0, errZstdCorrupt
+	}
+
+	var norm []int16
+	remaining := 1<<log + 1
+	threshold := 1 << log
+	width := log + 1
+	zero := false
+	for remaining > 1 && len(norm) <= maxSymbol {
+		if zero {
+			// A zero probability is followed by 2 bit counts of further
+			// zeros, with 3 meaning that another count follows.
+			n := len(norm)
+			for {
+				r := int(br.read(2))
+				n += r
+				if r != 3 {
+					break
+				}
+				if n > maxSymbol {
+					return nil, 0, errZstdCorrupt
+				}
+			}
+			if n > maxSymbol {
+				return nil, 0, errZstdCorrupt
+			}
+			for len(norm) < n {
+				norm = append(norm, 0)
+			}
+		}
+
+		// The values below limit take one bit fewer than the rest.
+		limit := 2*threshold - 1 - remaining
+		v := int(br.peek(uint8(width)))
+		var count int
+		if v&(threshold-1) < limit {
+			count = v & (threshold - 1)
+			br.pos += width - 1
+		} else {
+			count = v & (2*threshold - 1)
+			if count >= threshold {
+				count -= limit
+			}
+			br.pos += width
+		}
+		count--
+		if count < 0 {
+			remaining--
+		} else {
+			remaining -= count
+		}
+		if remaining < 1 {
+			return nil, 0, errZstdCorrupt
+		}
+		norm = append(norm, int16(count))
+		zero = count == 0
+		for remaining < threshold {
+			width--
+			threshold >>= 1
+		}
+	}
+	n := (br.pos + 7) / 8
+	if remaining != 1 || n > len(src) {
+		return nil, 0, errZstdCorrupt
+	}
+	t := buildZstdFSE(norm, uint8(log))
+	if t == nil {
+		return nil, 0, errZstdCorrupt
+	}
+
+	return t, n, nil
+}
+
+// buildZstdFSE builds the decoding table for normalized probabilities,
+// where -1 is a probability below 1 in 1<<log. It returns nil if the
+// probabilities are not valid.
+func buildZstdFSE(norm []int16, log uint8) *zstdFSE {
+	size := 1 << log
+	t := &zstdFSE{table: make([]zstdFSEState, size), log: log}
+	next := make([]int, len(norm))
+
+	// Symbols of probability below 1 take the last states.
+	high := size - 1
+	for s, p := range norm {
+		if p == -1 {
+			if high < 0 {
+				return nil
+			}
+			t.table[high].symbol = uint8(s)
+			high--
+			next[s] = 1
+		} else {
+			next[s] = int(p)
+		}
+	}
+
+	// The rest are spread over the other states with a fixed stride.
+	step := size>>1 + size>>3 + 3
+	pos := 0
+	for s, p := range norm {
+		for range p {
+			t.table[pos].symbol = uint8(s)
+			pos = (pos + step) & (size - 1)
+			for pos > high {
+				pos = (pos + step) & (size - 1)
+			}
+		}
+	}
+	if pos != 0 {
+		return nil
+	}
+
+	for i := range t.table {
+		s := t.table[i].symbol
+		state := next[s]
+		next[s]++
+		if state == 0 {
+			return nil
+		}
+		nb := int(log) + 1 - bits.Len(uint(state))
+		t.table[i].bits = uint8(nb)
+		t.table[i].base = uint16(state<<nb - size)
+	}
+
+	return t
+}
+
+// zstdHuffman is a Huffman decoding table indexed by the next log bits of
+// the stream, whose entries hold a symbol in the high byte and the length
+// of its code in the low byte.
+type zstdHuffman struct {
+	table []uint16
+	log   int
+}
+
+// read reads a Huffman tree description from the start of src, returning
+// the number of bytes read.
+func (h *zstdHuffman) read(src []byte) (int, error) {
+	if len(src) == 0 {
+		return 0, errZstdCorrupt
+	}
+
+	var weights []uint8
+	n := 1
+	if hb := int(src[0]); hb < 128 {
+		// Weights compressed with FSE.
+		if len(src) < 1+hb {
+			return 0, errZstdCorrupt
+		}
+		var err error
+		if weights, err = readZstdWeights(src[1 : 1+hb]); err != nil {
+			return 0, err
+		}
+		n += hb
+	} else {
+		// Weights stored directly in 4 bits each.
+		count := hb - 127
+		n += (count + 1) / 2
+		if len(src) < n {
+			return 0, errZstdCorrupt
+		}
+		for i := range count {
+			b := src[1+i/2]
+			if i%2 == 0 {
+				b >>= 4
+			}
+			weights = append(weights, b&0xf)
+		}
+	}
+
+	// The weight of the last symbol is implied by the total of the
+	// others, which must fall short of a power of 2 by a power of 2.
+	total := 0
+	for _, w := range weights {
+		if w > zstdMaxHuffmanBits {
+			return 0, errZstdCorrupt
+		}
+		if w > 0 {
+			total += 1 << (w - 1)
+		}
+	}
+	if total == 0 || len(weights) > 255 {
+		return 0, errZstdCorrupt
+	}
+	log := bits.Len(uint(total))
+	rest := 1<<log - total
+	if log > zstdMaxHuffmanBits || rest&(rest-1) != 0 {
+		return 0, errZstdCorrupt
+	}
+	weights = append(weights, uint8(bits.Len(uint(rest))))
+
+	// Symbols of each weight take consecutive runs of entries, lowest
+	// weights first.
+	var start [zstdMaxHuffmanBits + 2]int
+	for _, w := range weights {
+		if w > 0 {
+			start[w] += 1 << (w - 1)
+		}
+	}
+	next := 0
+	for w := 1; w <= log; w++ {
+		next, start[w] = next+start[w], next
+	}
+	if cap(h.table) < 1<<log {
+		h.table = make([]uint16, 1<<log)
+	}
+	h.table = h.table[:1<<log]
+	h.log = log
+	for s, w := range weights {
+		if w == 0 {
+			continue
+		}
+		entry := uint16(s)<<8 | uint16(log+1-int(w))
+		for i := range 1 << (w - 1) {
+			h.table[start[w]+i] = entry
+		}
+		start[w] += 1 << (w - 1)
+	}
+
+	return n, nil
+}
+
+// readZstdWeights decodes Huffman weights compressed with FSE, which use
+// two states in turn over a single stream.
+func readZstdWeights(src []byte) ([]uint8, error) {
+	t, n, err := readZstdFSE(src, 255, zstdMaxWeightLog)
+	if err != nil {
+		return nil, err
+	}
+	br, err := newZstdBackReader(src[n:])
+	if err != nil {
+		return nil, err
+	}
+
+	var weights []uint8
+	states := [2]int{int(br.read(t.log)), int(br.read(t.log))}
+	for i := 0; ; i ^= 1 {
+		if len(weights) >= 255 {
+			return nil, errZstdCorrupt
+		}
+		e := t.table[states[i]]
+		weights = append(weights, e.symbol)
+		states[i] = int(e.base) + int(br.read(e.bits))
+		// Once the stream is overrun, the other state holds the last
+		// weight.
+		if br.pos < 0 {
+			weights = append(weights, t.table[states[i^1]].symbol)
+
+			return weights, nil
+		}
+	}
+}
+
+// decode decodes size symbols from a single stream, appending them to
+// dst.
+func (h *zstdHuffman) decode(dst, src []byte, size int) ([]byte, error) {
+	br, err := newZstdBackReader(src)
+	if err != nil {
+		return nil, err
+	}
+	for range size {
+		e := h.table[br.peek(uint8(h.log))]
+		dst = append(dst, byte(e>>8))
+		br.pos -= int(e & 0xff)
+	}
+	if br.pos != 0 {
+		return nil, errZstdCorrupt
+	}
+
+	return dst, nil
+}
+
+// decode4 decodes size symbols from four streams that follow a table of
+// the sizes of the first three, appending them to dst.
+func (h *zstdHuffman) decode4(dst, src []byte, size int) ([]byte, error) {
+	if len(src) < 6 {
+		return nil, errZstdCorrupt
+	}
+	s1 := int(binary.LittleEndian.Uint16(src))
+	s2 := int(binary.LittleEndian.Uint16(src[2:]))
+	s3 := int(binary.LittleEndian.Uint16(src[4:]))
+	src = src[6:]
+	if s1+s2+s3 > len(src) {
+		return nil, errZstdCorrupt
+	}
+	streams := [4][]byte{src[:s1], src[s1 : s1+s2], src[s1+s2 : s1+s2+s3], src[s1+s2+s3:]}
+	each := (size + 3) / 4
+	if 3*each > size {
+		return nil, errZstdCorrupt
+	}
+
+	var err error
+	for i, s := range streams {
+		n := each
+		if i == 3 {
+			n = size - 3*each
+		}
+		if dst, err = h.decode(dst, s, n); err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+// zstdForwardReader reads bits from the start of a buffer, least
+// significant bit first, as in FSE table descriptions. Bits beyond the end
+// read as zero.
+type zstdForwardReader struct {
+	buf []byte
+	pos int
+}
+
+// peek returns the next n bits, for n up to 32, without consuming them.
+func (r *zstdForwardReader) peek(n uint8) uint64 {
+	var v uint64
+	i := r.pos / 8
+	for k := 0; k < 5 && i+k < len(r.buf); k++ {
+		v |= uint64(r.buf[i+k]) << (8 * k)
+	}
+
+	return v >> (r.pos % 8) & (1<<n - 1)
+}
+
+// read returns and consumes the next n bits.
+func (r *zstdForwardReader) read(n uint8) uint64 {
+	v := r.peek(n)
+	r.pos += int(n)
+
+	return v
+}
+
+// zstdBackReader reads bits from the end of a buffer towards its start,
+// most significant first, as in zstd's entropy coded streams. The last
+// byte holds a marker bit above the first bit of the stream. pos is the
+// number of bits left, and goes negative once the stream is overrun, when
+// the missing low bits read as zero.
+type zstdBackReader struct {
+	buf []byte
+	pos int
+}
+
+// newZstdBackReader returns a reader of the stream in buf.
+func newZstdBackReader(buf []byte) (*zstdBackReader, error) {
+	if len(buf) == 0 || buf[len(buf)-1] == 0 {
+		return nil, errZstdCorrupt
+	}
+
+	return &zstdBackReader{buf: buf, pos: 8*len(buf) - 9 + bits.Len8(buf[len(buf)-1])}, nil
+}
+
+// peek returns the next n bits, for n up to 56, without consuming them.
+func (r *zstdBackReader) peek(n uint8) uint64 {
+	start := r.pos - int(n)
+	shift := 0
+	if start < 0 {
+		shift, start = -start, 0
+		if shift >= int(n) {
+			return 0
+		}
+	}
+	i := start / 8
+	var v uint64
+	if i+8 <= len(r.buf) {
+		v = binary.LittleEndian.Uint64(r.buf[i:])
+	} else {
+		for k := len(r.buf) - 1; k >= i; k-- {
+			v = v<<8 | uint64(r.buf[k])
+		}
+	}
+	v >>= start % 8
+
+	return (v & (1<<(int(n)-shift) - 1)) << shift
+}
+
+// read returns and consumes the next n bits.
+func (r *zstdBackReader) read(n uint8) uint64 {
+	if n == 0 {
+		return 0
+	}
+	v := r.peek(n)
+	r.pos -= int(n)
+
+	return v
+}
@@ -0,0 +1,182 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package datasets
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The files in testdata/compressed were written by the zstd command from
+// points.csv and ../parquet/plain.parquet: points.csv.zst at level 19,
+// points_stream.csv.zst from a pipe at level 1 in small blocks without a
+// checksum, and points_multi.csv.zst as two frames of half the file each.
+
+// zstdHello is "hello, world" in a frame with a checksum.
+const zstdHello = "\x28\xb5\x2f\xfd\x04\x58\x61\x00\x00hello, world\x42\x12\x1b\x6d"
+
+func TestZstdReader(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "empty frame",
+			input: "\x28\xb5\x2f\xfd\x24\x00\x01\x00\x00\x99\xe9\xd8\x51",
+			want:  "",
+		},
+		{
+			name:  "raw block with checksum",
+			input: zstdHello,
+			want:  "hello, world",
+		},
+		{
+			name:  "raw and run length blocks",
+			input: "\x28\xb5\x2f\xfd\x20\x07\x18\x00\x00abc\x23\x00\x00z",
+			want:  "abczzzz",
+		},
+		{
+			name:  "skippable frame",
+			input: "\x52\x2a\x4d\x18\x03\x00\x00\x00xyz" + zstdHello,
+			want:  "hello, world",
+		},
+		{
+			name:  "two frames",
+			input: zstdHello + zstdHello,
+			want:  "hello, worldhello, world",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := io.ReadAll(newZstdReader(strings.NewReader(test.input)))
+			if err != nil {
+				t.Fatalf("zstdReader unexpected error: %v", err)
+			}
+			if string(got) != test.want {
+				t.Errorf("zstdReader = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestZstdReaderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{
+			name:    "checksum mismatch",
+			input:   zstdHello[:len(zstdHello)-1] + "\x00",
+			wantErr: "zstd checksum mismatch",
+		},
+		{
+			name:    "missing checksum",
+			input:   zstdHello[:len(zstdHello)-4],
+			wantErr: "unexpected EOF",
+		},
+		{
+			name:    "truncated block",
+			input:   zstdHello[:12],
+			wantErr: "unexpected EOF",
+		},
+		{
+			name:    "content size mismatch",
+			input:   "\x28\xb5\x2f\xfd\x20\x08\x18\x00\x00abc\x23\x00\x00z",
+			wantErr: "corrupt zstd data",
+		},
+		{
+			name:    "reserved block type",
+			input:   "\x28\xb5\x2f\xfd\x20\x01\x0f\x00\x00",
+			wantErr: "corrupt zstd data",
+		},
+		{
+			name:    "dictionary",
+			input:   "\x28\xb5\x2f\xfd\x21\x07\x00\x01\x00\x00",
+			wantErr: "dictionary is not supported",
+		},
+		{
+			name:    "window too large",
+			input:   "\x28\xb5\x2f\xfd\x00\x90\x01\x00\x00",
+			wantErr: "zstd window is too large",
+		},
+		{
+			name:    "trailing data",
+			input:   zstdHello + "junk",
+			wantErr: "not zstd data",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := io.ReadAll(newZstdReader(strings.NewReader(test.input)))
+			if err == nil {
+				t.Fatalf("zstdReader expected error containing %q but got none", test.wantErr)
+			}
+			if !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("zstdReader error = %q, want it to contain %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestZstdReaderFiles(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("testdata", "compressed", "points.csv"))
+	if err != nil {
+		t.Fatalf("os.ReadFile() unexpected error: %v", err)
+	}
+	for _, name := range []string{"points.csv.zst", "points_stream.csv.zst", "points_multi.csv.zst"} {
+		t.Run(name, func(t *testing.T) {
+			f, err := os.Open(filepath.Join("testdata", "compressed", name))
+			if err != nil {
+				t.Fatalf("os.Open() unexpected error: %v", err)
+			}
+			defer f.Close()
+
+			got, err := io.ReadAll(newZstdReader(f))
+			if err != nil {
+				t.Fatalf("zstdReader unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("zstdReader read %d bytes that differ from points.csv", len(got))
+			}
+		})
+	}
+}
+
+func TestZstdReaderCorrupt(t *testing.T) {
+	// Damaging any byte must give an error or wrong output, never a panic.
+	// Every fifth byte is tried to keep the test quick.
+	data, err := os.ReadFile(filepath.Join("testdata", "compressed", "points.csv.zst"))
+	if err != nil {
+		t.Fatalf("os.ReadFile() unexpected error: %v", err)
+	}
+	for i := 0; i < len(data); i += 5 {
+		damaged := bytes.Clone(data)
+		damaged[i] ^= 0xa5
+		_, _ = io.ReadAll(newZstdReader(bytes.NewReader(damaged)))
+	}
+	for n := 1; n < len(data); n += 5 {
+		if _, err := io.ReadAll(newZstdReader(bytes.NewReader(data[:n]))); err == nil {
+			t.Fatalf("zstdReader of the first %d bytes expected error but got none", n)
+		}
+	}
+}
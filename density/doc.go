@@ -0,0 +1,32 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package density holds routines for estimating the joint distribution of
+paired data, as a visual aid that goes beyond a plain scatter plot.
+
+The main entry point is KDE2D, a bivariate Gaussian kernel density
+estimate evaluated on a regular grid. The grid is laid out so it can be
+passed straight to a contour plotting routine.
+
+For very large datasets HexBinXY aggregates the points into hexagonal
+cells, which is both a compact summary for plotting and a cheap way to
+screen for dependence before running an exact correlation.
+
+For example:
+
+	grid, err := density.KDE2DDataset(datasets.DatasaurusDino, 64, 64, density.Scott)
+	// grid.Density[i][j] is the estimated density at (grid.X[j], grid.Y[i]).
+*/
+package density
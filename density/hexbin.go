@@ -0,0 +1,209 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package density
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+
+	"github.com/rsned/stats/datasets"
+)
+
+// HexBin is a single hexagonal cell holding at least one point.
+type HexBin struct {
+	// Q and R are the axial coordinates of the cell in the hexagonal grid.
+	Q, R int
+	// X and Y are the coordinates of the cell center in data units.
+	X, Y float64
+	// Count is the number of points that fell in the cell.
+	Count int
+}
+
+// HexGrid holds the result of hexagonal binning of paired data.
+//
+// The grid is laid out in coordinates normalized by the range of each
+// series, so the hexagons are regular regardless of the units of x and y.
+// In data units they are stretched to match the aspect of the data.
+type HexGrid struct {
+	// GridSize is the number of hexagons spanning the range of x.
+	GridSize int
+	// MinX, MaxX, MinY and MaxY give the range of the binned data.
+	MinX, MaxX float64
+	MinY, MaxY float64
+	// Bins holds every non-empty cell, ordered by R and then Q.
+	Bins []HexBin
+	// Total is the number of points binned.
+	Total int
+}
+
+// HexBinXY groups the paired data x and y into hexagonal cells, with
+// gridSize hexagons across the range of x. Only non-empty cells are kept,
+// so memory use depends on the number of occupied cells rather than the
+// number of points, making it practical for tens of millions of points.
+//
+// An error is returned if the slices have different lengths or are empty,
+// gridSize is not positive, or any value is NaN or ±Inf.
+func HexBinXY(x, y []float64, gridSize int) (*HexGrid, error) {
+	if len(x) != len(y) {
+		return nil, errors.New("slices must have the same length")
+	}
+	if len(x) == 0 {
+		return nil, errors.New("slices cannot be empty")
+	}
+	if gridSize < 1 {
+		return nil, errors.New("grid size must be positive")
+	}
+
+	minX, maxX := x[0], x[0]
+	minY, maxY := y[0], y[0]
+	for i := range x {
+		if math.IsNaN(x[i]) || math.IsInf(x[i], 0) || math.IsNaN(y[i]) || math.IsInf(y[i], 0) {
+			return nil, errors.New("values must be finite")
+		}
+		minX = math.Min(minX, x[i])
+		maxX = math.Max(maxX, x[i])
+		minY = math.Min(minY, y[i])
+		maxY = math.Max(maxY, y[i])
+	}
+
+	g := &HexGrid{
+		GridSize: gridSize,
+		MinX:     minX,
+		MaxX:     maxX,
+		MinY:     minY,
+		MaxY:     maxY,
+		Bins:     nil,
+		Total:    len(x),
+	}
+	spanX, spanY := g.spans()
+
+	// Pointy-top hexagons whose centers are 1/gridSize apart horizontally.
+	radius := 1 / (float64(gridSize) * math.Sqrt(3))
+
+	type axial struct{ q, r int }
+	counts := make(map[axial]int)
+	for i := range x {
+		u := (x[i] - minX) / spanX
+		v := (y[i] - minY) / spanY
+		q, r := hexRound((math.Sqrt(3)/3*u-v/3)/radius, (2.0/3*v)/radius)
+		counts[axial{q, r}]++
+	}
+
+	g.Bins = make([]HexBin, 0, len(counts))
+	for k, c := range counts {
+		u := radius * math.Sqrt(3) * (float64(k.q) + float64(k.r)/2)
+		v := radius * 1.5 * float64(k.r)
+		g.Bins = append(g.Bins, HexBin{
+			Q:     k.q,
+			R:     k.r,
+			X:     minX + u*spanX,
+			Y:     minY + v*spanY,
+			Count: c,
+		})
+	}
+	slices.SortFunc(g.Bins, func(a, b HexBin) int {
+		if c := cmp.Compare(a.R, b.R); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Q, b.Q)
+	})
+
+	return g, nil
+}
+
+// HexBinDataset groups the dataset d into hexagonal cells. See HexBinXY for
+// details.
+func HexBinDataset(d datasets.Dataset, gridSize int) (*HexGrid, error) {
+	return HexBinXY(d.X, d.Y, gridSize)
+}
+
+// spans returns the range of each series, using 1 for a series with no
+// spread so that it maps to a single row or column of cells.
+func (g *HexGrid) spans() (float64, float64) {
+	spanX := g.MaxX - g.MinX
+	if spanX == 0 {
+		spanX = 1
+	}
+	spanY := g.MaxY - g.MinY
+	if spanY == 0 {
+		spanY = 1
+	}
+
+	return spanX, spanY
+}
+
+// Correlation returns an approximate Pearson correlation of the binned data,
+// computed from the cell centers weighted by their counts. It is intended
+// for fast screening of very large datasets; the binning error shrinks as
+// the grid size grows.
+//
+// NaN is returned if the binned data has no spread along either axis.
+func (g *HexGrid) Correlation() float64 {
+	var n, sx, sy float64
+	for _, b := range g.Bins {
+		w := float64(b.Count)
+		n += w
+		sx += w * b.X
+		sy += w * b.Y
+	}
+	mx, my := sx/n, sy/n
+
+	var cxy, cxx, cyy float64
+	for _, b := range g.Bins {
+		w := float64(b.Count)
+		dx := b.X - mx
+		dy := b.Y - my
+		cxy += w * dx * dy
+		cxx += w * dx * dx
+		cyy += w * dy * dy
+	}
+	if cxx <= 0 || cyy <= 0 {
+		return math.NaN()
+	}
+
+	return math.Max(-1, math.Min(1, cxy/math.Sqrt(cxx*cyy)))
+}
+
+// MaxCount returns the largest count in any cell, which is useful for
+// scaling a color map.
+func (g *HexGrid) MaxCount() int {
+	m := 0
+	for _, b := range g.Bins {
+		m = max(m, b.Count)
+	}
+
+	return m
+}
+
+// hexRound rounds fractional axial coordinates to the nearest hexagon by
+// converting to cube coordinates and fixing up the component with the
+// largest rounding error.
+func hexRound(q, r float64) (int, int) {
+	s := -q - r
+	rq, rr, rs := math.Round(q), math.Round(r), math.Round(s)
+	dq, dr, ds := math.Abs(rq-q), math.Abs(rr-r), math.Abs(rs-s)
+
+	switch {
+	case dq > dr && dq > ds:
+		rq = -rr - rs
+	case dr > ds:
+		rr = -rq - rs
+	}
+
+	return int(rq), int(rr)
+}
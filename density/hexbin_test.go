@@ -0,0 +1,135 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package density
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/rsned/stats/datasets"
+)
+
+func TestHexBinXY(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	const n = 100000
+	x := make([]float64, n)
+	y := make([]float64, n)
+	var sx, sy, sxy, sxx, syy float64
+	for i := range n {
+		x[i] = rng.NormFloat64()*50 + 1000
+		y[i] = 0.002*x[i] + rng.NormFloat64()*0.1
+		sx += x[i]
+		sy += y[i]
+		sxy += x[i] * y[i]
+		sxx += x[i] * x[i]
+		syy += y[i] * y[i]
+	}
+	exact := (sxy - sx*sy/n) / math.Sqrt((sxx-sx*sx/n)*(syy-sy*sy/n))
+
+	g, err := HexBinXY(x, y, 40)
+	if err != nil {
+		t.Fatalf("HexBinXY() unexpected error: %v", err)
+	}
+
+	total := 0
+	for i, b := range g.Bins {
+		if b.Count <= 0 {
+			t.Fatalf("Bins[%d] has count %d, want positive", i, b.Count)
+		}
+		if i > 0 {
+			prev := g.Bins[i-1]
+			if prev.R > b.R || (prev.R == b.R && prev.Q >= b.Q) {
+				t.Fatalf("Bins not ordered at %d: (%d, %d) then (%d, %d)", i, prev.Q, prev.R, b.Q, b.R)
+			}
+		}
+		total += b.Count
+	}
+	if total != n || g.Total != n {
+		t.Errorf("counts sum to %d with Total %d, want %d", total, g.Total, n)
+	}
+	if g.MaxCount() <= 0 || g.MaxCount() > n {
+		t.Errorf("MaxCount() = %d, want in (0, %d]", g.MaxCount(), n)
+	}
+
+	if got := g.Correlation(); math.Abs(got-exact) > 0.01 {
+		t.Errorf("Correlation() = %v, want ≈ %v", got, exact)
+	}
+}
+
+func TestHexBinAssignsNearestCenter(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	x := make([]float64, 2000)
+	y := make([]float64, 2000)
+	for i := range x {
+		x[i] = rng.Float64()
+		y[i] = rng.Float64()
+	}
+	// Pin the range to the unit square so normalized and data units agree.
+	x[0], y[0], x[1], y[1] = 0, 0, 1, 1
+
+	g, err := HexBinXY(x, y, 10)
+	if err != nil {
+		t.Fatalf("HexBinXY() unexpected error: %v", err)
+	}
+
+	// Every point must be at least as close to its own cell center as to
+	// any other occupied cell center.
+	type key struct{ q, r int }
+	centers := make(map[key]HexBin, len(g.Bins))
+	for _, b := range g.Bins {
+		centers[key{b.Q, b.R}] = b
+	}
+	radius := 1 / (10 * math.Sqrt(3))
+	for i := range x {
+		q, r := hexRound((math.Sqrt(3)/3*x[i]-y[i]/3)/radius, (2.0/3*y[i])/radius)
+		own := centers[key{q, r}]
+		dOwn := math.Hypot(x[i]-own.X, y[i]-own.Y)
+		for _, b := range g.Bins {
+			if d := math.Hypot(x[i]-b.X, y[i]-b.Y); d < dOwn-1e-12 {
+				t.Fatalf("point (%v, %v) binned at (%v, %v) but (%v, %v) is closer", x[i], y[i], own.X, own.Y, b.X, b.Y)
+			}
+		}
+	}
+}
+
+func TestHexBinDataset(t *testing.T) {
+	g, err := HexBinDataset(datasets.AnscombeIV, 5)
+	if err != nil {
+		t.Fatalf("HexBinDataset() unexpected error: %v", err)
+	}
+	if g.Total != len(datasets.AnscombeIV.X) {
+		t.Errorf("Total = %d, want %d", g.Total, len(datasets.AnscombeIV.X))
+	}
+
+	// Constant series still bin without error.
+	flat, err := HexBinXY([]float64{3, 3, 3}, []float64{1, 2, 3}, 4)
+	if err != nil {
+		t.Fatalf("HexBinXY() unexpected error: %v", err)
+	}
+	if !math.IsNaN(flat.Correlation()) {
+		t.Errorf("Correlation() of constant x = %v, want NaN", flat.Correlation())
+	}
+
+	if _, err := HexBinXY([]float64{1, 2}, []float64{1}, 4); err == nil {
+		t.Errorf("HexBinXY() with different lengths expected error but got none")
+	}
+	if _, err := HexBinXY([]float64{1, 2}, []float64{1, 2}, 0); err == nil {
+		t.Errorf("HexBinXY() with grid size 0 expected error but got none")
+	}
+	if _, err := HexBinXY([]float64{1, math.NaN()}, []float64{1, 2}, 4); err == nil {
+		t.Errorf("HexBinXY() with NaN expected error but got none")
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package density
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/rsned/stats/datasets"
+)
+
+// integrate returns the trapezoidal approximation of the integral of the
+// grid's density over its area.
+func integrate(t *testing.T, g *Grid) float64 {
+	t.Helper()
+
+	dx := g.X[1] - g.X[0]
+	dy := g.Y[1] - g.Y[0]
+	sum := 0.0
+	for i := range g.Y {
+		for j := range g.X {
+			w := 1.0
+			if i == 0 || i == len(g.Y)-1 {
+				w *= 0.5
+			}
+			if j == 0 || j == len(g.X)-1 {
+				w *= 0.5
+			}
+			sum += w * g.Density[i][j]
+		}
+	}
+
+	return sum * dx * dy
+}
+
+func TestKDE2DIntegratesToOne(t *testing.T) {
+	for _, method := range []Bandwidth{Scott, Silverman, CrossValidation} {
+		t.Run(method.String(), func(t *testing.T) {
+			g, err := KDE2DDataset(datasets.DatasaurusDino, 80, 60, method)
+			if err != nil {
+				t.Fatalf("KDE2DDataset() unexpected error: %v", err)
+			}
+			if len(g.X) != 80 || len(g.Y) != 60 || len(g.Density) != 60 || len(g.Density[0]) != 80 {
+				t.Fatalf("KDE2DDataset() grid shape = %dx%d, want 80x60", len(g.Density[0]), len(g.Density))
+			}
+			if got := integrate(t, g); math.Abs(got-1) > 0.01 {
+				t.Errorf("density integrates to %v, want 1", got)
+			}
+			if g.BandwidthX <= 0 || g.BandwidthY <= 0 {
+				t.Errorf("bandwidths = (%v, %v), want positive", g.BandwidthX, g.BandwidthY)
+			}
+		})
+	}
+}
+
+func TestKDE2DBandwidths(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8}
+	y := []float64{2, 1, 4, 3, 6, 5, 8, 7}
+
+	g, err := KDE2D(x, y, 10, 10, Scott)
+	if err != nil {
+		t.Fatalf("KDE2D() unexpected error: %v", err)
+	}
+	// The sample variance of 1 to 8 is 6.
+	want := math.Sqrt(6) * math.Pow(8, -1.0/6)
+	if math.Abs(g.BandwidthX-want) > 1e-12 {
+		t.Errorf("Scott BandwidthX = %v, want %v", g.BandwidthX, want)
+	}
+	if g.X[0] != 1-3*g.BandwidthX || g.X[9] != 8+3*g.BandwidthX {
+		t.Errorf("grid X spans [%v, %v], want [%v, %v]", g.X[0], g.X[9], 1-3*g.BandwidthX, 8+3*g.BandwidthX)
+	}
+
+	// A heavy outlier inflates σ but not the IQR, so Silverman's robust
+	// rule must give a narrower bandwidth.
+	xo := append([]float64{}, x...)
+	xo[7] = 1000
+	scott, err := KDE2D(xo, y, 10, 10, Scott)
+	if err != nil {
+		t.Fatalf("KDE2D() unexpected error: %v", err)
+	}
+	silverman, err := KDE2D(xo, y, 10, 10, Silverman)
+	if err != nil {
+		t.Fatalf("KDE2D() unexpected error: %v", err)
+	}
+	if silverman.BandwidthX >= scott.BandwidthX {
+		t.Errorf("Silverman BandwidthX = %v, want less than Scott's %v", silverman.BandwidthX, scott.BandwidthX)
+	}
+}
+
+func TestKDE2DCrossValidationBimodal(t *testing.T) {
+	// Two well separated clusters: Scott's rule oversmooths, so cross
+	// validation should settle on narrower bandwidths.
+	rng := rand.New(rand.NewSource(42))
+	x := make([]float64, 200)
+	y := make([]float64, 200)
+	for i := range x {
+		offset := 0.0
+		if i%2 == 0 {
+			offset = 20
+		}
+		x[i] = offset + rng.NormFloat64()
+		y[i] = offset + rng.NormFloat64()
+	}
+
+	scott, err := KDE2D(x, y, 20, 20, Scott)
+	if err != nil {
+		t.Fatalf("KDE2D() unexpected error: %v", err)
+	}
+	cv, err := KDE2D(x, y, 20, 20, CrossValidation)
+	if err != nil {
+		t.Fatalf("KDE2D() unexpected error: %v", err)
+	}
+	if cv.BandwidthX >= scott.BandwidthX {
+		t.Errorf("cross validation BandwidthX = %v, want less than Scott's %v", cv.BandwidthX, scott.BandwidthX)
+	}
+}
+
+func TestKDE2DErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		x, y   []float64
+		nx, ny int
+		method Bandwidth
+	}{
+		{name: "different lengths", x: []float64{1, 2, 3}, y: []float64{1, 2}, nx: 5, ny: 5},
+		{name: "one point", x: []float64{1}, y: []float64{1}, nx: 5, ny: 5},
+		{name: "small grid", x: []float64{1, 2, 3}, y: []float64{3, 1, 2}, nx: 1, ny: 5},
+		{name: "NaN", x: []float64{1, math.NaN(), 3}, y: []float64{3, 1, 2}, nx: 5, ny: 5},
+		{name: "zero spread", x: []float64{2, 2, 2}, y: []float64{3, 1, 2}, nx: 5, ny: 5},
+		{name: "unknown method", x: []float64{1, 2, 3}, y: []float64{3, 1, 2}, nx: 5, ny: 5, method: Bandwidth(99)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := KDE2D(tt.x, tt.y, tt.nx, tt.ny, tt.method); err == nil {
+				t.Errorf("KDE2D() expected error but got none")
+			}
+		})
+	}
+}
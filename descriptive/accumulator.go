@@ -0,0 +1,165 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"errors"
+	"math"
+)
+
+// Summary holds the descriptive statistics of a series of values, as
+// returned by Accumulator.Value.
+type Summary struct {
+	// N is the number of values.
+	N int
+	// Mean is the arithmetic mean.
+	Mean float64
+	// Variance and StdDev are the sample variance and standard deviation,
+	// as Variance and StdDev. They are NaN for a single value.
+	Variance float64
+	StdDev   float64
+	// Skewness is the moment coefficient of skewness, m3/m2^1.5, and
+	// ExcessKurtosis is the moment coefficient of kurtosis less 3,
+	// m4/m2² - 3, where mk is the kth central moment of the values. Both
+	// are 0 for normally distributed data, and NaN if every value is
+	// equal.
+	Skewness       float64
+	ExcessKurtosis float64
+	// Min and Max are the smallest and largest values.
+	Min float64
+	Max float64
+}
+
+// Accumulator maintains descriptive statistics over a stream of values
+// without storing them: the count, mean, smallest and largest values, and
+// the sums of powers of the deviations from the mean from which the
+// variance, skewness and kurtosis follow. The sums are kept with the
+// numerically stable updates of Welford and Pébay, which avoid the
+// catastrophic cancellation of raw sums of powers.
+//
+// The zero value is an empty accumulator ready to use. An Accumulator is
+// not safe for concurrent use.
+type Accumulator struct {
+	n    int
+	mean float64
+	// m2, m3 and m4 are the sums of the second, third and fourth powers of
+	// the deviations from the mean.
+	m2  float64
+	m3  float64
+	m4  float64
+	min float64
+	max float64
+}
+
+// Add includes x in the statistics.
+//
+// An error is returned if x is NaN or ±Inf.
+func (a *Accumulator) Add(x float64) error {
+	if math.IsNaN(x) || math.IsInf(x, 0) {
+		return errors.New("values must be finite")
+	}
+
+	if a.n == 0 {
+		a.min, a.max = x, x
+	} else {
+		a.min, a.max = min(a.min, x), max(a.max, x)
+	}
+
+	n1 := float64(a.n)
+	a.n++
+	n := float64(a.n)
+	delta := x - a.mean
+	dn := delta / n
+	dn2 := dn * dn
+	term := delta * dn * n1
+
+	// The higher sums depend on the lower ones before the update.
+	a.mean += dn
+	a.m4 += term*dn2*(n*n-3*n+3) + 6*dn2*a.m2 - 4*dn*a.m3
+	a.m3 += term*dn*(n-2) - 3*dn*a.m2
+	a.m2 += term
+
+	return nil
+}
+
+// Merge adds every value included in other to a, leaving other unchanged.
+// The result is the same, up to rounding, as adding all of the values to a
+// single accumulator, so shards of a stream can be accumulated
+// independently and then combined.
+func (a *Accumulator) Merge(other *Accumulator) {
+	b := *other
+	if b.n == 0 {
+		return
+	}
+	if a.n == 0 {
+		*a = b
+
+		return
+	}
+
+	// The pairwise updates of Chan, Golub and LeVeque (1979), extended to
+	// the higher moments by Pébay (2008).
+	na := float64(a.n)
+	nb := float64(b.n)
+	n := na + nb
+	delta := b.mean - a.mean
+	d2 := delta * delta
+
+	m4 := a.m4 + b.m4 + d2*d2*na*nb*(na*na-na*nb+nb*nb)/(n*n*n) +
+		6*d2*(na*na*b.m2+nb*nb*a.m2)/(n*n) + 4*delta*(na*b.m3-nb*a.m3)/n
+	m3 := a.m3 + b.m3 + d2*delta*na*nb*(na-nb)/(n*n) + 3*delta*(na*b.m2-nb*a.m2)/n
+	m2 := a.m2 + b.m2 + d2*na*nb/n
+
+	a.n += b.n
+	a.mean += delta * nb / n
+	a.m2, a.m3, a.m4 = m2, m3, m4
+	a.min, a.max = min(a.min, b.min), max(a.max, b.max)
+}
+
+// N returns the number of values included.
+func (a *Accumulator) N() int {
+	return a.n
+}
+
+// Value returns the statistics of the values included.
+//
+// An error is returned if no values have been added.
+func (a *Accumulator) Value() (Summary, error) {
+	if a.n == 0 {
+		return Summary{}, errors.New("summary requires at least 1 value")
+	}
+
+	n := float64(a.n)
+	variance := math.NaN()
+	if a.n > 1 {
+		variance = max(0, a.m2) / (n - 1)
+	}
+	skewness, kurtosis := math.NaN(), math.NaN()
+	if a.m2 > 0 {
+		skewness = math.Sqrt(n) * a.m3 / math.Pow(a.m2, 1.5)
+		kurtosis = n*a.m4/(a.m2*a.m2) - 3
+	}
+
+	return Summary{
+		N:              a.n,
+		Mean:           a.mean,
+		Variance:       variance,
+		StdDev:         math.Sqrt(variance),
+		Skewness:       skewness,
+		ExcessKurtosis: kurtosis,
+		Min:            a.min,
+		Max:            a.max,
+	}, nil
+}
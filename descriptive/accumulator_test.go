@@ -0,0 +1,148 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestAccumulator(t *testing.T) {
+	// Central moments about the mean 5: m2 = 32, m3 = 42, m4 = 356.
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+	var a Accumulator
+	for _, v := range data {
+		if err := a.Add(v); err != nil {
+			t.Fatalf("Add(%v) unexpected error: %v", v, err)
+		}
+	}
+
+	got, err := a.Value()
+	if err != nil {
+		t.Fatalf("Value() unexpected error: %v", err)
+	}
+	want := Summary{
+		N:              8,
+		Mean:           5,
+		Variance:       32.0 / 7,
+		StdDev:         math.Sqrt(32.0 / 7),
+		Skewness:       0.65625,
+		ExcessKurtosis: -0.21875,
+		Min:            2,
+		Max:            9,
+	}
+	if got.N != want.N || got.Min != want.Min || got.Max != want.Max {
+		t.Errorf("Value() = %+v, want %+v", got, want)
+	}
+	for _, f := range []struct {
+		name      string
+		got, want float64
+	}{
+		{name: "Mean", got: got.Mean, want: want.Mean},
+		{name: "Variance", got: got.Variance, want: want.Variance},
+		{name: "StdDev", got: got.StdDev, want: want.StdDev},
+		{name: "Skewness", got: got.Skewness, want: want.Skewness},
+		{name: "ExcessKurtosis", got: got.ExcessKurtosis, want: want.ExcessKurtosis},
+	} {
+		if math.Abs(f.got-f.want) > 1e-12 {
+			t.Errorf("Value().%s = %v, want %v", f.name, f.got, f.want)
+		}
+	}
+	if a.N() != 8 {
+		t.Errorf("N() = %d, want 8", a.N())
+	}
+}
+
+func TestAccumulatorMerge(t *testing.T) {
+	// Shards merged in any grouping must match a single accumulator, even
+	// far from zero where raw sums of powers would cancel.
+	rng := rand.New(rand.NewSource(5))
+	data := make([]float64, 3000)
+	for i := range data {
+		data[i] = 1e6 + rng.ExpFloat64()
+	}
+
+	var all Accumulator
+	shards := make([]Accumulator, 4)
+	for i, v := range data {
+		_ = all.Add(v)
+		_ = shards[i*i%len(shards)].Add(v)
+	}
+	var merged Accumulator
+	merged.Merge(&Accumulator{})
+	for i := range shards {
+		merged.Merge(&shards[i])
+	}
+
+	want, _ := all.Value()
+	got, err := merged.Value()
+	if err != nil {
+		t.Fatalf("Value() unexpected error: %v", err)
+	}
+	if got.N != want.N || got.Min != want.Min || got.Max != want.Max {
+		t.Errorf("merged Value() = %+v, want %+v", got, want)
+	}
+	for _, f := range [][2]float64{
+		{got.Mean, want.Mean},
+		{got.Variance, want.Variance},
+		{got.Skewness, want.Skewness},
+		{got.ExcessKurtosis, want.ExcessKurtosis},
+	} {
+		if math.Abs(f[0]-f[1]) > 1e-9*max(1, math.Abs(f[1])) {
+			t.Errorf("merged Value() = %+v, want %+v", got, want)
+		}
+	}
+
+	// An exponential distribution has variance 1, skewness 2 and excess
+	// kurtosis 6, which the sample estimates approach.
+	mean, _ := Mean(data)
+	variance, _ := Variance(data)
+	if math.Abs(want.Mean-mean) > 1e-9 || math.Abs(want.Variance-variance) > 1e-9 {
+		t.Errorf("Value() mean, variance = %v, %v, want %v, %v", want.Mean, want.Variance, mean, variance)
+	}
+	if math.Abs(want.Skewness-2) > 0.5 || math.Abs(want.ExcessKurtosis-6) > 3 {
+		t.Errorf("Value() skewness, kurtosis = %v, %v, want about 2, 6", want.Skewness, want.ExcessKurtosis)
+	}
+}
+
+func TestAccumulatorDegenerate(t *testing.T) {
+	var a Accumulator
+	if _, err := a.Value(); err == nil || !strings.Contains(err.Error(), "at least 1") {
+		t.Errorf("Value() of empty error = %v, want one containing %q", err, "at least 1")
+	}
+	if err := a.Add(math.NaN()); err == nil || !strings.Contains(err.Error(), "finite") {
+		t.Errorf("Add(NaN) error = %v, want one containing %q", err, "finite")
+	}
+	if err := a.Add(math.Inf(-1)); err == nil {
+		t.Errorf("Add(-Inf) error = nil, want an error")
+	}
+
+	_ = a.Add(3)
+	got, err := a.Value()
+	if err != nil {
+		t.Fatalf("Value() unexpected error: %v", err)
+	}
+	if got.Mean != 3 || got.Min != 3 || got.Max != 3 || !math.IsNaN(got.Variance) || !math.IsNaN(got.Skewness) {
+		t.Errorf("Value() of one value = %+v, want mean 3 and NaN variance and skewness", got)
+	}
+
+	_ = a.Add(3)
+	got, _ = a.Value()
+	if got.Variance != 0 || !math.IsNaN(got.ExcessKurtosis) {
+		t.Errorf("Value() of equal values = %+v, want zero variance and NaN kurtosis", got)
+	}
+}
@@ -0,0 +1,275 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"math/bits"
+	"strconv"
+)
+
+// BigNumeric represents the big number types whose statistics can be
+// calculated.
+type BigNumeric interface {
+	*big.Float | *big.Int
+}
+
+// maxPrecision is the largest precision that can be set with WithPrecision,
+// leaving room below big.MaxPrec for the guard bits of intermediate values.
+const maxPrecision = big.MaxPrec - 128
+
+// BigOption configures the big.Float calculations of the *Big functions.
+type BigOption func(*bigOptions)
+
+// bigOptions holds the settings accumulated from a list of BigOption values.
+type bigOptions struct {
+	// prec is the precision of the result in bits, or 0 to derive it from
+	// the inputs.
+	prec uint
+}
+
+// WithPrecision sets the precision, in bits, of the result of a big.Float
+// calculation. By default it is the largest precision among the inputs,
+// and at least 64 bits. Intermediate values carry extra guard bits, so the
+// result is accurate to about this precision.
+func WithPrecision(prec uint) BigOption {
+	return func(o *bigOptions) {
+		o.prec = prec
+	}
+}
+
+// GeometricMeanBig returns the geometric mean of data, as GeometricMean,
+// computed in big.Float arithmetic. The product of the values is formed
+// exactly enough for the result to be accurate to its full precision. Its
+// binary exponent is kept apart from its mantissa as a 64-bit sum, so the
+// product cannot overflow or underflow, even where it lies far outside the
+// exponent range of big.Float.
+//
+// A zero value makes the result 0.
+//
+// An error is returned if data is empty, has a nil, infinite or negative
+// value, or if an option has an invalid value.
+func GeometricMeanBig[T BigNumeric](data []T, opts ...BigOption) (*big.Float, error) {
+	prec, err := workingPrecision(data, opts)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := bigValues(data, "geometric mean")
+	if err != nil {
+		return nil, err
+	}
+
+	// The product is mant × 2^exp. Each factor is split the same way, and
+	// the mantissa renormalized to [0.5, 1) after every multiplication, so
+	// that it stays well inside the range of big.Float.
+	n := len(vals)
+	wp := guardedPrecision(prec, n)
+	mant := new(big.Float).SetPrec(wp).SetInt64(1)
+	m := new(big.Float)
+	var exp int64
+	for _, v := range vals {
+		if v.Sign() == 0 {
+			return new(big.Float).SetPrec(prec), nil
+		}
+		exp += int64(v.MantExp(m))
+		mant.Mul(mant, m)
+		exp += int64(mant.MantExp(mant))
+	}
+
+	// The nth root of mant × 2^exp, with exp = qn + r and 0 <= r < n, is
+	// the nth root of mant × 2^r, scaled by 2^q.
+	q, r := exp/int64(n), exp%int64(n)
+	if r < 0 {
+		q, r = q-1, r+int64(n)
+	}
+	mant.SetMantExp(mant, int(r))
+	root := bigRoot(mant, n)
+
+	return root.SetMantExp(root, int(q)).SetPrec(prec), nil
+}
+
+// HarmonicMeanBig returns the harmonic mean of data, as HarmonicMean,
+// computed in big.Float arithmetic.
+//
+// A zero value makes the result 0.
+//
+// An error is returned if data is empty, has a nil, infinite or negative
+// value, or if an option has an invalid value.
+func HarmonicMeanBig[T BigNumeric](data []T, opts ...BigOption) (*big.Float, error) {
+	prec, err := workingPrecision(data, opts)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := bigValues(data, "harmonic mean")
+	if err != nil {
+		return nil, err
+	}
+
+	n := len(vals)
+	wp := guardedPrecision(prec, n)
+	sum := new(big.Float).SetPrec(wp)
+	r := new(big.Float).SetPrec(wp)
+	one := new(big.Float).SetPrec(wp).SetInt64(1)
+	for _, v := range vals {
+		if v.Sign() == 0 {
+			return new(big.Float).SetPrec(prec), nil
+		}
+		sum.Add(sum, r.Quo(one, v))
+	}
+	count := new(big.Float).SetPrec(wp).SetInt64(int64(n))
+
+	return new(big.Float).SetPrec(prec).Quo(count, sum), nil
+}
+
+// workingPrecision applies opts and returns the precision of the result of
+// a calculation over data.
+//
+// An error is returned if an explicit precision is out of range.
+func workingPrecision[T BigNumeric](data []T, opts []BigOption) (uint, error) {
+	o := bigOptions{prec: 0}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.prec == 0 {
+		return bigInputPrecision(data), nil
+	}
+	if o.prec > maxPrecision {
+		return 0, errors.New("precision must be between 1 and " + strconv.FormatUint(maxPrecision, 10) + " bits")
+	}
+
+	return o.prec, nil
+}
+
+// bigInputPrecision returns the default precision for big inputs: the
+// largest precision among them, and at least 64 bits. A *big.Int counts as
+// having as many bits of precision as it has significant bits.
+func bigInputPrecision[T BigNumeric](data []T) uint {
+	var prec uint = 64
+	for _, v := range data {
+		switch v := any(v).(type) {
+		case *big.Float:
+			if v != nil {
+				prec = max(prec, v.Prec())
+			}
+		case *big.Int:
+			if v != nil {
+				prec = max(prec, uint(v.BitLen()))
+			}
+		}
+	}
+
+	return prec
+}
+
+// guardedPrecision returns prec with enough guard bits added to absorb the
+// rounding errors of about n operations.
+func guardedPrecision(prec uint, n int) uint {
+	return prec + 32 + uint(bits.Len(uint(n)))
+}
+
+// bigValues returns data as *big.Float values for reading, after checking
+// that it is not empty and its values are finite and non-negative. name
+// names the statistic in error messages.
+func bigValues[T BigNumeric](data []T, name string) ([]*big.Float, error) {
+	if len(data) == 0 {
+		return nil, errors.New(name + " requires at least 1 value")
+	}
+
+	vals := make([]*big.Float, len(data))
+	for i, v := range data {
+		switch v := any(v).(type) {
+		case *big.Float:
+			if v == nil {
+				return nil, errors.New(name + " requires non-nil values")
+			}
+			if v.IsInf() {
+				return nil, errors.New(name + " requires finite values")
+			}
+			vals[i] = v
+		case *big.Int:
+			if v == nil {
+				return nil, errors.New(name + " requires non-nil values")
+			}
+			vals[i] = new(big.Float).SetPrec(0).SetInt(v)
+		}
+		if vals[i].Sign() < 0 {
+			return nil, errors.New(name + " requires non-negative values")
+		}
+	}
+
+	return vals, nil
+}
+
+// bigRoot returns the positive nth root of the positive x, at the
+// precision of x, by Newton's method from a float64 estimate.
+func bigRoot(x *big.Float, n int) *big.Float {
+	if n == 1 {
+		return x
+	}
+	prec := x.Prec()
+
+	// x = m × 2^e with m in [0.5, 1), so log2(x)/n = (e + log2(m))/n
+	// splits into an integer exponent and a float64 mantissa.
+	m := new(big.Float)
+	e := x.MantExp(m)
+	mf, _ := m.Float64()
+	l := (float64(e) + math.Log2(mf)) / float64(n)
+	whole := math.Floor(l)
+	y := new(big.Float).SetPrec(prec).SetFloat64(math.Exp2(l - whole))
+	y.SetMantExp(y, int(whole))
+
+	// Each step y = ((n-1)y + x/y^(n-1)) / n roughly doubles the number of
+	// correct bits, so a few dozen steps reach any precision from the 50
+	// or so bits of the estimate.
+	nf := new(big.Float).SetPrec(prec).SetInt64(int64(n))
+	n1 := new(big.Float).SetPrec(prec).SetInt64(int64(n - 1))
+	next := new(big.Float).SetPrec(prec)
+	q := new(big.Float).SetPrec(prec)
+	diff := new(big.Float).SetPrec(prec)
+	for range 64 {
+		q.Quo(x, bigPow(y, n-1))
+		next.Mul(n1, y)
+		next.Add(next, q)
+		next.Quo(next, nf)
+		diff.Sub(next, y)
+		y.Set(next)
+		if diff.Sign() == 0 || diff.MantExp(nil) < y.MantExp(nil)-int(prec)+2 {
+			break
+		}
+	}
+
+	return y
+}
+
+// bigPow returns x^k for k >= 1 at the precision of x, by repeated
+// squaring.
+func bigPow(x *big.Float, k int) *big.Float {
+	result := new(big.Float).SetPrec(x.Prec()).SetInt64(1)
+	base := new(big.Float).SetPrec(x.Prec()).Set(x)
+	for k > 0 {
+		if k&1 == 1 {
+			result.Mul(result, base)
+		}
+		k >>= 1
+		if k > 0 {
+			base.Mul(base, base)
+		}
+	}
+
+	return result
+}
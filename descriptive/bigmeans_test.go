@@ -0,0 +1,141 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// bigFloats returns vs as *big.Float values of precision prec.
+func bigFloats(prec uint, vs ...float64) []*big.Float {
+	out := make([]*big.Float, len(vs))
+	for i, v := range vs {
+		out[i] = new(big.Float).SetPrec(prec).SetFloat64(v)
+	}
+
+	return out
+}
+
+// closeBig reports whether got is within a relative 2^-bits of want.
+func closeBig(got, want *big.Float, bits int) bool {
+	diff := new(big.Float).Sub(got, want)
+	if diff.Sign() == 0 {
+		return true
+	}
+
+	return diff.MantExp(nil) <= want.MantExp(nil)-bits
+}
+
+func TestGeometricMeanBig(t *testing.T) {
+	sqrt8 := new(big.Float).SetPrec(300).Sqrt(big.NewFloat(8))
+	got, err := GeometricMeanBig(bigFloats(300, 1, 2, 4, 8))
+	if err != nil {
+		t.Fatalf("GeometricMeanBig() unexpected error: %v", err)
+	}
+	if got.Prec() != 300 || !closeBig(got, sqrt8, 296) {
+		t.Errorf("GeometricMeanBig(1, 2, 4, 8) = %v with precision %d, want %v with precision 300",
+			got.Text('g', 90), got.Prec(), sqrt8.Text('g', 90))
+	}
+
+	// Products far beyond the range of float64.
+	huge := []*big.Float{
+		new(big.Float).SetMantExp(big.NewFloat(1), 100000),
+		new(big.Float).SetMantExp(big.NewFloat(1), 200000),
+	}
+	want := new(big.Float).SetMantExp(big.NewFloat(1), 150000)
+	if got, err := GeometricMeanBig(huge); err != nil || !closeBig(got, want, 60) {
+		t.Errorf("GeometricMeanBig(2^100000, 2^200000) = %v, %v, want 2^150000", got, err)
+	}
+
+	// Products beyond the exponent range of big.Float itself. The values
+	// are printed in binary, as the decimal digits of such numbers would
+	// take very long to find.
+	for _, e := range []int{1 << 30, -(1 << 30)} {
+		v := new(big.Float).SetMantExp(big.NewFloat(1), e)
+		for _, data := range [][]*big.Float{{v, v}, {v, v, v}} {
+			if got, err := GeometricMeanBig(data); err != nil || !closeBig(got, v, 60) {
+				t.Errorf("GeometricMeanBig() of %d values of 2^%d = %s, %v, want 2^%d", len(data), e, got.Text('p', 0), err, e)
+			}
+		}
+	}
+	const e = 3 << 29
+	mixed := []*big.Float{
+		new(big.Float).SetMantExp(big.NewFloat(0.75), e),
+		new(big.Float).SetMantExp(big.NewFloat(0.75), e),
+		new(big.Float).SetMantExp(big.NewFloat(0.75), -e),
+	}
+	want = new(big.Float).SetMantExp(big.NewFloat(0.75), e/3)
+	if got, err := GeometricMeanBig(mixed); err != nil || !closeBig(got, want, 60) {
+		t.Errorf("GeometricMeanBig(0.75×2^%d, 0.75×2^%d, 0.75×2^-%d) = %s, %v, want %s", e, e, e, got.Text('p', 0), err, want.Text('p', 0))
+	}
+
+	ints := []*big.Int{big.NewInt(2), big.NewInt(4), big.NewInt(8)}
+	got, err = GeometricMeanBig(ints, WithPrecision(128))
+	if err != nil || got.Prec() != 128 || !closeBig(got, big.NewFloat(4), 124) {
+		t.Errorf("GeometricMeanBig(2, 4, 8) = %v, %v, want 4 with precision 128", got, err)
+	}
+
+	if got, err := GeometricMeanBig(bigFloats(64, 3, 0)); err != nil || got.Sign() != 0 {
+		t.Errorf("GeometricMeanBig() with zero = %v, %v, want 0", got, err)
+	}
+}
+
+func TestHarmonicMeanBig(t *testing.T) {
+	want := new(big.Float).SetPrec(200).Quo(big.NewFloat(12), big.NewFloat(7))
+	got, err := HarmonicMeanBig(bigFloats(200, 1, 2, 4))
+	if err != nil {
+		t.Fatalf("HarmonicMeanBig() unexpected error: %v", err)
+	}
+	if got.Prec() != 200 || !closeBig(got, want, 196) {
+		t.Errorf("HarmonicMeanBig(1, 2, 4) = %v, want %v", got.Text('g', 60), want.Text('g', 60))
+	}
+
+	ints := []*big.Int{big.NewInt(40), big.NewInt(60)}
+	if got, err := HarmonicMeanBig(ints); err != nil || got.Cmp(big.NewFloat(48)) != 0 {
+		t.Errorf("HarmonicMeanBig(40, 60) = %v, %v, want 48", got, err)
+	}
+
+	if got, err := HarmonicMeanBig(bigFloats(64, 3, 0)); err != nil || got.Sign() != 0 {
+		t.Errorf("HarmonicMeanBig() with zero = %v, %v, want 0", got, err)
+	}
+}
+
+func TestBigMeansErrors(t *testing.T) {
+	inf := new(big.Float).SetInf(false)
+	tests := []struct {
+		name    string
+		fn      func() error
+		wantErr string
+	}{
+		{name: "geometric empty", fn: func() error { _, err := GeometricMeanBig([]*big.Float{}); return err }, wantErr: "at least 1"},
+		{name: "geometric nil", fn: func() error { _, err := GeometricMeanBig([]*big.Int{nil}); return err }, wantErr: "non-nil"},
+		{name: "geometric infinite", fn: func() error { _, err := GeometricMeanBig([]*big.Float{inf}); return err }, wantErr: "finite"},
+		{name: "geometric negative", fn: func() error { _, err := GeometricMeanBig(bigFloats(64, 1, -1)); return err }, wantErr: "non-negative"},
+		{name: "harmonic empty", fn: func() error { _, err := HarmonicMeanBig([]*big.Int{}); return err }, wantErr: "at least 1"},
+		{name: "harmonic nil", fn: func() error { _, err := HarmonicMeanBig([]*big.Float{nil}); return err }, wantErr: "non-nil"},
+		{name: "harmonic negative", fn: func() error { _, err := HarmonicMeanBig([]*big.Int{big.NewInt(-2)}); return err }, wantErr: "non-negative"},
+		{name: "precision", fn: func() error { _, err := HarmonicMeanBig(bigFloats(64, 1), WithPrecision(big.MaxPrec)); return err }, wantErr: "precision"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.fn(); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
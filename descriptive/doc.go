@@ -0,0 +1,56 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package descriptive holds routines for the descriptive statistics of a
+single series of values: its sum, its arithmetic, geometric and harmonic
+means, its variance and standard deviation, its median absolute
+deviation, its smallest and largest values, its median and other
+quantiles, and its most frequent values.
+
+Every function takes a slice of any primitive numeric type, and Mode and
+ValueCounts also take strings, for categorical data. The results are
+float64, except for Min, Max and Mode, which return values of the slice.
+Sums are compensated, so their rounding error does not grow with the number
+of values, and the variance uses the corrected two pass algorithm, which is
+accurate even when the mean is large compared with the spread.
+GeometricMeanBig and HarmonicMeanBig take *big.Float or *big.Int values and
+compute their means in big.Float arithmetic to any precision.
+
+Quantile interpolates between order statistics using one of the nine
+methods of Hyndman and Fan, defaulting to R-7. It finds the values it needs
+by selection rather than sorting, so it takes linear time; Percentiles,
+which computes several quantiles at once, sorts a copy of the data instead.
+
+An Accumulator computes the count, mean, variance, skewness, kurtosis and
+range of a stream of values without storing them, and accumulators of
+separate shards of a stream can be merged.
+
+NewHistogram counts values in bins of equal width, choosing the number of
+bins by Sturges', Scott's or Freedman and Diaconis' rule, and reports the
+density of each bin.
+
+A NaN value makes the result NaN. Functions whose result is undefined for
+the data, such as the mean of no values, return an error.
+
+For example:
+
+	data := []float64{2, 4, 4, 4, 5, 5, 7, 9}
+
+	mean, err := descriptive.Mean(data)            // 5
+	sd, err := descriptive.PopulationStdDev(data)  // 2
+	s, err := descriptive.StdDev(data)             // ~2.138
+	med, err := descriptive.Median(data)           // 4.5
+*/
+package descriptive
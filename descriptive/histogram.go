@@ -0,0 +1,231 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"errors"
+	"math"
+)
+
+// BinRule selects how the number of bins of a histogram is chosen from the
+// data.
+type BinRule int
+
+const (
+	// Sturges uses Sturges' rule, ⌈log2 n⌉ + 1 bins. It suits roughly
+	// normal data of moderate size, and gives too few bins for large or
+	// skewed data. This is the default value.
+	Sturges BinRule = iota
+	// FreedmanDiaconis uses bins of width 2 IQR n^(-1/3), which is robust
+	// to outliers and long tails. If the interquartile range is zero it
+	// falls back to Sturges' rule.
+	FreedmanDiaconis
+	// Scott uses bins of width 3.49 σ n^(-1/3), which minimizes the mean
+	// integrated squared error of the density estimate for normal data.
+	Scott
+)
+
+// String returns the string representation of the BinRule.
+func (r BinRule) String() string {
+	switch r {
+	case Sturges:
+		return "Sturges"
+	case FreedmanDiaconis:
+		return "Freedman-Diaconis"
+	case Scott:
+		return "Scott"
+	default:
+		return "Unknown"
+	}
+}
+
+// HistogramOption configures NewHistogram.
+type HistogramOption func(*histogramOptions)
+
+// histogramOptions holds the settings accumulated from a list of
+// HistogramOption values.
+type histogramOptions struct {
+	rule BinRule
+	// bins is the number of bins, or 0 to choose it by rule.
+	bins int
+}
+
+// WithBinRule chooses the number of bins by rule, Sturges by default.
+func WithBinRule(rule BinRule) HistogramOption {
+	return func(o *histogramOptions) {
+		o.rule = rule
+	}
+}
+
+// WithBins sets the number of bins, overriding the bin rule.
+func WithBins(bins int) HistogramOption {
+	return func(o *histogramOptions) {
+		o.bins = bins
+	}
+}
+
+// Histogram holds the counts of a series of values in bins of equal width
+// spanning their range.
+type Histogram struct {
+	// Edges holds the edges of the bins in increasing order, one more than
+	// there are bins. Bin i holds the values in [Edges[i], Edges[i+1]),
+	// except that the last bin also holds the values equal to its upper
+	// edge, the largest value.
+	Edges []float64
+	// Counts holds the number of values in each bin.
+	Counts []int
+}
+
+// NewHistogram returns a histogram of data with bins of equal width from
+// its smallest to its largest value. The number of bins is chosen by the
+// bin rule set with WithBinRule, or set with WithBins, and a rule never
+// gives more bins than there are values. If every value is equal, the
+// single bin spans from half below to half above it.
+//
+// An error is returned if data is empty or contains NaN or ±Inf, or if the
+// bin rule is unknown or the number of bins is not positive.
+func NewHistogram[T Numeric](data []T, opts ...HistogramOption) (*Histogram, error) {
+	o := histogramOptions{rule: Sturges, bins: 0}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("histogram requires at least 1 value")
+	}
+	if o.bins < 0 {
+		return nil, errors.New("number of bins must be positive")
+	}
+	if o.rule < Sturges || o.rule > Scott {
+		return nil, errors.New("unknown bin rule")
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range data {
+		f := float64(v)
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, errors.New("values must be finite")
+		}
+		lo, hi = min(lo, f), max(hi, f)
+	}
+
+	k := o.bins
+	if k == 0 {
+		k = binCount(data, lo, hi, o.rule)
+	}
+	if lo == hi {
+		lo, hi = lo-0.5, hi+0.5
+	}
+
+	h := &Histogram{
+		Edges:  make([]float64, k+1),
+		Counts: make([]int, k),
+	}
+	// The width, and the offset of each edge from lo, are taken in parts
+	// where need be, as the span of values of opposite sign can overflow
+	// float64 even though every edge lies within it.
+	width := hi/float64(k) - lo/float64(k)
+	for i := range k {
+		offset := float64(i) * width
+		if math.IsInf(offset, 0) {
+			h.Edges[i] = 2 * (lo/2 + float64(i)*(width/2))
+
+			continue
+		}
+		h.Edges[i] = lo + offset
+	}
+	h.Edges[k] = hi
+	for _, v := range data {
+		h.Counts[h.Bin(float64(v))]++
+	}
+
+	return h, nil
+}
+
+// binCount returns the number of bins that rule chooses for data, which
+// spans from lo to hi: 1 if lo equals hi.
+func binCount[T Numeric](data []T, lo, hi float64, rule BinRule) int {
+	if lo == hi {
+		return 1
+	}
+	n := float64(len(data))
+	sturges := int(math.Ceil(math.Log2(n))) + 1
+
+	var width float64
+	switch rule {
+	case FreedmanDiaconis:
+		q, _ := Percentiles(data, []float64{25, 75})
+		width = 2 * (q[1] - q[0]) * math.Cbrt(1/n)
+	case Scott:
+		sd := math.Sqrt(sumSquares(data) / max(1, n-1))
+		width = 3.49 * sd * math.Cbrt(1/n)
+	}
+	// A width that overflowed, or came to nothing, says nothing about the
+	// number of bins.
+	count := math.Ceil(hi/width - lo/width)
+	if !(count >= 1 && count <= math.MaxFloat64) {
+		return min(sturges, len(data))
+	}
+
+	return int(min(count, n))
+}
+
+// N returns the number of values counted.
+func (h *Histogram) N() int {
+	n := 0
+	for _, c := range h.Counts {
+		n += c
+	}
+
+	return n
+}
+
+// Bin returns the index of the bin holding x, or -1 if x lies outside the
+// histogram.
+func (h *Histogram) Bin(x float64) int {
+	k := len(h.Counts)
+	if !(x >= h.Edges[0] && x <= h.Edges[k]) {
+		return -1
+	}
+
+	// The estimate can be off by one where rounding puts x just across an
+	// edge, so it is corrected against the edges themselves. Where the span
+	// overflows float64 everything is halved first, which leaves the ratio
+	// unchanged.
+	offset, span := x-h.Edges[0], h.Edges[k]-h.Edges[0]
+	if math.IsInf(span, 0) {
+		offset, span = x/2-h.Edges[0]/2, h.Edges[k]/2-h.Edges[0]/2
+	}
+	i := min(int(offset/span*float64(k)), k-1)
+	if x < h.Edges[i] {
+		i--
+	} else if i < k-1 && x >= h.Edges[i+1] {
+		i++
+	}
+
+	return i
+}
+
+// Densities returns the density of each bin, its count divided by the
+// total count and the width of the bin, so that the histogram encloses an
+// area of 1 and estimates the probability density of the data.
+func (h *Histogram) Densities() []float64 {
+	n := float64(h.N())
+	out := make([]float64, len(h.Counts))
+	for i, c := range h.Counts {
+		out[i] = float64(c) / n / (h.Edges[i+1] - h.Edges[i])
+	}
+
+	return out
+}
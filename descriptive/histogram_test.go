@@ -0,0 +1,211 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNewHistogramRules(t *testing.T) {
+	data := make([]int, 100)
+	for i := range data {
+		data[i] = i + 1
+	}
+	outlier := slices.Clone(data)
+	outlier[99] = 1e9
+
+	tests := []struct {
+		name string
+		data []int
+		rule BinRule
+		want int
+	}{
+		// ⌈log2 100⌉ + 1.
+		{name: "Sturges", data: data, rule: Sturges, want: 8},
+		// IQR 49.5, width 2 × 49.5 / ∛100 ≈ 21.3 over a span of 99.
+		{name: "Freedman-Diaconis", data: data, rule: FreedmanDiaconis, want: 5},
+		// σ ≈ 29.0, width 3.49σ / ∛100 ≈ 21.8.
+		{name: "Scott", data: data, rule: Scott, want: 5},
+		{name: "capped", data: outlier, rule: FreedmanDiaconis, want: 100},
+		{name: "zero IQR", data: []int{1, 5, 5, 5, 5, 5, 5, 9}, rule: FreedmanDiaconis, want: 4},
+		{name: "constant", data: []int{3, 3, 3}, rule: Scott, want: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			h, err := NewHistogram(test.data, WithBinRule(test.rule))
+			if err != nil {
+				t.Fatalf("NewHistogram() unexpected error: %v", err)
+			}
+			if len(h.Counts) != test.want || len(h.Edges) != test.want+1 {
+				t.Errorf("NewHistogram() by %v has %d bins and %d edges, want %d bins",
+					test.rule, len(h.Counts), len(h.Edges), test.want)
+			}
+			if h.N() != len(test.data) {
+				t.Errorf("N() = %d, want %d", h.N(), len(test.data))
+			}
+		})
+	}
+}
+
+func TestNewHistogramExtreme(t *testing.T) {
+	// The span is finite, but the squared deviations of Scott's rule
+	// overflow.
+	data := []float64{-8e307, 0, 1, 2, 8e307}
+	for _, rule := range []BinRule{Sturges, FreedmanDiaconis, Scott} {
+		h, err := NewHistogram(data, WithBinRule(rule))
+		if err != nil {
+			t.Fatalf("NewHistogram() by %v unexpected error: %v", rule, err)
+		}
+		if h.N() != len(data) || h.Edges[0] != data[0] || h.Edges[len(h.Edges)-1] != data[4] {
+			t.Errorf("NewHistogram() by %v = %+v, want %d values spanning the data", rule, h, len(data))
+		}
+	}
+
+	// The spans of these overflow float64.
+	for _, data := range [][]float64{
+		{-1e308, 0, 1, 2, 1e308},
+		{-math.MaxFloat64, 0, 1, 2, math.MaxFloat64},
+	} {
+		for _, rule := range []BinRule{Sturges, FreedmanDiaconis, Scott} {
+			h, err := NewHistogram(data, WithBinRule(rule))
+			if err != nil {
+				t.Fatalf("NewHistogram(%v) by %v unexpected error: %v", data, rule, err)
+			}
+			k := len(h.Counts)
+			if h.N() != len(data) || h.Edges[0] != data[0] || h.Edges[k] != data[4] {
+				t.Errorf("NewHistogram(%v) by %v = %+v, want %d values spanning the data", data, rule, h, len(data))
+			}
+			if got := h.Bin(data[0]); got != 0 {
+				t.Errorf("Bin(%v) = %d, want 0", data[0], got)
+			}
+			if got := h.Bin(data[4]); got != k-1 {
+				t.Errorf("Bin(%v) = %d, want %d", data[4], got, k-1)
+			}
+			for i, d := range h.Densities() {
+				if !(d >= 0) || math.IsInf(d, 0) {
+					t.Errorf("Densities()[%d] = %v, want a finite density", i, d)
+				}
+			}
+		}
+	}
+}
+
+func TestNewHistogramBins(t *testing.T) {
+	h, err := NewHistogram([]float64{0, 1, 2, 3, 4, 5, 6, 7, 8}, WithBins(4))
+	if err != nil {
+		t.Fatalf("NewHistogram() unexpected error: %v", err)
+	}
+	if want := []float64{0, 2, 4, 6, 8}; !slices.Equal(h.Edges, want) {
+		t.Errorf("Edges = %v, want %v", h.Edges, want)
+	}
+	// The largest value falls in the last bin.
+	if want := []int{2, 2, 2, 3}; !slices.Equal(h.Counts, want) {
+		t.Errorf("Counts = %v, want %v", h.Counts, want)
+	}
+	wantDensities := []float64{2.0 / 18, 2.0 / 18, 2.0 / 18, 3.0 / 18}
+	for i, d := range h.Densities() {
+		if math.Abs(d-wantDensities[i]) > 1e-15 {
+			t.Errorf("Densities() = %v, want %v", h.Densities(), wantDensities)
+
+			break
+		}
+	}
+
+	h, _ = NewHistogram([]int{7}, WithBins(1))
+	if want := []float64{6.5, 7.5}; !slices.Equal(h.Edges, want) || h.Counts[0] != 1 {
+		t.Errorf("NewHistogram(7) = %+v, want edges %v and count 1", h, want)
+	}
+}
+
+func TestHistogramBin(t *testing.T) {
+	// Every value must land in the bin whose edges hold it, however the
+	// division of the range rounds.
+	rng := rand.New(rand.NewSource(9))
+	data := make([]float64, 1000)
+	for i := range data {
+		data[i] = 0.1 + rng.NormFloat64()*0.3
+	}
+	h, err := NewHistogram(data, WithBins(37))
+	if err != nil {
+		t.Fatalf("NewHistogram() unexpected error: %v", err)
+	}
+	for _, x := range append(data, h.Edges...) {
+		i := h.Bin(x)
+		last := i == len(h.Counts)-1
+		if i < 0 || x < h.Edges[i] || (x >= h.Edges[i+1] && !(last && x == h.Edges[i+1])) {
+			t.Fatalf("Bin(%v) = %d, outside its edges", x, i)
+		}
+	}
+	for _, x := range []float64{h.Edges[0] - 1e-9, h.Edges[37] + 1e-9, math.NaN()} {
+		if got := h.Bin(x); got != -1 {
+			t.Errorf("Bin(%v) = %d, want -1", x, got)
+		}
+	}
+
+	area := 0.0
+	for i, d := range h.Densities() {
+		area += d * (h.Edges[i+1] - h.Edges[i])
+	}
+	if math.Abs(area-1) > 1e-12 {
+		t.Errorf("Densities() enclose an area of %v, want 1", area)
+	}
+}
+
+func TestNewHistogramErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []float64
+		opts    []HistogramOption
+		wantErr string
+	}{
+		{name: "empty", data: []float64{}, opts: nil, wantErr: "at least 1"},
+		{name: "NaN", data: []float64{1, math.NaN()}, opts: nil, wantErr: "finite"},
+		{name: "infinite", data: []float64{math.Inf(1)}, opts: nil, wantErr: "finite"},
+		{name: "negative bins", data: []float64{1}, opts: []HistogramOption{WithBins(-1)}, wantErr: "positive"},
+		{name: "unknown rule", data: []float64{1}, opts: []HistogramOption{WithBinRule(BinRule(9))}, wantErr: "unknown"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewHistogram(test.data, test.opts...)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("NewHistogram() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestBinRuleString(t *testing.T) {
+	tests := []struct {
+		rule BinRule
+		want string
+	}{
+		{rule: Sturges, want: "Sturges"},
+		{rule: FreedmanDiaconis, want: "Freedman-Diaconis"},
+		{rule: Scott, want: "Scott"},
+		{rule: BinRule(-1), want: "Unknown"},
+	}
+
+	for _, test := range tests {
+		if got := test.rule.String(); got != test.want {
+			t.Errorf("BinRule(%d).String() = %q, want %q", int(test.rule), got, test.want)
+		}
+	}
+}
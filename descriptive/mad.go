@@ -0,0 +1,78 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"errors"
+	"math"
+)
+
+// NormalConsistency is the consistency constant of the median absolute
+// deviation for normally distributed data, 1/Φ⁻¹(3/4) ≈ 1.4826. Scaled by
+// it, the MAD of a large normal sample estimates the standard deviation.
+const NormalConsistency = 1.482602218505602
+
+// MADOption configures MAD.
+type MADOption func(*madOptions)
+
+// madOptions holds the settings accumulated from a list of MADOption
+// values.
+type madOptions struct {
+	consistency float64
+}
+
+// WithConsistency scales the median absolute deviation by the consistency
+// constant c, which is 1 by default. NormalConsistency makes it an
+// estimate of the standard deviation of normally distributed data.
+func WithConsistency(c float64) MADOption {
+	return func(o *madOptions) {
+		o.consistency = c
+	}
+}
+
+// MAD returns the median absolute deviation of data, the median of the
+// absolute deviations of its values from their median, multiplied by the
+// consistency constant set with WithConsistency.
+//
+// It is a robust estimate of scale: unlike the standard deviation, it is
+// unaffected by up to half of the values being arbitrarily large. Both
+// medians are found by selection, in O(n) time, and data is not modified.
+// A NaN value makes the result NaN.
+//
+// An error is returned if data is empty or the consistency constant is not
+// finite and positive.
+func MAD[T Numeric](data []T, opts ...MADOption) (float64, error) {
+	o := madOptions{consistency: 1}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if len(data) == 0 {
+		return 0, errors.New("median absolute deviation requires at least 1 value")
+	}
+	if !(o.consistency > 0) || math.IsInf(o.consistency, 1) {
+		return 0, errors.New("consistency constant must be finite and positive")
+	}
+
+	med := selectQuantile(data, 0.5, R7)
+	if math.IsNaN(med) {
+		return med, nil
+	}
+	dev := make([]float64, len(data))
+	for i, v := range data {
+		dev[i] = math.Abs(float64(v) - med)
+	}
+
+	return o.consistency * selectQuantile(dev, 0.5, R7), nil
+}
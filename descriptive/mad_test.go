@@ -0,0 +1,101 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestMAD(t *testing.T) {
+	tests := []struct {
+		name string
+		data []float64
+		opts []MADOption
+		want float64
+	}{
+		{name: "single", data: []float64{7}, opts: nil, want: 0},
+		// Deviations from the median 2 are 1, 1, 0, 0, 2, 4, 7.
+		{name: "odd", data: []float64{1, 1, 2, 2, 4, 6, 9}, opts: nil, want: 1},
+		// Deviations from the median 3.5 are 2.5, 1.5, 0.5, 0.5, 1.5, 2.5.
+		{name: "even", data: []float64{1, 2, 3, 4, 5, 6}, opts: nil, want: 1.5},
+		{name: "outlier", data: []float64{1, 2, 3, 4, 1000}, opts: nil, want: 1},
+		{name: "scaled", data: []float64{1, 2, 3, 4, 1000}, opts: []MADOption{WithConsistency(NormalConsistency)}, want: NormalConsistency},
+		{name: "constant", data: []float64{5, 5, 5}, opts: []MADOption{WithConsistency(2)}, want: 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := MAD(test.data, test.opts...)
+			if err != nil {
+				t.Fatalf("MAD() unexpected error: %v", err)
+			}
+			if got != test.want {
+				t.Errorf("MAD(%v) = %v, want %v", test.data, got, test.want)
+			}
+		})
+	}
+
+	if got, _ := MAD([]int{3, 8, 1}); got != 2 {
+		t.Errorf("MAD(int) = %v, want 2", got)
+	}
+	if got, _ := MAD([]float64{1, math.NaN(), 3}); !math.IsNaN(got) {
+		t.Errorf("MAD() with NaN = %v, want NaN", got)
+	}
+}
+
+func TestMADNormal(t *testing.T) {
+	// Scaled by NormalConsistency, the MAD of a large normal sample is close
+	// to its standard deviation.
+	rng := rand.New(rand.NewSource(3))
+	data := make([]float64, 100000)
+	for i := range data {
+		data[i] = 10 + 2*rng.NormFloat64()
+	}
+
+	got, err := MAD(data, WithConsistency(NormalConsistency))
+	if err != nil {
+		t.Fatalf("MAD() unexpected error: %v", err)
+	}
+	if math.Abs(got-2) > 0.03 {
+		t.Errorf("MAD() of normal data with sd 2 = %v, want about 2", got)
+	}
+}
+
+func TestMADErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []float64
+		c       float64
+		wantErr string
+	}{
+		{name: "empty", data: []float64{}, c: 1, wantErr: "at least 1"},
+		{name: "zero constant", data: []float64{1}, c: 0, wantErr: "consistency"},
+		{name: "negative constant", data: []float64{1}, c: -1, wantErr: "consistency"},
+		{name: "NaN constant", data: []float64{1}, c: math.NaN(), wantErr: "consistency"},
+		{name: "infinite constant", data: []float64{1}, c: math.Inf(1), wantErr: "consistency"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := MAD(test.data, WithConsistency(test.c))
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("MAD() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"errors"
+	"math"
+)
+
+// GeometricMean returns the geometric mean of data, the nth root of the
+// product of its n values. It is the mean suited to ratios and growth
+// rates: the geometric mean of yearly growth factors is the single factor
+// that gives the same growth over all the years.
+//
+// The mean is computed as the exponential of the mean logarithm, so the
+// product never overflows or underflows, at the cost of a relative error
+// of about the magnitude of the mean logarithm times the machine epsilon.
+// GeometricMeanBig computes it to any precision instead.
+//
+// A zero value makes the result 0, and a NaN value makes it NaN.
+//
+// An error is returned if data is empty or has a negative value.
+func GeometricMean[T Numeric](data []T) (float64, error) {
+	if len(data) == 0 {
+		return 0, errors.New("geometric mean requires at least 1 value")
+	}
+
+	var logs neumaierSum
+	for _, v := range data {
+		f := float64(v)
+		if f < 0 {
+			return 0, errors.New("geometric mean requires non-negative values")
+		}
+		logs.add(math.Log(f))
+	}
+
+	return math.Exp(logs.value() / float64(len(data))), nil
+}
+
+// HarmonicMean returns the harmonic mean of data, the reciprocal of the
+// mean of the reciprocals of its values. It is the mean suited to rates
+// over equal amounts: the harmonic mean of the speeds over equal distances
+// is the average speed of the whole journey.
+//
+// The reciprocals are taken relative to the smallest value, so they neither
+// overflow for tiny values nor underflow for huge ones.
+//
+// A zero value makes the result 0, and a NaN value makes it NaN.
+//
+// An error is returned if data is empty or has a negative value.
+func HarmonicMean[T Numeric](data []T) (float64, error) {
+	if len(data) == 0 {
+		return 0, errors.New("harmonic mean requires at least 1 value")
+	}
+
+	lo := math.Inf(1)
+	for _, v := range data {
+		f := float64(v)
+		if f < 0 {
+			return 0, errors.New("harmonic mean requires non-negative values")
+		}
+		lo = min(lo, f)
+	}
+	if lo == 0 || math.IsNaN(lo) || math.IsInf(lo, 1) {
+		return lo, nil
+	}
+
+	// Every ratio is at most 1, and the smallest is exactly 1, so the sum
+	// is between 1 and n.
+	var s neumaierSum
+	for _, v := range data {
+		s.add(lo / float64(v))
+	}
+
+	return lo * (float64(len(data)) / s.value()), nil
+}
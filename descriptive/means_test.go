@@ -0,0 +1,112 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestGeometricMean(t *testing.T) {
+	tests := []struct {
+		name string
+		data []float64
+		want float64
+	}{
+		{name: "single", data: []float64{5}, want: 5},
+		{name: "pair", data: []float64{2, 8}, want: 4},
+		{name: "powers of 2", data: []float64{1, 2, 4, 8}, want: 2 * math.Sqrt2},
+		{name: "growth factors", data: []float64{1.1, 1.2, 0.9}, want: math.Cbrt(1.1 * 1.2 * 0.9)},
+		{name: "huge", data: []float64{1e300, 1e300, 1e300}, want: 1e300},
+		{name: "tiny", data: []float64{1e-300, 1e-300, 1e-300}, want: 1e-300},
+		{name: "zero", data: []float64{3, 0, 5}, want: 0},
+		{name: "infinite", data: []float64{3, math.Inf(1)}, want: math.Inf(1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := GeometricMean(test.data)
+			if err != nil {
+				t.Fatalf("GeometricMean() unexpected error: %v", err)
+			}
+			if got != test.want && math.Abs(got-test.want) > 1e-13*test.want {
+				t.Errorf("GeometricMean(%v) = %v, want %v", test.data, got, test.want)
+			}
+		})
+	}
+
+	if got, _ := GeometricMean([]uint8{4, 9}); got != 6 {
+		t.Errorf("GeometricMean(uint8) = %v, want 6", got)
+	}
+	if got, _ := GeometricMean([]float64{1, math.NaN()}); !math.IsNaN(got) {
+		t.Errorf("GeometricMean() with NaN = %v, want NaN", got)
+	}
+}
+
+func TestHarmonicMean(t *testing.T) {
+	tests := []struct {
+		name string
+		data []float64
+		want float64
+	}{
+		{name: "single", data: []float64{5}, want: 5},
+		{name: "speeds", data: []float64{40, 60}, want: 48},
+		{name: "powers of 2", data: []float64{1, 2, 4}, want: 12.0 / 7},
+		{name: "huge", data: []float64{1e308, 1e308}, want: 1e308},
+		// The reciprocals of these values overflow.
+		{name: "tiny", data: []float64{1e-310, 2e-310}, want: 4e-310 / 3},
+		{name: "zero", data: []float64{3, 0, 5}, want: 0},
+		{name: "infinite", data: []float64{2, math.Inf(1)}, want: 4},
+		{name: "all infinite", data: []float64{math.Inf(1)}, want: math.Inf(1)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := HarmonicMean(test.data)
+			if err != nil {
+				t.Fatalf("HarmonicMean() unexpected error: %v", err)
+			}
+			if got != test.want && math.Abs(got-test.want) > 1e-12*test.want {
+				t.Errorf("HarmonicMean(%v) = %v, want %v", test.data, got, test.want)
+			}
+		})
+	}
+
+	if got, _ := HarmonicMean([]float64{1, math.NaN()}); !math.IsNaN(got) {
+		t.Errorf("HarmonicMean() with NaN = %v, want NaN", got)
+	}
+}
+
+func TestMeansErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func() error
+		wantErr string
+	}{
+		{name: "geometric empty", fn: func() error { _, err := GeometricMean([]float64{}); return err }, wantErr: "at least 1"},
+		{name: "geometric negative", fn: func() error { _, err := GeometricMean([]int{2, -1}); return err }, wantErr: "non-negative"},
+		{name: "harmonic empty", fn: func() error { _, err := HarmonicMean([]float64{}); return err }, wantErr: "at least 1"},
+		{name: "harmonic negative", fn: func() error { _, err := HarmonicMean([]int{2, -1}); return err }, wantErr: "non-negative"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.fn(); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import "errors"
+
+// Min returns the smallest value of data. A NaN value makes the result
+// NaN.
+//
+// An error is returned if data is empty.
+func Min[T Numeric](data []T) (T, error) {
+	if len(data) == 0 {
+		return 0, errors.New("min requires at least 1 value")
+	}
+	lo := data[0]
+	for _, v := range data[1:] {
+		lo = min(lo, v)
+	}
+
+	return lo, nil
+}
+
+// Max returns the largest value of data. A NaN value makes the result NaN.
+//
+// An error is returned if data is empty.
+func Max[T Numeric](data []T) (T, error) {
+	if len(data) == 0 {
+		return 0, errors.New("max requires at least 1 value")
+	}
+	hi := data[0]
+	for _, v := range data[1:] {
+		hi = max(hi, v)
+	}
+
+	return hi, nil
+}
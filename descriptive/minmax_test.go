@@ -0,0 +1,71 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMinMax(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     []float64
+		min, max float64
+	}{
+		{name: "single", data: []float64{3}, min: 3, max: 3},
+		{name: "mixed", data: []float64{3, -1, 4, 1, -5, 9}, min: -5, max: 9},
+		{name: "infinities", data: []float64{0, math.Inf(-1), math.Inf(1)}, min: math.Inf(-1), max: math.Inf(1)},
+		{name: "NaN", data: []float64{1, math.NaN(), 3}, min: math.NaN(), max: math.NaN()},
+		{name: "leading NaN", data: []float64{math.NaN(), 1}, min: math.NaN(), max: math.NaN()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lo, err := Min(test.data)
+			if err != nil {
+				t.Fatalf("Min(%v) unexpected error: %v", test.data, err)
+			}
+			hi, err := Max(test.data)
+			if err != nil {
+				t.Fatalf("Max(%v) unexpected error: %v", test.data, err)
+			}
+			if lo != test.min && !(math.IsNaN(lo) && math.IsNaN(test.min)) {
+				t.Errorf("Min(%v) = %v, want %v", test.data, lo, test.min)
+			}
+			if hi != test.max && !(math.IsNaN(hi) && math.IsNaN(test.max)) {
+				t.Errorf("Max(%v) = %v, want %v", test.data, hi, test.max)
+			}
+		})
+	}
+}
+
+func TestMinMaxTypes(t *testing.T) {
+	if got, _ := Min([]int64{5, math.MinInt64, 7}); got != math.MinInt64 {
+		t.Errorf("Min([]int64) = %v, want %v", got, int64(math.MinInt64))
+	}
+	if got, _ := Max([]uint64{5, math.MaxUint64, 7}); got != math.MaxUint64 {
+		t.Errorf("Max([]uint64) = %v, want %v", got, uint64(math.MaxUint64))
+	}
+}
+
+func TestMinMaxEmpty(t *testing.T) {
+	if _, err := Min([]int{}); err == nil {
+		t.Errorf("Min() of no values expected error but got none")
+	}
+	if _, err := Max[float64](nil); err == nil {
+		t.Errorf("Max() of no values expected error but got none")
+	}
+}
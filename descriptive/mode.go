@@ -0,0 +1,82 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+)
+
+// ValueCount is a distinct value of a series and the number of times it
+// occurs.
+type ValueCount[T cmp.Ordered] struct {
+	Value T
+	Count int
+}
+
+// ValueCounts returns the number of times each distinct value occurs in
+// data, most frequent first, with values of equal frequency in ascending
+// order. It works equally for numbers and for categories held as strings.
+//
+// NaN values are counted together, as a single value ordered before all
+// others, and so are negative and positive zero. The result is empty if
+// data is.
+func ValueCounts[T cmp.Ordered](data []T) []ValueCount[T] {
+	sorted := slices.Clone(data)
+	slices.Sort(sorted)
+
+	var counts []ValueCount[T]
+	for i, v := range sorted {
+		if i > 0 && cmp.Compare(v, sorted[i-1]) == 0 {
+			counts[len(counts)-1].Count++
+
+			continue
+		}
+		counts = append(counts, ValueCount[T]{Value: v, Count: 1})
+	}
+
+	// A stable sort keeps equally frequent values in ascending order.
+	slices.SortStableFunc(counts, func(a, b ValueCount[T]) int {
+		return cmp.Compare(b.Count, a.Count)
+	})
+
+	return counts
+}
+
+// Mode returns the most frequent values of data in ascending order. A
+// series with several values sharing the highest frequency is multi-modal
+// and all of them are returned, so if every value occurs once, every value
+// is a mode.
+//
+// NaN values are counted together as for ValueCounts.
+//
+// An error is returned if data is empty.
+func Mode[T cmp.Ordered](data []T) ([]T, error) {
+	if len(data) == 0 {
+		return nil, errors.New("mode requires at least 1 value")
+	}
+
+	counts := ValueCounts(data)
+	var modes []T
+	for _, vc := range counts {
+		if vc.Count < counts[0].Count {
+			break
+		}
+		modes = append(modes, vc.Value)
+	}
+
+	return modes, nil
+}
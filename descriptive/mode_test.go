@@ -0,0 +1,98 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestValueCounts(t *testing.T) {
+	got := ValueCounts([]int{3, 1, 2, 3, 2, 3, 5})
+	want := []ValueCount[int]{
+		{Value: 3, Count: 3},
+		{Value: 2, Count: 2},
+		{Value: 1, Count: 1},
+		{Value: 5, Count: 1},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ValueCounts() = %v, want %v", got, want)
+	}
+
+	if got := ValueCounts([]int{}); len(got) != 0 {
+		t.Errorf("ValueCounts(empty) = %v, want empty", got)
+	}
+}
+
+func TestValueCountsStrings(t *testing.T) {
+	type color string
+	data := []color{"blue", "brown", "green", "brown", "hazel", "blue", "brown"}
+	got := ValueCounts(data)
+	want := []ValueCount[color]{
+		{Value: "brown", Count: 3},
+		{Value: "blue", Count: 2},
+		{Value: "green", Count: 1},
+		{Value: "hazel", Count: 1},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("ValueCounts() = %v, want %v", got, want)
+	}
+}
+
+func TestValueCountsFloats(t *testing.T) {
+	nan := math.NaN()
+	got := ValueCounts([]float64{1.5, nan, math.Copysign(0, -1), 0, nan, 1.5, nan})
+	if len(got) != 3 {
+		t.Fatalf("ValueCounts() = %v, want 3 distinct values", got)
+	}
+	if !math.IsNaN(got[0].Value) || got[0].Count != 3 {
+		t.Errorf("ValueCounts()[0] = %v, want {NaN 3}", got[0])
+	}
+	if got[1] != (ValueCount[float64]{Value: 0, Count: 2}) || got[2] != (ValueCount[float64]{Value: 1.5, Count: 2}) {
+		t.Errorf("ValueCounts()[1:] = %v, want [{0 2} {1.5 2}]", got[1:])
+	}
+}
+
+func TestMode(t *testing.T) {
+	tests := []struct {
+		data []float64
+		want []float64
+	}{
+		{data: []float64{4}, want: []float64{4}},
+		{data: []float64{1, 2, 2, 3}, want: []float64{2}},
+		{data: []float64{5, 1, 5, 1, 3}, want: []float64{1, 5}},
+		{data: []float64{3, 2, 1}, want: []float64{1, 2, 3}},
+	}
+
+	for _, test := range tests {
+		got, err := Mode(test.data)
+		if err != nil {
+			t.Fatalf("Mode(%v) unexpected error: %v", test.data, err)
+		}
+		if !slices.Equal(got, test.want) {
+			t.Errorf("Mode(%v) = %v, want %v", test.data, got, test.want)
+		}
+	}
+
+	if got, _ := Mode([]string{"a", "b", "b"}); !slices.Equal(got, []string{"b"}) {
+		t.Errorf("Mode(strings) = %v, want [b]", got)
+	}
+
+	if _, err := Mode([]string{}); err == nil || !strings.Contains(err.Error(), "at least 1") {
+		t.Errorf("Mode(empty) error = %v, want one containing %q", err, "at least 1")
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"errors"
+	"math"
+)
+
+// Mean returns the arithmetic mean of data.
+//
+// An error is returned if data is empty.
+func Mean[T Numeric](data []T) (float64, error) {
+	if len(data) == 0 {
+		return 0, errors.New("mean requires at least 1 value")
+	}
+
+	return mean(data), nil
+}
+
+// Variance returns the sample variance of data, the sum of squared
+// deviations from the mean divided by n-1, which is an unbiased estimate of
+// the variance of the population the data was drawn from.
+//
+// An error is returned if data has fewer than 2 values.
+func Variance[T Numeric](data []T) (float64, error) {
+	if len(data) < 2 {
+		return 0, errors.New("sample variance requires at least 2 values")
+	}
+
+	return sumSquares(data) / float64(len(data)-1), nil
+}
+
+// PopulationVariance returns the population variance of data, the sum of
+// squared deviations from the mean divided by n, for data that is the
+// whole population.
+//
+// An error is returned if data is empty.
+func PopulationVariance[T Numeric](data []T) (float64, error) {
+	if len(data) == 0 {
+		return 0, errors.New("population variance requires at least 1 value")
+	}
+
+	return sumSquares(data) / float64(len(data)), nil
+}
+
+// StdDev returns the sample standard deviation of data, the square root of
+// Variance.
+//
+// An error is returned if data has fewer than 2 values.
+func StdDev[T Numeric](data []T) (float64, error) {
+	v, err := Variance(data)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Sqrt(v), nil
+}
+
+// PopulationStdDev returns the population standard deviation of data, the
+// square root of PopulationVariance.
+//
+// An error is returned if data is empty.
+func PopulationStdDev[T Numeric](data []T) (float64, error) {
+	v, err := PopulationVariance(data)
+	if err != nil {
+		return 0, err
+	}
+
+	return math.Sqrt(v), nil
+}
+
+// sumSquares returns the sum of squared deviations of data from its mean,
+// by the corrected two pass algorithm: the compensated sum of the squared
+// deviations, less the squared sum of the deviations over n, which removes
+// most of the error left by the rounding of the mean. data must not be
+// empty.
+func sumSquares[T Numeric](data []T) float64 {
+	n := float64(len(data))
+	m := mean(data)
+	var ss, d neumaierSum
+	for _, v := range data {
+		dev := float64(v) - m
+		ss.add(dev * dev)
+		d.add(dev)
+	}
+	dev := d.value()
+
+	return max(0, ss.value()-dev*dev/n)
+}
+
+// mean returns the arithmetic mean of data, which must not be empty. If the
+// sum overflows, the values are scaled by 1/n before they are added, so the
+// mean of finite values is always finite.
+func mean[T Numeric](data []T) float64 {
+	n := float64(len(data))
+	m := Sum(data) / n
+	if !math.IsInf(m, 0) {
+		return m
+	}
+
+	var s neumaierSum
+	for _, v := range data {
+		s.add(float64(v) / n)
+	}
+
+	return s.value()
+}
@@ -0,0 +1,116 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestMoments(t *testing.T) {
+	tests := []struct {
+		name               string
+		data               []float64
+		mean, sVar, popVar float64
+	}{
+		{name: "textbook", data: []float64{2, 4, 4, 4, 5, 5, 7, 9}, mean: 5, sVar: 32.0 / 7, popVar: 4},
+		{name: "pair", data: []float64{1, 3}, mean: 2, sVar: 2, popVar: 1},
+		{name: "constant", data: []float64{7, 7, 7}, mean: 7, sVar: 0, popVar: 0},
+		// A large offset defeats the one pass sum of squares formula.
+		{name: "large offset", data: []float64{1e9 + 4, 1e9 + 7, 1e9 + 13, 1e9 + 16}, mean: 1e9 + 10, sVar: 30, popVar: 22.5},
+		{name: "tiny spread", data: []float64{1 + 1e-10, 1 - 1e-10}, mean: 1, sVar: 2e-20, popVar: 1e-20},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check := func(fn string, got, want float64, err error) {
+				t.Helper()
+				if err != nil {
+					t.Fatalf("%s(%v) unexpected error: %v", fn, test.data, err)
+				}
+				if math.Abs(got-want) > 1e-12*math.Max(1, math.Abs(want)) {
+					t.Errorf("%s(%v) = %v, want %v", fn, test.data, got, want)
+				}
+			}
+			got, err := Mean(test.data)
+			check("Mean", got, test.mean, err)
+			got, err = Variance(test.data)
+			check("Variance", got, test.sVar, err)
+			got, err = PopulationVariance(test.data)
+			check("PopulationVariance", got, test.popVar, err)
+			got, err = StdDev(test.data)
+			check("StdDev", got, math.Sqrt(test.sVar), err)
+			got, err = PopulationStdDev(test.data)
+			check("PopulationStdDev", got, math.Sqrt(test.popVar), err)
+		})
+	}
+}
+
+func TestMeanOverflow(t *testing.T) {
+	got, err := Mean([]float64{math.MaxFloat64, math.MaxFloat64 / 2})
+	if err != nil {
+		t.Fatalf("Mean() unexpected error: %v", err)
+	}
+	if want := 0.75 * math.MaxFloat64; got != want {
+		t.Errorf("Mean() of huge values = %v, want %v", got, want)
+	}
+}
+
+func TestMomentsTypes(t *testing.T) {
+	if got, _ := Mean([]int{1, 2, 3, 4}); got != 2.5 {
+		t.Errorf("Mean([]int) = %v, want 2.5", got)
+	}
+	if got, _ := Variance([]uint16{1, 2, 3, 4}); math.Abs(got-5.0/3) > 1e-15 {
+		t.Errorf("Variance([]uint16) = %v, want 5/3", got)
+	}
+	if got, _ := PopulationStdDev([]float32{2, 4, 4, 4, 5, 5, 7, 9}); got != 2 {
+		t.Errorf("PopulationStdDev([]float32) = %v, want 2", got)
+	}
+}
+
+func TestMomentsNaN(t *testing.T) {
+	data := []float64{1, math.NaN(), 3}
+	if got, _ := Mean(data); !math.IsNaN(got) {
+		t.Errorf("Mean() with NaN = %v, want NaN", got)
+	}
+	if got, _ := Variance(data); !math.IsNaN(got) {
+		t.Errorf("Variance() with NaN = %v, want NaN", got)
+	}
+}
+
+func TestMomentsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func([]float64) (float64, error)
+		data    []float64
+		wantErr string
+	}{
+		{name: "Mean", fn: Mean[float64], data: nil, wantErr: "at least 1"},
+		{name: "Variance", fn: Variance[float64], data: []float64{1}, wantErr: "at least 2"},
+		{name: "PopulationVariance", fn: PopulationVariance[float64], data: []float64{}, wantErr: "at least 1"},
+		{name: "StdDev", fn: StdDev[float64], data: []float64{1}, wantErr: "at least 2"},
+		{name: "PopulationStdDev", fn: PopulationStdDev[float64], data: nil, wantErr: "at least 1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.fn(test.data)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("%s(%v) error = %v, want one containing %q", test.name, test.data, err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// QuantileMethod selects how a quantile falling between two values of the
+// data is estimated, numbered as the nine methods of Hyndman and Fan
+// (1996), which are also the types of R's quantile function. With x[1] to
+// x[n] the sorted data, each method finds a position h for probability p
+// and interpolates between the values either side of it.
+type QuantileMethod int
+
+const (
+	// R1 is the inverse of the empirical distribution function, x[⌈np⌉].
+	R1 QuantileMethod = iota + 1
+	// R2 is R1 but averaging the two values at discontinuities, as the
+	// usual median of an even number of values does.
+	R2
+	// R3 is the value nearest to np, taking the even one in a tie, as in
+	// SAS.
+	R3
+	// R4 interpolates linearly with h = np.
+	R4
+	// R5 interpolates linearly with h = np + 1/2, the midpoints of the
+	// steps of the empirical distribution function.
+	R5
+	// R6 interpolates linearly with h = (n+1)p, as Excel's
+	// PERCENTILE.EXC, SPSS and Minitab do.
+	R6
+	// R7 interpolates linearly with h = (n-1)p + 1, the mode of the
+	// distribution of the order statistics. It is the default of R,
+	// NumPy and Excel's PERCENTILE.INC, and of Quantile.
+	R7
+	// R8 interpolates linearly with h = (n+1/3)p + 1/3, which is
+	// approximately median-unbiased whatever the distribution. Hyndman and
+	// Fan recommend it.
+	R8
+	// R9 interpolates linearly with h = (n+1/4)p + 3/8, which is
+	// approximately unbiased for normal data.
+	R9
+)
+
+// String returns the name of the method, such as "R-7".
+func (m QuantileMethod) String() string {
+	if m < R1 || m > R9 {
+		return "Unknown"
+	}
+
+	return "R-" + strconv.Itoa(int(m))
+}
+
+// QuantileOption configures Quantile and Percentiles.
+type QuantileOption func(*quantileOptions)
+
+// quantileOptions holds the settings accumulated from a list of
+// QuantileOption values.
+type quantileOptions struct {
+	method QuantileMethod
+}
+
+// WithMethod sets the method of estimating quantiles. The default is R7.
+func WithMethod(m QuantileMethod) QuantileOption {
+	return func(o *quantileOptions) {
+		o.method = m
+	}
+}
+
+// Median returns the median of data, the middle value, or the mean of the
+// two middle values of an even number of values.
+//
+// It selects the middle values without sorting, in O(n) time, and does
+// not modify data. A NaN value makes the result NaN.
+//
+// An error is returned if data is empty.
+func Median[T Numeric](data []T) (float64, error) {
+	if len(data) == 0 {
+		return 0, errors.New("median requires at least 1 value")
+	}
+
+	return selectQuantile(data, 0.5, R7), nil
+}
+
+// Quantile returns the p-quantile of data, the value below which a
+// fraction p of the data lies, estimated by the method set with WithMethod,
+// R7 by default. Quantile(data, 0.5) is the median.
+//
+// It selects the values it needs without sorting, in O(n) time, and does
+// not modify data. To find several quantiles of the same data, Percentiles
+// is faster. A NaN value makes the result NaN.
+//
+// An error is returned if data is empty, p is not in [0, 1] or the method
+// is unknown.
+func Quantile[T Numeric](data []T, p float64, opts ...QuantileOption) (float64, error) {
+	o := quantileOptions{method: R7}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if len(data) == 0 {
+		return 0, errors.New("quantile requires at least 1 value")
+	}
+	if !(p >= 0 && p <= 1) {
+		return 0, errors.New("quantile probability must be in the interval [0, 1]")
+	}
+	if o.method < R1 || o.method > R9 {
+		return 0, errors.New("unknown quantile method")
+	}
+
+	return selectQuantile(data, p, o.method), nil
+}
+
+// Percentiles returns the percentiles of data at each of ps, given in
+// percent, so that Percentiles(data, []float64{25, 50, 75}) returns the
+// quartiles. They are estimated as by Quantile, by the method set with
+// WithMethod.
+//
+// The data is sorted once, in a copy, in O(n log n) time, and each
+// percentile is then found in constant time. A NaN value makes every
+// result NaN.
+//
+// An error is returned if data is empty, a percentile is not in [0, 100]
+// or the method is unknown.
+func Percentiles[T Numeric](data []T, ps []float64, opts ...QuantileOption) ([]float64, error) {
+	o := quantileOptions{method: R7}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if len(data) == 0 {
+		return nil, errors.New("percentiles require at least 1 value")
+	}
+	for _, p := range ps {
+		if !(p >= 0 && p <= 100) {
+			return nil, errors.New("percentiles must be in the interval [0, 100]")
+		}
+	}
+	if o.method < R1 || o.method > R9 {
+		return nil, errors.New("unknown quantile method")
+	}
+
+	sorted, ok := floats(data)
+	out := make([]float64, len(ps))
+	if !ok {
+		for i := range out {
+			out[i] = math.NaN()
+		}
+
+		return out, nil
+	}
+	slices.Sort(sorted)
+	for i, p := range ps {
+		j, g := quantilePosition(len(sorted), p/100, o.method)
+		out[i] = sorted[j]
+		if g > 0 {
+			out[i] += g * (sorted[j+1] - sorted[j])
+		}
+	}
+
+	return out, nil
+}
+
+// selectQuantile returns the p-quantile of data by method m, selecting the
+// one or two values it needs from a copy. data must not be empty.
+func selectQuantile[T Numeric](data []T, p float64, m QuantileMethod) float64 {
+	a, ok := floats(data)
+	if !ok {
+		return math.NaN()
+	}
+	j, g := quantilePosition(len(a), p, m)
+	selectKth(a, j)
+	q := a[j]
+	if g > 0 {
+		// After selecting x[j], the next value is the smallest after it.
+		q += g * (slices.Min(a[j+1:]) - q)
+	}
+
+	return q
+}
+
+// floats returns a copy of data as float64, and whether it is free of NaN.
+func floats[T Numeric](data []T) ([]float64, bool) {
+	a := make([]float64, len(data))
+	for i, v := range data {
+		a[i] = float64(v)
+		if math.IsNaN(a[i]) {
+			return nil, false
+		}
+	}
+
+	return a, true
+}
+
+// quantilePosition returns where the p-quantile of n sorted values lies by
+// method m: the zero based index j of the value at or below it and the
+// fraction g of the way to the next value, which is 0 if j is the last.
+func quantilePosition(n int, p float64, m QuantileMethod) (int, float64) {
+	nf := float64(n)
+
+	// h is the one based position of the quantile in the sorted values.
+	var h float64
+	switch m {
+	case R1:
+		h = math.Ceil(nf * p)
+	case R2:
+		// The mean of x[⌈np⌉] and x[⌊np⌋+1], which differ only when np is
+		// a whole number.
+		h = math.Ceil(nf * p)
+		if nf*p == math.Floor(nf*p) && h < nf && h > 0 {
+			h += 0.5
+		}
+	case R3:
+		h = math.RoundToEven(nf * p)
+	case R4:
+		h = nf * p
+	case R5:
+		h = nf*p + 0.5
+	case R6:
+		h = (nf + 1) * p
+	case R7:
+		h = (nf-1)*p + 1
+	case R8:
+		h = (nf+1.0/3)*p + 1.0/3
+	case R9:
+		h = (nf+0.25)*p + 0.375
+	}
+
+	h = min(max(h, 1), nf)
+	j := math.Floor(h)
+	if j == nf {
+		return n - 1, 0
+	}
+
+	return int(j) - 1, h - j
+}
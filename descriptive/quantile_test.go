@@ -0,0 +1,173 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestQuantileMethods(t *testing.T) {
+	// The values of R's quantile(c(40, 10, 30, 20), p, type = k).
+	data := []float64{40, 10, 30, 20}
+	tests := []struct {
+		method QuantileMethod
+		p      float64
+		want   float64
+	}{
+		{method: R1, p: 0.3, want: 20},
+		{method: R2, p: 0.3, want: 20},
+		{method: R3, p: 0.3, want: 10},
+		{method: R4, p: 0.3, want: 12},
+		{method: R5, p: 0.3, want: 17},
+		{method: R6, p: 0.3, want: 15},
+		{method: R7, p: 0.3, want: 19},
+		{method: R8, p: 0.3, want: 49.0 / 3},
+		{method: R9, p: 0.3, want: 16.5},
+		{method: R1, p: 0.5, want: 20},
+		{method: R2, p: 0.5, want: 25},
+		{method: R3, p: 0.5, want: 20},
+		{method: R7, p: 0.5, want: 25},
+		{method: R6, p: 0.9, want: 40},
+		{method: R9, p: 0.9, want: 40},
+	}
+
+	for _, test := range tests {
+		for _, p := range []float64{0, 1} {
+			want := 10.0
+			if p == 1 {
+				want = 40
+			}
+			if got, err := Quantile(data, p, WithMethod(test.method)); err != nil || got != want {
+				t.Errorf("Quantile(%v, %v) by %v = %v, %v, want %v", data, p, test.method, got, err, want)
+			}
+		}
+
+		got, err := Quantile(data, test.p, WithMethod(test.method))
+		if err != nil {
+			t.Fatalf("Quantile(%v) by %v unexpected error: %v", test.p, test.method, err)
+		}
+		if math.Abs(got-test.want) > 1e-12 {
+			t.Errorf("Quantile(%v) by %v = %v, want %v", test.p, test.method, got, test.want)
+		}
+
+		ps, err := Percentiles(data, []float64{100 * test.p}, WithMethod(test.method))
+		if err != nil || math.Abs(ps[0]-got) > 1e-12 {
+			t.Errorf("Percentiles(%v) by %v = %v, %v, want %v", 100*test.p, test.method, ps, err, got)
+		}
+	}
+	if !slices.Equal(data, []float64{40, 10, 30, 20}) {
+		t.Errorf("Quantile() modified its input: %v", data)
+	}
+}
+
+func TestMedian(t *testing.T) {
+	tests := []struct {
+		data []int
+		want float64
+	}{
+		{data: []int{7}, want: 7},
+		{data: []int{3, 1, 2}, want: 2},
+		{data: []int{4, 1, 3, 2}, want: 2.5},
+		{data: []int{5, 5, 5, 1, 9}, want: 5},
+	}
+
+	for _, test := range tests {
+		if got, err := Median(test.data); err != nil || got != test.want {
+			t.Errorf("Median(%v) = %v, %v, want %v", test.data, got, err, test.want)
+		}
+	}
+	if got, _ := Median([]float64{1, math.NaN(), 3}); !math.IsNaN(got) {
+		t.Errorf("Median() with NaN = %v, want NaN", got)
+	}
+}
+
+func TestQuantileRandom(t *testing.T) {
+	// Selection must agree with interpolating in sorted data, including
+	// with many ties.
+	rng := rand.New(rand.NewSource(1))
+	for round := range 200 {
+		n := 1 + rng.Intn(60)
+		data := make([]float64, n)
+		for i := range data {
+			data[i] = float64(rng.Intn(1 + round%20))
+		}
+		sorted := slices.Sorted(slices.Values(data))
+		for _, m := range []QuantileMethod{R1, R2, R3, R4, R5, R6, R7, R8, R9} {
+			p := rng.Float64()
+			got, err := Quantile(data, p, WithMethod(m))
+			if err != nil {
+				t.Fatalf("Quantile() unexpected error: %v", err)
+			}
+			want, _ := Percentiles(sorted, []float64{100 * p}, WithMethod(m))
+			if math.Abs(got-want[0]) > 1e-9 {
+				t.Fatalf("Quantile(%v, %v) by %v = %v, want %v", data, p, m, got, want[0])
+			}
+		}
+	}
+}
+
+func TestPercentiles(t *testing.T) {
+	data := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}
+	got, err := Percentiles(data, []float64{0, 25, 50, 75, 100})
+	if err != nil {
+		t.Fatalf("Percentiles() unexpected error: %v", err)
+	}
+	if want := []float64{1, 3.5, 6, 8.5, 11}; !slices.Equal(got, want) {
+		t.Errorf("Percentiles() = %v, want %v", got, want)
+	}
+
+	got, _ = Percentiles([]float64{1, math.NaN()}, []float64{10, 90})
+	if len(got) != 2 || !math.IsNaN(got[0]) || !math.IsNaN(got[1]) {
+		t.Errorf("Percentiles() with NaN = %v, want NaN, NaN", got)
+	}
+}
+
+func TestQuantileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		fn      func() error
+		wantErr string
+	}{
+		{name: "empty median", fn: func() error { _, err := Median([]float64{}); return err }, wantErr: "at least 1"},
+		{name: "empty quantile", fn: func() error { _, err := Quantile([]float64{}, 0.5); return err }, wantErr: "at least 1"},
+		{name: "p above 1", fn: func() error { _, err := Quantile([]float64{1}, 1.5); return err }, wantErr: "[0, 1]"},
+		{name: "NaN p", fn: func() error { _, err := Quantile([]float64{1}, math.NaN()); return err }, wantErr: "[0, 1]"},
+		{name: "unknown method", fn: func() error { _, err := Quantile([]float64{1}, 0.5, WithMethod(0)); return err }, wantErr: "unknown"},
+		{name: "empty percentiles", fn: func() error { _, err := Percentiles([]int{}, []float64{5}); return err }, wantErr: "at least 1"},
+		{name: "percentile below 0", fn: func() error { _, err := Percentiles([]int{1}, []float64{-5}); return err }, wantErr: "[0, 100]"},
+		{name: "percentiles method", fn: func() error { _, err := Percentiles([]int{1}, []float64{5}, WithMethod(10)); return err }, wantErr: "unknown"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.fn(); err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestQuantileMethodString(t *testing.T) {
+	if got := R7.String(); got != "R-7" {
+		t.Errorf("R7.String() = %q, want %q", got, "R-7")
+	}
+	if got := QuantileMethod(0).String(); got != "Unknown" {
+		t.Errorf("QuantileMethod(0).String() = %q, want %q", got, "Unknown")
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math/bits"
+	"slices"
+)
+
+// selectKth rearranges a so that a[k] holds the value it would have if a
+// were sorted, with no greater value before it and no smaller value after
+// it, in O(n) expected time.
+//
+// It is an introselect: a quickselect with median of three pivots and a
+// three way partition, so runs of equal values cost nothing extra, which
+// sorts the remaining range instead if the partitions are so unbalanced
+// that the number of rounds exceeds twice the bit length of n. That bounds
+// the worst case at O(n log n). a must not contain NaN.
+func selectKth(a []float64, k int) {
+	lo, hi := 0, len(a)-1
+	depth := 2 * bits.Len(uint(len(a)))
+	for lo < hi {
+		if depth == 0 {
+			slices.Sort(a[lo : hi+1])
+
+			return
+		}
+		depth--
+
+		lt, gt := partition3(a, lo, hi)
+		switch {
+		case k < lt:
+			hi = lt - 1
+		case k > gt:
+			lo = gt + 1
+		default:
+			return
+		}
+	}
+}
+
+// partition3 partitions a[lo:hi+1] around the median of its first, middle
+// and last values, returning lt and gt such that a[lo:lt] is less than the
+// pivot, a[lt:gt+1] equals it and a[gt+1:hi+1] is greater.
+func partition3(a []float64, lo, hi int) (int, int) {
+	mid := lo + (hi-lo)/2
+	pivot := max(min(a[lo], a[mid]), min(max(a[lo], a[mid]), a[hi]))
+
+	lt, i, gt := lo, lo, hi
+	for i <= gt {
+		switch {
+		case a[i] < pivot:
+			a[lt], a[i] = a[i], a[lt]
+			lt++
+			i++
+		case a[i] > pivot:
+			a[i], a[gt] = a[gt], a[i]
+			gt--
+		default:
+			i++
+		}
+	}
+
+	return lt, gt
+}
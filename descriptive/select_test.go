@@ -0,0 +1,72 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestSelectKth(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	inputs := [][]float64{
+		{5},
+		{2, 1},
+		{3, 3, 3, 3},
+		{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1},
+	}
+	for range 50 {
+		a := make([]float64, 1+rng.Intn(100))
+		for i := range a {
+			a[i] = float64(rng.Intn(10))
+		}
+		inputs = append(inputs, a)
+	}
+
+	for _, in := range inputs {
+		sorted := slices.Sorted(slices.Values(in))
+		for k := range in {
+			a := slices.Clone(in)
+			selectKth(a, k)
+			if a[k] != sorted[k] {
+				t.Fatalf("selectKth(%v, %d) = %v, want %v", in, k, a[k], sorted[k])
+			}
+			if slices.Max(a[:k+1]) > a[k] || slices.Min(a[k:]) < a[k] {
+				t.Fatalf("selectKth(%v, %d) = %v is not partitioned", in, k, a)
+			}
+		}
+	}
+}
+
+func TestSelectKthAdversarial(t *testing.T) {
+	// An organ pipe defeats median of three pivots often enough to reach
+	// the sorting fallback; the result must still be right.
+	n := 1 << 12
+	a := make([]float64, n)
+	for i := range n / 2 {
+		a[i] = float64(i)
+		a[n-1-i] = float64(i)
+	}
+	sorted := slices.Sorted(slices.Values(a))
+	for _, k := range []int{0, n / 3, n / 2, n - 1} {
+		b := slices.Clone(a)
+		selectKth(b, k)
+		if b[k] != sorted[k] {
+			t.Errorf("selectKth(organ pipe, %d) = %v, want %v", k, b[k], sorted[k])
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import "math"
+
+// Numeric represents any primitive numeric type whose statistics can be
+// calculated.
+type Numeric interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// Sum returns the sum of data, or 0 if it is empty.
+//
+// The sum is compensated by Neumaier's improvement of Kahan summation, so
+// that adding many values of different sizes loses no more than a rounding
+// or so of precision, instead of an error growing with their number.
+func Sum[T Numeric](data []T) float64 {
+	var s neumaierSum
+	for _, v := range data {
+		s.add(float64(v))
+	}
+
+	return s.value()
+}
+
+// neumaierSum is a running float64 sum with Neumaier's improvement of Kahan
+// compensated summation. The rounding error of every addition is collected
+// in a separate compensation term.
+//
+// The zero value is an empty sum ready to use.
+type neumaierSum struct {
+	sum float64
+	c   float64
+}
+
+// add adds v to the sum.
+func (s *neumaierSum) add(v float64) {
+	t := s.sum + v
+	if math.Abs(s.sum) >= math.Abs(v) {
+		s.c += (s.sum - t) + v
+	} else {
+		s.c += (v - t) + s.sum
+	}
+	s.sum = t
+}
+
+// value returns the compensated total.
+func (s *neumaierSum) value() float64 {
+	// An infinite sum leaves a NaN compensation, from Inf - Inf.
+	if math.IsInf(s.sum, 0) {
+		return s.sum
+	}
+
+	return s.sum + s.c
+}
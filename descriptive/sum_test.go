@@ -0,0 +1,59 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package descriptive
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	tests := []struct {
+		name string
+		data []float64
+		want float64
+	}{
+		{name: "empty", data: nil, want: 0},
+		{name: "single", data: []float64{3.5}, want: 3.5},
+		{name: "simple", data: []float64{1, 2, 3, 4}, want: 10},
+		{name: "cancellation", data: []float64{1, 1e100, 1, -1e100}, want: 2},
+		{name: "tenths", data: []float64{0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1, 0.1}, want: 1},
+		{name: "infinity", data: []float64{1, math.Inf(1), 2}, want: math.Inf(1)},
+		{name: "overflow", data: []float64{math.MaxFloat64, math.MaxFloat64}, want: math.Inf(1)},
+		{name: "opposite infinities", data: []float64{math.Inf(1), math.Inf(-1)}, want: math.NaN()},
+		{name: "NaN", data: []float64{1, math.NaN()}, want: math.NaN()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Sum(test.data)
+			if got != test.want && !(math.IsNaN(got) && math.IsNaN(test.want)) {
+				t.Errorf("Sum(%v) = %v, want %v", test.data, got, test.want)
+			}
+		})
+	}
+}
+
+func TestSumTypes(t *testing.T) {
+	if got := Sum([]int{-3, 5, 7}); got != 9 {
+		t.Errorf("Sum([]int) = %v, want 9", got)
+	}
+	if got := Sum([]uint8{200, 200, 200}); got != 600 {
+		t.Errorf("Sum([]uint8) = %v, want 600 without overflow", got)
+	}
+	if got := Sum([]float32{0.5, 0.25}); got != 0.75 {
+		t.Errorf("Sum([]float32) = %v, want 0.75", got)
+	}
+}
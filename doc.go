@@ -5,5 +5,15 @@ routines for performing statistical calculations.
 Current packages include:
 
 	correlation/ - Methods for performing statistical correlation on datasets.
+	datasets/    - Types and example datasets for statistical analysis.
+	density/     - Density estimation for visualizing paired data.
+	descriptive/ - Descriptive statistics of a single series, such as the mean.
+	plot/        - SVG and PNG scatterplots and correlation heatmaps.
+	tools/       - Development tools, such as golden value generation for tests.
+	x/           - Experimental packages whose API may still change.
+
+Packages outside x/ are stable. New measures land under x/ first and are
+promoted into a stable package once their API has settled; see package x
+for the details.
 */
 package stats
@@ -0,0 +1,31 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package plot renders datasets and correlation matrices as images, in SVG
+for documents and web pages or PNG where a raster image is needed.
+
+Scatter draws a Dataset with axes scaled to the data and labelled from its
+XLabel, YLabel and units, optionally with the fitted regression line, and
+Heatmap draws a correlation.Matrix as a grid of colored cells. Both write
+the image to an io.Writer and are configured with Options:
+
+	f, err := os.Create("anscombe.svg")
+	...
+	err = plot.Scatter(f, datasets.AnscombeIII, plot.SVG,
+		plot.WithSize(480, 360), plot.WithRegressionLine())
+
+For a quick look at data in the terminal, see datasets.Dataset.Plot.
+*/
+package plot
@@ -0,0 +1,36 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGlyphs(t *testing.T) {
+	for r := rune(' '); r <= '~'; r++ {
+		g, ok := glyphs[r]
+		if !ok {
+			t.Errorf("no glyph for %q", r)
+
+			continue
+		}
+		for _, row := range g {
+			if len(row) != 5 || strings.Trim(row, "#.") != "" {
+				t.Errorf("glyph for %q has malformed row %q", r, row)
+			}
+		}
+	}
+}
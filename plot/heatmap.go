@@ -0,0 +1,138 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"errors"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+
+	"github.com/rsned/stats/correlation"
+)
+
+// Heatmap writes a heatmap of the correlation matrix to w in format, with
+// a cell for each pair of variables colored from blue for -1 through white
+// for 0 to red for +1, and a color bar giving the scale:
+//
+//	m, err := correlation.CorrelateNamed(series, correlation.Spearman)
+//	...
+//	err = plot.Heatmap(f, m.Matrix, plot.PNG, plot.WithLabels(m.Labels()...))
+//
+// The coefficients are written in the cells if they are large enough to
+// hold them. Undefined coefficients are gray. The variables are named with
+// WithLabels, and the title is the correlation type unless set with
+// WithTitle.
+//
+// An error is returned if the matrix is nil or empty, the number of labels
+// does not match it, the size is too small, the format is not supported,
+// or the image cannot be written.
+func Heatmap(w io.Writer, m *correlation.Matrix, format Format, opts ...Option) error {
+	if m == nil || m.Dim() == 0 {
+		return errors.New("heatmap needs a matrix of at least one variable")
+	}
+	o := newOptions(opts)
+	n := m.Dim()
+	labels := o.labels
+	if labels == nil {
+		labels = make([]string, n)
+		for i := range labels {
+			labels[i] = strconv.Itoa(i)
+		}
+	}
+	if len(labels) != n {
+		return errors.New("heatmap needs one label per variable, got " + strconv.Itoa(len(labels)) +
+			" for " + strconv.Itoa(n))
+	}
+	title := m.Type.String()
+	if o.title != nil {
+		title = *o.title
+	}
+
+	return render(w, format, o, func(c canvas, width, height float64) {
+		th := c.textHeight()
+		labelWidth := 0.0
+		for _, l := range labels {
+			labelWidth = math.Max(labelWidth, c.textWidth(l))
+		}
+
+		top := 15.0
+		if title != "" {
+			top = 2*th + 10
+			c.text(width/2, th+5, title, anchorMiddle, false, black)
+		}
+		top += labelWidth + 8
+		left := 15 + labelWidth + 8
+		barWidth := 15.0
+		right := 20 + barWidth + 8 + c.textWidth("-1")
+		cell := math.Min((width-left-right)/float64(n), (height-top-15)/float64(n))
+		if cell <= 0 {
+			return
+		}
+		// Center the matrix and color bar across any width left over.
+		left += (width - left - right - float64(n)*cell) / 2
+
+		showValues := cell >= c.textWidth("-0.00")+4 && cell >= th+4
+		for i := range n {
+			y := top + float64(i)*cell
+			c.text(left-8, y+cell/2, labels[i], anchorEnd, false, black)
+			c.text(left+float64(i)*cell+cell/2, top-8, labels[i], anchorStart, true, black)
+			for j := range n {
+				r := m.At(i, j)
+				x := left + float64(j)*cell
+				c.rect(x, y, cell, cell, heatColor(r))
+				if showValues && !math.IsNaN(r) {
+					ink := black
+					if math.Abs(r) > 0.6 {
+						ink = white
+					}
+					c.text(x+cell/2, y+cell/2, strconv.FormatFloat(r, 'f', 2, 64), anchorMiddle, false, ink)
+				}
+			}
+		}
+
+		// The color bar spans the height of the matrix, with +1 at the top.
+		barX := left + float64(n)*cell + 20
+		barHeight := float64(n) * cell
+		const steps = 50
+		for k := range steps {
+			r := 1 - 2*(float64(k)+0.5)/steps
+			c.rect(barX, top+float64(k)*barHeight/steps, barWidth, barHeight/steps+0.5, heatColor(r))
+		}
+		for _, r := range []float64{1, 0, -1} {
+			y := top + (1-r)/2*barHeight
+			c.text(barX+barWidth+6, y, strconv.FormatFloat(r, 'f', -1, 64), anchorStart, false, black)
+		}
+	})
+}
+
+// heatColor returns the color of coefficient r, blending from white at 0
+// to blue at -1 and red at +1, and gray if r is NaN.
+func heatColor(r float64) color.RGBA {
+	if math.IsNaN(r) {
+		return color.RGBA{R: 0xbb, G: 0xbb, B: 0xbb, A: 0xff}
+	}
+	end := color.RGBA{R: 0xb2, G: 0x18, B: 0x2b, A: 0xff}
+	if r < 0 {
+		end = color.RGBA{R: 0x21, G: 0x66, B: 0xac, A: 0xff}
+	}
+	t := math.Min(math.Abs(r), 1)
+	blend := func(v uint8) uint8 {
+		return uint8(math.Round(255 + t*(float64(v)-255)))
+	}
+
+	return color.RGBA{R: blend(end.R), G: blend(end.G), B: blend(end.B), A: 0xff}
+}
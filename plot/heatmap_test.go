@@ -0,0 +1,122 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rsned/stats/correlation"
+)
+
+// testMatrix returns the Pearson correlation matrix of three columns.
+func testMatrix(t *testing.T) *correlation.Matrix {
+	t.Helper()
+	m, err := correlation.CorrelationMatrix([][]float64{
+		{1, 2, 3, 4, 5},
+		{2, 4, 5, 4, 5},
+		{5, 3, 4, 2, 1},
+	}, correlation.Pearson)
+	if err != nil {
+		t.Fatalf("CorrelationMatrix() unexpected error: %v", err)
+	}
+
+	return m
+}
+
+func TestHeatmapSVG(t *testing.T) {
+	m := testMatrix(t)
+	var b strings.Builder
+	if err := Heatmap(&b, m, SVG, WithLabels("height", "weight", "age"), WithSize(800, 800)); err != nil {
+		t.Fatalf("Heatmap() unexpected error: %v", err)
+	}
+	got := b.String()
+	for _, want := range []string{">Pearson</text>", ">height</text>", ">age</text>", ">1.00</text>", ">-0.90</text>"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Heatmap() does not contain %s", want)
+		}
+	}
+	// Two labels per variable, and the ends and middle of the color bar.
+	if n := strings.Count(got, "<text"); n != 1+6+9+3 {
+		t.Errorf("Heatmap() wrote %d texts, want the title, 6 labels, 9 values and 3 scale marks", n)
+	}
+
+	b.Reset()
+	if err := Heatmap(&b, m, SVG, WithTitle(""), WithSize(100, 100)); err != nil {
+		t.Fatalf("Heatmap() unexpected error: %v", err)
+	}
+	if strings.Contains(b.String(), "Pearson") || strings.Contains(b.String(), "0.90") || !strings.Contains(b.String(), ">2</text>") {
+		t.Errorf("small untitled Heatmap() = %s, want numbered variables without title or values", b.String())
+	}
+}
+
+func TestHeatmapPNG(t *testing.T) {
+	var b bytes.Buffer
+	if err := Heatmap(&b, testMatrix(t), PNG); err != nil {
+		t.Fatalf("Heatmap() unexpected error: %v", err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatalf("png.Decode() unexpected error: %v", err)
+	}
+	if img.Bounds().Dx() != 640 || img.Bounds().Dy() != 480 {
+		t.Errorf("Heatmap() image size = %v, want 640 by 480", img.Bounds())
+	}
+}
+
+func TestHeatmapErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		m       *correlation.Matrix
+		opts    []Option
+		wantErr string
+	}{
+		{name: "nil matrix", m: nil, opts: nil, wantErr: "at least one variable"},
+		{name: "labels mismatch", m: testMatrix(t), opts: []Option{WithLabels("a", "b")}, wantErr: "got 2 for 3"},
+		{name: "too small", m: testMatrix(t), opts: []Option{WithSize(640, 99)}, wantErr: "at least 100 by 100"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := Heatmap(&b, test.m, SVG, test.opts...)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Heatmap() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestHeatColor(t *testing.T) {
+	tests := []struct {
+		r    float64
+		want color.RGBA
+	}{
+		{0, white},
+		{1, color.RGBA{R: 0xb2, G: 0x18, B: 0x2b, A: 0xff}},
+		{-1, color.RGBA{R: 0x21, G: 0x66, B: 0xac, A: 0xff}},
+		{-0.5, color.RGBA{R: 0x90, G: 0xb3, B: 0xd6, A: 0xff}},
+		{math.NaN(), color.RGBA{R: 0xbb, G: 0xbb, B: 0xbb, A: 0xff}},
+	}
+	for _, test := range tests {
+		if got := heatColor(test.r); got != test.want {
+			t.Errorf("heatColor(%v) = %v, want %v", test.r, got, test.want)
+		}
+	}
+}
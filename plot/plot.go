@@ -0,0 +1,223 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"errors"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Format is an image format that plots are written in.
+type Format int
+
+const (
+	// SVG is Scalable Vector Graphics, which suits documents and web pages
+	// and leaves the choice of font to the viewer.
+	SVG Format = iota
+	// PNG is a raster image, with text drawn in a built-in bitmap font.
+	PNG
+)
+
+// String returns the string representation of the Format.
+func (f Format) String() string {
+	switch f {
+	case SVG:
+		return "SVG"
+	case PNG:
+		return "PNG"
+	default:
+		return "Unknown"
+	}
+}
+
+// Option configures a plot.
+type Option func(*options)
+
+// options holds the settings accumulated from a list of Option values.
+type options struct {
+	width      int
+	height     int
+	title      *string
+	regression bool
+	labels     []string
+}
+
+// WithSize sets the size of the image in pixels. The default is 640 by
+// 480; the smallest allowed is 100 by 100.
+func WithSize(width, height int) Option {
+	return func(o *options) {
+		o.width, o.height = width, height
+	}
+}
+
+// WithTitle sets the title drawn above the plot. By default a scatterplot
+// is titled with the Name of the dataset, and a heatmap with the
+// correlation type.
+func WithTitle(title string) Option {
+	return func(o *options) {
+		o.title = &title
+	}
+}
+
+// WithRegressionLine adds the least squares line of Y on X to a
+// scatterplot.
+func WithRegressionLine() Option {
+	return func(o *options) {
+		o.regression = true
+	}
+}
+
+// WithLabels sets the names of the variables of a heatmap, in index order,
+// such as the Labels of a correlation.LabeledMatrix. By default the
+// variables are numbered from 0.
+func WithLabels(labels ...string) Option {
+	return func(o *options) {
+		o.labels = labels
+	}
+}
+
+// newOptions returns the options set by opts.
+func newOptions(opts []Option) options {
+	o := options{width: 640, height: 480, title: nil, regression: false, labels: nil}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// Colors shared by the plots.
+var (
+	white     = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	black     = color.RGBA{R: 0x22, G: 0x22, B: 0x22, A: 0xff}
+	gray      = color.RGBA{R: 0x88, G: 0x88, B: 0x88, A: 0xff}
+	lightGray = color.RGBA{R: 0xe5, G: 0xe5, B: 0xe5, A: 0xff}
+	blue      = color.RGBA{R: 0x1f, G: 0x77, B: 0xb4, A: 0xff}
+	red       = color.RGBA{R: 0xd6, G: 0x27, B: 0x28, A: 0xff}
+)
+
+// render draws a plot of the size set in o with fn and writes it to w in
+// format.
+func render(w io.Writer, format Format, o options, fn func(c canvas, width, height float64)) error {
+	if o.width < 100 || o.height < 100 {
+		return errors.New("plot size must be at least 100 by 100 pixels")
+	}
+	width, height := float64(o.width), float64(o.height)
+	switch format {
+	case SVG:
+		c := newSVGCanvas(o.width, o.height)
+		c.rect(0, 0, width, height, white)
+		fn(c, width, height)
+
+		return c.writeTo(w)
+	case PNG:
+		c := newRasterCanvas(o.width, o.height)
+		c.rect(0, 0, width, height, white)
+		fn(c, width, height)
+
+		return c.writeTo(w)
+	default:
+		return errors.New("unsupported plot format")
+	}
+}
+
+// axis maps values in the interval [lo, hi] onto pixels, with ticks at
+// round numbers.
+type axis struct {
+	lo, hi float64
+	ticks  []float64
+	// decimals is the number of decimal places the ticks are labelled with.
+	decimals int
+}
+
+// newAxis returns an axis covering vals, extended to the nearest ticks
+// around them, with about n ticks.
+func newAxis(vals []float64, n int) axis {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range vals {
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	switch {
+	case len(vals) == 0:
+		lo, hi = 0, 1
+	case lo == hi:
+		lo, hi = lo-1, hi+1
+	}
+
+	step := niceStep((hi - lo) / float64(n))
+	first, last := int(math.Floor(lo/step)), int(math.Ceil(hi/step))
+	a := axis{lo: 0, hi: 0, ticks: nil, decimals: max(0, -int(math.Floor(math.Log10(step))))}
+	for k := first; k <= last; k++ {
+		a.ticks = append(a.ticks, tick(k, step))
+	}
+	a.lo, a.hi = a.ticks[0], a.ticks[len(a.ticks)-1]
+
+	return a
+}
+
+// niceStep returns the smallest of 1, 2 or 5 times a power of 10 that is at
+// least raw.
+func niceStep(raw float64) float64 {
+	p := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if m*p >= raw {
+			return m * p
+		}
+	}
+
+	return 10 * p
+}
+
+// tick returns k times step. A step below 1 is divided into instead, as
+// its reciprocal is a whole number, so that ticks such as 0.6 are exact.
+func tick(k int, step float64) float64 {
+	if step < 1 {
+		return float64(k) / math.Round(1/step)
+	}
+
+	return float64(k) * step
+}
+
+// label returns the label of tick t.
+func (a axis) label(t float64) string {
+	s := strconv.FormatFloat(t, 'f', a.decimals, 64)
+	if s == "-"+strconv.FormatFloat(0, 'f', a.decimals, 64) {
+		return s[1:]
+	}
+
+	return s
+}
+
+// scale returns the position of v on an axis drawn from pixel p0, for lo,
+// to pixel p1, for hi.
+func (a axis) scale(v, p0, p1 float64) float64 {
+	return p0 + (v-a.lo)/(a.hi-a.lo)*(p1-p0)
+}
+
+// axisLabel returns the label of an axis with its units, if any.
+func axisLabel(label, units string) string {
+	switch {
+	case units == "":
+		return label
+	case label == "":
+		return "(" + units + ")"
+	default:
+		return label + " (" + units + ")"
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNewAxis(t *testing.T) {
+	tests := []struct {
+		name      string
+		vals      []float64
+		n         int
+		wantTicks []float64
+		wantLabel string
+	}{
+		{name: "Anscombe x", vals: []float64{4, 14, 9}, n: 6, wantTicks: []float64{4, 6, 8, 10, 12, 14}, wantLabel: "14"},
+		{name: "extends to ticks", vals: []float64{0.13, 0.91}, n: 5, wantTicks: []float64{0, 0.2, 0.4, 0.6, 0.8, 1}, wantLabel: "1.0"},
+		{name: "negative", vals: []float64{-35, 12}, n: 5, wantTicks: []float64{-40, -30, -20, -10, 0, 10, 20}, wantLabel: "20"},
+		{name: "single value", vals: []float64{5}, n: 4, wantTicks: []float64{4, 4.5, 5, 5.5, 6}, wantLabel: "6.0"},
+		{name: "empty", vals: nil, n: 5, wantTicks: []float64{0, 0.2, 0.4, 0.6, 0.8, 1}, wantLabel: "1.0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := newAxis(test.vals, test.n)
+			if !slices.Equal(a.ticks, test.wantTicks) {
+				t.Errorf("newAxis(%v, %d) ticks = %v, want %v", test.vals, test.n, a.ticks, test.wantTicks)
+			}
+			if a.lo != test.wantTicks[0] || a.hi != test.wantTicks[len(test.wantTicks)-1] {
+				t.Errorf("newAxis(%v, %d) range = [%v, %v], want the end ticks", test.vals, test.n, a.lo, a.hi)
+			}
+			if got := a.label(a.hi); got != test.wantLabel {
+				t.Errorf("label(%v) = %q, want %q", a.hi, got, test.wantLabel)
+			}
+		})
+	}
+}
+
+func TestAxisLabelNegativeZero(t *testing.T) {
+	a := newAxis([]float64{-1, 1}, 4)
+	if got := a.label(-1e-17); got != "0.0" {
+		t.Errorf("label(-1e-17) = %q, want %q", got, "0.0")
+	}
+}
+
+func TestNiceStep(t *testing.T) {
+	for raw, want := range map[float64]float64{0.7: 1, 1: 1, 1.2: 2, 3: 5, 7: 10, 0.013: 0.02, 450: 500} {
+		if got := niceStep(raw); got != want {
+			t.Errorf("niceStep(%v) = %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestAxisLabel(t *testing.T) {
+	tests := []struct{ label, units, want string }{
+		{"height", "cm", "height (cm)"},
+		{"height", "", "height"},
+		{"", "cm", "(cm)"},
+		{"", "", ""},
+	}
+	for _, test := range tests {
+		if got := axisLabel(test.label, test.units); got != test.want {
+			t.Errorf("axisLabel(%q, %q) = %q, want %q", test.label, test.units, got, test.want)
+		}
+	}
+}
+
+func TestFormatString(t *testing.T) {
+	for f, want := range map[Format]string{SVG: "SVG", PNG: "PNG", Format(9): "Unknown"} {
+		if got := f.String(); got != want {
+			t.Errorf("Format(%d).String() = %q, want %q", int(f), got, want)
+		}
+	}
+}
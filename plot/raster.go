@@ -0,0 +1,126 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+// rasterScale is the size in pixels of each pixel of a glyph in raster
+// plots, so that text is 10 by 14 pixels.
+const rasterScale = 2
+
+// rasterCanvas draws into an image, for PNG output.
+type rasterCanvas struct {
+	img *image.RGBA
+}
+
+// newRasterCanvas returns a canvas for an image width by height pixels.
+func newRasterCanvas(width, height int) *rasterCanvas {
+	return &rasterCanvas{img: image.NewRGBA(image.Rect(0, 0, width, height))}
+}
+
+func (c *rasterCanvas) rect(x, y, w, h float64, fill color.RGBA) {
+	r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+	draw.Draw(c.img, r, image.NewUniform(fill), image.Point{}, draw.Src)
+}
+
+// line draws a line as a run of squares of side width, one per pixel of
+// its length.
+func (c *rasterCanvas) line(x1, y1, x2, y2, width float64, stroke color.RGBA) {
+	steps := int(math.Ceil(math.Max(math.Abs(x2-x1), math.Abs(y2-y1))))
+	half := math.Max(width, 1) / 2
+	for i := 0; i <= steps; i++ {
+		t := 0.0
+		if steps > 0 {
+			t = float64(i) / float64(steps)
+		}
+		x := x1 + t*(x2-x1)
+		y := y1 + t*(y2-y1)
+		c.rect(x-half, y-half, 2*half, 2*half, stroke)
+	}
+}
+
+func (c *rasterCanvas) dot(x, y, r float64, fill color.RGBA) {
+	for py := int(math.Floor(y - r)); py <= int(math.Ceil(y+r)); py++ {
+		for px := int(math.Floor(x - r)); px <= int(math.Ceil(x+r)); px++ {
+			dx, dy := float64(px)+0.5-x, float64(py)+0.5-y
+			if dx*dx+dy*dy <= r*r {
+				c.img.SetRGBA(px, py, fill)
+			}
+		}
+	}
+}
+
+func (c *rasterCanvas) text(x, y float64, s string, a anchor, rotated bool, fill color.RGBA) {
+	w := c.textWidth(s)
+	start := 0.0
+	switch a {
+	case anchorStart:
+	case anchorMiddle:
+		start = -w / 2
+	case anchorEnd:
+		start = -w
+	}
+	top := -c.textHeight() / 2
+
+	// Each set pixel of a glyph is a square at (u, v) from the anchor,
+	// with u along the text and v down across it.
+	pixel := func(u, v float64) {
+		if rotated {
+			c.rect(x+v, y-u-rasterScale, rasterScale, rasterScale, fill)
+		} else {
+			c.rect(x+u, y+v, rasterScale, rasterScale, fill)
+		}
+	}
+	for i, r := range []rune(s) {
+		g, ok := glyphs[r]
+		if !ok {
+			g = unknownGlyph
+		}
+		for row, bits := range g {
+			for col, bit := range bits {
+				if bit == '#' {
+					pixel(start+float64((6*i+col)*rasterScale), top+float64(row*rasterScale))
+				}
+			}
+		}
+	}
+}
+
+// textWidth returns the width of s, in glyphs 5 pixels wide with a pixel
+// between them.
+func (c *rasterCanvas) textWidth(s string) float64 {
+	n := len([]rune(s))
+	if n == 0 {
+		return 0
+	}
+
+	return float64((6*n - 1) * rasterScale)
+}
+
+func (c *rasterCanvas) textHeight() float64 {
+	return 7 * rasterScale
+}
+
+// writeTo writes the image to w as a PNG.
+func (c *rasterCanvas) writeTo(w io.Writer) error {
+	return png.Encode(w, c.img)
+}
@@ -0,0 +1,85 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestRasterCanvas(t *testing.T) {
+	c := newRasterCanvas(40, 30)
+	c.rect(0, 0, 40, 30, white)
+	c.rect(2, 3, 2, 2, red)
+	c.dot(20, 15, 3, blue)
+	c.line(0, 29, 39, 29, 1, black)
+
+	for _, test := range []struct {
+		x, y int
+		want color.RGBA
+	}{
+		{2, 3, red},
+		{3, 4, red},
+		{4, 5, white},
+		{20, 15, blue},
+		{19, 14, blue},
+		{20, 19, white},
+		{0, 29, black},
+		{39, 29, black},
+		{20, 28, white},
+	} {
+		if got := c.img.RGBAAt(test.x, test.y); got != test.want {
+			t.Errorf("pixel (%d, %d) = %v, want %v", test.x, test.y, got, test.want)
+		}
+	}
+
+	var b bytes.Buffer
+	if err := c.writeTo(&b); err != nil {
+		t.Fatalf("writeTo() unexpected error: %v", err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatalf("png.Decode() unexpected error: %v", err)
+	}
+	if img.Bounds().Dx() != 40 || img.Bounds().Dy() != 30 {
+		t.Errorf("PNG size = %v, want 40 by 30", img.Bounds())
+	}
+}
+
+func TestRasterText(t *testing.T) {
+	// The glyph of '-' is a bar across its middle row, so drawn at (10, 10)
+	// it covers 10 <= x < 20 and 10 <= y < 12 at double scale.
+	c := newRasterCanvas(30, 30)
+	c.text(10, 10+c.textHeight()/2-3*rasterScale, "-", anchorStart, false, black)
+	if c.img.RGBAAt(10, 10) != black || c.img.RGBAAt(19, 11) != black || c.img.RGBAAt(20, 10) == black || c.img.RGBAAt(10, 12) == black {
+		t.Errorf("text() did not draw the bar of '-' at (10, 10)")
+	}
+
+	// Rotated, the bar runs up from the anchor instead.
+	c = newRasterCanvas(30, 30)
+	c.text(10+c.textHeight()/2-3*rasterScale, 20, "-", anchorStart, true, black)
+	if c.img.RGBAAt(10, 19) != black || c.img.RGBAAt(11, 10) != black || c.img.RGBAAt(10, 20) == black || c.img.RGBAAt(12, 15) == black {
+		t.Errorf("rotated text() did not draw the bar of '-' up from (10, 20)")
+	}
+
+	if w := c.textWidth("ab"); w != 11*rasterScale {
+		t.Errorf("textWidth(ab) = %v, want %v", w, 11*rasterScale)
+	}
+	if w := c.textWidth(""); w != 0 {
+		t.Errorf("textWidth() of nothing = %v, want 0", w)
+	}
+}
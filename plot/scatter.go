@@ -0,0 +1,163 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"errors"
+	"io"
+	"math"
+
+	"github.com/rsned/stats/datasets"
+)
+
+// Scatter writes a scatterplot of the dataset to w in format:
+//
+//	f, err := os.Create("anscombe.svg")
+//	...
+//	err = plot.Scatter(f, datasets.AnscombeIII, plot.SVG, plot.WithRegressionLine())
+//
+// The axes are scaled to the data, with ticks at round numbers, and are
+// labelled with XLabel and YLabel and their units. The title is the Name
+// of the dataset unless set with WithTitle. Missing pairs, as reported by
+// IsMissing, and infinite values are not drawn, and the regression line is
+// fitted to the pairs that are drawn, if there are at least two with
+// different X values.
+//
+// An error is returned if X and Y differ in length, the size is too small,
+// the format is not supported, or the image cannot be written.
+func Scatter(w io.Writer, d datasets.Dataset, format Format, opts ...Option) error {
+	if len(d.X) != len(d.Y) {
+		return errors.New("X and Y must have the same length")
+	}
+	o := newOptions(opts)
+	title := d.Name
+	if o.title != nil {
+		title = *o.title
+	}
+
+	var xs, ys []float64
+	for i, x := range d.X {
+		y := d.Y[i]
+		if !d.IsMissing(i) && !math.IsInf(x, 0) && !math.IsInf(y, 0) {
+			xs = append(xs, x)
+			ys = append(ys, y)
+		}
+	}
+
+	return render(w, format, o, func(c canvas, width, height float64) {
+		th := c.textHeight()
+		xa, ya := newAxis(xs, 6), newAxis(ys, 5)
+		xLabel, yLabel := axisLabel(d.XLabel, d.XUnits), axisLabel(d.YLabel, d.YUnits)
+
+		top := 15.0
+		if title != "" {
+			top = 2*th + 10
+			c.text(width/2, th+5, title, anchorMiddle, false, black)
+		}
+		left := 18.0
+		for _, t := range ya.ticks {
+			left = math.Max(left, 18+c.textWidth(ya.label(t)))
+		}
+		bottom := 18 + th
+		if yLabel != "" {
+			left += th + 8
+			c.text(10+th/2, top+(height-bottom-top)/2, yLabel, anchorMiddle, true, black)
+		}
+		if xLabel != "" {
+			bottom += th + 8
+		}
+		right := 20 + c.textWidth(xa.label(xa.hi))/2
+		x0, x1 := left, width-right
+		y0, y1 := height-bottom, top
+		if xLabel != "" {
+			c.text((x0+x1)/2, height-10-th/2, xLabel, anchorMiddle, false, black)
+		}
+
+		for _, t := range xa.ticks {
+			px := xa.scale(t, x0, x1)
+			c.line(px, y0, px, y1, 1, lightGray)
+			c.line(px, y0, px, y0+5, 1, gray)
+			c.text(px, y0+8+th/2, xa.label(t), anchorMiddle, false, black)
+		}
+		for _, t := range ya.ticks {
+			py := ya.scale(t, y0, y1)
+			c.line(x0, py, x1, py, 1, lightGray)
+			c.line(x0-5, py, x0, py, 1, gray)
+			c.text(x0-8, py, ya.label(t), anchorEnd, false, black)
+		}
+		c.line(x0, y0, x1, y0, 1, gray)
+		c.line(x0, y0, x0, y1, 1, gray)
+
+		for i, x := range xs {
+			c.dot(xa.scale(x, x0, x1), ya.scale(ys[i], y0, y1), 3, blue)
+		}
+
+		if o.regression {
+			slope, intercept, ok := leastSquares(xs, ys)
+			if ok {
+				if lx0, ly0, lx1, ly1, ok := clipLine(slope, intercept, xa, ya); ok {
+					c.line(xa.scale(lx0, x0, x1), ya.scale(ly0, y0, y1), xa.scale(lx1, x0, x1), ya.scale(ly1, y0, y1), 2, red)
+				}
+			}
+		}
+	})
+}
+
+// leastSquares returns the slope and intercept of the least squares line
+// of ys on xs, or false if it is not defined.
+func leastSquares(xs, ys []float64) (float64, float64, bool) {
+	if len(xs) < 2 {
+		return 0, 0, false
+	}
+	var mx, my float64
+	for i, x := range xs {
+		mx += x
+		my += ys[i]
+	}
+	mx /= float64(len(xs))
+	my /= float64(len(xs))
+
+	var sxx, sxy float64
+	for i, x := range xs {
+		sxx += (x - mx) * (x - mx)
+		sxy += (x - mx) * (ys[i] - my)
+	}
+	if sxx == 0 {
+		return 0, 0, false
+	}
+	slope := sxy / sxx
+
+	return slope, my - slope*mx, true
+}
+
+// clipLine returns the ends of the part of the line y = slope*x+intercept
+// inside the ranges of the axes, or false if it does not cross them.
+func clipLine(slope, intercept float64, xa, ya axis) (float64, float64, float64, float64, bool) {
+	lo, hi := xa.lo, xa.hi
+	if slope != 0 {
+		// The x values at which the line meets the bottom and top.
+		a := (ya.lo - intercept) / slope
+		b := (ya.hi - intercept) / slope
+		lo = math.Max(lo, math.Min(a, b))
+		hi = math.Min(hi, math.Max(a, b))
+	} else if intercept < ya.lo || intercept > ya.hi {
+		return 0, 0, 0, 0, false
+	}
+	if lo > hi {
+		return 0, 0, 0, 0, false
+	}
+
+	return lo, slope*lo + intercept, hi, slope*hi + intercept, true
+}
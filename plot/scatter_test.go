@@ -0,0 +1,151 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rsned/stats/datasets"
+)
+
+func TestScatterSVG(t *testing.T) {
+	d := datasets.AnscombeIII
+	d.XLabel, d.YLabel, d.YUnits = "dose", "response", "mg"
+	d.X = append(d.X, math.NaN(), 3)
+	d.Y = append(d.Y, 4, math.Inf(1))
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		want      []string
+		wantLines int
+	}{
+		{
+			name:      "default",
+			opts:      nil,
+			want:      []string{`width="640" height="480"`, ">Anscombe III</text>", ">dose</text>", ">response (mg)</text>", ">14</text>"},
+			wantLines: 0,
+		},
+		{
+			name:      "title, size and regression line",
+			opts:      []Option{WithTitle("Outlier & fit"), WithSize(300, 200), WithRegressionLine()},
+			want:      []string{`width="300" height="200"`, ">Outlier &amp; fit</text>"},
+			wantLines: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b strings.Builder
+			if err := Scatter(&b, d, SVG, test.opts...); err != nil {
+				t.Fatalf("Scatter() unexpected error: %v", err)
+			}
+			got := b.String()
+			for _, want := range test.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Scatter() does not contain %s", want)
+				}
+			}
+			// The missing and infinite pairs are not drawn.
+			if n := strings.Count(got, "<circle"); n != 11 {
+				t.Errorf("Scatter() drew %d points, want 11", n)
+			}
+			if n := strings.Count(got, `stroke="#d62728"`); n != test.wantLines {
+				t.Errorf("Scatter() drew %d regression lines, want %d", n, test.wantLines)
+			}
+		})
+	}
+}
+
+func TestScatterPNG(t *testing.T) {
+	var b bytes.Buffer
+	if err := Scatter(&b, datasets.DatasaurusDino, PNG, WithSize(320, 240), WithRegressionLine()); err != nil {
+		t.Fatalf("Scatter() unexpected error: %v", err)
+	}
+	img, err := png.Decode(&b)
+	if err != nil {
+		t.Fatalf("png.Decode() unexpected error: %v", err)
+	}
+	if img.Bounds().Dx() != 320 || img.Bounds().Dy() != 240 {
+		t.Errorf("Scatter() image size = %v, want 320 by 240", img.Bounds())
+	}
+}
+
+func TestScatterErrors(t *testing.T) {
+	short := datasets.AnscombeI
+	short.Y = short.Y[1:]
+	tests := []struct {
+		name    string
+		d       datasets.Dataset
+		format  Format
+		opts    []Option
+		wantErr string
+	}{
+		{name: "length mismatch", d: short, format: SVG, opts: nil, wantErr: "same length"},
+		{name: "too small", d: datasets.AnscombeI, format: PNG, opts: []Option{WithSize(50, 400)}, wantErr: "at least 100 by 100"},
+		{name: "unknown format", d: datasets.AnscombeI, format: Format(9), opts: nil, wantErr: "unsupported plot format"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var b bytes.Buffer
+			err := Scatter(&b, test.d, test.format, test.opts...)
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Errorf("Scatter() error = %v, want one containing %q", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLeastSquares(t *testing.T) {
+	slope, intercept, ok := leastSquares(datasets.AnscombeI.X, datasets.AnscombeI.Y)
+	if !ok || math.Abs(slope-0.5001) > 1e-4 || math.Abs(intercept-3.0001) > 1e-4 {
+		t.Errorf("leastSquares(Anscombe I) = %v, %v, %v, want 0.5001, 3.0001, true", slope, intercept, ok)
+	}
+	if _, _, ok := leastSquares([]float64{2, 2}, []float64{1, 3}); ok {
+		t.Errorf("leastSquares() of a vertical line is defined")
+	}
+}
+
+func TestClipLine(t *testing.T) {
+	xa := axis{lo: 0, hi: 10, ticks: nil, decimals: 0}
+	ya := axis{lo: 0, hi: 5, ticks: nil, decimals: 0}
+	tests := []struct {
+		name             string
+		slope, intercept float64
+		want             [4]float64
+		wantOK           bool
+	}{
+		{name: "inside", slope: 0.25, intercept: 1, want: [4]float64{0, 1, 10, 3.5}, wantOK: true},
+		{name: "leaves the top", slope: 1, intercept: 0, want: [4]float64{0, 0, 5, 5}, wantOK: true},
+		{name: "falling", slope: -1, intercept: 7, want: [4]float64{2, 5, 7, 0}, wantOK: true},
+		{name: "flat", slope: 0, intercept: 2, want: [4]float64{0, 2, 10, 2}, wantOK: true},
+		{name: "flat above", slope: 0, intercept: 6, want: [4]float64{}, wantOK: false},
+		{name: "misses", slope: 1, intercept: 20, want: [4]float64{}, wantOK: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x0, y0, x1, y1, ok := clipLine(test.slope, test.intercept, xa, ya)
+			if ok != test.wantOK || (ok && [4]float64{x0, y0, x1, y1} != test.want) {
+				t.Errorf("clipLine() = %v, %v, %v, %v, %v, want %v, %v", x0, y0, x1, y1, ok, test.want, test.wantOK)
+			}
+		})
+	}
+}
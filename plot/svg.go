@@ -0,0 +1,130 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"encoding/xml"
+	"image/color"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// anchor is the point of a line of text that is placed at its position.
+type anchor int
+
+const (
+	anchorStart anchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+// canvas is a surface that a plot is drawn on, in pixels from the top left
+// corner. It is implemented by svgCanvas and rasterCanvas, so that the same
+// drawing code produces both formats.
+type canvas interface {
+	// rect fills the rectangle with top left corner (x, y).
+	rect(x, y, w, h float64, fill color.RGBA)
+	// line draws a line between two points.
+	line(x1, y1, x2, y2, width float64, stroke color.RGBA)
+	// dot fills a circle of radius r centred at (x, y).
+	dot(x, y, r float64, fill color.RGBA)
+	// text draws s with its anchor at x and its middle at y. Rotated text
+	// reads from bottom to top, with the anchor at y and its middle at x.
+	text(x, y float64, s string, a anchor, rotated bool, fill color.RGBA)
+	// textWidth returns the length of s when drawn.
+	textWidth(s string) float64
+	// textHeight returns the height of a line of text.
+	textHeight() float64
+}
+
+// svgFontSize is the size of text in SVG plots, in pixels.
+const svgFontSize = 12
+
+// svgCanvas draws into an SVG document.
+type svgCanvas struct {
+	b strings.Builder
+}
+
+// newSVGCanvas returns a canvas for an SVG image width by height pixels.
+func newSVGCanvas(width, height int) *svgCanvas {
+	c := &svgCanvas{b: strings.Builder{}}
+	w, h := strconv.Itoa(width), strconv.Itoa(height)
+	c.b.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="` + w + `" height="` + h +
+		`" viewBox="0 0 ` + w + " " + h + `" font-family="sans-serif" font-size="` + strconv.Itoa(svgFontSize) + `">` + "\n")
+
+	return c
+}
+
+func (c *svgCanvas) rect(x, y, w, h float64, fill color.RGBA) {
+	c.b.WriteString(`<rect x="` + svgNum(x) + `" y="` + svgNum(y) + `" width="` + svgNum(w) + `" height="` + svgNum(h) +
+		`" fill="` + svgColor(fill) + `"/>` + "\n")
+}
+
+func (c *svgCanvas) line(x1, y1, x2, y2, width float64, stroke color.RGBA) {
+	c.b.WriteString(`<line x1="` + svgNum(x1) + `" y1="` + svgNum(y1) + `" x2="` + svgNum(x2) + `" y2="` + svgNum(y2) +
+		`" stroke="` + svgColor(stroke) + `" stroke-width="` + svgNum(width) + `"/>` + "\n")
+}
+
+func (c *svgCanvas) dot(x, y, r float64, fill color.RGBA) {
+	c.b.WriteString(`<circle cx="` + svgNum(x) + `" cy="` + svgNum(y) + `" r="` + svgNum(r) +
+		`" fill="` + svgColor(fill) + `"/>` + "\n")
+}
+
+func (c *svgCanvas) text(x, y float64, s string, a anchor, rotated bool, fill color.RGBA) {
+	anchors := [...]string{anchorStart: "start", anchorMiddle: "middle", anchorEnd: "end"}
+	// The baseline sits about a third of the font size below the middle.
+	c.b.WriteString(`<text x="` + svgNum(x) + `" y="` + svgNum(y) + `" dy="0.35em" text-anchor="` + anchors[a] +
+		`" fill="` + svgColor(fill) + `"`)
+	if rotated {
+		c.b.WriteString(` transform="rotate(-90 ` + svgNum(x) + " " + svgNum(y) + `)"`)
+	}
+	c.b.WriteString(">")
+	// Writing to a strings.Builder cannot fail.
+	_ = xml.EscapeText(&c.b, []byte(s))
+	c.b.WriteString("</text>\n")
+}
+
+// textWidth estimates the width of s, since the font is chosen by the
+// viewer: the average character of a sans-serif font is a little over half
+// as wide as it is high.
+func (c *svgCanvas) textWidth(s string) float64 {
+	return 0.6 * svgFontSize * float64(len([]rune(s)))
+}
+
+func (c *svgCanvas) textHeight() float64 {
+	return svgFontSize
+}
+
+// writeTo ends the document and writes it to w.
+func (c *svgCanvas) writeTo(w io.Writer) error {
+	c.b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, c.b.String())
+
+	return err
+}
+
+// svgNum formats v as an SVG coordinate, to a hundredth of a pixel.
+func svgNum(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+// svgColor formats c as an SVG color.
+func svgColor(c color.RGBA) string {
+	const hex = "0123456789abcdef"
+
+	return string([]byte{'#', hex[c.R>>4], hex[c.R&15], hex[c.G>>4], hex[c.G&15], hex[c.B>>4], hex[c.B&15]})
+}
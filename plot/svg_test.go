@@ -0,0 +1,56 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plot
+
+import (
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestSVGCanvas(t *testing.T) {
+	c := newSVGCanvas(200, 100)
+	c.rect(1, 2, 3.004, 4, color.RGBA{R: 0x12, G: 0xab, B: 0xff, A: 0xff})
+	c.line(0, 0, 10, 10.5, 2, black)
+	c.dot(5, 6, 3, red)
+	c.text(7, 8, `a < b & "c"`, anchorEnd, true, black)
+
+	var b strings.Builder
+	if err := c.writeTo(&b); err != nil {
+		t.Fatalf("writeTo() unexpected error: %v", err)
+	}
+	got := b.String()
+	for _, want := range []string{
+		`<svg xmlns="http://www.w3.org/2000/svg" width="200" height="100" viewBox="0 0 200 100"`,
+		`<rect x="1" y="2" width="3" height="4" fill="#12abff"/>`,
+		`<line x1="0" y1="0" x2="10" y2="10.5" stroke="#222222" stroke-width="2"/>`,
+		`<circle cx="5" cy="6" r="3" fill="#d62728"/>`,
+		`text-anchor="end"`,
+		`transform="rotate(-90 7 8)"`,
+		`>a &lt; b &amp; &#34;c&#34;</text>`,
+		"</svg>\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("SVG output does not contain %s:\n%s", want, got)
+		}
+	}
+}
+
+func TestSVGTextWidth(t *testing.T) {
+	c := newSVGCanvas(100, 100)
+	if c.textWidth("abc") != 3*c.textWidth("a") || c.textWidth("") != 0 {
+		t.Errorf("textWidth() is not proportional to the number of characters")
+	}
+}
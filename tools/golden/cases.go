@@ -0,0 +1,105 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golden
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/rsned/stats/datasets"
+)
+
+// DefaultCases returns the cases the golden file of the correlation package
+// is generated from: the bundled example datasets together with small hand
+// built series that exercise ties, perfect relationships and data with a
+// large offset from zero.
+func DefaultCases() []Case {
+	var cases []Case
+	for _, group := range []datasets.Datasets{datasets.AnscombeQuartet, datasets.DatasaurusDozen} {
+		for _, d := range group.Data {
+			cases = append(cases, Case{Name: d.Name, X: d.X, Y: d.Y})
+		}
+	}
+
+	cases = append(cases,
+		Case{
+			Name: "perfect positive",
+			X:    []float64{1, 2, 3, 4, 5},
+			Y:    []float64{2, 4, 6, 8, 10},
+		},
+		Case{
+			Name: "perfect negative",
+			X:    []float64{1, 2, 3, 4, 5},
+			Y:    []float64{10, 8, 6, 4, 2},
+		},
+		Case{
+			Name: "monotonic quadratic",
+			X:    []float64{1, 2, 3, 4, 5, 6, 7, 8},
+			Y:    []float64{1, 4, 9, 16, 25, 36, 49, 64},
+		},
+		Case{
+			Name: "ties in both series",
+			X:    []float64{1, 2, 2, 3, 3, 3, 4, 5},
+			Y:    []float64{2, 1, 3, 3, 5, 4, 4, 6},
+		},
+		Case{
+			Name: "ordinal scale",
+			X:    []float64{1, 1, 2, 2, 3, 3, 4, 4, 5, 5},
+			Y:    []float64{1, 2, 1, 3, 2, 4, 3, 5, 4, 5},
+		},
+		Case{
+			Name: "constant y",
+			X:    []float64{1, 2, 3, 4},
+			Y:    []float64{7, 7, 7, 7},
+		},
+		Case{
+			Name: "negative and fractional",
+			X:    []float64{-2.5, -1.25, 0, 0.125, 3.75, 10.5},
+			Y:    []float64{0.1, 0.3, -0.2, 0.4, 0.35, 1.1},
+		},
+		offsetCase("offset 1e6", 1e6, 1),
+		randomCase("random 200", 200, 0.6, 2),
+	)
+
+	return cases
+}
+
+// offsetCase returns a short series shifted far from zero, which defeats
+// implementations that accumulate raw sums of squares in float64.
+func offsetCase(name string, offset float64, seed int64) Case {
+	rng := rand.New(rand.NewSource(seed))
+	c := Case{Name: name, X: make([]float64, 50), Y: make([]float64, 50)}
+	for i := range c.X {
+		c.X[i] = offset + float64(i)
+		c.Y[i] = offset + float64(i) + math.Round(rng.NormFloat64()*100)/10
+	}
+
+	return c
+}
+
+// randomCase returns n pairs with population correlation rho, rounded to
+// four decimal places so the values are easy to read in the golden file.
+func randomCase(name string, n int, rho float64, seed int64) Case {
+	rng := rand.New(rand.NewSource(seed))
+	c := Case{Name: name, X: make([]float64, n), Y: make([]float64, n)}
+	for i := range n {
+		a := rng.NormFloat64()
+		b := rng.NormFloat64()
+		c.X[i] = math.Round(a*1e4) / 1e4
+		c.Y[i] = math.Round((rho*a+math.Sqrt(1-rho*rho)*b)*1e4) / 1e4
+	}
+
+	return c
+}
@@ -0,0 +1,51 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command gengolden writes the golden reference values for the correlation
+// package, computed with exact arithmetic by package golden.
+//
+// Usage:
+//
+//	gengolden -o testdata/golden.json
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/rsned/stats/tools/golden"
+)
+
+func main() {
+	out := flag.String("o", "testdata/golden.json", "output file")
+	flag.Parse()
+
+	records, err := golden.Generate(golden.DefaultCases())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := golden.Write(f, records); err != nil {
+		f.Close()
+		log.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,284 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package golden computes reference values for the measures in this module
+// using exact rational arithmetic, and reads and writes them as the golden
+// files used by the package tests.
+//
+// The package is exported so the generator and the tests of every package
+// in the module can share it, but it is a development tool rather than part
+// of the public API and may change at any time.
+//
+// To regenerate the golden file for the correlation package run
+//
+//	go generate ./correlation
+package golden
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"math"
+	"math/big"
+	"sort"
+)
+
+// precision is the mantissa size, in bits, used for the square roots that
+// cannot be taken in rational arithmetic. It is far beyond float64 so the
+// final rounding to float64 is correct.
+const precision = 256
+
+// Case is a named pair of series that golden values are computed for.
+type Case struct {
+	Name string
+	X    []float64
+	Y    []float64
+}
+
+// Record holds the reference values of every measure for one Case. A nil
+// value means the measure is undefined for that data, for example because a
+// series is constant.
+type Record struct {
+	Name           string    `json:"name"`
+	X              []float64 `json:"x"`
+	Y              []float64 `json:"y"`
+	Pearson        *float64  `json:"pearson"`
+	Spearman       *float64  `json:"spearman"`
+	KendallTau     *float64  `json:"kendallTau"`
+	GoodmanKruskal *float64  `json:"goodmanKruskal"`
+}
+
+// Generate computes the Record for each case.
+//
+// An error is returned if the series of a case differ in length, have fewer
+// than 2 values, or contain NaN or ±Inf.
+func Generate(cases []Case) ([]Record, error) {
+	records := make([]Record, 0, len(cases))
+	for _, c := range cases {
+		if len(c.X) != len(c.Y) {
+			return nil, errors.New(c.Name + ": series must have the same length")
+		}
+		if len(c.X) < 2 {
+			return nil, errors.New(c.Name + ": series must have at least 2 values")
+		}
+		for i := range c.X {
+			if math.IsNaN(c.X[i]) || math.IsInf(c.X[i], 0) || math.IsNaN(c.Y[i]) || math.IsInf(c.Y[i], 0) {
+				return nil, errors.New(c.Name + ": series must be finite")
+			}
+		}
+
+		records = append(records, Record{
+			Name:           c.Name,
+			X:              c.X,
+			Y:              c.Y,
+			Pearson:        toFloat64(Pearson(c.X, c.Y)),
+			Spearman:       toFloat64(Spearman(c.X, c.Y)),
+			KendallTau:     toFloat64(KendallTauB(c.X, c.Y)),
+			GoodmanKruskal: toFloat64(Gamma(c.X, c.Y)),
+		})
+	}
+
+	return records, nil
+}
+
+// Write writes the records to w as indented JSON.
+func Write(w io.Writer, records []Record) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(records)
+}
+
+// Read reads records written by Write.
+func Read(r io.Reader) ([]Record, error) {
+	var records []Record
+	if err := json.NewDecoder(r).Decode(&records); err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Pearson returns Pearson's correlation of x and y. The numerator and
+// variances are formed exactly in rational arithmetic, so the only rounding
+// is in the final square root. It returns nil if either series is constant.
+func Pearson(x, y []float64) *big.Float {
+	return pearsonRat(toRats(x), toRats(y))
+}
+
+// Spearman returns Spearman's rank correlation of x and y, with ties given
+// the average of the ranks they span. It returns nil if either series is
+// constant.
+func Spearman(x, y []float64) *big.Float {
+	return pearsonRat(ranks(x), ranks(y))
+}
+
+// KendallTauB returns Kendall's τ-b of x and y,
+//
+//	τb = (C - D) / sqrt((n0 - n1)(n0 - n2))
+//
+// where C and D count the concordant and discordant pairs, n0 = n(n-1)/2,
+// and n1 and n2 count the pairs tied in x and in y. It returns nil if either
+// series is constant.
+func KendallTauB(x, y []float64) *big.Float {
+	c, d, tx, ty := pairCounts(x, y)
+	n := int64(len(x))
+	n0 := n * (n - 1) / 2
+	den := new(big.Int).Mul(big.NewInt(n0-tx), big.NewInt(n0-ty))
+	if den.Sign() == 0 {
+		return nil
+	}
+
+	return signedSqrt(new(big.Rat).SetFrac(big.NewInt(c-d), big.NewInt(1)), new(big.Rat).SetInt(den))
+}
+
+// Gamma returns Goodman and Kruskal's γ = (C - D) / (C + D) of x and y,
+// where C and D count the concordant and discordant pairs. Pairs tied in
+// either series are ignored. It returns nil if every pair is tied.
+func Gamma(x, y []float64) *big.Float {
+	c, d, _, _ := pairCounts(x, y)
+	if c+d == 0 {
+		return nil
+	}
+	r := new(big.Rat).SetFrac(big.NewInt(c-d), big.NewInt(c+d))
+
+	return new(big.Float).SetPrec(precision).SetRat(r)
+}
+
+// pearsonRat returns the correlation of exact rational series.
+func pearsonRat(x, y []*big.Rat) *big.Float {
+	n := new(big.Rat).SetInt64(int64(len(x)))
+	sx, sy := new(big.Rat), new(big.Rat)
+	sxx, syy, sxy := new(big.Rat), new(big.Rat), new(big.Rat)
+	t := new(big.Rat)
+	for i := range x {
+		sx.Add(sx, x[i])
+		sy.Add(sy, y[i])
+		sxx.Add(sxx, t.Mul(x[i], x[i]))
+		syy.Add(syy, t.Mul(y[i], y[i]))
+		sxy.Add(sxy, t.Mul(x[i], y[i]))
+	}
+
+	// n·Σxy - Σx·Σy and the matching variance terms.
+	num := new(big.Rat).Mul(n, sxy)
+	num.Sub(num, t.Mul(sx, sy))
+	vx := new(big.Rat).Mul(n, sxx)
+	vx.Sub(vx, t.Mul(sx, sx))
+	vy := new(big.Rat).Mul(n, syy)
+	vy.Sub(vy, t.Mul(sy, sy))
+	if vx.Sign() <= 0 || vy.Sign() <= 0 {
+		return nil
+	}
+
+	return signedSqrt(num, vx.Mul(vx, vy))
+}
+
+// signedSqrt returns num / sqrt(den) for den > 0, computed as the square
+// root of the exact rational num²/den with the sign of num.
+func signedSqrt(num, den *big.Rat) *big.Float {
+	sq := new(big.Rat).Mul(num, num)
+	sq.Quo(sq, den)
+	r := new(big.Float).SetPrec(precision).SetRat(sq)
+	r.Sqrt(r)
+	if num.Sign() < 0 {
+		r.Neg(r)
+	}
+
+	return r
+}
+
+// pairCounts returns the number of concordant and discordant pairs and the
+// number of pairs tied in x and in y.
+func pairCounts(x, y []float64) (int64, int64, int64, int64) {
+	var c, d, tx, ty int64
+	for i := range x {
+		for j := i + 1; j < len(x); j++ {
+			sx := compare(x[i], x[j])
+			sy := compare(y[i], y[j])
+			if sx == 0 {
+				tx++
+			}
+			if sy == 0 {
+				ty++
+			}
+			switch sx * sy {
+			case 1:
+				c++
+			case -1:
+				d++
+			}
+		}
+	}
+
+	return c, d, tx, ty
+}
+
+// compare returns the sign of a - b.
+func compare(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ranks returns the 1-based ranks of data, giving tied values the average
+// of the ranks they span.
+func ranks(data []float64) []*big.Rat {
+	order := make([]int, len(data))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return data[order[a]] < data[order[b]] })
+
+	out := make([]*big.Rat, len(data))
+	for i := 0; i < len(order); {
+		j := i
+		for j+1 < len(order) && data[order[j+1]] == data[order[i]] {
+			j++
+		}
+		// Positions i..j share the average rank ((i+1) + (j+1)) / 2.
+		avg := big.NewRat(int64(i+j+2), 2)
+		for k := i; k <= j; k++ {
+			out[order[k]] = avg
+		}
+		i = j + 1
+	}
+
+	return out
+}
+
+// toRats converts data to exact rationals.
+func toRats(data []float64) []*big.Rat {
+	out := make([]*big.Rat, len(data))
+	for i, v := range data {
+		out[i] = new(big.Rat).SetFloat64(v)
+	}
+
+	return out
+}
+
+// toFloat64 rounds a reference value to the nearest float64, keeping nil
+// for undefined values.
+func toFloat64(v *big.Float) *float64 {
+	if v == nil {
+		return nil
+	}
+	f, _ := v.Float64()
+
+	return &f
+}
@@ -0,0 +1,91 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package golden
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestReferenceValues(t *testing.T) {
+	// x and y from the classic example with r = 0.529809...
+	x := []float64{43, 21, 25, 42, 57, 59}
+	y := []float64{99, 65, 79, 75, 87, 81}
+
+	tests := []struct {
+		name string
+		got  *big.Float
+		want float64
+	}{
+		{name: "pearson", got: Pearson(x, y), want: 0.5298089018901744},
+		// Ranks are (4,1,2,3,5,6) and (6,1,3,2,5,4): Σd² = 10, 1 - 6·10/210.
+		{name: "spearman", got: Spearman(x, y), want: 5.0 / 7},
+		// 11 concordant and 4 discordant pairs out of 15.
+		{name: "kendall", got: KendallTauB(x, y), want: 7.0 / 15},
+		{name: "gamma", got: Gamma(x, y), want: 7.0 / 15},
+		// Pairs tied in x: 2, in y: 1, of n0 = 6, C = 3, D = 0.
+		{name: "kendall ties", got: KendallTauB([]float64{1, 1, 2, 2}, []float64{1, 2, 2, 3}), want: 3 / math.Sqrt(20)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got == nil {
+				t.Fatalf("got nil, want %v", tt.want)
+			}
+			if got, _ := tt.got.Float64(); math.Abs(got-tt.want) > 1e-15 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	constant := []float64{3, 3, 3}
+	if Pearson(constant, x[:3]) != nil || Spearman(x[:3], constant) != nil || KendallTauB(constant, x[:3]) != nil {
+		t.Errorf("reference values for a constant series should be nil")
+	}
+	if Gamma(constant, constant) != nil {
+		t.Errorf("Gamma() of fully tied data should be nil")
+	}
+}
+
+func TestGenerateRoundTrip(t *testing.T) {
+	records, err := Generate(DefaultCases())
+	if err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, records); err != nil {
+		t.Fatalf("Write() unexpected error: %v", err)
+	}
+	got, err := Read(&buf)
+	if err != nil {
+		t.Fatalf("Read() unexpected error: %v", err)
+	}
+	if len(got) != len(records) {
+		t.Fatalf("Read() returned %d records, want %d", len(got), len(records))
+	}
+	for i := range got {
+		if got[i].Name != records[i].Name || (got[i].Pearson == nil) != (records[i].Pearson == nil) {
+			t.Errorf("record %d = %+v, want %+v", i, got[i], records[i])
+		}
+	}
+
+	bad := []Case{{Name: "nan", X: []float64{1, math.NaN()}, Y: []float64{1, 2}}}
+	if _, err := Generate(bad); err == nil {
+		t.Errorf("Generate() with NaN expected error but got none")
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2025 Robert Snedegar
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+Package x is the parent of the experimental packages of this module.
+
+Packages under x/ hold new measures and APIs that are still settling. They
+are held to the same standards of correctness and testing as the rest of
+the module, but their exported API may change or be removed between
+releases without notice. Packages outside x/, such as correlation, datasets
+and density, are stable: existing exported identifiers keep their meaning
+and signatures.
+
+New measures land in their own package under x/, for example
+x/<measure>, so they can be used and reviewed before committing to an API.
+A package is promoted once its API has been unchanged for a release and has
+been exercised by real callers. Promotion copies the code into the stable
+package it belongs in; the x/ package then becomes a thin set of
+deprecated aliases forwarding to the stable identifiers for one release
+before it is removed, so callers can migrate with a change of import path.
+
+Stable packages never import packages under x/.
+*/
+package x